@@ -14,6 +14,14 @@ var (
 	once     sync.Once
 )
 
+// delIfValueScript deletes a key only while it still holds the expected value.
+var delIfValueScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
 type Cache struct {
 	client *redis.Client
 }
@@ -62,6 +70,17 @@ func (c *Cache) Del(ctx context.Context, key string) error {
 	return c.client.Del(ctx, key).Err()
 }
 
+// GetDel retrieves a value and removes its key in a single step
+func (c *Cache) GetDel(ctx context.Context, key string) *redis.StringCmd {
+	return c.client.GetDel(ctx, key)
+}
+
+// DelIfValue removes a key if it holds value, in a single step, and reports whether it did
+func (c *Cache) DelIfValue(ctx context.Context, key, value string) (bool, error) {
+	deleted, err := delIfValueScript.Run(ctx, c.client, []string{key}, value).Int()
+	return deleted == 1, err
+}
+
 // Incr increments a key's value
 func (c *Cache) Incr(ctx context.Context, key string) (int64, error) {
 	return c.client.Incr(ctx, key).Result()
@@ -111,9 +130,9 @@ func (c *Cache) AddToBlacklist(ctx context.Context, tokenID string, expiration t
 func (c *Cache) IsTokenBlacklisted(ctx context.Context, tokenID string) (bool, error) {
 	key := "blacklist:token:" + tokenID
 	// If the key exists, it means the token is blacklisted.
-	_, err := c.client.Get(ctx, key).Result()
+	count, err := c.client.Exists(ctx, key).Result()
 	if err != nil {
 		return false, err
 	}
-	return true, nil // Token found in blacklist
+	return count > 0, nil
 }
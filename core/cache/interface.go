@@ -11,6 +11,8 @@ type ICache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
 	Get(ctx context.Context, key string) *redis.StringCmd
 	Del(ctx context.Context, key string) error
+	GetDel(ctx context.Context, key string) *redis.StringCmd
+	DelIfValue(ctx context.Context, key, value string) (bool, error)
 	Incr(ctx context.Context, key string) (int64, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
 	Close() error
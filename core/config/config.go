@@ -10,9 +10,10 @@ import (
 )
 
 type ServerConfig struct {
-	Port    int    `mapstructure:"port"`
-	Host    string `mapstructure:"host"`
-	BaseURL string `mapstructure:"base_url"`
+	Port        int    `mapstructure:"port"`
+	Host        string `mapstructure:"host"`
+	BaseURL     string `mapstructure:"base_url"`
+	FrontendURL string `mapstructure:"frontend_url"` // web app that hosts pages such as password reset
 }
 
 type DatabaseConfig struct {
@@ -48,6 +49,42 @@ type RedisConfig struct {
 	Password string `mapstructure:"password"`
 	DB       int    `mapstructure:"db"`
 }
+
+// OAuthProviderConfig holds the client credentials of an OAuth2/OIDC provider.
+// The endpoint URLs are optional and default to the provider's public endpoints.
+type OAuthProviderConfig struct {
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+	RedirectURL  string `mapstructure:"redirect_url"`
+	AuthURL      string `mapstructure:"auth_url"`
+	TokenURL     string `mapstructure:"token_url"`
+	UserInfoURL  string `mapstructure:"userinfo_url"`
+}
+type OAuthConfig struct {
+	Google OAuthProviderConfig `mapstructure:"google"`
+}
+
+// PaymentProviderConfig holds the credentials of a payment provider. APIURL is optional
+// and defaults to the provider's public API.
+type PaymentProviderConfig struct {
+	SecretKey     string `mapstructure:"secret_key"`
+	WebhookSecret string `mapstructure:"webhook_secret"`
+	APIURL        string `mapstructure:"api_url"`
+}
+type PaymentConfig struct {
+	SuccessURL string                `mapstructure:"success_url"` // where the provider sends the user after paying
+	CancelURL  string                `mapstructure:"cancel_url"`
+	Stripe     PaymentProviderConfig `mapstructure:"stripe"`
+}
+
+// AutoScoringConfig selects the scorer that pre-scores Speaking and Writing responses: "heuristic"
+// (the default) or "http", which calls the scoring service at URL.
+type AutoScoringConfig struct {
+	Scorer  string `mapstructure:"scorer"`
+	URL     string `mapstructure:"url"`
+	APIKey  string `mapstructure:"api_key"`
+	Timeout int    `mapstructure:"timeout"` // in seconds
+}
 type ImageSizeConfig struct {
 	Height uint `mapstructure:"height"`
 	Width  uint `mapstructure:"width"`
@@ -58,9 +95,12 @@ type Config struct {
 	Database    DatabaseConfig
 	JWT         JWTConfig
 	SMTP        SMTPConfig
-	Redis       RedisConfig     `mapstructure:"redis"`
-	Minio       MinIOConfig     `mapstructure:"minio"`
-	AvatarSize  ImageSizeConfig `mapstructure:"avatar_size"`
+	Redis       RedisConfig       `mapstructure:"redis"`
+	Minio       MinIOConfig       `mapstructure:"minio"`
+	AvatarSize  ImageSizeConfig   `mapstructure:"avatar_size"`
+	OAuth       OAuthConfig       `mapstructure:"oauth"`
+	Payment     PaymentConfig     `mapstructure:"payment"`
+	AutoScoring AutoScoringConfig `mapstructure:"auto_scoring"`
 }
 
 var (
@@ -117,6 +157,7 @@ func Init(env Environment) error {
 		v.BindEnv("server.port", "APP_SERVER_PORT")
 		v.BindEnv("server.host", "APP_SERVER_HOST")
 		v.BindEnv("server.base_url", "APP_SERVER_BASE_URL")
+		v.BindEnv("server.frontend_url", "APP_SERVER_FRONTEND_URL")
 		v.BindEnv("database.host", "APP_DATABASE_HOST")
 		v.BindEnv("database.port", "APP_DATABASE_PORT")
 		v.BindEnv("database.user", "APP_DATABASE_USER")
@@ -145,6 +186,25 @@ func Init(env Environment) error {
 		// Bind AvatarConfig (size) environment variables
 		v.BindEnv("avatar_size.height", "APP_AVATAR_HEIGHT")
 		v.BindEnv("avatar_size.width", "APP_AVATAR_WIDTH")
+		// Bind OAuth provider environment variables
+		v.BindEnv("oauth.google.client_id", "APP_OAUTH_GOOGLE_CLIENT_ID")
+		v.BindEnv("oauth.google.client_secret", "APP_OAUTH_GOOGLE_CLIENT_SECRET")
+		v.BindEnv("oauth.google.redirect_url", "APP_OAUTH_GOOGLE_REDIRECT_URL")
+		v.BindEnv("oauth.google.auth_url", "APP_OAUTH_GOOGLE_AUTH_URL")
+		v.BindEnv("oauth.google.token_url", "APP_OAUTH_GOOGLE_TOKEN_URL")
+		v.BindEnv("oauth.google.userinfo_url", "APP_OAUTH_GOOGLE_USERINFO_URL")
+		// Bind payment provider environment variables
+		v.BindEnv("payment.success_url", "APP_PAYMENT_SUCCESS_URL")
+		v.BindEnv("payment.cancel_url", "APP_PAYMENT_CANCEL_URL")
+		v.BindEnv("payment.stripe.secret_key", "APP_PAYMENT_STRIPE_SECRET_KEY")
+		v.BindEnv("payment.stripe.webhook_secret", "APP_PAYMENT_STRIPE_WEBHOOK_SECRET")
+		v.BindEnv("payment.stripe.api_url", "APP_PAYMENT_STRIPE_API_URL")
+		// Bind automated scoring environment variables
+		v.SetDefault("auto_scoring.scorer", "heuristic")
+		v.BindEnv("auto_scoring.scorer", "APP_AUTO_SCORING_SCORER")
+		v.BindEnv("auto_scoring.url", "APP_AUTO_SCORING_URL")
+		v.BindEnv("auto_scoring.api_key", "APP_AUTO_SCORING_API_KEY")
+		v.BindEnv("auto_scoring.timeout", "APP_AUTO_SCORING_TIMEOUT")
 		// Read from config file
 		// Load environment-specific config file
 		v.SetConfigName(fmt.Sprintf("config.%s", env))
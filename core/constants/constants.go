@@ -7,8 +7,64 @@ const (
 	RefreshTokenExpiry = 7 * 24 * time.Hour
 )
 
+// Token types carried in the "typ" claim
+const (
+	TokenTypeAccess            = "access"
+	TokenTypeRefresh           = "refresh"
+	TokenTypeEmailVerification = "email_verification"
+	TokenTypePasswordReset     = "password_reset"
+)
+
+// Reasons recorded when a session is revoked
+const (
+	SessionRevokedLogout     = "logout"
+	SessionRevokedTokenReuse = "refresh_token_reuse"
+	SessionRevokedSignOut    = "signed_out"
+	SessionRevokedByAdmin    = "revoked_by_admin"
+	SessionRevokedUserLocked = "user_locked"
+	SessionRevokedPassword   = "password_reset"
+)
+
 // Limit login times
 const (
 	MaxLoginAttempts = 5
 	BlockDuration    = 15 * time.Minute
 )
+
+// OAuth login state kept between the redirect to the provider and its callback
+const (
+	OAuthStateKey    = "oauth:state:%s"
+	OAuthStateExpiry = 10 * time.Minute
+)
+
+// Email verification: the id of the only valid link is kept per user, and resends are rate limited
+const (
+	EmailVerificationKey        = "email_verify:%s"
+	EmailVerificationExpiry     = 24 * time.Hour
+	EmailVerificationResendKey  = "email_verify_resend:%s"
+	EmailVerificationResendTTL  = time.Hour
+	MaxEmailVerificationResends = 3
+)
+
+// Password reset: one valid link per user, and requests are rate limited per email
+const (
+	PasswordResetKey         = "password_reset:%s"
+	PasswordResetExpiry      = 30 * time.Minute
+	PasswordResetRateKey     = "password_reset_rate:%s"
+	PasswordResetRateTTL     = time.Hour
+	MaxPasswordResetRequests = 3
+)
+
+// Cached entitlement of a user, cleared when one of their subscriptions changes
+const (
+	EntitlementCacheKey    = "subscription:entitlement:%s"
+	EntitlementCacheExpiry = 5 * time.Minute
+)
+
+// Cached exam trees. Every library write bumps the content version, which is part of the tree key,
+// so trees cached before the write are never read again and simply expire.
+const (
+	ContentVersionKey   = "library:content_version"
+	ExamTreeCacheKey    = "library:exam_tree:%s:v%d"
+	ExamTreeCacheExpiry = 30 * time.Minute
+)
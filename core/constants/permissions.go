@@ -0,0 +1,23 @@
+package constants
+
+import "time"
+
+// Permission names checked by the admin route groups
+const (
+	PermissionUsersRead           = "users:read"
+	PermissionUsersManage         = "users:manage"
+	PermissionRbacManage          = "rbac:manage"
+	PermissionLibraryRead         = "library:read"
+	PermissionLibraryWrite        = "library:write"
+	PermissionLibraryPublish      = "library:publish"
+	PermissionScoringManage       = "scoring:manage"
+	PermissionMailManage          = "mail:manage"
+	PermissionSubscriptionsManage = "subscriptions:manage"
+	PermissionResponsesRate       = "responses:rate"
+)
+
+// Cached permission names of a user
+const (
+	PermissionCacheKey    = "rbac:permissions:%s"
+	PermissionCacheExpiry = 10 * time.Minute
+)
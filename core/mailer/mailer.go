@@ -2,12 +2,30 @@ package mailer
 
 import (
 	"bytes"
+	"embed"
 	"fmt"
 	"html/template"
+	"io/fs"
 	"net/smtp"
+	"path"
 	"strings"
 )
 
+// Templates shipped with the binary
+const (
+	TemplateVerifyEmail   = "verify_email.html"
+	TemplateResetPassword = "reset_password.html"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// IMailer sends emails; it is implemented by Mailer and, asynchronously, by Queue.
+type IMailer interface {
+	SendMail(data EmailData) error
+	SendMailWithTemplate(data EmailData) error
+}
+
 type MailConfig struct {
 	Host     string
 	Port     int
@@ -22,14 +40,14 @@ type Mailer struct {
 }
 
 type EmailData struct {
-	To           []string
-	Subject      string
-	Body         string
-	Template     string // template file path
-	TemplateData any    // data for template
-	Attachments  []string
-	Cc           []string
-	Bcc          []string
+	To           []string `json:"to"`
+	Subject      string   `json:"subject"`
+	Body         string   `json:"body"`
+	Template     string   `json:"template,omitempty"`      // embedded template name or template file path
+	TemplateData any      `json:"template_data,omitempty"` // data for template
+	Attachments  []string `json:"attachments,omitempty"`
+	Cc           []string `json:"cc,omitempty"`
+	Bcc          []string `json:"bcc,omitempty"`
 }
 
 func NewMailer(config MailConfig) *Mailer {
@@ -48,15 +66,16 @@ func (m *Mailer) SendMail(data EmailData) error {
 	headers := []string{
 		fmt.Sprintf("From: %s <%s>", m.config.FromName, m.config.Username),
 		fmt.Sprintf("To: %s", strings.Join(data.To, ", ")),
-		fmt.Sprintf("Subject: %s", data.Subject),
-		"MIME-Version: 1.0",
-		fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s", boundary),
-		"",
 	}
-
 	if len(data.Cc) > 0 {
 		headers = append(headers, fmt.Sprintf("Cc: %s", strings.Join(data.Cc, ", ")))
 	}
+	headers = append(headers,
+		fmt.Sprintf("Subject: %s", data.Subject),
+		"MIME-Version: 1.0",
+		fmt.Sprintf("Content-Type: multipart/mixed; boundary=%s", boundary),
+		"",
+	)
 
 	buffer.WriteString(strings.Join(headers, "\r\n"))
 
@@ -64,6 +83,7 @@ func (m *Mailer) SendMail(data EmailData) error {
 	buffer.WriteString(fmt.Sprintf("\r\n--%s\r\n", boundary))
 	buffer.WriteString("Content-Type: text/html; charset=utf-8\r\n\r\n")
 	buffer.WriteString(data.Body)
+	buffer.WriteString(fmt.Sprintf("\r\n--%s--\r\n", boundary))
 
 	// Combine all recipients
 	recipients := append(data.To, data.Cc...)
@@ -74,19 +94,35 @@ func (m *Mailer) SendMail(data EmailData) error {
 }
 
 func (m *Mailer) SendMailWithTemplate(data EmailData) error {
-	// Parse template if provided
-	if data.Template != "" {
-		tmpl, err := template.ParseFiles(data.Template)
-		if err != nil {
-			return fmt.Errorf("failed to parse template: %w", err)
-		}
-
-		var body bytes.Buffer
-		if err := tmpl.Execute(&body, data.TemplateData); err != nil {
-			return fmt.Errorf("failed to execute template: %w", err)
-		}
-		data.Body = body.String()
+	if err := renderTemplate(&data); err != nil {
+		return err
 	}
-
 	return m.SendMail(data)
 }
+
+// renderTemplate replaces the body with the rendered template, if one is set.
+func renderTemplate(data *EmailData) error {
+	if data.Template == "" {
+		return nil
+	}
+	tmpl, err := parseTemplate(data.Template)
+	if err != nil {
+		return fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, data.TemplateData); err != nil {
+		return fmt.Errorf("failed to execute template: %w", err)
+	}
+	data.Body = body.String()
+	return nil
+}
+
+// parseTemplate prefers a template embedded in the binary and falls back to a file on disk.
+func parseTemplate(name string) (*template.Template, error) {
+	embedded := path.Join("templates", name)
+	if _, err := fs.Stat(templateFS, embedded); err == nil {
+		return template.ParseFS(templateFS, embedded)
+	}
+	return template.ParseFiles(name)
+}
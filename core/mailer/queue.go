@@ -0,0 +1,348 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"pirate-lang-go/core/logger"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis keys used by the queue
+const (
+	queueKey         = "mail:queue"       // list of jobs ready to be sent
+	retryKey         = "mail:retry"       // sorted set of jobs waiting for their next attempt, scored by due time
+	processingPrefix = "mail:processing:" // list per worker of the job it is sending, <instance>:<worker>
+	instancePrefix   = "mail:instance:"   // set while a queue instance runs, expiring if it dies
+)
+
+// Job is an email waiting to be delivered by the queue workers.
+type Job struct {
+	ID         uuid.UUID `json:"id"`
+	Email      EmailData `json:"email"`
+	Attempts   int       `json:"attempts"`
+	LastError  string    `json:"last_error,omitempty"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+}
+
+// DeadLetterStore keeps the jobs that ran out of attempts so they can be inspected and retried.
+type DeadLetterStore interface {
+	SaveDeadLetter(ctx context.Context, job *Job) error
+}
+
+type QueueConfig struct {
+	Workers      int           // number of goroutines sending mails
+	MaxAttempts  int           // attempts before a job is moved to the dead-letter store
+	BaseBackoff  time.Duration // delay before the first retry, doubled on every attempt
+	MaxBackoff   time.Duration
+	PollInterval time.Duration // how often due retries are moved back to the queue, and how long a worker waits for a job
+}
+
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		Workers:      4,
+		MaxAttempts:  5,
+		BaseBackoff:  30 * time.Second,
+		MaxBackoff:   30 * time.Minute,
+		PollInterval: 5 * time.Second,
+	}
+}
+
+// IQueue is a mailer that can also re-enqueue jobs; it is implemented by Queue.
+type IQueue interface {
+	IMailer
+	Enqueue(ctx context.Context, job *Job) error
+}
+
+// Queue delivers emails in the background. SendMail and SendMailWithTemplate only enqueue the
+// email, so request handlers never wait on the SMTP server.
+type Queue struct {
+	client      *redis.Client
+	sender      IMailer
+	deadLetters DeadLetterStore
+	config      QueueConfig
+	instanceID  string
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewQueue(client *redis.Client, sender IMailer, deadLetters DeadLetterStore, config QueueConfig) *Queue {
+	defaults := DefaultQueueConfig()
+	if config.Workers <= 0 {
+		config.Workers = defaults.Workers
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = defaults.MaxAttempts
+	}
+	if config.BaseBackoff <= 0 {
+		config.BaseBackoff = defaults.BaseBackoff
+	}
+	if config.MaxBackoff < config.BaseBackoff {
+		config.MaxBackoff = max(defaults.MaxBackoff, config.BaseBackoff)
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = defaults.PollInterval
+	}
+	return &Queue{
+		client:      client,
+		sender:      sender,
+		deadLetters: deadLetters,
+		config:      config,
+		instanceID:  uuid.NewString(),
+	}
+}
+
+func (q *Queue) SendMail(data EmailData) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return q.Enqueue(ctx, &Job{ID: uuid.New(), Email: data})
+}
+
+// SendMailWithTemplate renders the template right away so that a broken template is reported to
+// the caller instead of failing in the worker.
+func (q *Queue) SendMailWithTemplate(data EmailData) error {
+	if err := renderTemplate(&data); err != nil {
+		return err
+	}
+	data.Template = ""
+	data.TemplateData = nil
+	return q.SendMail(data)
+}
+
+// Enqueue pushes a job to the queue; it is also used to retry dead letters.
+func (q *Queue) Enqueue(ctx context.Context, job *Job) error {
+	if job.ID == uuid.Nil {
+		job.ID = uuid.New()
+	}
+	job.EnqueuedAt = time.Now()
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode mail job: %w", err)
+	}
+	if err = q.client.LPush(ctx, queueKey, payload).Err(); err != nil {
+		return fmt.Errorf("failed to enqueue mail job: %w", err)
+	}
+	return nil
+}
+
+// Start runs the workers and the retry scheduler until Stop is called. Jobs left in the processing
+// lists of instances that died while sending them are queued again first.
+func (q *Queue) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	q.cancel = cancel
+
+	q.heartbeat(ctx)
+	q.requeueOrphans(ctx)
+	for i := 0; i < q.config.Workers; i++ {
+		q.wg.Add(1)
+		go q.work(ctx, q.processingKey(i))
+	}
+	q.wg.Add(1)
+	go q.schedule(ctx)
+	logger.Info("Mail queue started", "workers", q.config.Workers)
+}
+
+// Stop waits for the jobs being sent to finish; queued jobs stay in Redis.
+func (q *Queue) Stop() {
+	if q.cancel == nil {
+		return
+	}
+	q.cancel()
+	q.wg.Wait()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := q.client.Del(ctx, instancePrefix+q.instanceID).Err(); err != nil {
+		logger.Error("MailQueue:Stop:Error when clearing heartbeat", "error", err)
+	}
+	logger.Info("Mail queue stopped")
+}
+
+func (q *Queue) processingKey(worker int) string {
+	return fmt.Sprintf("%s%s:%d", processingPrefix, q.instanceID, worker)
+}
+
+// work moves each job into the worker's processing list while it is sent, so a job is not lost if
+// the process dies before it is handled; it is removed from there once sent, retried or dead-lettered.
+func (q *Queue) work(ctx context.Context, processingKey string) {
+	defer q.wg.Done()
+	for {
+		payload, err := q.client.BLMove(ctx, queueKey, processingKey, "RIGHT", "LEFT", q.config.PollInterval).Result()
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			logger.Error("MailQueue:work:Error when reading queue", "error", err)
+			sleep(ctx, q.config.PollInterval)
+			continue
+		}
+		q.process(processingKey, payload)
+	}
+}
+
+func (q *Queue) process(processingKey, payload string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if q.handle(ctx, payload) {
+		if err := q.client.LRem(ctx, processingKey, 1, payload).Err(); err != nil {
+			logger.Error("MailQueue:process:Error when removing job from processing list", "error", err)
+		}
+	}
+}
+
+// handle sends a job and reports whether it is done with it: sent, dropped, scheduled for a retry or
+// dead-lettered.
+func (q *Queue) handle(ctx context.Context, payload string) bool {
+	job := new(Job)
+	if err := json.Unmarshal([]byte(payload), job); err != nil {
+		logger.Error("MailQueue:handle:Dropping malformed job", "payload", payload, "error", err)
+		return true
+	}
+
+	err := q.sender.SendMail(job.Email)
+	if err == nil {
+		return true
+	}
+	job.Attempts++
+	job.LastError = err.Error()
+
+	if job.Attempts >= q.config.MaxAttempts {
+		logger.Error("MailQueue:handle:Giving up on mail", "job_id", job.ID, "attempts", job.Attempts, "error", err)
+		saveErr := q.deadLetters.SaveDeadLetter(ctx, job)
+		if saveErr == nil {
+			return true
+		}
+		// Keep the job around rather than losing it
+		logger.Error("MailQueue:handle:Error when saving dead letter", "job_id", job.ID, "error", saveErr)
+	} else {
+		logger.Warn("MailQueue:handle:Mail failed, will retry", "job_id", job.ID, "attempts", job.Attempts, "error", err)
+	}
+
+	if retryErr := q.retryLater(ctx, job); retryErr != nil {
+		// Left in the processing list, the job is queued again when the queue next starts
+		logger.Error("MailQueue:handle:Error when scheduling retry", "job_id", job.ID, "error", retryErr)
+		return false
+	}
+	return true
+}
+
+func (q *Queue) retryLater(ctx context.Context, job *Job) error {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	due := time.Now().Add(q.backoff(job.Attempts))
+	return q.client.ZAdd(ctx, retryKey, redis.Z{Score: float64(due.Unix()), Member: payload}).Err()
+}
+
+// backoff doubles the delay on every attempt and adds up to 20% jitter.
+func (q *Queue) backoff(attempts int) time.Duration {
+	delay := q.config.BaseBackoff
+	for i := 1; i < attempts && delay < q.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, q.config.MaxBackoff)
+	return delay + time.Duration(rand.Int64N(int64(delay)/5+1))
+}
+
+func (q *Queue) schedule(ctx context.Context) {
+	defer q.wg.Done()
+	ticker := time.NewTicker(q.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.heartbeat(ctx)
+			q.moveDueRetries(ctx)
+		}
+	}
+}
+
+// heartbeat marks the instance as running for a few poll intervals; its processing lists are only
+// taken over once it stops renewing the mark.
+func (q *Queue) heartbeat(ctx context.Context) {
+	if err := q.client.Set(ctx, instancePrefix+q.instanceID, time.Now().Unix(), 3*q.config.PollInterval).Err(); err != nil && ctx.Err() == nil {
+		logger.Error("MailQueue:heartbeat:Error when renewing heartbeat", "error", err)
+	}
+}
+
+// requeueOrphans moves back to the queue the jobs of processing lists whose instance is gone. They are
+// the oldest jobs, so they go to the end workers read from.
+func (q *Queue) requeueOrphans(ctx context.Context) {
+	iter := q.client.Scan(ctx, 0, processingPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		instanceID, _, _ := strings.Cut(strings.TrimPrefix(key, processingPrefix), ":")
+		alive, err := q.client.Exists(ctx, instancePrefix+instanceID).Result()
+		if err != nil {
+			logger.Error("MailQueue:requeueOrphans:Error when checking instance", "key", key, "error", err)
+			continue
+		}
+		if alive > 0 {
+			continue
+		}
+		requeued := 0
+		for {
+			err = q.client.LMove(ctx, key, queueKey, "LEFT", "RIGHT").Err()
+			if err != nil {
+				break
+			}
+			requeued++
+		}
+		if !errors.Is(err, redis.Nil) {
+			logger.Error("MailQueue:requeueOrphans:Error when re-queueing jobs", "key", key, "error", err)
+		}
+		if requeued > 0 {
+			logger.Warn("MailQueue:requeueOrphans:Re-queued jobs of a stopped worker", "key", key, "jobs", requeued)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		logger.Error("MailQueue:requeueOrphans:Error when listing processing lists", "error", err)
+	}
+}
+
+func (q *Queue) moveDueRetries(ctx context.Context) {
+	due, err := q.client.ZRangeByScore(ctx, retryKey, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(time.Now().Unix(), 10),
+		Count: 100,
+	}).Result()
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.Error("MailQueue:moveDueRetries:Error when reading retries", "error", err)
+		}
+		return
+	}
+	for _, payload := range due {
+		// Only the instance that removes the job re-queues it
+		removed, err := q.client.ZRem(ctx, retryKey, payload).Result()
+		if err != nil || removed == 0 {
+			continue
+		}
+		if err = q.client.LPush(ctx, queueKey, payload).Err(); err != nil {
+			logger.Error("MailQueue:moveDueRetries:Error when re-queueing job", "error", err)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,167 @@
+package mailer_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/mailer/smtptest"
+)
+
+func TestMain(m *testing.M) {
+	if err := logger.Init(logger.LogConfig{Level: logger.LogLevelError}); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+type deadLetters struct {
+	mu   sync.Mutex
+	jobs []*mailer.Job
+}
+
+func (d *deadLetters) SaveDeadLetter(ctx context.Context, job *mailer.Job) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.jobs = append(d.jobs, job)
+	return nil
+}
+
+func (d *deadLetters) count() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.jobs)
+}
+
+type queueFixture struct {
+	redis       *miniredis.Miniredis
+	smtp        *smtptest.Server
+	queue       *mailer.Queue
+	deadLetters *deadLetters
+}
+
+func newQueueFixture(t *testing.T, maxAttempts int) *queueFixture {
+	t.Helper()
+	store := miniredis.RunT(t)
+	server := smtptest.NewServer()
+	t.Cleanup(func() { server.Close() })
+	client := redis.NewClient(&redis.Options{Addr: store.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	dead := &deadLetters{}
+	queue := mailer.NewQueue(client, mailer.NewMailer(server.MailConfig()), dead, mailer.QueueConfig{
+		Workers:      2,
+		MaxAttempts:  maxAttempts,
+		BaseBackoff:  10 * time.Millisecond,
+		PollInterval: 20 * time.Millisecond,
+	})
+	return &queueFixture{redis: store, smtp: server, queue: queue, deadLetters: dead}
+}
+
+func (f *queueFixture) start(t *testing.T) {
+	t.Helper()
+	f.queue.Start()
+	t.Cleanup(f.queue.Stop)
+}
+
+// processing returns the jobs held in the processing lists of the workers.
+func (f *queueFixture) processing(t *testing.T) int {
+	t.Helper()
+	jobs := 0
+	for _, key := range f.redis.Keys() {
+		if strings.HasPrefix(key, "mail:processing:") {
+			list, _ := f.redis.List(key)
+			jobs += len(list)
+		}
+	}
+	return jobs
+}
+
+func eventually(t *testing.T, what string, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func mail(subject string) mailer.EmailData {
+	return mailer.EmailData{To: []string{"learner@example.com"}, Subject: subject, Body: "<p>Hello</p>"}
+}
+
+func TestQueueDeliversMail(t *testing.T) {
+	f := newQueueFixture(t, 3)
+	f.start(t)
+
+	if err := f.queue.SendMail(mail("Welcome")); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the mail", func() bool { return len(f.smtp.Messages()) == 1 })
+	if to := f.smtp.Messages()[0].To; len(to) != 1 || to[0] != "learner@example.com" {
+		t.Errorf("recipients = %v, want learner@example.com", to)
+	}
+	eventually(t, "the processing list to empty", func() bool { return f.processing(t) == 0 })
+}
+
+func TestQueueRetriesFailedMail(t *testing.T) {
+	f := newQueueFixture(t, 3)
+	f.smtp.FailNext(1)
+	f.start(t)
+
+	if err := f.queue.SendMail(mail("Retry")); err != nil {
+		t.Fatal(err)
+	}
+	// The retry is due within the second the backoff rounds to
+	eventually(t, "the retried mail", func() bool { return len(f.smtp.Messages()) == 1 })
+	eventually(t, "the processing list to empty", func() bool { return f.processing(t) == 0 })
+	if f.deadLetters.count() != 0 {
+		t.Errorf("dead letters = %d, want none", f.deadLetters.count())
+	}
+}
+
+func TestQueueDeadLettersMailOutOfAttempts(t *testing.T) {
+	f := newQueueFixture(t, 1)
+	f.smtp.FailNext(1)
+	f.start(t)
+
+	if err := f.queue.SendMail(mail("Lost")); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the dead letter", func() bool { return f.deadLetters.count() == 1 })
+	eventually(t, "the processing list to empty", func() bool { return f.processing(t) == 0 })
+	if messages := f.smtp.Messages(); len(messages) != 0 {
+		t.Errorf("messages = %d, want none", len(messages))
+	}
+}
+
+// A job a stopped instance was sending is sent again by the next instance to start, while the jobs
+// of instances still running are left to them.
+func TestQueueRequeuesOrphanedJobs(t *testing.T) {
+	f := newQueueFixture(t, 3)
+	orphan, _ := json.Marshal(&mailer.Job{ID: uuid.New(), Email: mail("Orphan")})
+	busy, _ := json.Marshal(&mailer.Job{ID: uuid.New(), Email: mail("Busy")})
+	f.redis.Lpush("mail:processing:stopped:0", string(orphan))
+	f.redis.Lpush("mail:processing:running:0", string(busy))
+	f.redis.Set("mail:instance:running", "1")
+	f.start(t)
+
+	eventually(t, "the orphaned mail", func() bool { return len(f.smtp.Messages()) == 1 })
+	if f.redis.Exists("mail:processing:stopped:0") {
+		t.Error("processing list of the stopped instance was not emptied")
+	}
+	if list, _ := f.redis.List("mail:processing:running:0"); len(list) != 1 {
+		t.Errorf("processing list of the running instance = %d jobs, want 1", len(list))
+	}
+}
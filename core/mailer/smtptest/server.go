@@ -0,0 +1,171 @@
+// Package smtptest provides a local SMTP server that records the mails it receives, for
+// exercising the mailer and the mail queue without reaching a real SMTP server.
+package smtptest
+
+import (
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"pirate-lang-go/core/mailer"
+)
+
+const (
+	Username = "smtptest@localhost"
+	Password = "smtptest-secret"
+)
+
+// Message is a mail accepted by the server.
+type Message struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+// Server accepts any AUTH PLAIN login on 127.0.0.1 and stores every message sent to it.
+// FailNext makes it reject the next messages with a temporary error.
+type Server struct {
+	listener net.Listener
+
+	mu       sync.Mutex
+	messages []Message
+	failures int
+	wg       sync.WaitGroup
+}
+
+// NewServer starts a server on a random local port; it panics if it cannot listen, like httptest.
+func NewServer() *Server {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(fmt.Sprintf("smtptest: failed to listen: %v", err))
+	}
+	s := &Server{listener: listener}
+	s.wg.Add(1)
+	go s.serve()
+	return s
+}
+
+// MailConfig points a mailer at this server.
+func (s *Server) MailConfig() mailer.MailConfig {
+	addr := s.listener.Addr().(*net.TCPAddr)
+	return mailer.MailConfig{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		Username: Username,
+		Password: Password,
+		FromName: "smtptest",
+	}
+}
+
+// FailNext rejects the next n messages with "451 temporary failure".
+func (s *Server) FailNext(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = n
+}
+
+// Messages returns the messages received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Message(nil), s.messages...)
+}
+
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *Server) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+			s.handle(textproto.NewConn(conn))
+		}()
+	}
+}
+
+func (s *Server) handle(conn *textproto.Conn) {
+	reply := func(format string, args ...any) bool {
+		return conn.PrintfLine(format, args...) == nil
+	}
+	if !reply("220 smtptest ESMTP ready") {
+		return
+	}
+
+	var current Message
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO":
+			if !reply("250-smtptest\r\n250-8BITMIME\r\n250 AUTH PLAIN") {
+				return
+			}
+		case "HELO", "NOOP":
+			reply("250 OK")
+		case "AUTH":
+			reply("235 2.7.0 Authentication successful")
+		case "MAIL":
+			current = Message{From: address(arg)}
+			reply("250 OK")
+		case "RCPT":
+			current.To = append(current.To, address(arg))
+			reply("250 OK")
+		case "DATA":
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = data
+			if s.accept(current) {
+				reply("250 OK")
+			} else {
+				reply("451 4.3.0 temporary failure")
+			}
+			current = Message{}
+		case "RSET":
+			current = Message{}
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *Server) accept(message Message) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return false
+	}
+	s.messages = append(s.messages, message)
+	return true
+}
+
+// address extracts the mailbox from "FROM:<a@b>" or "TO:<a@b>".
+func address(arg string) string {
+	_, value, _ := strings.Cut(arg, ":")
+	value, _, _ = strings.Cut(strings.TrimSpace(value), " ")
+	return strings.Trim(value, "<>")
+}
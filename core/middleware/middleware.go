@@ -1,10 +1,11 @@
 package middleware
 
 import (
-	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"net/http"
+	"pirate-lang-go/core/constants"
 	"pirate-lang-go/core/controller"
+	"pirate-lang-go/core/errors"
 	"pirate-lang-go/core/logger"
 	"pirate-lang-go/core/utils"
 	"pirate-lang-go/modules/account/service"
@@ -80,6 +81,18 @@ func (m *Middleware) AuthMiddleware() echo.MiddlewareFunc {
 			if err != nil {
 				return m.Unauthorized("invalid token")
 			}
+			// Refresh tokens are only accepted by the refresh endpoint
+			if claims.TokenType != constants.TokenTypeAccess {
+				return m.Unauthorized("invalid token")
+			}
+			revoked, appErr := m.accountService.IsTokenRevoked(c.Request().Context(), claims)
+			if appErr != nil {
+				logger.Error("Error checking token revocation", "error", appErr)
+				return m.InternalServerError("error checking token")
+			}
+			if revoked {
+				return m.Unauthorized("token revoked")
+			}
 
 			// Set user claims in context
 			c.Set("user", claims)
@@ -88,23 +101,46 @@ func (m *Middleware) AuthMiddleware() echo.MiddlewareFunc {
 		}
 	}
 }
+
+// PermissionMiddleware requires the authenticated user to hold every listed permission.
+// It must run after AuthMiddleware.
 func (m *Middleware) PermissionMiddleware(requiredPermissions ...string) echo.MiddlewareFunc {
+	return m.permissionMiddleware(true, requiredPermissions)
+}
+
+// AnyPermissionMiddleware requires the authenticated user to hold at least one of the listed permissions.
+// It must run after AuthMiddleware.
+func (m *Middleware) AnyPermissionMiddleware(requiredPermissions ...string) echo.MiddlewareFunc {
+	return m.permissionMiddleware(false, requiredPermissions)
+}
+
+func (m *Middleware) permissionMiddleware(requireAll bool, requiredPermissions []string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			userClaims, ok := c.Get("user").(*utils.Claims)
 			if !ok {
-				return m.Unauthorized("missing authorization header")
+				return m.Unauthorized("missing authorization header",
+					errors.NewAppError(errors.ErrUnauthorized, "authentication required", nil))
 			}
 
 			// Use account service to check permissions
-			hasPermission, err := m.accountService.HasPermission(c.Request().Context(), userClaims.UserID, uuid.Nil)
+			var (
+				hasPermission bool
+				err           *errors.AppError
+			)
+			if requireAll {
+				hasPermission, err = m.accountService.HasAllPermissions(c.Request().Context(), userClaims.UserID, requiredPermissions...)
+			} else {
+				hasPermission, err = m.accountService.HasAnyPermission(c.Request().Context(), userClaims.UserID, requiredPermissions...)
+			}
 			if err != nil {
 				logger.Error("Error checking permissions", "error", err)
 				return m.InternalServerError("error checking permissions")
 			}
 
 			if !hasPermission {
-				return m.Forbidden("insufficient permissions")
+				return m.Forbidden("insufficient permissions",
+					errors.NewAppError(errors.ErrForbidden, "requires "+strings.Join(requiredPermissions, ", "), nil))
 			}
 
 			return next(c)
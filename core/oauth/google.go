@@ -0,0 +1,29 @@
+package oauth
+
+import "pirate-lang-go/core/config"
+
+const ProviderGoogle = "google"
+
+// Google's public OpenID Connect endpoints
+var GoogleEndpoints = Endpoints{
+	AuthURL:     "https://accounts.google.com/o/oauth2/v2/auth",
+	TokenURL:    "https://oauth2.googleapis.com/token",
+	UserInfoURL: "https://openidconnect.googleapis.com/v1/userinfo",
+}
+
+// NewGoogleProvider builds the Google provider. Endpoints set in cfg override
+// the public ones, which lets a local fake server stand in for Google.
+func NewGoogleProvider(cfg config.OAuthProviderConfig) *OIDCProvider {
+	endpoints := GoogleEndpoints
+	if cfg.AuthURL != "" {
+		endpoints.AuthURL = cfg.AuthURL
+	}
+	if cfg.TokenURL != "" {
+		endpoints.TokenURL = cfg.TokenURL
+	}
+	if cfg.UserInfoURL != "" {
+		endpoints.UserInfoURL = cfg.UserInfoURL
+	}
+	return NewOIDCProvider(ProviderGoogle, cfg.ClientID, cfg.ClientSecret, cfg.RedirectURL,
+		[]string{"openid", "email", "profile"}, endpoints)
+}
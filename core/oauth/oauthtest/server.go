@@ -0,0 +1,197 @@
+// Package oauthtest provides a local fake OIDC provider for exercising the OAuth login flow
+// without reaching a real identity provider.
+package oauthtest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/oauth"
+)
+
+const (
+	ClientID     = "oauthtest-client"
+	ClientSecret = "oauthtest-secret"
+)
+
+// User is an identity the fake provider signs in.
+type User struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+}
+
+type authorization struct {
+	user          User
+	redirectURI   string
+	codeChallenge string
+}
+
+// Server is a fake OIDC provider serving /authorize, /token and /userinfo.
+// /authorize signs in the user set with SignInAs and redirects straight back with a code.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	current     User
+	codes       map[string]authorization
+	accessToken map[string]User
+}
+
+func NewServer() *Server {
+	s := &Server{
+		codes:       make(map[string]authorization),
+		accessToken: make(map[string]User),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/authorize", s.authorize)
+	mux.HandleFunc("/token", s.token)
+	mux.HandleFunc("/userinfo", s.userInfo)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// SignInAs sets the identity returned for the next authorizations.
+func (s *Server) SignInAs(user User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.current = user
+}
+
+// Endpoints returns the URLs of the fake provider.
+func (s *Server) Endpoints() oauth.Endpoints {
+	return oauth.Endpoints{
+		AuthURL:     s.URL + "/authorize",
+		TokenURL:    s.URL + "/token",
+		UserInfoURL: s.URL + "/userinfo",
+	}
+}
+
+// ProviderConfig returns a provider config pointing at the fake server.
+func (s *Server) ProviderConfig(redirectURL string) config.OAuthProviderConfig {
+	endpoints := s.Endpoints()
+	return config.OAuthProviderConfig{
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		RedirectURL:  redirectURL,
+		AuthURL:      endpoints.AuthURL,
+		TokenURL:     endpoints.TokenURL,
+		UserInfoURL:  endpoints.UserInfoURL,
+	}
+}
+
+// Authorize performs the browser leg of the flow for an authorization URL and
+// returns the callback URL the provider redirects to.
+func (s *Server) Authorize(authCodeURL string) (*url.URL, error) {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Get(authCodeURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return resp.Location()
+}
+
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("client_id") != ClientID || query.Get("response_type") != "code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request: PKCE required", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.String() == "" {
+		http.Error(w, "invalid_request: redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	code := randomToken()
+	s.mu.Lock()
+	s.codes[code] = authorization{
+		user:          s.current,
+		redirectURI:   redirectURI.String(),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	s.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (s *Server) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeError(w, "invalid_request")
+		return
+	}
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeError(w, "invalid_client")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	code := r.PostForm.Get("code")
+	auth, ok := s.codes[code]
+	delete(s.codes, code) // codes are single use
+	if !ok || auth.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeError(w, "invalid_grant")
+		return
+	}
+	if oauth.CodeChallengeS256(r.PostForm.Get("code_verifier")) != auth.codeChallenge {
+		writeError(w, "invalid_grant")
+		return
+	}
+
+	accessToken := randomToken()
+	s.accessToken[accessToken] = auth.user
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(oauth.Token{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+	})
+}
+
+func (s *Server) userInfo(w http.ResponseWriter, r *http.Request) {
+	accessToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	s.mu.Lock()
+	user, ok := s.accessToken[accessToken]
+	s.mu.Unlock()
+	if !ok {
+		http.Error(w, "invalid_token", http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(user)
+}
+
+func writeError(w http.ResponseWriter, code string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{"error": code})
+}
+
+func randomToken() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,137 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Endpoints are the URLs of an OIDC provider.
+type Endpoints struct {
+	AuthURL     string
+	TokenURL    string
+	UserInfoURL string
+}
+
+// OIDCProvider implements Provider for a standard OpenID Connect provider
+// using the authorization code flow and the userinfo endpoint.
+type OIDCProvider struct {
+	name         string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	scopes       []string
+	endpoints    Endpoints
+	httpClient   *http.Client
+}
+
+func NewOIDCProvider(name, clientID, clientSecret, redirectURL string, scopes []string, endpoints Endpoints) *OIDCProvider {
+	return &OIDCProvider{
+		name:         name,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		scopes:       scopes,
+		endpoints:    endpoints,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *OIDCProvider) Name() string {
+	return p.name
+}
+
+func (p *OIDCProvider) AuthCodeURL(state, codeChallenge string) string {
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.clientID)
+	params.Set("redirect_uri", p.redirectURL)
+	params.Set("scope", strings.Join(p.scopes, " "))
+	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.endpoints.AuthURL, "?") {
+		separator = "&"
+	}
+	return p.endpoints.AuthURL + separator + params.Encode()
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code, codeVerifier string) (*Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("client_id", p.clientID)
+	form.Set("client_secret", p.clientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoints.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	token := new(Token)
+	if err = p.do(req, token); err != nil {
+		return nil, fmt.Errorf("oauth: token exchange failed: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("oauth: token response has no access_token")
+	}
+	return token, nil
+}
+
+func (p *OIDCProvider) UserInfo(ctx context.Context, token *Token) (*UserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoints.UserInfoURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var claims struct {
+		Subject       string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+		Picture       string `json:"picture"`
+	}
+	if err = p.do(req, &claims); err != nil {
+		return nil, fmt.Errorf("oauth: userinfo request failed: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("oauth: userinfo response has no sub")
+	}
+	return &UserInfo{
+		ProviderUserID: claims.Subject,
+		Email:          strings.ToLower(claims.Email),
+		EmailVerified:  claims.EmailVerified,
+		FullName:       claims.Name,
+		AvatarURL:      claims.Picture,
+	}, nil
+}
+
+func (p *OIDCProvider) do(req *http.Request, out any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
@@ -0,0 +1,31 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// GenerateState returns a random value used for the state parameter.
+func GenerateState() (string, error) {
+	return randomString(32)
+}
+
+// GenerateCodeVerifier returns a random PKCE code verifier (RFC 7636).
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// CodeChallengeS256 derives the S256 code challenge of a verifier.
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,72 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"pirate-lang-go/core/config"
+)
+
+var ErrUnknownProvider = errors.New("oauth: unknown provider")
+
+// Token is the token response of an authorization code exchange.
+type Token struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// UserInfo is the identity returned by a provider for a signed-in user.
+type UserInfo struct {
+	ProviderUserID string
+	Email          string
+	EmailVerified  bool
+	FullName       string
+	AvatarURL      string
+}
+
+// Provider is an OAuth2 authorization code provider with PKCE support.
+type Provider interface {
+	Name() string
+	// AuthCodeURL returns the URL the user is sent to in order to sign in.
+	AuthCodeURL(state, codeChallenge string) string
+	// Exchange trades an authorization code for tokens.
+	Exchange(ctx context.Context, code, codeVerifier string) (*Token, error)
+	// UserInfo fetches the identity of the user the token was issued for.
+	UserInfo(ctx context.Context, token *Token) (*UserInfo, error)
+}
+
+// Registry holds the providers available for login, keyed by name.
+type Registry struct {
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	registry := &Registry{providers: make(map[string]Provider)}
+	for _, provider := range providers {
+		registry.Register(provider)
+	}
+	return registry
+}
+
+// NewRegistryFromConfig registers every provider that has a client id configured.
+func NewRegistryFromConfig(cfg config.OAuthConfig) *Registry {
+	registry := NewRegistry()
+	if cfg.Google.ClientID != "" {
+		registry.Register(NewGoogleProvider(cfg.Google))
+	}
+	return registry
+}
+
+func (r *Registry) Register(provider Provider) {
+	r.providers[provider.Name()] = provider
+}
+
+func (r *Registry) Get(name string) (Provider, error) {
+	provider, ok := r.providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return provider, nil
+}
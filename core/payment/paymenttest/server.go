@@ -0,0 +1,187 @@
+// Package paymenttest provides a local fake of the Stripe Checkout API for exercising checkout
+// and webhook processing without reaching a real payment provider.
+package paymenttest
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/payment"
+)
+
+const (
+	SecretKey     = "sk_test_paymenttest"
+	WebhookSecret = "whsec_paymenttest"
+)
+
+// Session is a checkout session created on the fake server.
+type Session struct {
+	ID                string
+	ClientReferenceID string
+	AmountCents       int64
+	Currency          string
+	CustomerEmail     string
+	SuccessURL        string
+	CancelURL         string
+}
+
+// Server serves POST /v1/checkout/sessions and builds the signed webhooks a provider would send
+// once a session is paid, fails or expires.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+	byKey    map[string]string // Idempotency-Key to session id
+}
+
+func NewServer() *Server {
+	s := &Server{
+		sessions: make(map[string]*Session),
+		byKey:    make(map[string]string),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/checkout/sessions", s.createSession)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// ProviderConfig returns a provider config pointing at the fake server.
+func (s *Server) ProviderConfig() config.PaymentProviderConfig {
+	return config.PaymentProviderConfig{
+		SecretKey:     SecretKey,
+		WebhookSecret: WebhookSecret,
+		APIURL:        s.URL,
+	}
+}
+
+// Session returns a session created on the server.
+func (s *Server) Session(id string) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[id]
+	return session, ok
+}
+
+// Pay builds the signed webhook reporting that the session was paid in full.
+func (s *Server) Pay(sessionID string) ([]byte, http.Header, error) {
+	return s.Webhook(sessionID, "checkout.session.completed", "paid")
+}
+
+// Expire builds the signed webhook reporting that the session expired unpaid.
+func (s *Server) Expire(sessionID string) ([]byte, http.Header, error) {
+	return s.Webhook(sessionID, "checkout.session.expired", "unpaid")
+}
+
+// Webhook builds a signed event of the given type about a session. Each call yields a new event id;
+// send the same payload twice to exercise duplicate deliveries.
+func (s *Server) Webhook(sessionID, eventType, paymentStatus string) ([]byte, http.Header, error) {
+	session, ok := s.Session(sessionID)
+	if !ok {
+		return nil, nil, fmt.Errorf("paymenttest: unknown session %q", sessionID)
+	}
+	now := time.Now()
+	payload, err := json.Marshal(map[string]any{
+		"id":      "evt_" + randomToken(),
+		"type":    eventType,
+		"created": now.Unix(),
+		"data": map[string]any{
+			"object": map[string]any{
+				"id":                  session.ID,
+				"object":              "checkout.session",
+				"client_reference_id": session.ClientReferenceID,
+				"amount_total":        session.AmountCents,
+				"currency":            session.Currency,
+				"payment_status":      paymentStatus,
+				"metadata":            map[string]string{"order_id": session.ClientReferenceID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set(payment.StripeSignatureHeader, payment.SignPayload(WebhookSecret, payload, now))
+	return payload, header, nil
+}
+
+// Deliver posts a webhook to the application, as the provider would.
+func Deliver(url string, payload []byte, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = header.Clone()
+	return http.DefaultClient.Do(req)
+}
+
+func (s *Server) createSession(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer "+SecretKey {
+		writeError(w, http.StatusUnauthorized, "Invalid API Key provided")
+		return
+	}
+	if r.ParseForm() != nil || r.PostForm.Get("mode") != "payment" {
+		writeError(w, http.StatusBadRequest, "mode must be payment")
+		return
+	}
+	amount, err := strconv.ParseInt(r.PostForm.Get("line_items[0][price_data][unit_amount]"), 10, 64)
+	if err != nil || amount < 0 {
+		writeError(w, http.StatusBadRequest, "invalid unit_amount")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := r.Header.Get("Idempotency-Key")
+	session, ok := s.sessions[s.byKey[key]]
+	if key == "" || !ok {
+		session = &Session{
+			ID:                "cs_test_" + randomToken(),
+			ClientReferenceID: r.PostForm.Get("client_reference_id"),
+			AmountCents:       amount,
+			Currency:          strings.ToLower(r.PostForm.Get("line_items[0][price_data][currency]")),
+			CustomerEmail:     r.PostForm.Get("customer_email"),
+			SuccessURL:        r.PostForm.Get("success_url"),
+			CancelURL:         r.PostForm.Get("cancel_url"),
+		}
+		s.sessions[session.ID] = session
+		if key != "" {
+			s.byKey[key] = session.ID
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"id":         session.ID,
+		"object":     "checkout.session",
+		"url":        s.URL + "/pay/" + session.ID,
+		"expires_at": time.Now().Add(24 * time.Hour).Unix(),
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{"error": map[string]string{"message": message}})
+}
+
+func randomToken() string {
+	buf := make([]byte, 12)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
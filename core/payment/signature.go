@@ -0,0 +1,71 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureTolerance is how old a signed webhook may be, limiting replays.
+const SignatureTolerance = 5 * time.Minute
+
+// SignPayload builds a "t=<unix>,v1=<hex hmac>" signature header for a webhook payload. The HMAC-SHA256
+// covers "<unix>.<payload>", so the timestamp cannot be changed without the secret.
+func SignPayload(secret string, payload []byte, timestamp time.Time) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", unix, computeSignature(secret, unix, payload))
+}
+
+// VerifySignature checks a header built by SignPayload. Any of several v1 values may match,
+// which allows the secret to be rotated. Nothing verifies against an empty secret.
+func VerifySignature(header string, payload []byte, secret string, now time.Time) error {
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+	var (
+		unix       string
+		signatures []string
+	)
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if unix == "" || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := []byte(computeSignature(secret, unix, payload))
+	for _, signature := range signatures {
+		if hmac.Equal(expected, []byte(signature)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func computeSignature(secret, unix string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,175 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"pirate-lang-go/core/config"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ProviderStripe = "stripe"
+	StripeAPIURL   = "https://api.stripe.com"
+	// StripeSignatureHeader carries the webhook signature, in the format of SignPayload.
+	StripeSignatureHeader = "Stripe-Signature"
+)
+
+// StripeProvider implements Provider with Stripe Checkout in payment mode. The API URL can be
+// overridden, which lets a local fake server stand in for Stripe.
+type StripeProvider struct {
+	secretKey     string
+	webhookSecret string
+	apiURL        string
+	httpClient    *http.Client
+}
+
+func NewStripeProvider(cfg config.PaymentProviderConfig) *StripeProvider {
+	apiURL := StripeAPIURL
+	if cfg.APIURL != "" {
+		apiURL = strings.TrimRight(cfg.APIURL, "/")
+	}
+	return &StripeProvider{
+		secretKey:     cfg.SecretKey,
+		webhookSecret: cfg.WebhookSecret,
+		apiURL:        apiURL,
+		httpClient:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *StripeProvider) Name() string {
+	return ProviderStripe
+}
+
+func (p *StripeProvider) CreateCheckoutSession(ctx context.Context, request *CheckoutRequest) (*CheckoutSession, error) {
+	form := url.Values{}
+	form.Set("mode", "payment")
+	form.Set("client_reference_id", request.OrderID.String())
+	form.Set("metadata[order_id]", request.OrderID.String())
+	form.Set("success_url", request.SuccessURL)
+	form.Set("cancel_url", request.CancelURL)
+	if request.CustomerEmail != "" {
+		form.Set("customer_email", request.CustomerEmail)
+	}
+	form.Set("line_items[0][quantity]", "1")
+	form.Set("line_items[0][price_data][currency]", strings.ToLower(request.Currency))
+	form.Set("line_items[0][price_data][unit_amount]", strconv.FormatInt(request.AmountCents, 10))
+	form.Set("line_items[0][price_data][product_data][name]", request.Description)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiURL+"/v1/checkout/sessions", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.secretKey)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// The order id makes a retried request return the same session
+	req.Header.Set("Idempotency-Key", "checkout-"+request.OrderID.String())
+
+	var session struct {
+		ID        string `json:"id"`
+		URL       string `json:"url"`
+		ExpiresAt int64  `json:"expires_at"`
+	}
+	if err = p.do(req, &session); err != nil {
+		return nil, fmt.Errorf("payment: create checkout session failed: %w", err)
+	}
+	if session.ID == "" || session.URL == "" {
+		return nil, fmt.Errorf("payment: checkout session response has no id or url")
+	}
+	checkout := &CheckoutSession{SessionID: session.ID, URL: session.URL}
+	if session.ExpiresAt > 0 {
+		expiresAt := time.Unix(session.ExpiresAt, 0)
+		checkout.ExpiresAt = &expiresAt
+	}
+	return checkout, nil
+}
+
+// stripeEvent is the part of a Stripe event about a checkout session that we read.
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object struct {
+			ID                string            `json:"id"`
+			Object            string            `json:"object"`
+			ClientReferenceID string            `json:"client_reference_id"`
+			AmountTotal       int64             `json:"amount_total"`
+			Currency          string            `json:"currency"`
+			PaymentStatus     string            `json:"payment_status"`
+			Metadata          map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+func (p *StripeProvider) ParseWebhook(payload []byte, header http.Header) (*Event, error) {
+	if err := VerifySignature(header.Get(StripeSignatureHeader), payload, p.webhookSecret, time.Now()); err != nil {
+		return nil, err
+	}
+	var raw stripeEvent
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return nil, fmt.Errorf("payment: invalid webhook payload: %w", err)
+	}
+	if raw.ID == "" {
+		return nil, fmt.Errorf("payment: webhook event has no id")
+	}
+
+	event := &Event{
+		ID:          raw.ID,
+		Type:        EventIgnored,
+		RawType:     raw.Type,
+		SessionID:   raw.Data.Object.ID,
+		AmountCents: raw.Data.Object.AmountTotal,
+		Currency:    strings.ToUpper(raw.Data.Object.Currency),
+		OccurredAt:  time.Unix(raw.Created, 0),
+	}
+	if raw.Data.Object.Object != "checkout.session" {
+		return event, nil
+	}
+	orderID := raw.Data.Object.ClientReferenceID
+	if orderID == "" {
+		orderID = raw.Data.Object.Metadata["order_id"]
+	}
+	if parsed, err := uuid.Parse(orderID); err == nil {
+		event.OrderID = parsed
+	}
+
+	switch raw.Type {
+	case "checkout.session.completed":
+		// Delayed payment methods complete the session before the money arrives
+		if raw.Data.Object.PaymentStatus == "paid" {
+			event.Type = EventPaymentSucceeded
+		}
+	case "checkout.session.async_payment_succeeded":
+		event.Type = EventPaymentSucceeded
+	case "checkout.session.async_payment_failed":
+		event.Type = EventPaymentFailed
+	case "checkout.session.expired":
+		event.Type = EventCheckoutExpired
+	}
+	return event, nil
+}
+
+func (p *StripeProvider) do(req *http.Request, out any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
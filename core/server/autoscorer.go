@@ -0,0 +1,31 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"pirate-lang-go/modules/attempt/scorer"
+	"time"
+)
+
+// RunAutoScorer serves the heuristic scorer over HTTP, standing in for an external scoring service.
+// Point the server at it with APP_AUTO_SCORING_SCORER=http and APP_AUTO_SCORING_URL:
+//
+//	go run main.go autoscorer -addr localhost:8090 -api-key secret
+func RunAutoScorer(args []string) error {
+	flags := flag.NewFlagSet("autoscorer", flag.ContinueOnError)
+	addr := flags.String("addr", "localhost:8090", "Address to listen on")
+	apiKey := flags.String("api-key", "", "Bearer token the requests must carry")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           scorer.NewHandler(scorer.NewHeuristicScorer(), *apiKey),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintln(os.Stderr, "autoscorer: listening on", *addr)
+	return srv.ListenAndServe()
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/modules/library"
+)
+
+// RunImport imports an exam package from the command line and prints the import report:
+//
+//	go run main.go import -env dev -editor <user id> package.zip
+func RunImport(args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	env := flags.String("env", "dev", "Environment (dev/prod)")
+	editor := flags.String("editor", "", "ID of the user recorded as the author of the imported content")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return fmt.Errorf("usage: import [-env dev|prod] [-editor user-id] package.zip")
+	}
+	environment, err := parseEnvironment(*env)
+	if err != nil {
+		return err
+	}
+	editorId := uuid.Nil
+	if *editor != "" {
+		if editorId, err = uuid.Parse(*editor); err != nil {
+			return fmt.Errorf("invalid editor ID: %w", err)
+		}
+	}
+
+	archive, err := zip.OpenReader(flags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to open package: %w", err)
+	}
+	defer archive.Close()
+
+	deps, err := initDependencies(environment)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := deps.cache.Close(); err != nil {
+			logger.Error("Failed to close Redis connection", "error", err)
+		}
+	}()
+
+	report, appErr := library.NewService(deps.db, deps.cache, deps.storage).ImportPackage(context.Background(), editorId, &archive.Reader)
+	if appErr != nil {
+		return appErr
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	if !report.Imported {
+		return fmt.Errorf("package has %d errors, nothing was imported", len(report.Errors))
+	}
+	return nil
+}
@@ -5,8 +5,16 @@ import (
 	"flag"
 	"fmt"
 	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/mailer"
 	"pirate-lang-go/core/storage"
+	"pirate-lang-go/modules/attempt"
+	attemptservice "pirate-lang-go/modules/attempt/service"
 	"pirate-lang-go/modules/library"
+	libraryservice "pirate-lang-go/modules/library/service"
+	"pirate-lang-go/modules/mail"
+	mailrepo "pirate-lang-go/modules/mail/repository"
+	"pirate-lang-go/modules/payment"
+	"pirate-lang-go/modules/subscription"
 
 	"os"
 	"os/signal"
@@ -27,13 +35,19 @@ type Server struct {
 	cache   *cache.Cache
 	db      database.Database
 	storage *storage.Storage
+	mails   *mailer.Queue
+	purger  *libraryservice.TrashPurger
+	scorer  *attemptservice.AutoScoreWorker
 }
 
 func initEnvironment() (config.Environment, error) {
 	env := flag.String("env", "dev", "Environment (dev/prod)")
 	flag.Parse()
+	return parseEnvironment(*env)
+}
 
-	switch *env {
+func parseEnvironment(env string) (config.Environment, error) {
+	switch env {
 	case "dev":
 		return config.DevEnvironment, nil
 	case "prod":
@@ -43,12 +57,14 @@ func initEnvironment() (config.Environment, error) {
 	}
 }
 
-func initServer() (*Server, error) {
-	environment, err := initEnvironment()
-	if err != nil {
-		return nil, err
-	}
+// dependencies are the connections shared by the HTTP server and the command line tools.
+type dependencies struct {
+	db      database.Database
+	cache   *cache.Cache
+	storage *storage.Storage
+}
 
+func initDependencies(environment config.Environment) (*dependencies, error) {
 	if errInitConfig := config.Init(environment); errInitConfig != nil {
 		return nil, fmt.Errorf("failed to initialize config: %w", errInitConfig)
 	}
@@ -82,11 +98,6 @@ func initServer() (*Server, error) {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
-	logger.Info("Server initializing",
-		"environment", environment,
-		"host", cfg.Server.Host,
-		"port", cfg.Server.Port,
-	)
 	// Initialize Redis cache
 	redisCache := cache.NewCache(
 		cfg.Redis.Address,
@@ -104,6 +115,44 @@ func initServer() (*Server, error) {
 		logger.Error("failed to initialize MinIO client: %w", err)
 		return nil, err
 	}
+	return &dependencies{
+		db:      db,
+		cache:   redisCache,
+		storage: minioStorage,
+	}, nil
+}
+
+func initServer() (*Server, error) {
+	environment, err := initEnvironment()
+	if err != nil {
+		return nil, err
+	}
+	deps, err := initDependencies(environment)
+	if err != nil {
+		return nil, err
+	}
+	cfg := config.Get()
+	db, redisCache, minioStorage := deps.db, deps.cache, deps.storage
+	logger.Info("Server initializing",
+		"environment", environment,
+		"host", cfg.Server.Host,
+		"port", cfg.Server.Port,
+	)
+	// Initialize SMTP mailer
+	smtpMailer := mailer.NewMailer(mailer.MailConfig{
+		Host:     cfg.SMTP.Host,
+		Port:     cfg.SMTP.Port,
+		Username: cfg.SMTP.Username,
+		Password: cfg.SMTP.Password,
+		FromName: cfg.SMTP.FromName,
+	})
+	// Mails are sent by background workers; the ones that keep failing are stored in Postgres
+	mailQueue := mailer.NewQueue(
+		redisCache.GetClient(),
+		smtpMailer,
+		mailrepo.NewMailRepository(db.DB()),
+		mailer.DefaultQueueConfig(),
+	)
 	e := echo.New()
 
 	// Middleware
@@ -111,19 +160,29 @@ func initServer() (*Server, error) {
 	e.Use(middleware.CORSMiddleware())
 
 	// Initialize modules
-	account.Init(e, db, redisCache, minioStorage)
-	library.Init(e, db, redisCache, minioStorage)
+	account.Init(e, db, redisCache, minioStorage, mailQueue)
+	library.Init(e, db, redisCache, minioStorage, mailQueue)
+	attempt.Init(e, db, redisCache, minioStorage, mailQueue)
+	mail.Init(e, db, redisCache, minioStorage, mailQueue)
+	subscription.Init(e, db, redisCache, minioStorage, mailQueue)
+	payment.Init(e, db, redisCache, minioStorage, mailQueue)
 	return &Server{
 		echo:    e,
 		addr:    fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
 		cache:   redisCache,
 		storage: minioStorage,
 		db:      db,
+		mails:   mailQueue,
+		purger:  library.NewTrashPurger(db, redisCache, minioStorage),
+		scorer:  attempt.NewAutoScoreWorker(db, redisCache, minioStorage),
 	}, nil
 }
 
 func (s *Server) start() error {
 	logger.Info("Starting HTTP server", "address", s.addr)
+	s.mails.Start()
+	s.purger.Start()
+	s.scorer.Start()
 
 	go func() {
 		if err := s.echo.Start(s.addr); err != nil {
@@ -141,6 +200,10 @@ func (s *Server) start() error {
 	if err := s.echo.Shutdown(ctx); err != nil {
 		return fmt.Errorf("failed to shutdown server gracefully: %w", err)
 	}
+	// Let the mails being sent, a running purge and a scoring batch finish before Redis goes away
+	s.mails.Stop()
+	s.purger.Stop()
+	s.scorer.Stop()
 	// Close Redis connection
 	if err := s.cache.Close(); err != nil {
 		logger.Error("Failed to close Redis connection", "error", err)
@@ -15,5 +15,11 @@ type IStorage interface {
 	// Audio Operations
 	UploadAudio(ctx context.Context, id uuid.UUID, file io.Reader, fileSize int64, filename string, folder string) (string, string, error)
 	UploadTranscriptAudio(ctx context.Context, id uuid.UUID, file io.Reader, fileSize int64, filename string, folder string, lang string) (string, string, error)
+	UploadResponseAudio(ctx context.Context, attemptID, questionID uuid.UUID, file io.Reader, fileSize int64, filename string) (objectName string, objectURL string, err error)
 	UploadImage(ctx context.Context, id uuid.UUID, file io.Reader, fileSize int64, filename string, folder string) (string, string, error)
+	// OpenObject opens an uploaded file by the URL or object name stored for it
+	OpenObject(ctx context.Context, reference string) (io.ReadCloser, error)
+	// Cleanup Operations
+	ListContentObjects(ctx context.Context) ([]StoredObject, error)
+	DeleteObject(ctx context.Context, bucket, objectName string) error
 }
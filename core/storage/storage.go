@@ -6,6 +6,7 @@ import (
 	"io"
 	"mime"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,6 +27,7 @@ type Storage struct {
 	imageBucket    string
 	audioBucket    string
 	questionBucket string
+	responseBucket string
 	endpoint       string
 	useSSL         bool
 }
@@ -35,6 +37,7 @@ const (
 	ImageBucket    = "images"
 	AudioBucket    = "audio-files"
 	QuestionBucket = "question-images"
+	ResponseBucket = "attempt-responses"
 )
 
 // NewMinIOService initializes and returns a new MinIO service instance
@@ -61,7 +64,7 @@ func NewStorageClient(addr, access string, secret string, ssl bool) (*Storage, e
 		logger.Info("Successfully connected to MinIO.")
 
 		// Initialize and ensure buckets exist
-		bucketsToCreate := []string{ImageBucket, AudioBucket, QuestionBucket}
+		bucketsToCreate := []string{ImageBucket, AudioBucket, QuestionBucket, ResponseBucket}
 		for _, b := range bucketsToCreate {
 			found, err := client.BucketExists(ctx, b)
 			if err != nil {
@@ -83,6 +86,7 @@ func NewStorageClient(addr, access string, secret string, ssl bool) (*Storage, e
 			imageBucket:    ImageBucket,
 			audioBucket:    AudioBucket,
 			questionBucket: QuestionBucket,
+			responseBucket: ResponseBucket,
 			endpoint:       addr,
 			useSSL:         ssl,
 		}
@@ -200,6 +204,36 @@ func (s *Storage) UploadImage(ctx context.Context, id uuid.UUID, file io.Reader,
 	}
 	return objectName, s.buildObjectURL(s.imageBucket, objectName), nil
 }
+
+// UploadResponseAudio stores a learner's spoken response under the attempt and question it answers. Every
+// upload gets a new object name, so a retake never overwrites the recording that is still on record.
+func (s *Storage) UploadResponseAudio(ctx context.Context, attemptID, questionID uuid.UUID, file io.Reader, fileSize int64, filename string) (string, string, error) {
+
+	objectName := generateObjectName(fmt.Sprintf("%s/%s", attemptID, questionID), filename)
+	contentType := getContentType(filename)
+	_, err := s.uploadFile(ctx, s.responseBucket, objectName, file, fileSize, contentType)
+	if err != nil {
+		return "", "", err
+	}
+	return objectName, s.buildObjectURL(s.responseBucket, objectName), nil
+}
+
+// OpenObject opens an object by the URL returned when it was uploaded. References that are not URLs
+// are object names in the image bucket, as stored by the image uploads.
+func (s *Storage) OpenObject(ctx context.Context, reference string) (io.ReadCloser, error) {
+	bucket, objectName := s.imageBucket, reference
+	for _, b := range []string{s.imageBucket, s.audioBucket, s.questionBucket} {
+		if name, ok := strings.CutPrefix(reference, s.buildObjectURL(b, "")); ok {
+			bucket, objectName = b, name
+			break
+		}
+	}
+	object, _, err := s.getObject(ctx, bucket, objectName)
+	if err != nil {
+		return nil, err
+	}
+	return object, nil
+}
 func (s *Storage) getObject(ctx context.Context, bucket, objectName string) (*minio.Object, minio.ObjectInfo, error) {
 	object, err := s.client.GetObject(ctx, bucket, objectName, minio.GetObjectOptions{})
 	if err != nil {
@@ -223,3 +257,43 @@ func (s *Storage) getObject(ctx context.Context, bucket, objectName string) (*mi
 	logger.Info(fmt.Sprintf("Successfully retrieved object '%s' from bucket '%s'. Size: %d", objectName, bucket, objectInfo.Size))
 	return object, objectInfo, nil
 }
+
+// StoredObject is an uploaded file, with the references content may hold to it: its URL, and for the
+// image bucket its bare object name as well.
+type StoredObject struct {
+	Bucket       string
+	Name         string
+	References   []string
+	LastModified time.Time
+}
+
+// ListContentObjects lists the files uploaded for library content, which leaves out the avatars.
+func (s *Storage) ListContentObjects(ctx context.Context) ([]StoredObject, error) {
+	var objects []StoredObject
+	for _, bucket := range []string{s.audioBucket, s.imageBucket, s.questionBucket} {
+		for info := range s.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Recursive: true}) {
+			if info.Err != nil {
+				return nil, fmt.Errorf("failed to list objects of bucket '%s': %w", bucket, info.Err)
+			}
+			if bucket == s.imageBucket && strings.HasPrefix(info.Key, "avatars/") {
+				continue
+			}
+			references := []string{s.buildObjectURL(bucket, info.Key)}
+			if bucket == s.imageBucket {
+				references = append(references, info.Key)
+			}
+			objects = append(objects, StoredObject{
+				Bucket:       bucket,
+				Name:         info.Key,
+				References:   references,
+				LastModified: info.LastModified,
+			})
+		}
+	}
+	return objects, nil
+}
+
+// DeleteObject removes a file listed by ListContentObjects.
+func (s *Storage) DeleteObject(ctx context.Context, bucket, objectName string) error {
+	return s.deleteFile(ctx, bucket, objectName)
+}
@@ -14,25 +14,26 @@ type Claims struct {
 	Email    string    `json:"email"`
 	UserName string    `json:"user_name"`
 	Role     string    `json:"roles"`
+	// TokenType tells access tokens apart from refresh tokens
+	TokenType string `json:"typ"`
+	// SessionID is the login session the token was issued for
+	SessionID uuid.UUID `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func GenerateToken(userID uuid.UUID, email, userName string, expireTime ...time.Duration) (string, error) {
+// GenerateSessionToken signs a token of the given type for a login session and returns it with its jti.
+func GenerateSessionToken(userID uuid.UUID, email, userName, tokenType string, sessionID uuid.UUID, expiration time.Duration) (string, uuid.UUID, error) {
 	cfg := config.Get()
 
-	// Use custom expire time if provided, otherwise use config value
-	var expiration time.Duration
-	if len(expireTime) > 0 {
-		expiration = expireTime[0]
-	} else {
-		expiration = time.Hour * time.Duration(cfg.JWT.ExpireTime)
-	}
-
+	tokenID := uuid.New()
 	claims := Claims{
-		UserID:   userID,
-		Email:    email,
-		UserName: userName,
+		UserID:    userID,
+		Email:     email,
+		UserName:  userName,
+		TokenType: tokenType,
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expiration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
@@ -41,10 +42,10 @@ func GenerateToken(userID uuid.UUID, email, userName string, expireTime ...time.
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := token.SignedString([]byte(cfg.JWT.Secret))
 	if err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+		return "", uuid.Nil, fmt.Errorf("failed to generate token: %w", err)
 	}
 
-	return tokenString, nil
+	return tokenString, tokenID, nil
 }
 
 func ValidateToken(tokenString string) (*Claims, error) {
@@ -6,24 +6,114 @@ package database
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type AttemptQuestion struct {
+	AttemptID           uuid.UUID      `json:"attempt_id"`
+	QuestionID          uuid.UUID      `json:"question_id"`
+	PartID              uuid.UUID      `json:"part_id"`
+	ParagraphID         uuid.NullUUID  `json:"paragraph_id"`
+	SequenceNumber      int32          `json:"sequence_number"`
+	Answer              sql.NullString `json:"answer"`
+	AnsweredAt          sql.NullTime   `json:"answered_at"`
+	IsCorrect           sql.NullBool   `json:"is_correct"`
+	QuestionRevisionID  uuid.UUID      `json:"question_revision_id"`
+	ParagraphRevisionID uuid.NullUUID  `json:"paragraph_revision_id"`
+}
+
+type AttemptResponse struct {
+	AttemptID          uuid.UUID       `json:"attempt_id"`
+	QuestionID         uuid.UUID       `json:"question_id"`
+	ResponseType       string          `json:"response_type"`
+	StartedAt          time.Time       `json:"started_at"`
+	PreparationSeconds int32           `json:"preparation_seconds"`
+	ResponseSeconds    int32           `json:"response_seconds"`
+	AudioObject        sql.NullString  `json:"audio_object"`
+	AudioUrl           sql.NullString  `json:"audio_url"`
+	TextResponse       sql.NullString  `json:"text_response"`
+	WordCount          sql.NullInt32   `json:"word_count"`
+	SubmittedAt        sql.NullTime    `json:"submitted_at"`
+	CreatedAt          sql.NullTime    `json:"created_at"`
+	UpdatedAt          sql.NullTime    `json:"updated_at"`
+	RatingStatus       string          `json:"rating_status"`
+	FinalScore         sql.NullInt32   `json:"final_score"`
+	MaxScore           sql.NullInt32   `json:"max_score"`
+	RatedAt            sql.NullTime    `json:"rated_at"`
+	AutoScore          sql.NullInt32   `json:"auto_score"`
+	AutoMaxScore       sql.NullInt32   `json:"auto_max_score"`
+	AutoFeedback       json.RawMessage `json:"auto_feedback"`
+	AutoScorer         sql.NullString  `json:"auto_scorer"`
+	AutoScoredAt       sql.NullTime    `json:"auto_scored_at"`
+	AutoScoreFailures  int32           `json:"auto_score_failures"`
+}
+
+type AttemptResult struct {
+	AttemptID         uuid.UUID     `json:"attempt_id"`
+	ConversionTableID uuid.NullUUID `json:"conversion_table_id"`
+	ListeningCorrect  int32         `json:"listening_correct"`
+	ListeningTotal    int32         `json:"listening_total"`
+	ListeningScaled   sql.NullInt32 `json:"listening_scaled"`
+	ReadingCorrect    int32         `json:"reading_correct"`
+	ReadingTotal      int32         `json:"reading_total"`
+	ReadingScaled     sql.NullInt32 `json:"reading_scaled"`
+	TotalScaled       sql.NullInt32 `json:"total_scaled"`
+	ScoredAt          time.Time     `json:"scored_at"`
+	SpeakingPoints    int32         `json:"speaking_points"`
+	SpeakingMaxPoints int32         `json:"speaking_max_points"`
+	SpeakingPending   int32         `json:"speaking_pending"`
+	SpeakingScaled    sql.NullInt32 `json:"speaking_scaled"`
+	WritingPoints     int32         `json:"writing_points"`
+	WritingMaxPoints  int32         `json:"writing_max_points"`
+	WritingPending    int32         `json:"writing_pending"`
+	WritingScaled     sql.NullInt32 `json:"writing_scaled"`
+}
+
+type ContentStatusChange struct {
+	ChangeID    uuid.UUID     `json:"change_id"`
+	ContentType string        `json:"content_type"`
+	ContentID   uuid.UUID     `json:"content_id"`
+	FromStatus  string        `json:"from_status"`
+	ToStatus    string        `json:"to_status"`
+	ChangedBy   uuid.NullUUID `json:"changed_by"`
+	Note        string        `json:"note"`
+	ChangedAt   time.Time     `json:"changed_at"`
+}
+
 type Exam struct {
-	ExamID            uuid.UUID      `json:"exam_id"`
-	ExamTitle         string         `json:"exam_title"`
-	Description       sql.NullString `json:"description"`
-	DurationMinutes   sql.NullInt32  `json:"duration_minutes"`
-	ExamType          string         `json:"exam_type"`
-	MaxListeningScore sql.NullInt32  `json:"max_listening_score"`
-	MaxReadingScore   sql.NullInt32  `json:"max_reading_score"`
-	MaxSpeakingScore  sql.NullInt32  `json:"max_speaking_score"`
-	MaxWritingScore   sql.NullInt32  `json:"max_writing_score"`
-	TotalScore        sql.NullInt32  `json:"total_score"`
-	CreatedAt         sql.NullTime   `json:"created_at"`
-	UpdatedAt         sql.NullTime   `json:"updated_at"`
+	ExamID                 uuid.UUID      `json:"exam_id"`
+	ExamTitle              string         `json:"exam_title"`
+	Description            sql.NullString `json:"description"`
+	DurationMinutes        sql.NullInt32  `json:"duration_minutes"`
+	ExamType               string         `json:"exam_type"`
+	MaxListeningScore      sql.NullInt32  `json:"max_listening_score"`
+	MaxReadingScore        sql.NullInt32  `json:"max_reading_score"`
+	MaxSpeakingScore       sql.NullInt32  `json:"max_speaking_score"`
+	MaxWritingScore        sql.NullInt32  `json:"max_writing_score"`
+	TotalScore             sql.NullInt32  `json:"total_score"`
+	CreatedAt              sql.NullTime   `json:"created_at"`
+	UpdatedAt              sql.NullTime   `json:"updated_at"`
+	ScoreConversionTableID uuid.NullUUID  `json:"score_conversion_table_id"`
+	Status                 string         `json:"status"`
+	PublishedAt            sql.NullTime   `json:"published_at"`
+	DeletedAt              sql.NullTime   `json:"deleted_at"`
+	DeletedBy              uuid.NullUUID  `json:"deleted_by"`
+}
+
+type ExamAttempt struct {
+	AttemptID   uuid.UUID    `json:"attempt_id"`
+	ExamID      uuid.UUID    `json:"exam_id"`
+	UserID      uuid.UUID    `json:"user_id"`
+	Status      string       `json:"status"`
+	StartedAt   time.Time    `json:"started_at"`
+	DeadlineAt  sql.NullTime `json:"deadline_at"`
+	SubmittedAt sql.NullTime `json:"submitted_at"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
 }
 
 type ExamPart struct {
@@ -37,6 +127,21 @@ type ExamPart struct {
 	CreatedAt           sql.NullTime   `json:"created_at"`
 	UpdatedAt           sql.NullTime   `json:"updated_at"`
 	ToeicPartNumber     sql.NullInt32  `json:"toeic_part_number"`
+	Status              string         `json:"status"`
+	PublishedAt         sql.NullTime   `json:"published_at"`
+	DeletedAt           sql.NullTime   `json:"deleted_at"`
+	DeletedBy           uuid.NullUUID  `json:"deleted_by"`
+}
+
+type MailDeadLetter struct {
+	JobID      uuid.UUID       `json:"job_id"`
+	Recipients string          `json:"recipients"`
+	Subject    string          `json:"subject"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int32           `json:"attempts"`
+	LastError  string          `json:"last_error"`
+	FailedAt   time.Time       `json:"failed_at"`
+	RequeuedAt sql.NullTime    `json:"requeued_at"`
 }
 
 type Paragraph struct {
@@ -50,6 +155,50 @@ type Paragraph struct {
 	ImageUrl         sql.NullString `json:"image_url"`
 	CreatedAt        sql.NullTime   `json:"created_at"`
 	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	DeletedAt        sql.NullTime   `json:"deleted_at"`
+	DeletedBy        uuid.NullUUID  `json:"deleted_by"`
+}
+
+type ParagraphRevision struct {
+	RevisionID       uuid.UUID       `json:"revision_id"`
+	ParagraphID      uuid.UUID       `json:"paragraph_id"`
+	RevisionNumber   int32           `json:"revision_number"`
+	ParagraphContent string          `json:"paragraph_content"`
+	Title            sql.NullString  `json:"title"`
+	PartID           uuid.UUID       `json:"part_id"`
+	ParagraphOrder   int32           `json:"paragraph_order"`
+	ParagraphType    sql.NullString  `json:"paragraph_type"`
+	AudioUrl         sql.NullString  `json:"audio_url"`
+	ImageUrl         sql.NullString  `json:"image_url"`
+	Diff             json.RawMessage `json:"diff"`
+	RestoredFrom     sql.NullInt32   `json:"restored_from"`
+	CreatedBy        uuid.NullUUID   `json:"created_by"`
+	CreatedAt        time.Time       `json:"created_at"`
+}
+
+type PaymentEvent struct {
+	Provider   string          `json:"provider"`
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	OrderID    uuid.NullUUID   `json:"order_id"`
+	Payload    json.RawMessage `json:"payload"`
+	ReceivedAt time.Time       `json:"received_at"`
+}
+
+type PaymentOrder struct {
+	OrderID           uuid.UUID      `json:"order_id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	PlanID            uuid.UUID      `json:"plan_id"`
+	Provider          string         `json:"provider"`
+	ProviderSessionID sql.NullString `json:"provider_session_id"`
+	AmountCents       int64          `json:"amount_cents"`
+	Currency          string         `json:"currency"`
+	Status            string         `json:"status"`
+	FailureReason     string         `json:"failure_reason"`
+	SubscriptionID    uuid.NullUUID  `json:"subscription_id"`
+	PaidAt            sql.NullTime   `json:"paid_at"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	UpdatedAt         sql.NullTime   `json:"updated_at"`
 }
 
 type Permission struct {
@@ -75,6 +224,64 @@ type Question struct {
 	CorrectAnswer        sql.NullString        `json:"correct_answer"`
 	CreatedAt            sql.NullTime          `json:"created_at"`
 	UpdatedAt            sql.NullTime          `json:"updated_at"`
+	DeletedAt            sql.NullTime          `json:"deleted_at"`
+	DeletedBy            uuid.NullUUID         `json:"deleted_by"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+}
+
+type QuestionExplanation struct {
+	QuestionID         uuid.UUID       `json:"question_id"`
+	Lang               string          `json:"lang"`
+	Explanation        string          `json:"explanation"`
+	OptionExplanations json.RawMessage `json:"option_explanations"`
+	UpdatedBy          uuid.NullUUID   `json:"updated_by"`
+	CreatedAt          sql.NullTime    `json:"created_at"`
+	UpdatedAt          sql.NullTime    `json:"updated_at"`
+}
+
+type QuestionRevision struct {
+	RevisionID           uuid.UUID             `json:"revision_id"`
+	QuestionID           uuid.UUID             `json:"question_id"`
+	RevisionNumber       int32                 `json:"revision_number"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	Diff                 json.RawMessage       `json:"diff"`
+	RestoredFrom         sql.NullInt32         `json:"restored_from"`
+	CreatedBy            uuid.NullUUID         `json:"created_by"`
+	CreatedAt            time.Time             `json:"created_at"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+}
+
+type RefreshToken struct {
+	TokenID   uuid.UUID    `json:"token_id"`
+	SessionID uuid.UUID    `json:"session_id"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type ResponseRating struct {
+	RatingID   uuid.UUID      `json:"rating_id"`
+	AttemptID  uuid.UUID      `json:"attempt_id"`
+	QuestionID uuid.UUID      `json:"question_id"`
+	RaterID    uuid.UUID      `json:"rater_id"`
+	Round      int32          `json:"round"`
+	Score      sql.NullInt32  `json:"score"`
+	MaxScore   sql.NullInt32  `json:"max_score"`
+	Comment    sql.NullString `json:"comment"`
+	AssignedAt time.Time      `json:"assigned_at"`
+	RatedAt    sql.NullTime   `json:"rated_at"`
 }
 
 type Role struct {
@@ -90,19 +297,60 @@ type RolePermission struct {
 	PermissionID uuid.UUID `json:"permission_id"`
 }
 
+type ScoreConversionEntry struct {
+	TableID     uuid.UUID `json:"table_id"`
+	Section     string    `json:"section"`
+	RawScore    int32     `json:"raw_score"`
+	ScaledScore int32     `json:"scaled_score"`
+}
+
+type ScoreConversionTable struct {
+	TableID     uuid.UUID      `json:"table_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	IsDefault   bool           `json:"is_default"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	UpdatedAt   sql.NullTime   `json:"updated_at"`
+}
+
+type SubscriptionPlan struct {
+	PlanID          uuid.UUID    `json:"plan_id"`
+	Code            string       `json:"code"`
+	Name            string       `json:"name"`
+	Description     string       `json:"description"`
+	DurationDays    int32        `json:"duration_days"`
+	GracePeriodDays int32        `json:"grace_period_days"`
+	PriceCents      int64        `json:"price_cents"`
+	Currency        string       `json:"currency"`
+	IsActive        bool         `json:"is_active"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+}
+
+type TrashItem struct {
+	ContentType string         `json:"content_type"`
+	ContentID   uuid.UUID      `json:"content_id"`
+	Title       string         `json:"title"`
+	ParentType  sql.NullString `json:"parent_type"`
+	ParentID    uuid.NullUUID  `json:"parent_id"`
+	DeletedAt   sql.NullTime   `json:"deleted_at"`
+	DeletedBy   uuid.NullUUID  `json:"deleted_by"`
+}
+
 type User struct {
-	ID            uuid.UUID      `json:"id"`
-	UserName      string         `json:"user_name"`
-	Email         string         `json:"email"`
-	Password      string         `json:"password"`
-	IsSocialLogin sql.NullBool   `json:"is_social_login"`
-	IsLocked      sql.NullBool   `json:"is_locked"`
-	LockedAt      sql.NullTime   `json:"locked_at"`
-	LockReason    sql.NullString `json:"lock_reason"`
-	UnlockedAt    sql.NullTime   `json:"unlocked_at"`
-	UnlockReason  sql.NullString `json:"unlock_reason"`
-	CreatedAt     sql.NullTime   `json:"created_at"`
-	UpdatedAt     sql.NullTime   `json:"updated_at"`
+	ID              uuid.UUID      `json:"id"`
+	UserName        string         `json:"user_name"`
+	Email           string         `json:"email"`
+	Password        string         `json:"password"`
+	IsSocialLogin   sql.NullBool   `json:"is_social_login"`
+	IsLocked        sql.NullBool   `json:"is_locked"`
+	LockedAt        sql.NullTime   `json:"locked_at"`
+	LockReason      sql.NullString `json:"lock_reason"`
+	UnlockedAt      sql.NullTime   `json:"unlocked_at"`
+	UnlockReason    sql.NullString `json:"unlock_reason"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	EmailVerifiedAt sql.NullTime   `json:"email_verified_at"`
 }
 
 type UserProfile struct {
@@ -136,3 +384,30 @@ type UserRole struct {
 	UserID uuid.UUID `json:"user_id"`
 	RoleID uuid.UUID `json:"role_id"`
 }
+
+type UserSession struct {
+	SessionID    uuid.UUID    `json:"session_id"`
+	UserID       uuid.UUID    `json:"user_id"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+	LastUsedAt   time.Time    `json:"last_used_at"`
+	RevokedAt    sql.NullTime `json:"revoked_at"`
+	RevokeReason string       `json:"revoke_reason"`
+	CreatedAt    sql.NullTime `json:"created_at"`
+	UserAgent    string       `json:"user_agent"`
+	IpAddress    string       `json:"ip_address"`
+}
+
+type UserSubscription struct {
+	SubscriptionID uuid.UUID     `json:"subscription_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	PlanID         uuid.UUID     `json:"plan_id"`
+	Source         string        `json:"source"`
+	StartsAt       time.Time     `json:"starts_at"`
+	EndsAt         time.Time     `json:"ends_at"`
+	GraceEndsAt    time.Time     `json:"grace_ends_at"`
+	GrantedBy      uuid.NullUUID `json:"granted_by"`
+	Note           string        `json:"note"`
+	CanceledAt     sql.NullTime  `json:"canceled_at"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+	UpdatedAt      sql.NullTime  `json:"updated_at"`
+}
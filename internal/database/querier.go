@@ -7,6 +7,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -16,78 +17,327 @@ type Querier interface {
 	AssignPermissionToRole(ctx context.Context, arg AssignPermissionToRoleParams) error
 	// AssignRoleToUser assigns a role to a user.
 	AssignRoleToUser(ctx context.Context, arg AssignRoleToUserParams) error
+	// CancelUserSubscription ends a subscription immediately; zero rows means it does not exist or was already canceled.
+	CancelUserSubscription(ctx context.Context, arg CancelUserSubscriptionParams) (int64, error)
+	// ClearDefaultScoreConversionTable unsets the current default conversion table.
+	ClearDefaultScoreConversionTable(ctx context.Context) error
+	// ClosePaymentOrder marks a pending order as failed or expired; zero rows means it was no longer pending.
+	ClosePaymentOrder(ctx context.Context, arg ClosePaymentOrderParams) (int64, error)
 	// CreateAccount creates a new user and returns selected fields.
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (CreateAccountRow, error)
+	// CreateAttemptQuestion records a question served in an attempt.
+	// The question and its paragraph are pinned to their current revisions.
+	CreateAttemptQuestion(ctx context.Context, arg CreateAttemptQuestionParams) error
+	// CreateContentStatusChange records a status transition of an exam or practice part.
+	CreateContentStatusChange(ctx context.Context, arg CreateContentStatusChangeParams) error
 	// ========================
 	// 002
 	// ========================
 	CreateExam(ctx context.Context, arg CreateExamParams) (uuid.UUID, error)
+	// CreateExamAttempt starts a new attempt; deadline_at is derived from the exam duration when one is set.
+	CreateExamAttempt(ctx context.Context, arg CreateExamAttemptParams) (ExamAttempt, error)
 	CreateExamPart(ctx context.Context, arg CreateExamPartParams) (uuid.UUID, error)
 	//-
 	// Paragraphs Queries
 	//-
 	CreateParagraph(ctx context.Context, arg CreateParagraphParams) (uuid.UUID, error)
+	// CreateParagraphRevision snapshots the current state of a paragraph as its next revision.
+	CreateParagraphRevision(ctx context.Context, arg CreateParagraphRevisionParams) (ParagraphRevision, error)
+	// CreatePaymentOrder opens a pending order for a plan, priced at the plan's current price.
+	CreatePaymentOrder(ctx context.Context, arg CreatePaymentOrderParams) (PaymentOrder, error)
 	// CreatePermission creates a new permission.
 	CreatePermission(ctx context.Context, arg CreatePermissionParams) error
 	//-
 	// Questions Queries
 	//-
 	CreateQuestion(ctx context.Context, arg CreateQuestionParams) (CreateQuestionRow, error)
+	// CreateQuestionRevision snapshots the current state of a question as its next revision.
+	CreateQuestionRevision(ctx context.Context, arg CreateQuestionRevisionParams) (QuestionRevision, error)
+	// CreateRatingAssignment assigns a response to a rater for the given round.
+	CreateRatingAssignment(ctx context.Context, arg CreateRatingAssignmentParams) (ResponseRating, error)
+	// CreateRefreshToken records a refresh token issued for a session.
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
 	// CreateRole creates a new role.
 	CreateRole(ctx context.Context, arg CreateRoleParams) error
+	// CreateScoreConversionEntry adds one raw-to-scaled row to a conversion table.
+	CreateScoreConversionEntry(ctx context.Context, arg CreateScoreConversionEntryParams) error
+	// CreateScoreConversionTable creates a new, empty conversion table.
+	CreateScoreConversionTable(ctx context.Context, arg CreateScoreConversionTableParams) (ScoreConversionTable, error)
+	// CreateSocialAccount creates a user that signs in through an OAuth provider only.
+	CreateSocialAccount(ctx context.Context, arg CreateSocialAccountParams) (CreateSocialAccountRow, error)
+	// CreateSocialUserProfile creates the profile of a social account from the provider's profile data.
+	CreateSocialUserProfile(ctx context.Context, arg CreateSocialUserProfileParams) error
+	// CreateSubscriptionPlan adds a plan users can subscribe to or be granted.
+	CreateSubscriptionPlan(ctx context.Context, arg CreateSubscriptionPlanParams) (SubscriptionPlan, error)
 	// 00002
 	// CreateUserProfile creates a new Userprofile.
 	CreateUserProfile(ctx context.Context, arg CreateUserProfileParams) error
+	// CreateUserProvider links a provider identity to a local user.
+	CreateUserProvider(ctx context.Context, arg CreateUserProviderParams) error
+	// CreateUserSession opens a session for a successful login.
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
+	// CreateUserSubscription records a subscription period for a user.
+	CreateUserSubscription(ctx context.Context, arg CreateUserSubscriptionParams) (UserSubscription, error)
 	DeleteExam(ctx context.Context, examID uuid.UUID) error
 	DeleteExamPart(ctx context.Context, partID uuid.UUID) error
 	DeleteParagraph(ctx context.Context, paragraphID uuid.UUID) error
 	// DeletePermission deletes a permission by its ID.
 	DeletePermission(ctx context.Context, id uuid.UUID) error
 	DeleteQuestion(ctx context.Context, questionID uuid.UUID) error
+	// DeleteQuestionExplanation removes the explanations of a question in one language.
+	DeleteQuestionExplanation(ctx context.Context, arg DeleteQuestionExplanationParams) (int64, error)
 	// DeleteRole deletes a role by its ID.
 	DeleteRole(ctx context.Context, id uuid.UUID) error
+	// DeleteScoreConversionEntries removes every row of a conversion table.
+	DeleteScoreConversionEntries(ctx context.Context, tableID uuid.UUID) error
+	// FinalizeExamAttempt closes an in-progress attempt with the given final status.
+	FinalizeExamAttempt(ctx context.Context, arg FinalizeExamAttemptParams) (int64, error)
+	// GetActiveSubscriptionPlans lists the plans offered to learners, cheapest first.
+	GetActiveSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error)
+	GetAttemptResponse(ctx context.Context, arg GetAttemptResponseParams) (AttemptResponse, error)
+	// GetAttemptResult retrieves the scores of an attempt.
+	GetAttemptResult(ctx context.Context, attemptID uuid.UUID) (AttemptResult, error)
+	// GetContentIds lists the ids of all questions and paragraphs, which name their transcript files.
+	GetContentIds(ctx context.Context) ([]uuid.UUID, error)
+	// GetContentMediaReferences lists the audio and image references held by questions and paragraphs, trashed
+	// ones and revisions included, which is every uploaded file library content may still use.
+	GetContentMediaReferences(ctx context.Context) ([]string, error)
+	// GetContentStatusChanges lists the transitions of an exam or practice part, most recent first.
+	GetContentStatusChanges(ctx context.Context, arg GetContentStatusChangesParams) ([]ContentStatusChange, error)
 	GetCountSeparateQuestionsByPartID(ctx context.Context, partID uuid.UUID) (int64, error)
-	GetExam(ctx context.Context, examID uuid.UUID) (Exam, error)
-	GetExamPartByID(ctx context.Context, partID uuid.UUID) (ExamPart, error)
-	GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID) ([]ExamPart, error)
-	GetExamsCount(ctx context.Context) (int64, error)
-	GetPaginatedExams(ctx context.Context, arg GetPaginatedExamsParams) ([]Exam, error)
-	GetPaginatedPracticeExamParts(ctx context.Context, arg GetPaginatedPracticeExamPartsParams) ([]ExamPart, error)
-	GetPaginatedSeparateQuestionsByPartID(ctx context.Context, arg GetPaginatedSeparateQuestionsByPartIDParams) ([]Question, error)
+	// GetCurrentUserSubscription returns the subscription giving the user access right now, the one lasting longest first.
+	GetCurrentUserSubscription(ctx context.Context, userID uuid.UUID) (GetCurrentUserSubscriptionRow, error)
+	GetExam(ctx context.Context, examID uuid.UUID) (GetExamRow, error)
+	// GetExamAttempt retrieves an attempt owned by the given user.
+	GetExamAttempt(ctx context.Context, arg GetExamAttemptParams) (ExamAttempt, error)
+	GetExamPartByID(ctx context.Context, partID uuid.UUID) (GetExamPartByIDRow, error)
+	GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID) ([]GetExamPartsByExamIdRow, error)
+	// GetExamScoringConfig retrieves the score caps of an exam and the conversion table it uses, falling back to the default table.
+	GetExamScoringConfig(ctx context.Context, examID uuid.UUID) (GetExamScoringConfigRow, error)
+	GetExamsCount(ctx context.Context, status sql.NullString) (int64, error)
+	// GetInProgressExamAttempt retrieves the latest unfinished attempt of a user for an exam.
+	GetInProgressExamAttempt(ctx context.Context, arg GetInProgressExamAttemptParams) (ExamAttempt, error)
+	GetLatestParagraphRevision(ctx context.Context, paragraphID uuid.UUID) (ParagraphRevision, error)
+	GetLatestQuestionRevision(ctx context.Context, questionID uuid.UUID) (QuestionRevision, error)
+	// GetLatestUserSubscriptionEnd returns when the last running or scheduled subscription of the user ends, so a new one can start after it.
+	GetLatestUserSubscriptionEnd(ctx context.Context, userID uuid.UUID) (time.Time, error)
+	// GetMailDeadLetter returns a failed mail by its job id.
+	GetMailDeadLetter(ctx context.Context, jobID uuid.UUID) (MailDeadLetter, error)
+	// GetMailDeadLettersCount counts the failed mails.
+	GetMailDeadLettersCount(ctx context.Context) (int64, error)
+	// GetOpenRatingAssignment retrieves the response a rater was assigned and has not rated yet.
+	GetOpenRatingAssignment(ctx context.Context, raterID uuid.UUID) (ResponseRating, error)
+	GetPaginatedExams(ctx context.Context, arg GetPaginatedExamsParams) ([]GetPaginatedExamsRow, error)
+	// GetPaginatedMailDeadLetters lists failed mails, most recent first.
+	GetPaginatedMailDeadLetters(ctx context.Context, arg GetPaginatedMailDeadLettersParams) ([]MailDeadLetter, error)
+	GetPaginatedPracticeExamParts(ctx context.Context, arg GetPaginatedPracticeExamPartsParams) ([]GetPaginatedPracticeExamPartsRow, error)
+	GetPaginatedSeparateQuestionsByPartID(ctx context.Context, arg GetPaginatedSeparateQuestionsByPartIDParams) ([]GetPaginatedSeparateQuestionsByPartIDRow, error)
 	// GetPaginatedUsers retrieves a list of users with pagination.
 	GetPaginatedUsers(ctx context.Context, arg GetPaginatedUsersParams) ([]GetPaginatedUsersRow, error)
-	GetParagraphByID(ctx context.Context, paragraphID uuid.UUID) (Paragraph, error)
-	GetParagraphByPartId(ctx context.Context, partID uuid.UUID) ([]Paragraph, error)
+	GetParagraphByID(ctx context.Context, paragraphID uuid.UUID) (GetParagraphByIDRow, error)
+	GetParagraphByPartId(ctx context.Context, partID uuid.UUID) ([]GetParagraphByPartIdRow, error)
+	GetParagraphRevision(ctx context.Context, arg GetParagraphRevisionParams) (ParagraphRevision, error)
+	// GetParagraphRevisions lists the revisions of a paragraph, most recent first.
+	GetParagraphRevisions(ctx context.Context, paragraphID uuid.UUID) ([]ParagraphRevision, error)
+	// GetParagraphsByPartIds loads the paragraphs of several parts in one query, in display order.
+	GetParagraphsByPartIds(ctx context.Context, partIds []uuid.UUID) ([]GetParagraphsByPartIdsRow, error)
+	// GetPaymentOrder returns an order with the name of its plan.
+	GetPaymentOrder(ctx context.Context, orderID uuid.UUID) (GetPaymentOrderRow, error)
+	// GetPaymentOrderForUpdate locks an order while a webhook event is applied to it.
+	GetPaymentOrderForUpdate(ctx context.Context, orderID uuid.UUID) (PaymentOrder, error)
 	// GetPermissions retrieves all permissions.
 	GetPermissions(ctx context.Context) ([]Permission, error)
-	GetPracticeExamPartCount(ctx context.Context) (int64, error)
-	GetQuestionByID(ctx context.Context, questionID uuid.UUID) (Question, error)
+	GetPracticeExamPartCount(ctx context.Context, status sql.NullString) (int64, error)
+	GetQuestionByID(ctx context.Context, questionID uuid.UUID) (GetQuestionByIDRow, error)
+	GetQuestionRevision(ctx context.Context, arg GetQuestionRevisionParams) (QuestionRevision, error)
+	// GetQuestionRevisions lists the revisions of a question, most recent first.
+	GetQuestionRevisions(ctx context.Context, questionID uuid.UUID) ([]QuestionRevision, error)
+	// GetQuestionsByPartIds loads the questions of several parts in one query, both those under a paragraph
+	// and the standalone ones, in display order.
+	GetQuestionsByPartIds(ctx context.Context, partIds []uuid.UUID) ([]GetQuestionsByPartIdsRow, error)
+	// GetRatingQueueStats counts the submitted responses waiting for raters.
+	GetRatingQueueStats(ctx context.Context) (GetRatingQueueStatsRow, error)
+	// GetRatingTask retrieves what a rater sees of an assigned response: the pinned question and the
+	// response, without the learner or the other ratings.
+	GetRatingTask(ctx context.Context, ratingID uuid.UUID) (GetRatingTaskRow, error)
+	// GetRefreshToken returns a refresh token together with the state of its session.
+	GetRefreshToken(ctx context.Context, tokenID uuid.UUID) (GetRefreshTokenRow, error)
 	GetRole(ctx context.Context) (GetRoleRow, error)
 	// GetRoles retrieves all roles.
 	GetRoles(ctx context.Context) ([]Role, error)
+	// GetScoreConversionTable retrieves a conversion table by id.
+	GetScoreConversionTable(ctx context.Context, tableID uuid.UUID) (ScoreConversionTable, error)
+	// GetSubscriptionPlan returns a plan by id.
+	GetSubscriptionPlan(ctx context.Context, planID uuid.UUID) (SubscriptionPlan, error)
+	// GetSubscriptionPlans lists every plan, cheapest first.
+	GetSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error)
+	GetTrashItem(ctx context.Context, arg GetTrashItemParams) (TrashItem, error)
+	// GetTrashItems lists the items deleted on their own, most recent first.
+	GetTrashItems(ctx context.Context, arg GetTrashItemsParams) ([]TrashItem, error)
+	GetTrashItemsCount(ctx context.Context, contentType sql.NullString) (int64, error)
 	GetUserAvatar(ctx context.Context, userID uuid.UUID) (sql.NullString, error)
 	// GetUserByEmailOrUserNameOrId retrieves a user by email, user_name, or id.
 	GetUserByEmailOrUserNameOrId(ctx context.Context, arg GetUserByEmailOrUserNameOrIdParams) (GetUserByEmailOrUserNameOrIdRow, error)
+	// GetUserIdsByRole retrieves the ids of the users holding a role.
+	GetUserIdsByRole(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error)
+	// GetUserPaymentOrders lists the orders of a user with the name of their plan, most recent first.
+	GetUserPaymentOrders(ctx context.Context, userID uuid.UUID) ([]GetUserPaymentOrdersRow, error)
+	// GetUserPermissionNames retrieves the names of every permission granted to a user through their roles.
+	GetUserPermissionNames(ctx context.Context, userID uuid.UUID) ([]string, error)
 	GetUserProfile(ctx context.Context, userID uuid.UUID) (GetUserProfileRow, error)
+	// GetUserProvider returns the link between a provider identity and a local user.
+	GetUserProvider(ctx context.Context, arg GetUserProviderParams) (UserProvider, error)
+	// GetUserSubscriptions lists the subscriptions of a user with their plan, most recent first.
+	GetUserSubscriptions(ctx context.Context, userID uuid.UUID) ([]GetUserSubscriptionsRow, error)
 	// GetUsersCount returns the total number of users.
 	GetUsersCount(ctx context.Context) (int64, error)
 	// HasPermission checks if a user has a specific permission.
 	HasPermission(ctx context.Context, arg HasPermissionParams) (bool, error)
-	ListParagraphs(ctx context.Context) ([]Paragraph, error)
-	ListParagraphsByPartID(ctx context.Context, partID uuid.UUID) ([]Paragraph, error)
-	ListQuestions(ctx context.Context) ([]Question, error)
-	ListQuestionsByParagraphID(ctx context.Context, paragraphID uuid.NullUUID) ([]Question, error)
-	ListQuestionsByPartID(ctx context.Context, partID uuid.UUID) ([]Question, error)
+	// ListActiveUserSessions returns the sessions of a user that are neither revoked nor expired, most recently used first.
+	ListActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]UserSession, error)
+	// ListAttemptParagraphs retrieves the paragraphs referenced by the questions of an attempt.
+	ListAttemptParagraphs(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptParagraphsRow, error)
+	// ListAttemptQuestions retrieves the questions of an attempt, as pinned when it started, with the learner's answers.
+	ListAttemptQuestions(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptQuestionsRow, error)
+	// ListAttemptQuestionsForScoring retrieves the answers of an attempt along with the answer keys of the pinned revisions.
+	ListAttemptQuestionsForScoring(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptQuestionsForScoringRow, error)
+	// ListAttemptRatingStates retrieves the Speaking and Writing questions of an attempt with the rating state
+	// of their responses; a question without a submitted response has no response_submitted_at.
+	ListAttemptRatingStates(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptRatingStatesRow, error)
+	ListAttemptResponses(ctx context.Context, attemptID uuid.UUID) ([]AttemptResponse, error)
+	// ListAttemptReviewQuestions retrieves the questions of an attempt as pinned when it started, with the
+	// learner's answers, the answer keys and the explanation in the requested language, else in the fallback one;
+	// explanation_lang is empty when the question has neither. Explanations are written for the current answer key,
+	// so they are left out for an attempt pinned to a revision whose key or options have changed since.
+	ListAttemptReviewQuestions(ctx context.Context, arg ListAttemptReviewQuestionsParams) ([]ListAttemptReviewQuestionsRow, error)
+	// ListExamAttemptsByUser retrieves all attempts of a user for an exam, newest first.
+	ListExamAttemptsByUser(ctx context.Context, arg ListExamAttemptsByUserParams) ([]ExamAttempt, error)
+	ListParagraphs(ctx context.Context) ([]ListParagraphsRow, error)
+	ListParagraphsByPartID(ctx context.Context, partID uuid.UUID) ([]ListParagraphsByPartIDRow, error)
+	//-
+	// Question Explanations Queries
+	//-
+	// ListQuestionExplanations retrieves every translation of the explanations of a question.
+	ListQuestionExplanations(ctx context.Context, questionID uuid.UUID) ([]QuestionExplanation, error)
+	ListQuestions(ctx context.Context) ([]ListQuestionsRow, error)
+	ListQuestionsByParagraphID(ctx context.Context, paragraphID uuid.NullUUID) ([]ListQuestionsByParagraphIDRow, error)
+	ListQuestionsByPartID(ctx context.Context, partID uuid.UUID) ([]ListQuestionsByPartIDRow, error)
+	// ListResponseRatings retrieves the ratings of a response in round order.
+	ListResponseRatings(ctx context.Context, arg ListResponseRatingsParams) ([]ResponseRating, error)
+	//-
+	// Response Auto Scores Queries
+	//-
+	// ListResponsesToAutoScore retrieves the submitted responses of finalized attempts to the given question
+	// types that the automated scorer has not handled yet, oldest first.
+	ListResponsesToAutoScore(ctx context.Context, arg ListResponsesToAutoScoreParams) ([]ListResponsesToAutoScoreRow, error)
+	// ListScoreConversionEntries retrieves the raw-to-scaled rows of a conversion table.
+	ListScoreConversionEntries(ctx context.Context, tableID uuid.UUID) ([]ScoreConversionEntry, error)
+	// ListScoreConversionTables retrieves all conversion tables.
+	ListScoreConversionTables(ctx context.Context) ([]ScoreConversionTable, error)
+	// LockNextResponseToRate picks the oldest submitted response that still needs a rating the rater may give:
+	// not on their own attempt, not a response they already rated, adjudications first. The row stays locked
+	// until the assignment is committed, so concurrent raters pick different responses.
+	LockNextResponseToRate(ctx context.Context, raterID uuid.UUID) (LockNextResponseToRateRow, error)
 	// LockUser to lock user account
 	LockUser(ctx context.Context, arg LockUserParams) (sql.Result, error)
+	// MarkEmailVerified records that the user confirmed their email; zero rows means it was already verified.
+	MarkEmailVerified(ctx context.Context, id uuid.UUID) (int64, error)
+	// MarkMailDeadLetterRequeued records that a failed mail was put back on the queue; zero rows means it already was.
+	MarkMailDeadLetterRequeued(ctx context.Context, jobID uuid.UUID) (int64, error)
+	// MarkPaymentOrderPaid records the payment of an order and the subscription it activated.
+	MarkPaymentOrderPaid(ctx context.Context, arg MarkPaymentOrderPaidParams) error
 	// PermissionExists checks if a permission with the given ID exists.
 	PermissionExists(ctx context.Context, id uuid.UUID) (bool, error)
+	PurgeTrashedExamParts(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	PurgeTrashedExams(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	PurgeTrashedParagraphs(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	// PurgeTrashedQuestions and the queries below delete for good the content trashed before deleted_before,
+	// children first. Content attempts refer to stays in the trash, and so do the parents of whatever stayed.
+	PurgeTrashedQuestions(ctx context.Context, deletedBefore sql.NullTime) (int64, error)
+	// RecordPaymentEvent stores a webhook event; zero rows means it was received before.
+	RecordPaymentEvent(ctx context.Context, arg RecordPaymentEventParams) (int64, error)
+	// RecordResponseAutoScoreFailure counts a failed call to the scorer for a response.
+	RecordResponseAutoScoreFailure(ctx context.Context, arg RecordResponseAutoScoreFailureParams) error
+	//-
+	// Response Ratings Queries
+	//-
+	// ReleaseStaleRatingAssignments gives back to the queue the responses assigned but not rated in time.
+	ReleaseStaleRatingAssignments(ctx context.Context, assignedBefore time.Time) (int64, error)
+	// ResolveResponseRating moves a response to ADJUDICATION, or to RATED with its final score.
+	ResolveResponseRating(ctx context.Context, arg ResolveResponseRatingParams) error
+	// RestoreParagraph puts every field of a paragraph back to the given values, media included.
+	RestoreParagraph(ctx context.Context, arg RestoreParagraphParams) error
+	// RestoreQuestion puts every field of a question back to the given values, media included.
+	RestoreQuestion(ctx context.Context, arg RestoreQuestionParams) error
+	// RestoreTrashedExam and the queries below take an item out of the trash along with the children trashed
+	// at the same time; children deleted before their parent stay in the trash.
+	RestoreTrashedExam(ctx context.Context, arg RestoreTrashedExamParams) (int64, error)
+	RestoreTrashedExamPart(ctx context.Context, arg RestoreTrashedExamPartParams) (int64, error)
+	RestoreTrashedParagraph(ctx context.Context, arg RestoreTrashedParagraphParams) (int64, error)
+	RestoreTrashedParagraphsOfParts(ctx context.Context, arg RestoreTrashedParagraphsOfPartsParams) error
+	RestoreTrashedPartsOfExam(ctx context.Context, arg RestoreTrashedPartsOfExamParams) ([]uuid.UUID, error)
+	RestoreTrashedQuestion(ctx context.Context, arg RestoreTrashedQuestionParams) (int64, error)
+	RestoreTrashedQuestionsOfParagraph(ctx context.Context, arg RestoreTrashedQuestionsOfParagraphParams) error
+	RestoreTrashedQuestionsOfParts(ctx context.Context, arg RestoreTrashedQuestionsOfPartsParams) error
+	// RevokeAllUserSessions closes every open session of a user and returns their ids.
+	RevokeAllUserSessions(ctx context.Context, arg RevokeAllUserSessionsParams) ([]uuid.UUID, error)
+	// RevokeUserSession closes a session so none of its refresh tokens can be exchanged again.
+	RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error)
+	// RevokeUserSessionOfUser closes one session, provided it belongs to the given user.
+	RevokeUserSessionOfUser(ctx context.Context, arg RevokeUserSessionOfUserParams) (int64, error)
 	// RoleExists checks if a role with the given ID exists.
 	RoleExists(ctx context.Context, id uuid.UUID) (bool, error)
+	// SaveAttemptAnswers stores a batch of answers only while the attempt is in progress and before its deadline.
+	// Being one statement, the batch is stored or refused as a whole; an empty answer clears the question.
+	SaveAttemptAnswers(ctx context.Context, arg SaveAttemptAnswersParams) (int64, error)
+	// SaveAttemptResponse stores a response from the end of the preparation window until the response window
+	// closes, grace_seconds included, while the attempt is in progress and before its deadline.
+	SaveAttemptResponse(ctx context.Context, arg SaveAttemptResponseParams) (int64, error)
+	// SaveMailDeadLetter stores a mail the queue gave up on; a retried mail that fails again replaces its row.
+	SaveMailDeadLetter(ctx context.Context, arg SaveMailDeadLetterParams) error
+	// SaveResponseAutoScore records the pre-score of a response once; auto_score is NULL when the scorer
+	// cannot handle the response.
+	SaveResponseAutoScore(ctx context.Context, arg SaveResponseAutoScoreParams) (int64, error)
+	// SetDefaultScoreConversionTable marks a conversion table as the default one.
+	SetDefaultScoreConversionTable(ctx context.Context, tableID uuid.UUID) (int64, error)
+	// SetExamScoreConversionTable pins a conversion table to an exam; NULL reverts to the default table.
+	SetExamScoreConversionTable(ctx context.Context, arg SetExamScoreConversionTableParams) (int64, error)
+	// SetPaymentOrderSession stores the provider's checkout session of an order.
+	SetPaymentOrderSession(ctx context.Context, arg SetPaymentOrderSessionParams) error
+	//-
+	// Attempt Responses Queries
+	//-
+	// StartAttemptResponse opens the windows of a spoken or written response while the attempt is in progress
+	// and before its deadline; zero rows means they were already open or the attempt is closed.
+	StartAttemptResponse(ctx context.Context, arg StartAttemptResponseParams) (int64, error)
+	// SubmitRating records the score of an open assignment of the rater.
+	SubmitRating(ctx context.Context, arg SubmitRatingParams) (ResponseRating, error)
+	// TouchUserSession extends a session after its refresh token was rotated.
+	TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error
+	// TrashExam moves an exam to the trash; zero rows means it is missing or already there.
+	TrashExam(ctx context.Context, arg TrashExamParams) (int64, error)
+	TrashExamPart(ctx context.Context, arg TrashExamPartParams) (int64, error)
+	TrashParagraph(ctx context.Context, arg TrashParagraphParams) (int64, error)
+	TrashParagraphsOfParts(ctx context.Context, arg TrashParagraphsOfPartsParams) error
+	// TrashPartsOfExam moves the parts of an exam to the trash along with it and returns their ids.
+	TrashPartsOfExam(ctx context.Context, arg TrashPartsOfExamParams) ([]uuid.UUID, error)
+	TrashQuestion(ctx context.Context, arg TrashQuestionParams) (int64, error)
+	TrashQuestionsOfParagraph(ctx context.Context, arg TrashQuestionsOfParagraphParams) error
+	TrashQuestionsOfParts(ctx context.Context, arg TrashQuestionsOfPartsParams) error
 	// UnlockUser to unlock user account
 	UnlockUser(ctx context.Context, arg UnlockUserParams) (sql.Result, error)
+	// UpdateAttemptQuestionCorrectness stores the grading outcome of one answered question.
+	UpdateAttemptQuestionCorrectness(ctx context.Context, arg UpdateAttemptQuestionCorrectnessParams) error
+	// UpdateAttemptResultRatings stores the rolled up Speaking and Writing scores. total_scaled stays the
+	// Listening and Reading total, as on the TOEIC L&R score report.
+	UpdateAttemptResultRatings(ctx context.Context, arg UpdateAttemptResultRatingsParams) (AttemptResult, error)
 	UpdateExam(ctx context.Context, arg UpdateExamParams) error
 	UpdateExamPart(ctx context.Context, arg UpdateExamPartParams) error
+	// UpdateExamPartStatus moves a part to a new status only if it is still in the expected one.
+	UpdateExamPartStatus(ctx context.Context, arg UpdateExamPartStatusParams) (int64, error)
+	// UpdateExamStatus moves an exam to a new status only if it is still in the expected one; zero rows means
+	// the exam changed in the meantime.
+	UpdateExamStatus(ctx context.Context, arg UpdateExamStatusParams) (int64, error)
 	UpdateParagraph(ctx context.Context, arg UpdateParagraphParams) error
 	UpdateParagraphAudioURL(ctx context.Context, arg UpdateParagraphAudioURLParams) error
 	UpdateParagraphImageURL(ctx context.Context, arg UpdateParagraphImageURLParams) error
@@ -96,8 +346,18 @@ type Querier interface {
 	UpdateQuestion(ctx context.Context, arg UpdateQuestionParams) error
 	UpdateQuestionAudioURL(ctx context.Context, arg UpdateQuestionAudioURLParams) error
 	UpdateQuestionImageURL(ctx context.Context, arg UpdateQuestionImageURLParams) error
+	// UpdateSubscriptionPlan changes a plan; existing subscriptions keep the period they were created with.
+	UpdateSubscriptionPlan(ctx context.Context, arg UpdateSubscriptionPlanParams) (SubscriptionPlan, error)
 	UpdateUserAvatar(ctx context.Context, arg UpdateUserAvatarParams) error
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) error
+	// UpdateUserProvider refreshes the tokens and profile data of a linked provider identity.
+	UpdateUserProvider(ctx context.Context, arg UpdateUserProviderParams) error
+	// UpsertAttemptResult stores the scores of an attempt, replacing a previous result.
+	UpsertAttemptResult(ctx context.Context, arg UpsertAttemptResultParams) (AttemptResult, error)
+	// UpsertQuestionExplanation stores the explanations of a question in one language, replacing the previous ones.
+	UpsertQuestionExplanation(ctx context.Context, arg UpsertQuestionExplanationParams) (QuestionExplanation, error)
+	// UseRefreshToken marks a refresh token as exchanged; zero rows means it was already used.
+	UseRefreshToken(ctx context.Context, tokenID uuid.UUID) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
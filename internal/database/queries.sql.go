@@ -8,8 +8,11 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -45,6 +48,65 @@ func (q *Queries) AssignRoleToUser(ctx context.Context, arg AssignRoleToUserPara
 	return err
 }
 
+const cancelUserSubscription = `-- name: CancelUserSubscription :execrows
+UPDATE user_subscriptions
+SET canceled_at = CURRENT_TIMESTAMP,
+    updated_at  = CURRENT_TIMESTAMP
+WHERE subscription_id = $1
+  AND user_id = $2
+  AND canceled_at IS NULL
+`
+
+type CancelUserSubscriptionParams struct {
+	SubscriptionID uuid.UUID `json:"subscription_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+// CancelUserSubscription ends a subscription immediately; zero rows means it does not exist or was already canceled.
+func (q *Queries) CancelUserSubscription(ctx context.Context, arg CancelUserSubscriptionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelUserSubscription, arg.SubscriptionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const clearDefaultScoreConversionTable = `-- name: ClearDefaultScoreConversionTable :exec
+UPDATE score_conversion_tables
+SET is_default = FALSE
+WHERE is_default
+`
+
+// ClearDefaultScoreConversionTable unsets the current default conversion table.
+func (q *Queries) ClearDefaultScoreConversionTable(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, clearDefaultScoreConversionTable)
+	return err
+}
+
+const closePaymentOrder = `-- name: ClosePaymentOrder :execrows
+UPDATE payment_orders
+SET status         = $2,
+    failure_reason = $3,
+    updated_at     = CURRENT_TIMESTAMP
+WHERE order_id = $1
+  AND status = 'PENDING'
+`
+
+type ClosePaymentOrderParams struct {
+	OrderID       uuid.UUID `json:"order_id"`
+	Status        string    `json:"status"`
+	FailureReason string    `json:"failure_reason"`
+}
+
+// ClosePaymentOrder marks a pending order as failed or expired; zero rows means it was no longer pending.
+func (q *Queries) ClosePaymentOrder(ctx context.Context, arg ClosePaymentOrderParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, closePaymentOrder, arg.OrderID, arg.Status, arg.FailureReason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO users (user_name, email, password)
 VALUES ($1, $2, $3)
@@ -71,6 +133,70 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (C
 	return i, err
 }
 
+const createAttemptQuestion = `-- name: CreateAttemptQuestion :exec
+INSERT INTO attempt_questions (
+    attempt_id,
+    question_id,
+    part_id,
+    paragraph_id,
+    sequence_number,
+    question_revision_id,
+    paragraph_revision_id
+) VALUES (
+    $1, $2, $3, $4, $5,
+    (SELECT qr.revision_id FROM question_revisions qr WHERE qr.question_id = $2 ORDER BY qr.revision_number DESC LIMIT 1),
+    (SELECT pr.revision_id FROM paragraph_revisions pr WHERE pr.paragraph_id = $4 ORDER BY pr.revision_number DESC LIMIT 1)
+)
+`
+
+type CreateAttemptQuestionParams struct {
+	AttemptID      uuid.UUID     `json:"attempt_id"`
+	QuestionID     uuid.UUID     `json:"question_id"`
+	PartID         uuid.UUID     `json:"part_id"`
+	ParagraphID    uuid.NullUUID `json:"paragraph_id"`
+	SequenceNumber int32         `json:"sequence_number"`
+}
+
+// CreateAttemptQuestion records a question served in an attempt.
+// The question and its paragraph are pinned to their current revisions.
+func (q *Queries) CreateAttemptQuestion(ctx context.Context, arg CreateAttemptQuestionParams) error {
+	_, err := q.db.ExecContext(ctx, createAttemptQuestion,
+		arg.AttemptID,
+		arg.QuestionID,
+		arg.PartID,
+		arg.ParagraphID,
+		arg.SequenceNumber,
+	)
+	return err
+}
+
+const createContentStatusChange = `-- name: CreateContentStatusChange :exec
+INSERT INTO content_status_changes (content_type, content_id, from_status, to_status, changed_by, note)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateContentStatusChangeParams struct {
+	ContentType string        `json:"content_type"`
+	ContentID   uuid.UUID     `json:"content_id"`
+	FromStatus  string        `json:"from_status"`
+	ToStatus    string        `json:"to_status"`
+	ChangedBy   uuid.NullUUID `json:"changed_by"`
+	Note        string        `json:"note"`
+}
+
+// CreateContentStatusChange records a status transition of an exam or practice part.
+func (q *Queries) CreateContentStatusChange(ctx context.Context, arg CreateContentStatusChangeParams) error {
+	_, err := q.db.ExecContext(ctx, createContentStatusChange,
+		arg.ContentType,
+		arg.ContentID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ChangedBy,
+		arg.Note,
+	)
+	return err
+}
+
 const createExam = `-- name: CreateExam :one
 
 INSERT INTO Exams (
@@ -120,6 +246,42 @@ func (q *Queries) CreateExam(ctx context.Context, arg CreateExamParams) (uuid.UU
 	return exam_id, err
 }
 
+const createExamAttempt = `-- name: CreateExamAttempt :one
+INSERT INTO exam_attempts (
+    exam_id,
+    user_id,
+    deadline_at
+) VALUES (
+    $1,
+    $2,
+    CURRENT_TIMESTAMP + ($3::int * INTERVAL '1 minute')
+) RETURNING attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+`
+
+type CreateExamAttemptParams struct {
+	ExamID          uuid.UUID     `json:"exam_id"`
+	UserID          uuid.UUID     `json:"user_id"`
+	DurationMinutes sql.NullInt32 `json:"duration_minutes"`
+}
+
+// CreateExamAttempt starts a new attempt; deadline_at is derived from the exam duration when one is set.
+func (q *Queries) CreateExamAttempt(ctx context.Context, arg CreateExamAttemptParams) (ExamAttempt, error) {
+	row := q.db.QueryRowContext(ctx, createExamAttempt, arg.ExamID, arg.UserID, arg.DurationMinutes)
+	var i ExamAttempt
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ExamID,
+		&i.UserID,
+		&i.Status,
+		&i.StartedAt,
+		&i.DeadlineAt,
+		&i.SubmittedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createExamPart = `-- name: CreateExamPart :one
 INSERT INTO exam_parts (
     exam_id,
@@ -202,6 +364,95 @@ func (q *Queries) CreateParagraph(ctx context.Context, arg CreateParagraphParams
 	return paragraph_id, err
 }
 
+const createParagraphRevision = `-- name: CreateParagraphRevision :one
+INSERT INTO paragraph_revisions (paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order,
+                                 paragraph_type, audio_url, image_url, diff, restored_from, created_by)
+SELECT p.paragraph_id,
+       COALESCE((SELECT MAX(r.revision_number) FROM paragraph_revisions r WHERE r.paragraph_id = p.paragraph_id), 0) + 1,
+       p.paragraph_content, p.title, p.part_id, p.paragraph_order,
+       p.paragraph_type, p.audio_url, p.image_url, $1, $2, $3
+FROM paragraphs p
+WHERE p.paragraph_id = $4
+RETURNING revision_id, paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order, paragraph_type, audio_url, image_url, diff, restored_from, created_by, created_at
+`
+
+type CreateParagraphRevisionParams struct {
+	Diff         json.RawMessage `json:"diff"`
+	RestoredFrom sql.NullInt32   `json:"restored_from"`
+	CreatedBy    uuid.NullUUID   `json:"created_by"`
+	ParagraphID  uuid.UUID       `json:"paragraph_id"`
+}
+
+// CreateParagraphRevision snapshots the current state of a paragraph as its next revision.
+func (q *Queries) CreateParagraphRevision(ctx context.Context, arg CreateParagraphRevisionParams) (ParagraphRevision, error) {
+	row := q.db.QueryRowContext(ctx, createParagraphRevision,
+		arg.Diff,
+		arg.RestoredFrom,
+		arg.CreatedBy,
+		arg.ParagraphID,
+	)
+	var i ParagraphRevision
+	err := row.Scan(
+		&i.RevisionID,
+		&i.ParagraphID,
+		&i.RevisionNumber,
+		&i.ParagraphContent,
+		&i.Title,
+		&i.PartID,
+		&i.ParagraphOrder,
+		&i.ParagraphType,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createPaymentOrder = `-- name: CreatePaymentOrder :one
+INSERT INTO payment_orders (user_id, plan_id, provider, amount_cents, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING order_id, user_id, plan_id, provider, provider_session_id, amount_cents, currency, status, failure_reason, subscription_id, paid_at, created_at, updated_at
+`
+
+type CreatePaymentOrderParams struct {
+	UserID      uuid.UUID `json:"user_id"`
+	PlanID      uuid.UUID `json:"plan_id"`
+	Provider    string    `json:"provider"`
+	AmountCents int64     `json:"amount_cents"`
+	Currency    string    `json:"currency"`
+}
+
+// CreatePaymentOrder opens a pending order for a plan, priced at the plan's current price.
+func (q *Queries) CreatePaymentOrder(ctx context.Context, arg CreatePaymentOrderParams) (PaymentOrder, error) {
+	row := q.db.QueryRowContext(ctx, createPaymentOrder,
+		arg.UserID,
+		arg.PlanID,
+		arg.Provider,
+		arg.AmountCents,
+		arg.Currency,
+	)
+	var i PaymentOrder
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.PlanID,
+		&i.Provider,
+		&i.ProviderSessionID,
+		&i.AmountCents,
+		&i.Currency,
+		&i.Status,
+		&i.FailureReason,
+		&i.SubscriptionID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createPermission = `-- name: CreatePermission :exec
 INSERT INTO permissions (name, description)
 VALUES ($1, $2)
@@ -231,9 +482,11 @@ INSERT INTO Questions (
     toeic_question_section,
     question_number_in_part,
     answer_option,
-    correct_answer
+    correct_answer,
+    preparation_seconds,
+    response_seconds
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
          ) RETURNING question_id,question_content,question_type,part_id,paragraph_id,question_order,audio_url,image_url,toeic_question_section,question_number_in_part
 `
 
@@ -249,6 +502,8 @@ type CreateQuestionParams struct {
 	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
 	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
 	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
 }
 
 type CreateQuestionRow struct {
@@ -280,6 +535,8 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		arg.QuestionNumberInPart,
 		arg.AnswerOption,
 		arg.CorrectAnswer,
+		arg.PreparationSeconds,
+		arg.ResponseSeconds,
 	)
 	var i CreateQuestionRow
 	err := row.Scan(
@@ -297,6 +554,116 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 	return i, err
 }
 
+const createQuestionRevision = `-- name: CreateQuestionRevision :one
+INSERT INTO question_revisions (question_id, revision_number, question_content, question_type, part_id, paragraph_id,
+                                question_order, audio_url, image_url, toeic_question_section, question_number_in_part,
+                                answer_option, correct_answer, preparation_seconds, response_seconds, diff, restored_from,
+                                created_by)
+SELECT q.question_id,
+       COALESCE((SELECT MAX(r.revision_number) FROM question_revisions r WHERE r.question_id = q.question_id), 0) + 1,
+       q.question_content, q.question_type, q.part_id, q.paragraph_id,
+       q.question_order, q.audio_url, q.image_url, q.toeic_question_section, q.question_number_in_part,
+       q.answer_option, q.correct_answer, q.preparation_seconds, q.response_seconds, $1, $2, $3
+FROM questions q
+WHERE q.question_id = $4
+RETURNING revision_id, question_id, revision_number, question_content, question_type, part_id, paragraph_id, question_order, audio_url, image_url, toeic_question_section, question_number_in_part, answer_option, correct_answer, diff, restored_from, created_by, created_at, preparation_seconds, response_seconds
+`
+
+type CreateQuestionRevisionParams struct {
+	Diff         json.RawMessage `json:"diff"`
+	RestoredFrom sql.NullInt32   `json:"restored_from"`
+	CreatedBy    uuid.NullUUID   `json:"created_by"`
+	QuestionID   uuid.UUID       `json:"question_id"`
+}
+
+// CreateQuestionRevision snapshots the current state of a question as its next revision.
+func (q *Queries) CreateQuestionRevision(ctx context.Context, arg CreateQuestionRevisionParams) (QuestionRevision, error) {
+	row := q.db.QueryRowContext(ctx, createQuestionRevision,
+		arg.Diff,
+		arg.RestoredFrom,
+		arg.CreatedBy,
+		arg.QuestionID,
+	)
+	var i QuestionRevision
+	err := row.Scan(
+		&i.RevisionID,
+		&i.QuestionID,
+		&i.RevisionNumber,
+		&i.QuestionContent,
+		&i.QuestionType,
+		&i.PartID,
+		&i.ParagraphID,
+		&i.QuestionOrder,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.ToeicQuestionSection,
+		&i.QuestionNumberInPart,
+		&i.AnswerOption,
+		&i.CorrectAnswer,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.PreparationSeconds,
+		&i.ResponseSeconds,
+	)
+	return i, err
+}
+
+const createRatingAssignment = `-- name: CreateRatingAssignment :one
+INSERT INTO response_ratings (attempt_id, question_id, rater_id, round)
+VALUES ($1, $2, $3, $4)
+RETURNING rating_id, attempt_id, question_id, rater_id, round, score, max_score, comment, assigned_at, rated_at
+`
+
+type CreateRatingAssignmentParams struct {
+	AttemptID  uuid.UUID `json:"attempt_id"`
+	QuestionID uuid.UUID `json:"question_id"`
+	RaterID    uuid.UUID `json:"rater_id"`
+	Round      int32     `json:"round"`
+}
+
+// CreateRatingAssignment assigns a response to a rater for the given round.
+func (q *Queries) CreateRatingAssignment(ctx context.Context, arg CreateRatingAssignmentParams) (ResponseRating, error) {
+	row := q.db.QueryRowContext(ctx, createRatingAssignment,
+		arg.AttemptID,
+		arg.QuestionID,
+		arg.RaterID,
+		arg.Round,
+	)
+	var i ResponseRating
+	err := row.Scan(
+		&i.RatingID,
+		&i.AttemptID,
+		&i.QuestionID,
+		&i.RaterID,
+		&i.Round,
+		&i.Score,
+		&i.MaxScore,
+		&i.Comment,
+		&i.AssignedAt,
+		&i.RatedAt,
+	)
+	return i, err
+}
+
+const createRefreshToken = `-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (token_id, session_id, expires_at)
+VALUES ($1, $2, $3)
+`
+
+type CreateRefreshTokenParams struct {
+	TokenID   uuid.UUID `json:"token_id"`
+	SessionID uuid.UUID `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// CreateRefreshToken records a refresh token issued for a session.
+func (q *Queries) CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createRefreshToken, arg.TokenID, arg.SessionID, arg.ExpiresAt)
+	return err
+}
+
 const createRole = `-- name: CreateRole :exec
 INSERT INTO roles (name, description)
 VALUES ($1, $2)
@@ -313,6 +680,142 @@ func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) error {
 	return err
 }
 
+const createScoreConversionEntry = `-- name: CreateScoreConversionEntry :exec
+INSERT INTO score_conversion_entries (table_id, section, raw_score, scaled_score)
+VALUES ($1, $2, $3, $4)
+`
+
+type CreateScoreConversionEntryParams struct {
+	TableID     uuid.UUID `json:"table_id"`
+	Section     string    `json:"section"`
+	RawScore    int32     `json:"raw_score"`
+	ScaledScore int32     `json:"scaled_score"`
+}
+
+// CreateScoreConversionEntry adds one raw-to-scaled row to a conversion table.
+func (q *Queries) CreateScoreConversionEntry(ctx context.Context, arg CreateScoreConversionEntryParams) error {
+	_, err := q.db.ExecContext(ctx, createScoreConversionEntry,
+		arg.TableID,
+		arg.Section,
+		arg.RawScore,
+		arg.ScaledScore,
+	)
+	return err
+}
+
+const createScoreConversionTable = `-- name: CreateScoreConversionTable :one
+INSERT INTO score_conversion_tables (name, description)
+VALUES ($1, $2)
+RETURNING table_id, name, description, is_default, created_at, updated_at
+`
+
+type CreateScoreConversionTableParams struct {
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+}
+
+// CreateScoreConversionTable creates a new, empty conversion table.
+func (q *Queries) CreateScoreConversionTable(ctx context.Context, arg CreateScoreConversionTableParams) (ScoreConversionTable, error) {
+	row := q.db.QueryRowContext(ctx, createScoreConversionTable, arg.Name, arg.Description)
+	var i ScoreConversionTable
+	err := row.Scan(
+		&i.TableID,
+		&i.Name,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const createSocialAccount = `-- name: CreateSocialAccount :one
+INSERT INTO users (user_name, email, is_social_login, email_verified_at)
+VALUES ($1, $2, TRUE, CURRENT_TIMESTAMP)
+RETURNING id, user_name, email
+`
+
+type CreateSocialAccountParams struct {
+	UserName string `json:"user_name"`
+	Email    string `json:"email"`
+}
+
+type CreateSocialAccountRow struct {
+	ID       uuid.UUID `json:"id"`
+	UserName string    `json:"user_name"`
+	Email    string    `json:"email"`
+}
+
+// CreateSocialAccount creates a user that signs in through an OAuth provider only.
+func (q *Queries) CreateSocialAccount(ctx context.Context, arg CreateSocialAccountParams) (CreateSocialAccountRow, error) {
+	row := q.db.QueryRowContext(ctx, createSocialAccount, arg.UserName, arg.Email)
+	var i CreateSocialAccountRow
+	err := row.Scan(&i.ID, &i.UserName, &i.Email)
+	return i, err
+}
+
+const createSocialUserProfile = `-- name: CreateSocialUserProfile :exec
+INSERT INTO user_profiles (user_id, full_name)
+VALUES ($1, $2)
+`
+
+type CreateSocialUserProfileParams struct {
+	UserID   uuid.UUID      `json:"user_id"`
+	FullName sql.NullString `json:"full_name"`
+}
+
+// CreateSocialUserProfile creates the profile of a social account from the provider's profile data.
+func (q *Queries) CreateSocialUserProfile(ctx context.Context, arg CreateSocialUserProfileParams) error {
+	_, err := q.db.ExecContext(ctx, createSocialUserProfile, arg.UserID, arg.FullName)
+	return err
+}
+
+const createSubscriptionPlan = `-- name: CreateSubscriptionPlan :one
+INSERT INTO subscription_plans (code, name, description, duration_days, grace_period_days, price_cents, currency, is_active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING plan_id, code, name, description, duration_days, grace_period_days, price_cents, currency, is_active, created_at, updated_at
+`
+
+type CreateSubscriptionPlanParams struct {
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DurationDays    int32  `json:"duration_days"`
+	GracePeriodDays int32  `json:"grace_period_days"`
+	PriceCents      int64  `json:"price_cents"`
+	Currency        string `json:"currency"`
+	IsActive        bool   `json:"is_active"`
+}
+
+// CreateSubscriptionPlan adds a plan users can subscribe to or be granted.
+func (q *Queries) CreateSubscriptionPlan(ctx context.Context, arg CreateSubscriptionPlanParams) (SubscriptionPlan, error) {
+	row := q.db.QueryRowContext(ctx, createSubscriptionPlan,
+		arg.Code,
+		arg.Name,
+		arg.Description,
+		arg.DurationDays,
+		arg.GracePeriodDays,
+		arg.PriceCents,
+		arg.Currency,
+		arg.IsActive,
+	)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.PlanID,
+		&i.Code,
+		&i.Name,
+		&i.Description,
+		&i.DurationDays,
+		&i.GracePeriodDays,
+		&i.PriceCents,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const createUserProfile = `-- name: CreateUserProfile :exec
 
 INSERT INTO user_profiles(user_id, full_name, birthday, gender, phone_number, address, bio)
@@ -344,6 +847,122 @@ func (q *Queries) CreateUserProfile(ctx context.Context, arg CreateUserProfilePa
 	return err
 }
 
+const createUserProvider = `-- name: CreateUserProvider :exec
+INSERT INTO user_providers (user_id, provider, provider_user_id, email, access_token, refresh_token, avatar_url, full_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateUserProviderParams struct {
+	UserID         uuid.UUID      `json:"user_id"`
+	Provider       string         `json:"provider"`
+	ProviderUserID string         `json:"provider_user_id"`
+	Email          sql.NullString `json:"email"`
+	AccessToken    sql.NullString `json:"access_token"`
+	RefreshToken   sql.NullString `json:"refresh_token"`
+	AvatarUrl      sql.NullString `json:"avatar_url"`
+	FullName       sql.NullString `json:"full_name"`
+}
+
+// CreateUserProvider links a provider identity to a local user.
+func (q *Queries) CreateUserProvider(ctx context.Context, arg CreateUserProviderParams) error {
+	_, err := q.db.ExecContext(ctx, createUserProvider,
+		arg.UserID,
+		arg.Provider,
+		arg.ProviderUserID,
+		arg.Email,
+		arg.AccessToken,
+		arg.RefreshToken,
+		arg.AvatarUrl,
+		arg.FullName,
+	)
+	return err
+}
+
+const createUserSession = `-- name: CreateUserSession :one
+INSERT INTO user_sessions (session_id, user_id, expires_at, user_agent, ip_address)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING session_id, user_id, expires_at, last_used_at, revoked_at, revoke_reason, created_at, user_agent, ip_address
+`
+
+type CreateUserSessionParams struct {
+	SessionID uuid.UUID `json:"session_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserAgent string    `json:"user_agent"`
+	IpAddress string    `json:"ip_address"`
+}
+
+// CreateUserSession opens a session for a successful login.
+func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error) {
+	row := q.db.QueryRowContext(ctx, createUserSession,
+		arg.SessionID,
+		arg.UserID,
+		arg.ExpiresAt,
+		arg.UserAgent,
+		arg.IpAddress,
+	)
+	var i UserSession
+	err := row.Scan(
+		&i.SessionID,
+		&i.UserID,
+		&i.ExpiresAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.RevokeReason,
+		&i.CreatedAt,
+		&i.UserAgent,
+		&i.IpAddress,
+	)
+	return i, err
+}
+
+const createUserSubscription = `-- name: CreateUserSubscription :one
+INSERT INTO user_subscriptions (user_id, plan_id, source, starts_at, ends_at, grace_ends_at, granted_by, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING subscription_id, user_id, plan_id, source, starts_at, ends_at, grace_ends_at, granted_by, note, canceled_at, created_at, updated_at
+`
+
+type CreateUserSubscriptionParams struct {
+	UserID      uuid.UUID     `json:"user_id"`
+	PlanID      uuid.UUID     `json:"plan_id"`
+	Source      string        `json:"source"`
+	StartsAt    time.Time     `json:"starts_at"`
+	EndsAt      time.Time     `json:"ends_at"`
+	GraceEndsAt time.Time     `json:"grace_ends_at"`
+	GrantedBy   uuid.NullUUID `json:"granted_by"`
+	Note        string        `json:"note"`
+}
+
+// CreateUserSubscription records a subscription period for a user.
+func (q *Queries) CreateUserSubscription(ctx context.Context, arg CreateUserSubscriptionParams) (UserSubscription, error) {
+	row := q.db.QueryRowContext(ctx, createUserSubscription,
+		arg.UserID,
+		arg.PlanID,
+		arg.Source,
+		arg.StartsAt,
+		arg.EndsAt,
+		arg.GraceEndsAt,
+		arg.GrantedBy,
+		arg.Note,
+	)
+	var i UserSubscription
+	err := row.Scan(
+		&i.SubscriptionID,
+		&i.UserID,
+		&i.PlanID,
+		&i.Source,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.GraceEndsAt,
+		&i.GrantedBy,
+		&i.Note,
+		&i.CanceledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const deleteExam = `-- name: DeleteExam :exec
 DELETE FROM Exams
 WHERE
@@ -398,6 +1017,26 @@ func (q *Queries) DeleteQuestion(ctx context.Context, questionID uuid.UUID) erro
 	return err
 }
 
+const deleteQuestionExplanation = `-- name: DeleteQuestionExplanation :execrows
+DELETE FROM question_explanations
+WHERE question_id = $1
+  AND lang = $2
+`
+
+type DeleteQuestionExplanationParams struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Lang       string    `json:"lang"`
+}
+
+// DeleteQuestionExplanation removes the explanations of a question in one language.
+func (q *Queries) DeleteQuestionExplanation(ctx context.Context, arg DeleteQuestionExplanationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteQuestionExplanation, arg.QuestionID, arg.Lang)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteRole = `-- name: DeleteRole :exec
 DELETE FROM roles WHERE id = $1
 `
@@ -408,20 +1047,342 @@ func (q *Queries) DeleteRole(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
-const getCountSeparateQuestionsByPartID = `-- name: GetCountSeparateQuestionsByPartID :one
-SELECT
-    count(*)
-FROM
-    Questions
-WHERE
-    part_id = $1 and paragraph_id ISNULL
+const deleteScoreConversionEntries = `-- name: DeleteScoreConversionEntries :exec
+DELETE FROM score_conversion_entries
+WHERE table_id = $1
 `
 
-func (q *Queries) GetCountSeparateQuestionsByPartID(ctx context.Context, partID uuid.UUID) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getCountSeparateQuestionsByPartID, partID)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
+// DeleteScoreConversionEntries removes every row of a conversion table.
+func (q *Queries) DeleteScoreConversionEntries(ctx context.Context, tableID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteScoreConversionEntries, tableID)
+	return err
+}
+
+const finalizeExamAttempt = `-- name: FinalizeExamAttempt :execrows
+UPDATE exam_attempts
+SET
+    status = $2,
+    submitted_at = CURRENT_TIMESTAMP
+WHERE attempt_id = $1 AND status = 'IN_PROGRESS'
+`
+
+type FinalizeExamAttemptParams struct {
+	AttemptID uuid.UUID `json:"attempt_id"`
+	Status    string    `json:"status"`
+}
+
+// FinalizeExamAttempt closes an in-progress attempt with the given final status.
+func (q *Queries) FinalizeExamAttempt(ctx context.Context, arg FinalizeExamAttemptParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, finalizeExamAttempt, arg.AttemptID, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const getActiveSubscriptionPlans = `-- name: GetActiveSubscriptionPlans :many
+SELECT plan_id, code, name, description, duration_days, grace_period_days, price_cents, currency, is_active, created_at, updated_at
+FROM subscription_plans
+WHERE is_active = TRUE
+ORDER BY price_cents, name
+`
+
+// GetActiveSubscriptionPlans lists the plans offered to learners, cheapest first.
+func (q *Queries) GetActiveSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error) {
+	rows, err := q.db.QueryContext(ctx, getActiveSubscriptionPlans)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SubscriptionPlan{}
+	for rows.Next() {
+		var i SubscriptionPlan
+		if err := rows.Scan(
+			&i.PlanID,
+			&i.Code,
+			&i.Name,
+			&i.Description,
+			&i.DurationDays,
+			&i.GracePeriodDays,
+			&i.PriceCents,
+			&i.Currency,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttemptResponse = `-- name: GetAttemptResponse :one
+SELECT attempt_id, question_id, response_type, started_at, preparation_seconds, response_seconds, audio_object, audio_url, text_response, word_count, submitted_at, created_at, updated_at, rating_status, final_score, max_score, rated_at, auto_score, auto_max_score, auto_feedback, auto_scorer, auto_scored_at, auto_score_failures
+FROM attempt_responses
+WHERE attempt_id = $1
+  AND question_id = $2
+`
+
+type GetAttemptResponseParams struct {
+	AttemptID  uuid.UUID `json:"attempt_id"`
+	QuestionID uuid.UUID `json:"question_id"`
+}
+
+func (q *Queries) GetAttemptResponse(ctx context.Context, arg GetAttemptResponseParams) (AttemptResponse, error) {
+	row := q.db.QueryRowContext(ctx, getAttemptResponse, arg.AttemptID, arg.QuestionID)
+	var i AttemptResponse
+	err := row.Scan(
+		&i.AttemptID,
+		&i.QuestionID,
+		&i.ResponseType,
+		&i.StartedAt,
+		&i.PreparationSeconds,
+		&i.ResponseSeconds,
+		&i.AudioObject,
+		&i.AudioUrl,
+		&i.TextResponse,
+		&i.WordCount,
+		&i.SubmittedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RatingStatus,
+		&i.FinalScore,
+		&i.MaxScore,
+		&i.RatedAt,
+		&i.AutoScore,
+		&i.AutoMaxScore,
+		&i.AutoFeedback,
+		&i.AutoScorer,
+		&i.AutoScoredAt,
+		&i.AutoScoreFailures,
+	)
+	return i, err
+}
+
+const getAttemptResult = `-- name: GetAttemptResult :one
+SELECT attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+       speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled
+FROM attempt_results
+WHERE attempt_id = $1
+`
+
+// GetAttemptResult retrieves the scores of an attempt.
+func (q *Queries) GetAttemptResult(ctx context.Context, attemptID uuid.UUID) (AttemptResult, error) {
+	row := q.db.QueryRowContext(ctx, getAttemptResult, attemptID)
+	var i AttemptResult
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ConversionTableID,
+		&i.ListeningCorrect,
+		&i.ListeningTotal,
+		&i.ListeningScaled,
+		&i.ReadingCorrect,
+		&i.ReadingTotal,
+		&i.ReadingScaled,
+		&i.TotalScaled,
+		&i.ScoredAt,
+		&i.SpeakingPoints,
+		&i.SpeakingMaxPoints,
+		&i.SpeakingPending,
+		&i.SpeakingScaled,
+		&i.WritingPoints,
+		&i.WritingMaxPoints,
+		&i.WritingPending,
+		&i.WritingScaled,
+	)
+	return i, err
+}
+
+const getContentIds = `-- name: GetContentIds :many
+SELECT question_id AS content_id
+FROM questions
+UNION ALL
+SELECT paragraph_id
+FROM paragraphs
+`
+
+// GetContentIds lists the ids of all questions and paragraphs, which name their transcript files.
+func (q *Queries) GetContentIds(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getContentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var content_id uuid.UUID
+		if err := rows.Scan(&content_id); err != nil {
+			return nil, err
+		}
+		items = append(items, content_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContentMediaReferences = `-- name: GetContentMediaReferences :many
+SELECT DISTINCT r.reference::text AS reference
+FROM (SELECT audio_url AS reference FROM questions
+      UNION ALL SELECT image_url FROM questions
+      UNION ALL SELECT audio_url FROM paragraphs
+      UNION ALL SELECT image_url FROM paragraphs
+      UNION ALL SELECT audio_url FROM question_revisions
+      UNION ALL SELECT image_url FROM question_revisions
+      UNION ALL SELECT audio_url FROM paragraph_revisions
+      UNION ALL SELECT image_url FROM paragraph_revisions) r
+WHERE r.reference IS NOT NULL
+  AND r.reference <> ''
+`
+
+// GetContentMediaReferences lists the audio and image references held by questions and paragraphs, trashed
+// ones and revisions included, which is every uploaded file library content may still use.
+func (q *Queries) GetContentMediaReferences(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getContentMediaReferences)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var reference string
+		if err := rows.Scan(&reference); err != nil {
+			return nil, err
+		}
+		items = append(items, reference)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getContentStatusChanges = `-- name: GetContentStatusChanges :many
+SELECT change_id, content_type, content_id, from_status, to_status, changed_by, note, changed_at
+FROM content_status_changes
+WHERE content_type = $1
+  AND content_id = $2
+ORDER BY changed_at DESC
+`
+
+type GetContentStatusChangesParams struct {
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+}
+
+// GetContentStatusChanges lists the transitions of an exam or practice part, most recent first.
+func (q *Queries) GetContentStatusChanges(ctx context.Context, arg GetContentStatusChangesParams) ([]ContentStatusChange, error) {
+	rows, err := q.db.QueryContext(ctx, getContentStatusChanges, arg.ContentType, arg.ContentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ContentStatusChange{}
+	for rows.Next() {
+		var i ContentStatusChange
+		if err := rows.Scan(
+			&i.ChangeID,
+			&i.ContentType,
+			&i.ContentID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.ChangedBy,
+			&i.Note,
+			&i.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCountSeparateQuestionsByPartID = `-- name: GetCountSeparateQuestionsByPartID :one
+SELECT
+    count(*)
+FROM
+    Questions
+WHERE
+    part_id = $1 and paragraph_id ISNULL and deleted_at IS NULL
+`
+
+func (q *Queries) GetCountSeparateQuestionsByPartID(ctx context.Context, partID uuid.UUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getCountSeparateQuestionsByPartID, partID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getCurrentUserSubscription = `-- name: GetCurrentUserSubscription :one
+SELECT us.subscription_id, us.user_id, us.plan_id, us.source, us.starts_at, us.ends_at, us.grace_ends_at, us.granted_by, us.note, us.canceled_at, us.created_at, us.updated_at, sp.code AS plan_code, sp.name AS plan_name
+FROM user_subscriptions us
+         JOIN subscription_plans sp ON sp.plan_id = us.plan_id
+WHERE us.user_id = $1
+  AND us.canceled_at IS NULL
+  AND us.starts_at <= CURRENT_TIMESTAMP
+  AND us.grace_ends_at > CURRENT_TIMESTAMP
+ORDER BY us.grace_ends_at DESC
+LIMIT 1
+`
+
+type GetCurrentUserSubscriptionRow struct {
+	SubscriptionID uuid.UUID     `json:"subscription_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	PlanID         uuid.UUID     `json:"plan_id"`
+	Source         string        `json:"source"`
+	StartsAt       time.Time     `json:"starts_at"`
+	EndsAt         time.Time     `json:"ends_at"`
+	GraceEndsAt    time.Time     `json:"grace_ends_at"`
+	GrantedBy      uuid.NullUUID `json:"granted_by"`
+	Note           string        `json:"note"`
+	CanceledAt     sql.NullTime  `json:"canceled_at"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+	UpdatedAt      sql.NullTime  `json:"updated_at"`
+	PlanCode       string        `json:"plan_code"`
+	PlanName       string        `json:"plan_name"`
+}
+
+// GetCurrentUserSubscription returns the subscription giving the user access right now, the one lasting longest first.
+func (q *Queries) GetCurrentUserSubscription(ctx context.Context, userID uuid.UUID) (GetCurrentUserSubscriptionRow, error) {
+	row := q.db.QueryRowContext(ctx, getCurrentUserSubscription, userID)
+	var i GetCurrentUserSubscriptionRow
+	err := row.Scan(
+		&i.SubscriptionID,
+		&i.UserID,
+		&i.PlanID,
+		&i.Source,
+		&i.StartsAt,
+		&i.EndsAt,
+		&i.GraceEndsAt,
+		&i.GrantedBy,
+		&i.Note,
+		&i.CanceledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PlanCode,
+		&i.PlanName,
+	)
+	return i, err
 }
 
 const getExam = `-- name: GetExam :one
@@ -437,16 +1398,35 @@ SELECT
     max_writing_score,
     total_score,
     created_at,
-    updated_at
+    updated_at,
+    status,
+    published_at
 FROM
     Exams
 WHERE
-    exam_id = $1
+    exam_id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetExam(ctx context.Context, examID uuid.UUID) (Exam, error) {
+type GetExamRow struct {
+	ExamID            uuid.UUID      `json:"exam_id"`
+	ExamTitle         string         `json:"exam_title"`
+	Description       sql.NullString `json:"description"`
+	DurationMinutes   sql.NullInt32  `json:"duration_minutes"`
+	ExamType          string         `json:"exam_type"`
+	MaxListeningScore sql.NullInt32  `json:"max_listening_score"`
+	MaxReadingScore   sql.NullInt32  `json:"max_reading_score"`
+	MaxSpeakingScore  sql.NullInt32  `json:"max_speaking_score"`
+	MaxWritingScore   sql.NullInt32  `json:"max_writing_score"`
+	TotalScore        sql.NullInt32  `json:"total_score"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	UpdatedAt         sql.NullTime   `json:"updated_at"`
+	Status            string         `json:"status"`
+	PublishedAt       sql.NullTime   `json:"published_at"`
+}
+
+func (q *Queries) GetExam(ctx context.Context, examID uuid.UUID) (GetExamRow, error) {
 	row := q.db.QueryRowContext(ctx, getExam, examID)
-	var i Exam
+	var i GetExamRow
 	err := row.Scan(
 		&i.ExamID,
 		&i.ExamTitle,
@@ -460,6 +1440,37 @@ func (q *Queries) GetExam(ctx context.Context, examID uuid.UUID) (Exam, error) {
 		&i.TotalScore,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
+		&i.PublishedAt,
+	)
+	return i, err
+}
+
+const getExamAttempt = `-- name: GetExamAttempt :one
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE attempt_id = $1 AND user_id = $2
+`
+
+type GetExamAttemptParams struct {
+	AttemptID uuid.UUID `json:"attempt_id"`
+	UserID    uuid.UUID `json:"user_id"`
+}
+
+// GetExamAttempt retrieves an attempt owned by the given user.
+func (q *Queries) GetExamAttempt(ctx context.Context, arg GetExamAttemptParams) (ExamAttempt, error) {
+	row := q.db.QueryRowContext(ctx, getExamAttempt, arg.AttemptID, arg.UserID)
+	var i ExamAttempt
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ExamID,
+		&i.UserID,
+		&i.Status,
+		&i.StartedAt,
+		&i.DeadlineAt,
+		&i.SubmittedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -475,16 +1486,33 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    part_id = $1
+    part_id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetExamPartByID(ctx context.Context, partID uuid.UUID) (ExamPart, error) {
+type GetExamPartByIDRow struct {
+	PartID              uuid.UUID      `json:"part_id"`
+	ExamID              uuid.NullUUID  `json:"exam_id"`
+	PartTitle           string         `json:"part_title"`
+	PartOrder           sql.NullInt32  `json:"part_order"`
+	Description         sql.NullString `json:"description"`
+	IsPracticeComponent sql.NullBool   `json:"is_practice_component"`
+	PlanType            string         `json:"plan_type"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	UpdatedAt           sql.NullTime   `json:"updated_at"`
+	ToeicPartNumber     sql.NullInt32  `json:"toeic_part_number"`
+	Status              string         `json:"status"`
+	PublishedAt         sql.NullTime   `json:"published_at"`
+}
+
+func (q *Queries) GetExamPartByID(ctx context.Context, partID uuid.UUID) (GetExamPartByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getExamPartByID, partID)
-	var i ExamPart
+	var i GetExamPartByIDRow
 	err := row.Scan(
 		&i.PartID,
 		&i.ExamID,
@@ -496,6 +1524,8 @@ func (q *Queries) GetExamPartByID(ctx context.Context, partID uuid.UUID) (ExamPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.ToeicPartNumber,
+		&i.Status,
+		&i.PublishedAt,
 	)
 	return i, err
 }
@@ -511,24 +1541,41 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    exam_id = $1
+    exam_id = $1 AND deleted_at IS NULL
 ORDER BY
     part_order
 `
 
-func (q *Queries) GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID) ([]ExamPart, error) {
+type GetExamPartsByExamIdRow struct {
+	PartID              uuid.UUID      `json:"part_id"`
+	ExamID              uuid.NullUUID  `json:"exam_id"`
+	PartTitle           string         `json:"part_title"`
+	PartOrder           sql.NullInt32  `json:"part_order"`
+	Description         sql.NullString `json:"description"`
+	IsPracticeComponent sql.NullBool   `json:"is_practice_component"`
+	PlanType            string         `json:"plan_type"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	UpdatedAt           sql.NullTime   `json:"updated_at"`
+	ToeicPartNumber     sql.NullInt32  `json:"toeic_part_number"`
+	Status              string         `json:"status"`
+	PublishedAt         sql.NullTime   `json:"published_at"`
+}
+
+func (q *Queries) GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID) ([]GetExamPartsByExamIdRow, error) {
 	rows, err := q.db.QueryContext(ctx, getExamPartsByExamId, examID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ExamPart{}
+	items := []GetExamPartsByExamIdRow{}
 	for rows.Next() {
-		var i ExamPart
+		var i GetExamPartsByExamIdRow
 		if err := rows.Scan(
 			&i.PartID,
 			&i.ExamID,
@@ -540,6 +1587,8 @@ func (q *Queries) GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ToeicPartNumber,
+			&i.Status,
+			&i.PublishedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -554,66 +1603,356 @@ func (q *Queries) GetExamPartsByExamId(ctx context.Context, examID uuid.NullUUID
 	return items, nil
 }
 
+const getExamScoringConfig = `-- name: GetExamScoringConfig :one
+SELECT
+    e.exam_id,
+    e.max_listening_score,
+    e.max_reading_score,
+    e.max_speaking_score,
+    e.max_writing_score,
+    t.table_id AS conversion_table_id
+FROM exams e
+LEFT JOIN score_conversion_tables t ON t.table_id = COALESCE(e.score_conversion_table_id, (
+    SELECT d.table_id FROM score_conversion_tables d WHERE d.is_default LIMIT 1
+))
+WHERE e.exam_id = $1
+`
+
+type GetExamScoringConfigRow struct {
+	ExamID            uuid.UUID     `json:"exam_id"`
+	MaxListeningScore sql.NullInt32 `json:"max_listening_score"`
+	MaxReadingScore   sql.NullInt32 `json:"max_reading_score"`
+	MaxSpeakingScore  sql.NullInt32 `json:"max_speaking_score"`
+	MaxWritingScore   sql.NullInt32 `json:"max_writing_score"`
+	ConversionTableID uuid.NullUUID `json:"conversion_table_id"`
+}
+
+// GetExamScoringConfig retrieves the score caps of an exam and the conversion table it uses, falling back to the default table.
+func (q *Queries) GetExamScoringConfig(ctx context.Context, examID uuid.UUID) (GetExamScoringConfigRow, error) {
+	row := q.db.QueryRowContext(ctx, getExamScoringConfig, examID)
+	var i GetExamScoringConfigRow
+	err := row.Scan(
+		&i.ExamID,
+		&i.MaxListeningScore,
+		&i.MaxReadingScore,
+		&i.MaxSpeakingScore,
+		&i.MaxWritingScore,
+		&i.ConversionTableID,
+	)
+	return i, err
+}
+
 const getExamsCount = `-- name: GetExamsCount :one
 SELECT COUNT(*) FROM exams
+WHERE deleted_at IS NULL
+  AND ($1::varchar IS NULL OR status = $1)
 `
 
-func (q *Queries) GetExamsCount(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getExamsCount)
+func (q *Queries) GetExamsCount(ctx context.Context, status sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getExamsCount, status)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
-const getPaginatedExams = `-- name: GetPaginatedExams :many
-SELECT
-    exam_id,
-    exam_title,
-    description,
-    duration_minutes,
-    exam_type,
-    max_listening_score,
-    max_reading_score,
-    max_speaking_score,
-    max_writing_score,
-    total_score,
-    created_at,
-    updated_at
-FROM
-    Exams
-LIMIT $1 OFFSET $2
+const getInProgressExamAttempt = `-- name: GetInProgressExamAttempt :one
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE user_id = $1 AND exam_id = $2 AND status = 'IN_PROGRESS'
+ORDER BY started_at DESC
+LIMIT 1
 `
 
-type GetPaginatedExamsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+type GetInProgressExamAttemptParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	ExamID uuid.UUID `json:"exam_id"`
 }
 
-func (q *Queries) GetPaginatedExams(ctx context.Context, arg GetPaginatedExamsParams) ([]Exam, error) {
-	rows, err := q.db.QueryContext(ctx, getPaginatedExams, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Exam{}
-	for rows.Next() {
-		var i Exam
-		if err := rows.Scan(
-			&i.ExamID,
-			&i.ExamTitle,
-			&i.Description,
-			&i.DurationMinutes,
-			&i.ExamType,
-			&i.MaxListeningScore,
-			&i.MaxReadingScore,
-			&i.MaxSpeakingScore,
-			&i.MaxWritingScore,
-			&i.TotalScore,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
+// GetInProgressExamAttempt retrieves the latest unfinished attempt of a user for an exam.
+func (q *Queries) GetInProgressExamAttempt(ctx context.Context, arg GetInProgressExamAttemptParams) (ExamAttempt, error) {
+	row := q.db.QueryRowContext(ctx, getInProgressExamAttempt, arg.UserID, arg.ExamID)
+	var i ExamAttempt
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ExamID,
+		&i.UserID,
+		&i.Status,
+		&i.StartedAt,
+		&i.DeadlineAt,
+		&i.SubmittedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getLatestParagraphRevision = `-- name: GetLatestParagraphRevision :one
+SELECT revision_id, paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order, paragraph_type, audio_url, image_url, diff, restored_from, created_by, created_at
+FROM paragraph_revisions
+WHERE paragraph_id = $1
+ORDER BY revision_number DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestParagraphRevision(ctx context.Context, paragraphID uuid.UUID) (ParagraphRevision, error) {
+	row := q.db.QueryRowContext(ctx, getLatestParagraphRevision, paragraphID)
+	var i ParagraphRevision
+	err := row.Scan(
+		&i.RevisionID,
+		&i.ParagraphID,
+		&i.RevisionNumber,
+		&i.ParagraphContent,
+		&i.Title,
+		&i.PartID,
+		&i.ParagraphOrder,
+		&i.ParagraphType,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getLatestQuestionRevision = `-- name: GetLatestQuestionRevision :one
+SELECT revision_id, question_id, revision_number, question_content, question_type, part_id, paragraph_id, question_order, audio_url, image_url, toeic_question_section, question_number_in_part, answer_option, correct_answer, diff, restored_from, created_by, created_at, preparation_seconds, response_seconds
+FROM question_revisions
+WHERE question_id = $1
+ORDER BY revision_number DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestQuestionRevision(ctx context.Context, questionID uuid.UUID) (QuestionRevision, error) {
+	row := q.db.QueryRowContext(ctx, getLatestQuestionRevision, questionID)
+	var i QuestionRevision
+	err := row.Scan(
+		&i.RevisionID,
+		&i.QuestionID,
+		&i.RevisionNumber,
+		&i.QuestionContent,
+		&i.QuestionType,
+		&i.PartID,
+		&i.ParagraphID,
+		&i.QuestionOrder,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.ToeicQuestionSection,
+		&i.QuestionNumberInPart,
+		&i.AnswerOption,
+		&i.CorrectAnswer,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.PreparationSeconds,
+		&i.ResponseSeconds,
+	)
+	return i, err
+}
+
+const getLatestUserSubscriptionEnd = `-- name: GetLatestUserSubscriptionEnd :one
+SELECT ends_at
+FROM user_subscriptions
+WHERE user_id = $1
+  AND canceled_at IS NULL
+  AND ends_at > CURRENT_TIMESTAMP
+ORDER BY ends_at DESC
+LIMIT 1
+`
+
+// GetLatestUserSubscriptionEnd returns when the last running or scheduled subscription of the user ends, so a new one can start after it.
+func (q *Queries) GetLatestUserSubscriptionEnd(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	row := q.db.QueryRowContext(ctx, getLatestUserSubscriptionEnd, userID)
+	var ends_at time.Time
+	err := row.Scan(&ends_at)
+	return ends_at, err
+}
+
+const getMailDeadLetter = `-- name: GetMailDeadLetter :one
+SELECT job_id, recipients, subject, payload, attempts, last_error, failed_at, requeued_at
+FROM mail_dead_letters
+WHERE job_id = $1
+`
+
+// GetMailDeadLetter returns a failed mail by its job id.
+func (q *Queries) GetMailDeadLetter(ctx context.Context, jobID uuid.UUID) (MailDeadLetter, error) {
+	row := q.db.QueryRowContext(ctx, getMailDeadLetter, jobID)
+	var i MailDeadLetter
+	err := row.Scan(
+		&i.JobID,
+		&i.Recipients,
+		&i.Subject,
+		&i.Payload,
+		&i.Attempts,
+		&i.LastError,
+		&i.FailedAt,
+		&i.RequeuedAt,
+	)
+	return i, err
+}
+
+const getMailDeadLettersCount = `-- name: GetMailDeadLettersCount :one
+SELECT COUNT(*)
+FROM mail_dead_letters
+`
+
+// GetMailDeadLettersCount counts the failed mails.
+func (q *Queries) GetMailDeadLettersCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getMailDeadLettersCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getOpenRatingAssignment = `-- name: GetOpenRatingAssignment :one
+SELECT rating_id, attempt_id, question_id, rater_id, round, score, max_score, comment, assigned_at, rated_at
+FROM response_ratings
+WHERE rater_id = $1
+  AND score IS NULL
+ORDER BY assigned_at
+LIMIT 1
+`
+
+// GetOpenRatingAssignment retrieves the response a rater was assigned and has not rated yet.
+func (q *Queries) GetOpenRatingAssignment(ctx context.Context, raterID uuid.UUID) (ResponseRating, error) {
+	row := q.db.QueryRowContext(ctx, getOpenRatingAssignment, raterID)
+	var i ResponseRating
+	err := row.Scan(
+		&i.RatingID,
+		&i.AttemptID,
+		&i.QuestionID,
+		&i.RaterID,
+		&i.Round,
+		&i.Score,
+		&i.MaxScore,
+		&i.Comment,
+		&i.AssignedAt,
+		&i.RatedAt,
+	)
+	return i, err
+}
+
+const getPaginatedExams = `-- name: GetPaginatedExams :many
+SELECT
+    exam_id,
+    exam_title,
+    description,
+    duration_minutes,
+    exam_type,
+    max_listening_score,
+    max_reading_score,
+    max_speaking_score,
+    max_writing_score,
+    total_score,
+    created_at,
+    updated_at,
+    status,
+    published_at
+FROM
+    Exams
+WHERE
+    deleted_at IS NULL
+    AND ($3::varchar IS NULL OR status = $3)
+LIMIT $1 OFFSET $2
+`
+
+type GetPaginatedExamsParams struct {
+	Limit  int32          `json:"limit"`
+	Offset int32          `json:"offset"`
+	Status sql.NullString `json:"status"`
+}
+
+type GetPaginatedExamsRow struct {
+	ExamID            uuid.UUID      `json:"exam_id"`
+	ExamTitle         string         `json:"exam_title"`
+	Description       sql.NullString `json:"description"`
+	DurationMinutes   sql.NullInt32  `json:"duration_minutes"`
+	ExamType          string         `json:"exam_type"`
+	MaxListeningScore sql.NullInt32  `json:"max_listening_score"`
+	MaxReadingScore   sql.NullInt32  `json:"max_reading_score"`
+	MaxSpeakingScore  sql.NullInt32  `json:"max_speaking_score"`
+	MaxWritingScore   sql.NullInt32  `json:"max_writing_score"`
+	TotalScore        sql.NullInt32  `json:"total_score"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	UpdatedAt         sql.NullTime   `json:"updated_at"`
+	Status            string         `json:"status"`
+	PublishedAt       sql.NullTime   `json:"published_at"`
+}
+
+func (q *Queries) GetPaginatedExams(ctx context.Context, arg GetPaginatedExamsParams) ([]GetPaginatedExamsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPaginatedExams, arg.Limit, arg.Offset, arg.Status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPaginatedExamsRow{}
+	for rows.Next() {
+		var i GetPaginatedExamsRow
+		if err := rows.Scan(
+			&i.ExamID,
+			&i.ExamTitle,
+			&i.Description,
+			&i.DurationMinutes,
+			&i.ExamType,
+			&i.MaxListeningScore,
+			&i.MaxReadingScore,
+			&i.MaxSpeakingScore,
+			&i.MaxWritingScore,
+			&i.TotalScore,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+			&i.PublishedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPaginatedMailDeadLetters = `-- name: GetPaginatedMailDeadLetters :many
+SELECT job_id, recipients, subject, payload, attempts, last_error, failed_at, requeued_at
+FROM mail_dead_letters
+ORDER BY failed_at DESC
+LIMIT $1 OFFSET $2
+`
+
+type GetPaginatedMailDeadLettersParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+// GetPaginatedMailDeadLetters lists failed mails, most recent first.
+func (q *Queries) GetPaginatedMailDeadLetters(ctx context.Context, arg GetPaginatedMailDeadLettersParams) ([]MailDeadLetter, error) {
+	rows, err := q.db.QueryContext(ctx, getPaginatedMailDeadLetters, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MailDeadLetter{}
+	for rows.Next() {
+		var i MailDeadLetter
+		if err := rows.Scan(
+			&i.JobID,
+			&i.Recipients,
+			&i.Subject,
+			&i.Payload,
+			&i.Attempts,
+			&i.LastError,
+			&i.FailedAt,
+			&i.RequeuedAt,
+		); err != nil {
+			return nil, err
+		}
 		items = append(items, i)
 	}
 	if err := rows.Close(); err != nil {
@@ -636,28 +1975,48 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    is_practice_component == 'TRUE'
+    is_practice_component = TRUE
+    AND deleted_at IS NULL
+    AND ($3::varchar IS NULL OR status = $3)
 LIMIT $1 OFFSET $2
 `
 
 type GetPaginatedPracticeExamPartsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Limit  int32          `json:"limit"`
+	Offset int32          `json:"offset"`
+	Status sql.NullString `json:"status"`
+}
+
+type GetPaginatedPracticeExamPartsRow struct {
+	PartID              uuid.UUID      `json:"part_id"`
+	ExamID              uuid.NullUUID  `json:"exam_id"`
+	PartTitle           string         `json:"part_title"`
+	PartOrder           sql.NullInt32  `json:"part_order"`
+	Description         sql.NullString `json:"description"`
+	IsPracticeComponent sql.NullBool   `json:"is_practice_component"`
+	PlanType            string         `json:"plan_type"`
+	CreatedAt           sql.NullTime   `json:"created_at"`
+	UpdatedAt           sql.NullTime   `json:"updated_at"`
+	ToeicPartNumber     sql.NullInt32  `json:"toeic_part_number"`
+	Status              string         `json:"status"`
+	PublishedAt         sql.NullTime   `json:"published_at"`
 }
 
-func (q *Queries) GetPaginatedPracticeExamParts(ctx context.Context, arg GetPaginatedPracticeExamPartsParams) ([]ExamPart, error) {
-	rows, err := q.db.QueryContext(ctx, getPaginatedPracticeExamParts, arg.Limit, arg.Offset)
+func (q *Queries) GetPaginatedPracticeExamParts(ctx context.Context, arg GetPaginatedPracticeExamPartsParams) ([]GetPaginatedPracticeExamPartsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPaginatedPracticeExamParts, arg.Limit, arg.Offset, arg.Status)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []ExamPart{}
+	items := []GetPaginatedPracticeExamPartsRow{}
 	for rows.Next() {
-		var i ExamPart
+		var i GetPaginatedPracticeExamPartsRow
 		if err := rows.Scan(
 			&i.PartID,
 			&i.ExamID,
@@ -669,6 +2028,8 @@ func (q *Queries) GetPaginatedPracticeExamParts(ctx context.Context, arg GetPagi
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ToeicPartNumber,
+			&i.Status,
+			&i.PublishedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -697,12 +2058,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    part_id = $1 and paragraph_id ISNULL
+    part_id = $1 and paragraph_id ISNULL and deleted_at IS NULL
 Order By
     question_order ASC,
     question_number_in_part ASC,
@@ -716,15 +2079,34 @@ type GetPaginatedSeparateQuestionsByPartIDParams struct {
 	Offset int32     `json:"offset"`
 }
 
-func (q *Queries) GetPaginatedSeparateQuestionsByPartID(ctx context.Context, arg GetPaginatedSeparateQuestionsByPartIDParams) ([]Question, error) {
+type GetPaginatedSeparateQuestionsByPartIDRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+}
+
+func (q *Queries) GetPaginatedSeparateQuestionsByPartID(ctx context.Context, arg GetPaginatedSeparateQuestionsByPartIDParams) ([]GetPaginatedSeparateQuestionsByPartIDRow, error) {
 	rows, err := q.db.QueryContext(ctx, getPaginatedSeparateQuestionsByPartID, arg.PartID, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Question{}
+	items := []GetPaginatedSeparateQuestionsByPartIDRow{}
 	for rows.Next() {
-		var i Question
+		var i GetPaginatedSeparateQuestionsByPartIDRow
 		if err := rows.Scan(
 			&i.QuestionID,
 			&i.QuestionContent,
@@ -738,6 +2120,8 @@ func (q *Queries) GetPaginatedSeparateQuestionsByPartID(ctx context.Context, arg
 			&i.QuestionNumberInPart,
 			&i.AnswerOption,
 			&i.CorrectAnswer,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -819,12 +2203,25 @@ SELECT
 FROM
     Paragraphs
 WHERE
-    paragraph_id = $1
+    paragraph_id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetParagraphByID(ctx context.Context, paragraphID uuid.UUID) (Paragraph, error) {
+type GetParagraphByIDRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) GetParagraphByID(ctx context.Context, paragraphID uuid.UUID) (GetParagraphByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getParagraphByID, paragraphID)
-	var i Paragraph
+	var i GetParagraphByIDRow
 	err := row.Scan(
 		&i.ParagraphID,
 		&i.ParagraphContent,
@@ -855,18 +2252,31 @@ SELECT
 FROM
     Paragraphs
 WHERE
-    part_id = $1
+    part_id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetParagraphByPartId(ctx context.Context, partID uuid.UUID) ([]Paragraph, error) {
+type GetParagraphByPartIdRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) GetParagraphByPartId(ctx context.Context, partID uuid.UUID) ([]GetParagraphByPartIdRow, error) {
 	rows, err := q.db.QueryContext(ctx, getParagraphByPartId, partID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Paragraph{}
+	items := []GetParagraphByPartIdRow{}
 	for rows.Next() {
-		var i Paragraph
+		var i GetParagraphByPartIdRow
 		if err := rows.Scan(
 			&i.ParagraphID,
 			&i.ParagraphContent,
@@ -892,27 +2302,72 @@ func (q *Queries) GetParagraphByPartId(ctx context.Context, partID uuid.UUID) ([
 	return items, nil
 }
 
-const getPermissions = `-- name: GetPermissions :many
-SELECT id, name, description, created_at, updated_at
-FROM permissions
+const getParagraphRevision = `-- name: GetParagraphRevision :one
+SELECT revision_id, paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order, paragraph_type, audio_url, image_url, diff, restored_from, created_by, created_at
+FROM paragraph_revisions
+WHERE revision_id = $1
+  AND paragraph_id = $2
 `
 
-// GetPermissions retrieves all permissions.
-func (q *Queries) GetPermissions(ctx context.Context) ([]Permission, error) {
-	rows, err := q.db.QueryContext(ctx, getPermissions)
+type GetParagraphRevisionParams struct {
+	RevisionID  uuid.UUID `json:"revision_id"`
+	ParagraphID uuid.UUID `json:"paragraph_id"`
+}
+
+func (q *Queries) GetParagraphRevision(ctx context.Context, arg GetParagraphRevisionParams) (ParagraphRevision, error) {
+	row := q.db.QueryRowContext(ctx, getParagraphRevision, arg.RevisionID, arg.ParagraphID)
+	var i ParagraphRevision
+	err := row.Scan(
+		&i.RevisionID,
+		&i.ParagraphID,
+		&i.RevisionNumber,
+		&i.ParagraphContent,
+		&i.Title,
+		&i.PartID,
+		&i.ParagraphOrder,
+		&i.ParagraphType,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getParagraphRevisions = `-- name: GetParagraphRevisions :many
+SELECT revision_id, paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order, paragraph_type, audio_url, image_url, diff, restored_from, created_by, created_at
+FROM paragraph_revisions
+WHERE paragraph_id = $1
+ORDER BY revision_number DESC
+`
+
+// GetParagraphRevisions lists the revisions of a paragraph, most recent first.
+func (q *Queries) GetParagraphRevisions(ctx context.Context, paragraphID uuid.UUID) ([]ParagraphRevision, error) {
+	rows, err := q.db.QueryContext(ctx, getParagraphRevisions, paragraphID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Permission{}
+	items := []ParagraphRevision{}
 	for rows.Next() {
-		var i Permission
+		var i ParagraphRevision
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
+			&i.RevisionID,
+			&i.ParagraphID,
+			&i.RevisionNumber,
+			&i.ParagraphContent,
+			&i.Title,
+			&i.PartID,
+			&i.ParagraphOrder,
+			&i.ParagraphType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.Diff,
+			&i.RestoredFrom,
+			&i.CreatedBy,
 			&i.CreatedAt,
-			&i.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -927,22 +2382,201 @@ func (q *Queries) GetPermissions(ctx context.Context) ([]Permission, error) {
 	return items, nil
 }
 
-const getPracticeExamPartCount = `-- name: GetPracticeExamPartCount :one
-SELECT COUNT(*) FROM exam_parts where is_practice_component == 'TRUE'
+const getParagraphsByPartIds = `-- name: GetParagraphsByPartIds :many
+SELECT paragraph_id,
+       paragraph_content,
+       title,
+       part_id,
+       paragraph_order,
+       paragraph_type,
+       audio_url,
+       image_url,
+       created_at,
+       updated_at
+FROM paragraphs
+WHERE part_id = ANY ($1::uuid[])
+  AND deleted_at IS NULL
+ORDER BY part_id, paragraph_order, paragraph_id
 `
 
-func (q *Queries) GetPracticeExamPartCount(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getPracticeExamPartCount)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const getQuestionByID = `-- name: GetQuestionByID :one
-SELECT
-    question_id,
-    question_content,
-    question_type,
+type GetParagraphsByPartIdsRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+// GetParagraphsByPartIds loads the paragraphs of several parts in one query, in display order.
+func (q *Queries) GetParagraphsByPartIds(ctx context.Context, partIds []uuid.UUID) ([]GetParagraphsByPartIdsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getParagraphsByPartIds, pq.Array(partIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetParagraphsByPartIdsRow{}
+	for rows.Next() {
+		var i GetParagraphsByPartIdsRow
+		if err := rows.Scan(
+			&i.ParagraphID,
+			&i.ParagraphContent,
+			&i.Title,
+			&i.PartID,
+			&i.ParagraphOrder,
+			&i.ParagraphType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPaymentOrder = `-- name: GetPaymentOrder :one
+SELECT po.order_id, po.user_id, po.plan_id, po.provider, po.provider_session_id, po.amount_cents, po.currency, po.status, po.failure_reason, po.subscription_id, po.paid_at, po.created_at, po.updated_at, sp.name AS plan_name
+FROM payment_orders po
+         JOIN subscription_plans sp ON sp.plan_id = po.plan_id
+WHERE po.order_id = $1
+`
+
+type GetPaymentOrderRow struct {
+	OrderID           uuid.UUID      `json:"order_id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	PlanID            uuid.UUID      `json:"plan_id"`
+	Provider          string         `json:"provider"`
+	ProviderSessionID sql.NullString `json:"provider_session_id"`
+	AmountCents       int64          `json:"amount_cents"`
+	Currency          string         `json:"currency"`
+	Status            string         `json:"status"`
+	FailureReason     string         `json:"failure_reason"`
+	SubscriptionID    uuid.NullUUID  `json:"subscription_id"`
+	PaidAt            sql.NullTime   `json:"paid_at"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	UpdatedAt         sql.NullTime   `json:"updated_at"`
+	PlanName          string         `json:"plan_name"`
+}
+
+// GetPaymentOrder returns an order with the name of its plan.
+func (q *Queries) GetPaymentOrder(ctx context.Context, orderID uuid.UUID) (GetPaymentOrderRow, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentOrder, orderID)
+	var i GetPaymentOrderRow
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.PlanID,
+		&i.Provider,
+		&i.ProviderSessionID,
+		&i.AmountCents,
+		&i.Currency,
+		&i.Status,
+		&i.FailureReason,
+		&i.SubscriptionID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.PlanName,
+	)
+	return i, err
+}
+
+const getPaymentOrderForUpdate = `-- name: GetPaymentOrderForUpdate :one
+SELECT order_id, user_id, plan_id, provider, provider_session_id, amount_cents, currency, status, failure_reason, subscription_id, paid_at, created_at, updated_at
+FROM payment_orders
+WHERE order_id = $1
+    FOR UPDATE
+`
+
+// GetPaymentOrderForUpdate locks an order while a webhook event is applied to it.
+func (q *Queries) GetPaymentOrderForUpdate(ctx context.Context, orderID uuid.UUID) (PaymentOrder, error) {
+	row := q.db.QueryRowContext(ctx, getPaymentOrderForUpdate, orderID)
+	var i PaymentOrder
+	err := row.Scan(
+		&i.OrderID,
+		&i.UserID,
+		&i.PlanID,
+		&i.Provider,
+		&i.ProviderSessionID,
+		&i.AmountCents,
+		&i.Currency,
+		&i.Status,
+		&i.FailureReason,
+		&i.SubscriptionID,
+		&i.PaidAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getPermissions = `-- name: GetPermissions :many
+SELECT id, name, description, created_at, updated_at
+FROM permissions
+`
+
+// GetPermissions retrieves all permissions.
+func (q *Queries) GetPermissions(ctx context.Context) ([]Permission, error) {
+	rows, err := q.db.QueryContext(ctx, getPermissions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Permission{}
+	for rows.Next() {
+		var i Permission
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPracticeExamPartCount = `-- name: GetPracticeExamPartCount :one
+SELECT COUNT(*) FROM exam_parts
+WHERE is_practice_component = TRUE
+  AND deleted_at IS NULL
+  AND ($1::varchar IS NULL OR status = $1)
+`
+
+func (q *Queries) GetPracticeExamPartCount(ctx context.Context, status sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPracticeExamPartCount, status)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getQuestionByID = `-- name: GetQuestionByID :one
+SELECT
+    question_id,
+    question_content,
+    question_type,
     part_id,
     paragraph_id,
     question_order,
@@ -952,17 +2586,38 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    question_id = $1
+    question_id = $1 AND deleted_at IS NULL
 `
 
-func (q *Queries) GetQuestionByID(ctx context.Context, questionID uuid.UUID) (Question, error) {
+type GetQuestionByIDRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+}
+
+func (q *Queries) GetQuestionByID(ctx context.Context, questionID uuid.UUID) (GetQuestionByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getQuestionByID, questionID)
-	var i Question
+	var i GetQuestionByIDRow
 	err := row.Scan(
 		&i.QuestionID,
 		&i.QuestionContent,
@@ -976,72 +2631,92 @@ func (q *Queries) GetQuestionByID(ctx context.Context, questionID uuid.UUID) (Qu
 		&i.QuestionNumberInPart,
 		&i.AnswerOption,
 		&i.CorrectAnswer,
+		&i.PreparationSeconds,
+		&i.ResponseSeconds,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
-const getRole = `-- name: GetRole :one
-SELECT
-    r.id AS role_id,
-    r.name AS role_name,
-    r.description AS role_description,
-    p.id AS permission_id,
-    p.name AS permission_name,
-    p.description AS permission_description
-FROM
-    roles AS r
-        JOIN
-    role_permissions AS rp ON r.id = rp.role_id
-        JOIN
-    permissions AS p ON rp.permission_id = p.id
+const getQuestionRevision = `-- name: GetQuestionRevision :one
+SELECT revision_id, question_id, revision_number, question_content, question_type, part_id, paragraph_id, question_order, audio_url, image_url, toeic_question_section, question_number_in_part, answer_option, correct_answer, diff, restored_from, created_by, created_at, preparation_seconds, response_seconds
+FROM question_revisions
+WHERE revision_id = $1
+  AND question_id = $2
 `
 
-type GetRoleRow struct {
-	RoleID                uuid.UUID      `json:"role_id"`
-	RoleName              string         `json:"role_name"`
-	RoleDescription       sql.NullString `json:"role_description"`
-	PermissionID          uuid.UUID      `json:"permission_id"`
-	PermissionName        string         `json:"permission_name"`
-	PermissionDescription sql.NullString `json:"permission_description"`
+type GetQuestionRevisionParams struct {
+	RevisionID uuid.UUID `json:"revision_id"`
+	QuestionID uuid.UUID `json:"question_id"`
 }
 
-func (q *Queries) GetRole(ctx context.Context) (GetRoleRow, error) {
-	row := q.db.QueryRowContext(ctx, getRole)
-	var i GetRoleRow
+func (q *Queries) GetQuestionRevision(ctx context.Context, arg GetQuestionRevisionParams) (QuestionRevision, error) {
+	row := q.db.QueryRowContext(ctx, getQuestionRevision, arg.RevisionID, arg.QuestionID)
+	var i QuestionRevision
 	err := row.Scan(
-		&i.RoleID,
-		&i.RoleName,
-		&i.RoleDescription,
-		&i.PermissionID,
-		&i.PermissionName,
-		&i.PermissionDescription,
+		&i.RevisionID,
+		&i.QuestionID,
+		&i.RevisionNumber,
+		&i.QuestionContent,
+		&i.QuestionType,
+		&i.PartID,
+		&i.ParagraphID,
+		&i.QuestionOrder,
+		&i.AudioUrl,
+		&i.ImageUrl,
+		&i.ToeicQuestionSection,
+		&i.QuestionNumberInPart,
+		&i.AnswerOption,
+		&i.CorrectAnswer,
+		&i.Diff,
+		&i.RestoredFrom,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.PreparationSeconds,
+		&i.ResponseSeconds,
 	)
 	return i, err
 }
 
-const getRoles = `-- name: GetRoles :many
-SELECT id, name, description, created_at, updated_at
-FROM roles
+const getQuestionRevisions = `-- name: GetQuestionRevisions :many
+SELECT revision_id, question_id, revision_number, question_content, question_type, part_id, paragraph_id, question_order, audio_url, image_url, toeic_question_section, question_number_in_part, answer_option, correct_answer, diff, restored_from, created_by, created_at, preparation_seconds, response_seconds
+FROM question_revisions
+WHERE question_id = $1
+ORDER BY revision_number DESC
 `
 
-// GetRoles retrieves all roles.
-func (q *Queries) GetRoles(ctx context.Context) ([]Role, error) {
-	rows, err := q.db.QueryContext(ctx, getRoles)
+// GetQuestionRevisions lists the revisions of a question, most recent first.
+func (q *Queries) GetQuestionRevisions(ctx context.Context, questionID uuid.UUID) ([]QuestionRevision, error) {
+	rows, err := q.db.QueryContext(ctx, getQuestionRevisions, questionID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Role{}
+	items := []QuestionRevision{}
 	for rows.Next() {
-		var i Role
+		var i QuestionRevision
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
-			&i.Description,
+			&i.RevisionID,
+			&i.QuestionID,
+			&i.RevisionNumber,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.QuestionOrder,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.Diff,
+			&i.RestoredFrom,
+			&i.CreatedBy,
 			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
 		); err != nil {
 			return nil, err
 		}
@@ -1056,167 +2731,294 @@ func (q *Queries) GetRoles(ctx context.Context) ([]Role, error) {
 	return items, nil
 }
 
-const getUserAvatar = `-- name: GetUserAvatar :one
-SELECT avatar_url
-FROM  user_profiles
-where user_id =$1
+const getQuestionsByPartIds = `-- name: GetQuestionsByPartIds :many
+SELECT question_id,
+       question_content,
+       question_type,
+       part_id,
+       paragraph_id,
+       question_order,
+       audio_url,
+       image_url,
+       toeic_question_section,
+       question_number_in_part,
+       answer_option,
+       correct_answer,
+       preparation_seconds,
+       response_seconds,
+       created_at,
+       updated_at
+FROM questions
+WHERE part_id = ANY ($1::uuid[])
+  AND deleted_at IS NULL
+ORDER BY question_order, question_number_in_part, question_id
 `
 
-func (q *Queries) GetUserAvatar(ctx context.Context, userID uuid.UUID) (sql.NullString, error) {
-	row := q.db.QueryRowContext(ctx, getUserAvatar, userID)
-	var avatar_url sql.NullString
-	err := row.Scan(&avatar_url)
-	return avatar_url, err
+type GetQuestionsByPartIdsRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
 }
 
-const getUserByEmailOrUserNameOrId = `-- name: GetUserByEmailOrUserNameOrId :one
-SELECT id, user_name, email, password, created_at, updated_at
-FROM users
-WHERE
-    ($1::text IS NULL OR email = $1::text) AND
-    ($2::text IS NULL OR user_name = $2::text) AND
-    ($3::uuid IS NULL OR id = $3::uuid)
-LIMIT 1
+// GetQuestionsByPartIds loads the questions of several parts in one query, both those under a paragraph
+// and the standalone ones, in display order.
+func (q *Queries) GetQuestionsByPartIds(ctx context.Context, partIds []uuid.UUID) ([]GetQuestionsByPartIdsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getQuestionsByPartIds, pq.Array(partIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetQuestionsByPartIdsRow{}
+	for rows.Next() {
+		var i GetQuestionsByPartIdsRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.QuestionOrder,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRatingQueueStats = `-- name: GetRatingQueueStats :one
+SELECT COUNT(*) FILTER (WHERE r.rating_status = 'PENDING')::int      AS awaiting_rating,
+       COUNT(*) FILTER (WHERE r.rating_status = 'ADJUDICATION')::int AS awaiting_adjudication,
+       (SELECT COUNT(*) FROM response_ratings rr WHERE rr.score IS NULL)::int AS assigned
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND r.submitted_at IS NOT NULL
+  AND r.rating_status <> 'RATED'
 `
 
-type GetUserByEmailOrUserNameOrIdParams struct {
-	Email    sql.NullString `json:"email"`
-	UserName sql.NullString `json:"user_name"`
-	ID       uuid.NullUUID  `json:"id"`
+type GetRatingQueueStatsRow struct {
+	AwaitingRating       int32 `json:"awaiting_rating"`
+	AwaitingAdjudication int32 `json:"awaiting_adjudication"`
+	Assigned             int32 `json:"assigned"`
 }
 
-type GetUserByEmailOrUserNameOrIdRow struct {
-	ID        uuid.UUID    `json:"id"`
-	UserName  string       `json:"user_name"`
-	Email     string       `json:"email"`
-	Password  string       `json:"password"`
-	CreatedAt sql.NullTime `json:"created_at"`
-	UpdatedAt sql.NullTime `json:"updated_at"`
+// GetRatingQueueStats counts the submitted responses waiting for raters.
+func (q *Queries) GetRatingQueueStats(ctx context.Context) (GetRatingQueueStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getRatingQueueStats)
+	var i GetRatingQueueStatsRow
+	err := row.Scan(&i.AwaitingRating, &i.AwaitingAdjudication, &i.Assigned)
+	return i, err
 }
 
-// GetUserByEmailOrUserNameOrId retrieves a user by email, user_name, or id.
-func (q *Queries) GetUserByEmailOrUserNameOrId(ctx context.Context, arg GetUserByEmailOrUserNameOrIdParams) (GetUserByEmailOrUserNameOrIdRow, error) {
-	row := q.db.QueryRowContext(ctx, getUserByEmailOrUserNameOrId, arg.Email, arg.UserName, arg.ID)
-	var i GetUserByEmailOrUserNameOrIdRow
+const getRatingTask = `-- name: GetRatingTask :one
+SELECT rr.rating_id,
+       rr.round,
+       rr.assigned_at,
+       r.attempt_id,
+       ea.exam_id,
+       r.question_id,
+       r.response_type,
+       r.audio_url,
+       r.text_response,
+       r.word_count,
+       r.submitted_at,
+       q.question_content,
+       q.question_type,
+       q.toeic_question_section,
+       q.audio_url AS question_audio_url,
+       q.image_url AS question_image_url,
+       r.auto_score,
+       r.auto_max_score,
+       r.auto_feedback,
+       r.auto_scorer,
+       r.auto_scored_at
+FROM response_ratings rr
+JOIN attempt_responses r ON r.attempt_id = rr.attempt_id AND r.question_id = rr.question_id
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+JOIN attempt_questions aq ON aq.attempt_id = r.attempt_id AND aq.question_id = r.question_id
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE rr.rating_id = $1
+`
+
+type GetRatingTaskRow struct {
+	RatingID             uuid.UUID       `json:"rating_id"`
+	Round                int32           `json:"round"`
+	AssignedAt           time.Time       `json:"assigned_at"`
+	AttemptID            uuid.UUID       `json:"attempt_id"`
+	ExamID               uuid.UUID       `json:"exam_id"`
+	QuestionID           uuid.UUID       `json:"question_id"`
+	ResponseType         string          `json:"response_type"`
+	AudioUrl             sql.NullString  `json:"audio_url"`
+	TextResponse         sql.NullString  `json:"text_response"`
+	WordCount            sql.NullInt32   `json:"word_count"`
+	SubmittedAt          sql.NullTime    `json:"submitted_at"`
+	QuestionContent      string          `json:"question_content"`
+	QuestionType         string          `json:"question_type"`
+	ToeicQuestionSection string          `json:"toeic_question_section"`
+	QuestionAudioUrl     sql.NullString  `json:"question_audio_url"`
+	QuestionImageUrl     sql.NullString  `json:"question_image_url"`
+	AutoScore            sql.NullInt32   `json:"auto_score"`
+	AutoMaxScore         sql.NullInt32   `json:"auto_max_score"`
+	AutoFeedback         json.RawMessage `json:"auto_feedback"`
+	AutoScorer           sql.NullString  `json:"auto_scorer"`
+	AutoScoredAt         sql.NullTime    `json:"auto_scored_at"`
+}
+
+// GetRatingTask retrieves what a rater sees of an assigned response: the pinned question and the
+// response, without the learner or the other ratings.
+func (q *Queries) GetRatingTask(ctx context.Context, ratingID uuid.UUID) (GetRatingTaskRow, error) {
+	row := q.db.QueryRowContext(ctx, getRatingTask, ratingID)
+	var i GetRatingTaskRow
 	err := row.Scan(
-		&i.ID,
-		&i.UserName,
-		&i.Email,
-		&i.Password,
-		&i.CreatedAt,
-		&i.UpdatedAt,
+		&i.RatingID,
+		&i.Round,
+		&i.AssignedAt,
+		&i.AttemptID,
+		&i.ExamID,
+		&i.QuestionID,
+		&i.ResponseType,
+		&i.AudioUrl,
+		&i.TextResponse,
+		&i.WordCount,
+		&i.SubmittedAt,
+		&i.QuestionContent,
+		&i.QuestionType,
+		&i.ToeicQuestionSection,
+		&i.QuestionAudioUrl,
+		&i.QuestionImageUrl,
+		&i.AutoScore,
+		&i.AutoMaxScore,
+		&i.AutoFeedback,
+		&i.AutoScorer,
+		&i.AutoScoredAt,
 	)
 	return i, err
 }
 
-const getUserProfile = `-- name: GetUserProfile :one
-SELECT
-    user_id,u.email,u.user_name,full_name,birthday,gender,phone_number,address,avatar_url,bio
-FROM
-    user_profiles p join users u on p.user_id = u.id
-WHERE
-    user_id = $1
+const getRefreshToken = `-- name: GetRefreshToken :one
+SELECT rt.token_id,
+       rt.session_id,
+       rt.expires_at,
+       rt.used_at,
+       s.user_id,
+       s.revoked_at
+FROM refresh_tokens rt
+         JOIN user_sessions s ON s.session_id = rt.session_id
+WHERE rt.token_id = $1
 `
 
-type GetUserProfileRow struct {
-	UserID      uuid.UUID      `json:"user_id"`
-	Email       string         `json:"email"`
-	UserName    string         `json:"user_name"`
-	FullName    sql.NullString `json:"full_name"`
-	Birthday    sql.NullTime   `json:"birthday"`
-	Gender      sql.NullString `json:"gender"`
-	PhoneNumber sql.NullString `json:"phone_number"`
-	Address     sql.NullString `json:"address"`
-	AvatarUrl   sql.NullString `json:"avatar_url"`
-	Bio         sql.NullString `json:"bio"`
+type GetRefreshTokenRow struct {
+	TokenID   uuid.UUID    `json:"token_id"`
+	SessionID uuid.UUID    `json:"session_id"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+	UserID    uuid.UUID    `json:"user_id"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
 }
 
-func (q *Queries) GetUserProfile(ctx context.Context, userID uuid.UUID) (GetUserProfileRow, error) {
-	row := q.db.QueryRowContext(ctx, getUserProfile, userID)
-	var i GetUserProfileRow
+// GetRefreshToken returns a refresh token together with the state of its session.
+func (q *Queries) GetRefreshToken(ctx context.Context, tokenID uuid.UUID) (GetRefreshTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, getRefreshToken, tokenID)
+	var i GetRefreshTokenRow
 	err := row.Scan(
+		&i.TokenID,
+		&i.SessionID,
+		&i.ExpiresAt,
+		&i.UsedAt,
 		&i.UserID,
-		&i.Email,
-		&i.UserName,
-		&i.FullName,
-		&i.Birthday,
-		&i.Gender,
-		&i.PhoneNumber,
-		&i.Address,
-		&i.AvatarUrl,
-		&i.Bio,
+		&i.RevokedAt,
 	)
 	return i, err
 }
 
-const getUsersCount = `-- name: GetUsersCount :one
-SELECT COUNT(*) FROM users
-`
-
-// GetUsersCount returns the total number of users.
-func (q *Queries) GetUsersCount(ctx context.Context) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getUsersCount)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
-const hasPermission = `-- name: HasPermission :one
-SELECT EXISTS(
-    SELECT 1 FROM user_roles ur
-                      JOIN role_permissions rp ON ur.role_id = rp.role_id
-                      JOIN permissions p ON rp.permission_id = p.id
-    WHERE ur.user_id = $1 AND p.id = $2
-)
+const getRole = `-- name: GetRole :one
+SELECT
+    r.id AS role_id,
+    r.name AS role_name,
+    r.description AS role_description,
+    p.id AS permission_id,
+    p.name AS permission_name,
+    p.description AS permission_description
+FROM
+    roles AS r
+        JOIN
+    role_permissions AS rp ON r.id = rp.role_id
+        JOIN
+    permissions AS p ON rp.permission_id = p.id
 `
 
-type HasPermissionParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	ID     uuid.UUID `json:"id"`
+type GetRoleRow struct {
+	RoleID                uuid.UUID      `json:"role_id"`
+	RoleName              string         `json:"role_name"`
+	RoleDescription       sql.NullString `json:"role_description"`
+	PermissionID          uuid.UUID      `json:"permission_id"`
+	PermissionName        string         `json:"permission_name"`
+	PermissionDescription sql.NullString `json:"permission_description"`
 }
 
-// HasPermission checks if a user has a specific permission.
-func (q *Queries) HasPermission(ctx context.Context, arg HasPermissionParams) (bool, error) {
-	row := q.db.QueryRowContext(ctx, hasPermission, arg.UserID, arg.ID)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+func (q *Queries) GetRole(ctx context.Context) (GetRoleRow, error) {
+	row := q.db.QueryRowContext(ctx, getRole)
+	var i GetRoleRow
+	err := row.Scan(
+		&i.RoleID,
+		&i.RoleName,
+		&i.RoleDescription,
+		&i.PermissionID,
+		&i.PermissionName,
+		&i.PermissionDescription,
+	)
+	return i, err
 }
 
-const listParagraphs = `-- name: ListParagraphs :many
-SELECT
-    paragraph_id,
-    paragraph_content,
-    title,
-    part_id,
-    paragraph_order,
-    paragraph_type,
-    audio_url,
-    image_url,
-    created_at,
-    updated_at
-FROM
-    Paragraphs
+const getRoles = `-- name: GetRoles :many
+SELECT id, name, description, created_at, updated_at
+FROM roles
 `
 
-func (q *Queries) ListParagraphs(ctx context.Context) ([]Paragraph, error) {
-	rows, err := q.db.QueryContext(ctx, listParagraphs)
+// GetRoles retrieves all roles.
+func (q *Queries) GetRoles(ctx context.Context) ([]Role, error) {
+	rows, err := q.db.QueryContext(ctx, getRoles)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Paragraph{}
+	items := []Role{}
 	for rows.Next() {
-		var i Paragraph
+		var i Role
 		if err := rows.Scan(
-			&i.ParagraphID,
-			&i.ParagraphContent,
-			&i.Title,
-			&i.PartID,
-			&i.ParagraphOrder,
-			&i.ParagraphType,
-			&i.AudioUrl,
-			&i.ImageUrl,
+			&i.ID,
+			&i.Name,
+			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -1233,44 +3035,79 @@ func (q *Queries) ListParagraphs(ctx context.Context) ([]Paragraph, error) {
 	return items, nil
 }
 
-const listParagraphsByPartID = `-- name: ListParagraphsByPartID :many
-SELECT
-    paragraph_id,
-    paragraph_content,
-    title,
-    part_id,
-    paragraph_order,
-    paragraph_type,
-    audio_url,
-    image_url,
-    created_at,
-    updated_at
-FROM
-    Paragraphs
-WHERE
-    part_id = $1
-ORDER BY
-    paragraph_order
+const getScoreConversionTable = `-- name: GetScoreConversionTable :one
+SELECT table_id, name, description, is_default, created_at, updated_at
+FROM score_conversion_tables
+WHERE table_id = $1
 `
 
-func (q *Queries) ListParagraphsByPartID(ctx context.Context, partID uuid.UUID) ([]Paragraph, error) {
-	rows, err := q.db.QueryContext(ctx, listParagraphsByPartID, partID)
+// GetScoreConversionTable retrieves a conversion table by id.
+func (q *Queries) GetScoreConversionTable(ctx context.Context, tableID uuid.UUID) (ScoreConversionTable, error) {
+	row := q.db.QueryRowContext(ctx, getScoreConversionTable, tableID)
+	var i ScoreConversionTable
+	err := row.Scan(
+		&i.TableID,
+		&i.Name,
+		&i.Description,
+		&i.IsDefault,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSubscriptionPlan = `-- name: GetSubscriptionPlan :one
+SELECT plan_id, code, name, description, duration_days, grace_period_days, price_cents, currency, is_active, created_at, updated_at
+FROM subscription_plans
+WHERE plan_id = $1
+`
+
+// GetSubscriptionPlan returns a plan by id.
+func (q *Queries) GetSubscriptionPlan(ctx context.Context, planID uuid.UUID) (SubscriptionPlan, error) {
+	row := q.db.QueryRowContext(ctx, getSubscriptionPlan, planID)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.PlanID,
+		&i.Code,
+		&i.Name,
+		&i.Description,
+		&i.DurationDays,
+		&i.GracePeriodDays,
+		&i.PriceCents,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSubscriptionPlans = `-- name: GetSubscriptionPlans :many
+SELECT plan_id, code, name, description, duration_days, grace_period_days, price_cents, currency, is_active, created_at, updated_at
+FROM subscription_plans
+ORDER BY price_cents, name
+`
+
+// GetSubscriptionPlans lists every plan, cheapest first.
+func (q *Queries) GetSubscriptionPlans(ctx context.Context) ([]SubscriptionPlan, error) {
+	rows, err := q.db.QueryContext(ctx, getSubscriptionPlans)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Paragraph{}
+	items := []SubscriptionPlan{}
 	for rows.Next() {
-		var i Paragraph
+		var i SubscriptionPlan
 		if err := rows.Scan(
-			&i.ParagraphID,
-			&i.ParagraphContent,
-			&i.Title,
-			&i.PartID,
-			&i.ParagraphOrder,
-			&i.ParagraphType,
-			&i.AudioUrl,
-			&i.ImageUrl,
+			&i.PlanID,
+			&i.Code,
+			&i.Name,
+			&i.Description,
+			&i.DurationDays,
+			&i.GracePeriodDays,
+			&i.PriceCents,
+			&i.Currency,
+			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -1287,50 +3124,65 @@ func (q *Queries) ListParagraphsByPartID(ctx context.Context, partID uuid.UUID)
 	return items, nil
 }
 
-const listQuestions = `-- name: ListQuestions :many
-SELECT
-    question_id,
-    question_content,
-    question_type,
-    part_id,
-    paragraph_id,
-    question_order,
-    audio_url,
-    image_url,
-    toeic_question_section,
-    question_number_in_part,
-    answer_option,
-    correct_answer,
-    created_at,
-    updated_at
-FROM
-    Questions
+const getTrashItem = `-- name: GetTrashItem :one
+SELECT content_type, content_id, title, parent_type, parent_id, deleted_at, deleted_by
+FROM trash_items
+WHERE content_type = $1
+  AND content_id = $2
 `
 
-func (q *Queries) ListQuestions(ctx context.Context) ([]Question, error) {
-	rows, err := q.db.QueryContext(ctx, listQuestions)
+type GetTrashItemParams struct {
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+}
+
+func (q *Queries) GetTrashItem(ctx context.Context, arg GetTrashItemParams) (TrashItem, error) {
+	row := q.db.QueryRowContext(ctx, getTrashItem, arg.ContentType, arg.ContentID)
+	var i TrashItem
+	err := row.Scan(
+		&i.ContentType,
+		&i.ContentID,
+		&i.Title,
+		&i.ParentType,
+		&i.ParentID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+	)
+	return i, err
+}
+
+const getTrashItems = `-- name: GetTrashItems :many
+SELECT content_type, content_id, title, parent_type, parent_id, deleted_at, deleted_by
+FROM trash_items
+WHERE $3::varchar IS NULL OR content_type = $3
+ORDER BY deleted_at DESC, content_id
+LIMIT $1 OFFSET $2
+`
+
+type GetTrashItemsParams struct {
+	Limit       int32          `json:"limit"`
+	Offset      int32          `json:"offset"`
+	ContentType sql.NullString `json:"content_type"`
+}
+
+// GetTrashItems lists the items deleted on their own, most recent first.
+func (q *Queries) GetTrashItems(ctx context.Context, arg GetTrashItemsParams) ([]TrashItem, error) {
+	rows, err := q.db.QueryContext(ctx, getTrashItems, arg.Limit, arg.Offset, arg.ContentType)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Question{}
+	items := []TrashItem{}
 	for rows.Next() {
-		var i Question
+		var i TrashItem
 		if err := rows.Scan(
-			&i.QuestionID,
-			&i.QuestionContent,
-			&i.QuestionType,
-			&i.PartID,
-			&i.ParagraphID,
-			&i.QuestionOrder,
-			&i.AudioUrl,
-			&i.ImageUrl,
-			&i.ToeicQuestionSection,
-			&i.QuestionNumberInPart,
-			&i.AnswerOption,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.ContentType,
+			&i.ContentID,
+			&i.Title,
+			&i.ParentType,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -1345,122 +3197,2576 @@ func (q *Queries) ListQuestions(ctx context.Context) ([]Question, error) {
 	return items, nil
 }
 
-const listQuestionsByParagraphID = `-- name: ListQuestionsByParagraphID :many
-SELECT
-    question_id,
-    question_content,
-    question_type,
-    part_id,
-    paragraph_id,
-    question_order,
-    audio_url,
-    image_url,
-    toeic_question_section,
-    question_number_in_part,
-    answer_option,
-    correct_answer,
-    created_at,
-    updated_at
-FROM
-    Questions
-WHERE
-    paragraph_id = $1
-Order By
-    question_order ASC,
-    question_number_in_part ASC,
-    question_id ASC
+const getTrashItemsCount = `-- name: GetTrashItemsCount :one
+SELECT COUNT(*)
+FROM trash_items
+WHERE $1::varchar IS NULL OR content_type = $1
+`
+
+func (q *Queries) GetTrashItemsCount(ctx context.Context, contentType sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getTrashItemsCount, contentType)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getUserAvatar = `-- name: GetUserAvatar :one
+SELECT avatar_url
+FROM  user_profiles
+where user_id =$1
+`
+
+func (q *Queries) GetUserAvatar(ctx context.Context, userID uuid.UUID) (sql.NullString, error) {
+	row := q.db.QueryRowContext(ctx, getUserAvatar, userID)
+	var avatar_url sql.NullString
+	err := row.Scan(&avatar_url)
+	return avatar_url, err
+}
+
+const getUserByEmailOrUserNameOrId = `-- name: GetUserByEmailOrUserNameOrId :one
+SELECT id, user_name, email, password, is_locked, email_verified_at, created_at, updated_at
+FROM users
+WHERE
+    ($1::text IS NULL OR email = $1::text) AND
+    ($2::text IS NULL OR user_name = $2::text) AND
+    ($3::uuid IS NULL OR id = $3::uuid)
+LIMIT 1
+`
+
+type GetUserByEmailOrUserNameOrIdParams struct {
+	Email    sql.NullString `json:"email"`
+	UserName sql.NullString `json:"user_name"`
+	ID       uuid.NullUUID  `json:"id"`
+}
+
+type GetUserByEmailOrUserNameOrIdRow struct {
+	ID              uuid.UUID    `json:"id"`
+	UserName        string       `json:"user_name"`
+	Email           string       `json:"email"`
+	Password        string       `json:"password"`
+	IsLocked        sql.NullBool `json:"is_locked"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	CreatedAt       sql.NullTime `json:"created_at"`
+	UpdatedAt       sql.NullTime `json:"updated_at"`
+}
+
+// GetUserByEmailOrUserNameOrId retrieves a user by email, user_name, or id.
+func (q *Queries) GetUserByEmailOrUserNameOrId(ctx context.Context, arg GetUserByEmailOrUserNameOrIdParams) (GetUserByEmailOrUserNameOrIdRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserByEmailOrUserNameOrId, arg.Email, arg.UserName, arg.ID)
+	var i GetUserByEmailOrUserNameOrIdRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserName,
+		&i.Email,
+		&i.Password,
+		&i.IsLocked,
+		&i.EmailVerifiedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserIdsByRole = `-- name: GetUserIdsByRole :many
+SELECT user_id
+FROM user_roles
+WHERE role_id = $1
+`
+
+// GetUserIdsByRole retrieves the ids of the users holding a role.
+func (q *Queries) GetUserIdsByRole(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, getUserIdsByRole, roleID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserPaymentOrders = `-- name: GetUserPaymentOrders :many
+SELECT po.order_id, po.user_id, po.plan_id, po.provider, po.provider_session_id, po.amount_cents, po.currency, po.status, po.failure_reason, po.subscription_id, po.paid_at, po.created_at, po.updated_at, sp.name AS plan_name
+FROM payment_orders po
+         JOIN subscription_plans sp ON sp.plan_id = po.plan_id
+WHERE po.user_id = $1
+ORDER BY po.created_at DESC
+`
+
+type GetUserPaymentOrdersRow struct {
+	OrderID           uuid.UUID      `json:"order_id"`
+	UserID            uuid.UUID      `json:"user_id"`
+	PlanID            uuid.UUID      `json:"plan_id"`
+	Provider          string         `json:"provider"`
+	ProviderSessionID sql.NullString `json:"provider_session_id"`
+	AmountCents       int64          `json:"amount_cents"`
+	Currency          string         `json:"currency"`
+	Status            string         `json:"status"`
+	FailureReason     string         `json:"failure_reason"`
+	SubscriptionID    uuid.NullUUID  `json:"subscription_id"`
+	PaidAt            sql.NullTime   `json:"paid_at"`
+	CreatedAt         sql.NullTime   `json:"created_at"`
+	UpdatedAt         sql.NullTime   `json:"updated_at"`
+	PlanName          string         `json:"plan_name"`
+}
+
+// GetUserPaymentOrders lists the orders of a user with the name of their plan, most recent first.
+func (q *Queries) GetUserPaymentOrders(ctx context.Context, userID uuid.UUID) ([]GetUserPaymentOrdersRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserPaymentOrders, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserPaymentOrdersRow{}
+	for rows.Next() {
+		var i GetUserPaymentOrdersRow
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.UserID,
+			&i.PlanID,
+			&i.Provider,
+			&i.ProviderSessionID,
+			&i.AmountCents,
+			&i.Currency,
+			&i.Status,
+			&i.FailureReason,
+			&i.SubscriptionID,
+			&i.PaidAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PlanName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserPermissionNames = `-- name: GetUserPermissionNames :many
+SELECT DISTINCT p.name
+FROM user_roles ur
+         JOIN role_permissions rp ON ur.role_id = rp.role_id
+         JOIN permissions p ON rp.permission_id = p.id
+WHERE ur.user_id = $1
+ORDER BY p.name
+`
+
+// GetUserPermissionNames retrieves the names of every permission granted to a user through their roles.
+func (q *Queries) GetUserPermissionNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getUserPermissionNames, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserProfile = `-- name: GetUserProfile :one
+SELECT
+    user_id,u.email,u.user_name,full_name,birthday,gender,phone_number,address,avatar_url,bio
+FROM
+    user_profiles p join users u on p.user_id = u.id
+WHERE
+    user_id = $1
+`
+
+type GetUserProfileRow struct {
+	UserID      uuid.UUID      `json:"user_id"`
+	Email       string         `json:"email"`
+	UserName    string         `json:"user_name"`
+	FullName    sql.NullString `json:"full_name"`
+	Birthday    sql.NullTime   `json:"birthday"`
+	Gender      sql.NullString `json:"gender"`
+	PhoneNumber sql.NullString `json:"phone_number"`
+	Address     sql.NullString `json:"address"`
+	AvatarUrl   sql.NullString `json:"avatar_url"`
+	Bio         sql.NullString `json:"bio"`
+}
+
+func (q *Queries) GetUserProfile(ctx context.Context, userID uuid.UUID) (GetUserProfileRow, error) {
+	row := q.db.QueryRowContext(ctx, getUserProfile, userID)
+	var i GetUserProfileRow
+	err := row.Scan(
+		&i.UserID,
+		&i.Email,
+		&i.UserName,
+		&i.FullName,
+		&i.Birthday,
+		&i.Gender,
+		&i.PhoneNumber,
+		&i.Address,
+		&i.AvatarUrl,
+		&i.Bio,
+	)
+	return i, err
+}
+
+const getUserProvider = `-- name: GetUserProvider :one
+SELECT id, user_id, provider, provider_user_id, email, access_token, refresh_token, avatar_url, full_name, created_at, updated_at
+FROM user_providers
+WHERE provider = $1
+  AND provider_user_id = $2
+`
+
+type GetUserProviderParams struct {
+	Provider       string `json:"provider"`
+	ProviderUserID string `json:"provider_user_id"`
+}
+
+// GetUserProvider returns the link between a provider identity and a local user.
+func (q *Queries) GetUserProvider(ctx context.Context, arg GetUserProviderParams) (UserProvider, error) {
+	row := q.db.QueryRowContext(ctx, getUserProvider, arg.Provider, arg.ProviderUserID)
+	var i UserProvider
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.ProviderUserID,
+		&i.Email,
+		&i.AccessToken,
+		&i.RefreshToken,
+		&i.AvatarUrl,
+		&i.FullName,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getUserSubscriptions = `-- name: GetUserSubscriptions :many
+SELECT us.subscription_id, us.user_id, us.plan_id, us.source, us.starts_at, us.ends_at, us.grace_ends_at, us.granted_by, us.note, us.canceled_at, us.created_at, us.updated_at, sp.code AS plan_code, sp.name AS plan_name
+FROM user_subscriptions us
+         JOIN subscription_plans sp ON sp.plan_id = us.plan_id
+WHERE us.user_id = $1
+ORDER BY us.starts_at DESC
+`
+
+type GetUserSubscriptionsRow struct {
+	SubscriptionID uuid.UUID     `json:"subscription_id"`
+	UserID         uuid.UUID     `json:"user_id"`
+	PlanID         uuid.UUID     `json:"plan_id"`
+	Source         string        `json:"source"`
+	StartsAt       time.Time     `json:"starts_at"`
+	EndsAt         time.Time     `json:"ends_at"`
+	GraceEndsAt    time.Time     `json:"grace_ends_at"`
+	GrantedBy      uuid.NullUUID `json:"granted_by"`
+	Note           string        `json:"note"`
+	CanceledAt     sql.NullTime  `json:"canceled_at"`
+	CreatedAt      sql.NullTime  `json:"created_at"`
+	UpdatedAt      sql.NullTime  `json:"updated_at"`
+	PlanCode       string        `json:"plan_code"`
+	PlanName       string        `json:"plan_name"`
+}
+
+// GetUserSubscriptions lists the subscriptions of a user with their plan, most recent first.
+func (q *Queries) GetUserSubscriptions(ctx context.Context, userID uuid.UUID) ([]GetUserSubscriptionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUserSubscriptions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserSubscriptionsRow{}
+	for rows.Next() {
+		var i GetUserSubscriptionsRow
+		if err := rows.Scan(
+			&i.SubscriptionID,
+			&i.UserID,
+			&i.PlanID,
+			&i.Source,
+			&i.StartsAt,
+			&i.EndsAt,
+			&i.GraceEndsAt,
+			&i.GrantedBy,
+			&i.Note,
+			&i.CanceledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.PlanCode,
+			&i.PlanName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersCount = `-- name: GetUsersCount :one
+SELECT COUNT(*) FROM users
+`
+
+// GetUsersCount returns the total number of users.
+func (q *Queries) GetUsersCount(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getUsersCount)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const hasPermission = `-- name: HasPermission :one
+SELECT EXISTS(
+    SELECT 1 FROM user_roles ur
+                      JOIN role_permissions rp ON ur.role_id = rp.role_id
+                      JOIN permissions p ON rp.permission_id = p.id
+    WHERE ur.user_id = $1 AND p.id = $2
+)
+`
+
+type HasPermissionParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	ID     uuid.UUID `json:"id"`
+}
+
+// HasPermission checks if a user has a specific permission.
+func (q *Queries) HasPermission(ctx context.Context, arg HasPermissionParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, hasPermission, arg.UserID, arg.ID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listActiveUserSessions = `-- name: ListActiveUserSessions :many
+SELECT session_id, user_id, expires_at, last_used_at, revoked_at, revoke_reason, created_at, user_agent, ip_address
+FROM user_sessions
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+ORDER BY last_used_at DESC
+`
+
+// ListActiveUserSessions returns the sessions of a user that are neither revoked nor expired, most recently used first.
+func (q *Queries) ListActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]UserSession, error) {
+	rows, err := q.db.QueryContext(ctx, listActiveUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []UserSession{}
+	for rows.Next() {
+		var i UserSession
+		if err := rows.Scan(
+			&i.SessionID,
+			&i.UserID,
+			&i.ExpiresAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.RevokeReason,
+			&i.CreatedAt,
+			&i.UserAgent,
+			&i.IpAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptParagraphs = `-- name: ListAttemptParagraphs :many
+SELECT
+    p.paragraph_id,
+    p.paragraph_content,
+    p.title,
+    p.part_id,
+    p.paragraph_order,
+    p.paragraph_type,
+    p.audio_url,
+    p.image_url
+FROM paragraph_revisions p
+WHERE p.revision_id IN (
+    SELECT aq.paragraph_revision_id FROM attempt_questions aq WHERE aq.attempt_id = $1
+)
+ORDER BY p.paragraph_order
+`
+
+type ListAttemptParagraphsRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+}
+
+// ListAttemptParagraphs retrieves the paragraphs referenced by the questions of an attempt.
+func (q *Queries) ListAttemptParagraphs(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptParagraphsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptParagraphs, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAttemptParagraphsRow{}
+	for rows.Next() {
+		var i ListAttemptParagraphsRow
+		if err := rows.Scan(
+			&i.ParagraphID,
+			&i.ParagraphContent,
+			&i.Title,
+			&i.PartID,
+			&i.ParagraphOrder,
+			&i.ParagraphType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptQuestions = `-- name: ListAttemptQuestions :many
+SELECT
+    aq.attempt_id,
+    aq.question_id,
+    aq.part_id,
+    aq.paragraph_id,
+    aq.sequence_number,
+    aq.answer,
+    aq.answered_at,
+    q.question_content,
+    q.question_type,
+    q.audio_url,
+    q.image_url,
+    q.toeic_question_section,
+    q.question_number_in_part,
+    q.answer_option,
+    q.preparation_seconds,
+    q.response_seconds
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE aq.attempt_id = $1
+ORDER BY aq.sequence_number
+`
+
+type ListAttemptQuestionsRow struct {
+	AttemptID            uuid.UUID             `json:"attempt_id"`
+	QuestionID           uuid.UUID             `json:"question_id"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	SequenceNumber       int32                 `json:"sequence_number"`
+	Answer               sql.NullString        `json:"answer"`
+	AnsweredAt           sql.NullTime          `json:"answered_at"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+}
+
+// ListAttemptQuestions retrieves the questions of an attempt, as pinned when it started, with the learner's answers.
+func (q *Queries) ListAttemptQuestions(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptQuestionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptQuestions, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAttemptQuestionsRow{}
+	for rows.Next() {
+		var i ListAttemptQuestionsRow
+		if err := rows.Scan(
+			&i.AttemptID,
+			&i.QuestionID,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.SequenceNumber,
+			&i.Answer,
+			&i.AnsweredAt,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptQuestionsForScoring = `-- name: ListAttemptQuestionsForScoring :many
+SELECT
+    aq.question_id,
+    aq.answer,
+    q.question_type,
+    q.toeic_question_section,
+    q.correct_answer
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE aq.attempt_id = $1
+ORDER BY aq.sequence_number
+`
+
+type ListAttemptQuestionsForScoringRow struct {
+	QuestionID           uuid.UUID      `json:"question_id"`
+	Answer               sql.NullString `json:"answer"`
+	QuestionType         string         `json:"question_type"`
+	ToeicQuestionSection string         `json:"toeic_question_section"`
+	CorrectAnswer        sql.NullString `json:"correct_answer"`
+}
+
+// ListAttemptQuestionsForScoring retrieves the answers of an attempt along with the answer keys of the pinned revisions.
+func (q *Queries) ListAttemptQuestionsForScoring(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptQuestionsForScoringRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptQuestionsForScoring, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAttemptQuestionsForScoringRow{}
+	for rows.Next() {
+		var i ListAttemptQuestionsForScoringRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Answer,
+			&i.QuestionType,
+			&i.ToeicQuestionSection,
+			&i.CorrectAnswer,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptRatingStates = `-- name: ListAttemptRatingStates :many
+SELECT aq.question_id,
+       q.question_type,
+       q.toeic_question_section,
+       r.submitted_at AS response_submitted_at,
+       r.rating_status,
+       r.final_score,
+       r.max_score
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+LEFT JOIN attempt_responses r ON r.attempt_id = aq.attempt_id AND r.question_id = aq.question_id
+WHERE aq.attempt_id = $1
+  AND q.toeic_question_section IN ('Speaking', 'Writing')
+ORDER BY aq.sequence_number
+`
+
+type ListAttemptRatingStatesRow struct {
+	QuestionID           uuid.UUID      `json:"question_id"`
+	QuestionType         string         `json:"question_type"`
+	ToeicQuestionSection string         `json:"toeic_question_section"`
+	ResponseSubmittedAt  sql.NullTime   `json:"response_submitted_at"`
+	RatingStatus         sql.NullString `json:"rating_status"`
+	FinalScore           sql.NullInt32  `json:"final_score"`
+	MaxScore             sql.NullInt32  `json:"max_score"`
+}
+
+// ListAttemptRatingStates retrieves the Speaking and Writing questions of an attempt with the rating state
+// of their responses; a question without a submitted response has no response_submitted_at.
+func (q *Queries) ListAttemptRatingStates(ctx context.Context, attemptID uuid.UUID) ([]ListAttemptRatingStatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptRatingStates, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAttemptRatingStatesRow{}
+	for rows.Next() {
+		var i ListAttemptRatingStatesRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionType,
+			&i.ToeicQuestionSection,
+			&i.ResponseSubmittedAt,
+			&i.RatingStatus,
+			&i.FinalScore,
+			&i.MaxScore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptResponses = `-- name: ListAttemptResponses :many
+SELECT attempt_id, question_id, response_type, started_at, preparation_seconds, response_seconds, audio_object, audio_url, text_response, word_count, submitted_at, created_at, updated_at, rating_status, final_score, max_score, rated_at, auto_score, auto_max_score, auto_feedback, auto_scorer, auto_scored_at, auto_score_failures
+FROM attempt_responses
+WHERE attempt_id = $1
+`
+
+func (q *Queries) ListAttemptResponses(ctx context.Context, attemptID uuid.UUID) ([]AttemptResponse, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptResponses, attemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AttemptResponse{}
+	for rows.Next() {
+		var i AttemptResponse
+		if err := rows.Scan(
+			&i.AttemptID,
+			&i.QuestionID,
+			&i.ResponseType,
+			&i.StartedAt,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+			&i.AudioObject,
+			&i.AudioUrl,
+			&i.TextResponse,
+			&i.WordCount,
+			&i.SubmittedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RatingStatus,
+			&i.FinalScore,
+			&i.MaxScore,
+			&i.RatedAt,
+			&i.AutoScore,
+			&i.AutoMaxScore,
+			&i.AutoFeedback,
+			&i.AutoScorer,
+			&i.AutoScoredAt,
+			&i.AutoScoreFailures,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAttemptReviewQuestions = `-- name: ListAttemptReviewQuestions :many
+SELECT
+    aq.question_id,
+    aq.part_id,
+    aq.paragraph_id,
+    aq.sequence_number,
+    aq.answer,
+    aq.is_correct,
+    q.question_content,
+    q.question_type,
+    q.audio_url,
+    q.image_url,
+    q.toeic_question_section,
+    q.question_number_in_part,
+    q.answer_option,
+    q.correct_answer,
+    COALESCE(qe.lang, '')::varchar AS explanation_lang,
+    COALESCE(qe.explanation, '')::text AS explanation,
+    COALESCE(qe.option_explanations, '{}')::jsonb AS option_explanations
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+LEFT JOIN questions cur ON cur.question_id = aq.question_id
+LEFT JOIN LATERAL (
+    SELECT e.lang, e.explanation, e.option_explanations
+    FROM question_explanations e
+    WHERE e.question_id = aq.question_id
+      AND e.lang IN ($1::varchar, $2::varchar)
+      AND cur.correct_answer IS NOT DISTINCT FROM q.correct_answer
+      AND cur.answer_option::jsonb IS NOT DISTINCT FROM q.answer_option::jsonb
+    ORDER BY e.lang = $1::varchar DESC
+    LIMIT 1
+) qe ON TRUE
+WHERE aq.attempt_id = $3
+ORDER BY aq.sequence_number
+`
+
+type ListAttemptReviewQuestionsParams struct {
+	Lang         string    `json:"lang"`
+	FallbackLang string    `json:"fallback_lang"`
+	AttemptID    uuid.UUID `json:"attempt_id"`
+}
+
+type ListAttemptReviewQuestionsRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	SequenceNumber       int32                 `json:"sequence_number"`
+	Answer               sql.NullString        `json:"answer"`
+	IsCorrect            sql.NullBool          `json:"is_correct"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	ExplanationLang      string                `json:"explanation_lang"`
+	Explanation          string                `json:"explanation"`
+	OptionExplanations   json.RawMessage       `json:"option_explanations"`
+}
+
+// ListAttemptReviewQuestions retrieves the questions of an attempt as pinned when it started, with the
+// learner's answers, the answer keys and the explanation in the requested language, else in the fallback one;
+// explanation_lang is empty when the question has neither. Explanations are written for the current answer key,
+// so they are left out for an attempt pinned to a revision whose key or options have changed since.
+func (q *Queries) ListAttemptReviewQuestions(ctx context.Context, arg ListAttemptReviewQuestionsParams) ([]ListAttemptReviewQuestionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listAttemptReviewQuestions, arg.Lang, arg.FallbackLang, arg.AttemptID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAttemptReviewQuestionsRow{}
+	for rows.Next() {
+		var i ListAttemptReviewQuestionsRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.SequenceNumber,
+			&i.Answer,
+			&i.IsCorrect,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.ExplanationLang,
+			&i.Explanation,
+			&i.OptionExplanations,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExamAttemptsByUser = `-- name: ListExamAttemptsByUser :many
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE user_id = $1 AND exam_id = $2
+ORDER BY started_at DESC
+`
+
+type ListExamAttemptsByUserParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	ExamID uuid.UUID `json:"exam_id"`
+}
+
+// ListExamAttemptsByUser retrieves all attempts of a user for an exam, newest first.
+func (q *Queries) ListExamAttemptsByUser(ctx context.Context, arg ListExamAttemptsByUserParams) ([]ExamAttempt, error) {
+	rows, err := q.db.QueryContext(ctx, listExamAttemptsByUser, arg.UserID, arg.ExamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ExamAttempt{}
+	for rows.Next() {
+		var i ExamAttempt
+		if err := rows.Scan(
+			&i.AttemptID,
+			&i.ExamID,
+			&i.UserID,
+			&i.Status,
+			&i.StartedAt,
+			&i.DeadlineAt,
+			&i.SubmittedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listParagraphs = `-- name: ListParagraphs :many
+SELECT
+    paragraph_id,
+    paragraph_content,
+    title,
+    part_id,
+    paragraph_order,
+    paragraph_type,
+    audio_url,
+    image_url,
+    created_at,
+    updated_at
+FROM
+    Paragraphs
+WHERE
+    deleted_at IS NULL
+`
+
+type ListParagraphsRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) ListParagraphs(ctx context.Context) ([]ListParagraphsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listParagraphs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListParagraphsRow{}
+	for rows.Next() {
+		var i ListParagraphsRow
+		if err := rows.Scan(
+			&i.ParagraphID,
+			&i.ParagraphContent,
+			&i.Title,
+			&i.PartID,
+			&i.ParagraphOrder,
+			&i.ParagraphType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listParagraphsByPartID = `-- name: ListParagraphsByPartID :many
+SELECT
+    paragraph_id,
+    paragraph_content,
+    title,
+    part_id,
+    paragraph_order,
+    paragraph_type,
+    audio_url,
+    image_url,
+    created_at,
+    updated_at
+FROM
+    Paragraphs
+WHERE
+    part_id = $1 AND deleted_at IS NULL
+ORDER BY
+    paragraph_order
+`
+
+type ListParagraphsByPartIDRow struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) ListParagraphsByPartID(ctx context.Context, partID uuid.UUID) ([]ListParagraphsByPartIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listParagraphsByPartID, partID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListParagraphsByPartIDRow{}
+	for rows.Next() {
+		var i ListParagraphsByPartIDRow
+		if err := rows.Scan(
+			&i.ParagraphID,
+			&i.ParagraphContent,
+			&i.Title,
+			&i.PartID,
+			&i.ParagraphOrder,
+			&i.ParagraphType,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionExplanations = `-- name: ListQuestionExplanations :many
+
+SELECT question_id, lang, explanation, option_explanations, updated_by, created_at, updated_at
+FROM question_explanations
+WHERE question_id = $1
+ORDER BY lang
+`
+
+// -
+// Question Explanations Queries
+// -
+// ListQuestionExplanations retrieves every translation of the explanations of a question.
+func (q *Queries) ListQuestionExplanations(ctx context.Context, questionID uuid.UUID) ([]QuestionExplanation, error) {
+	rows, err := q.db.QueryContext(ctx, listQuestionExplanations, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []QuestionExplanation{}
+	for rows.Next() {
+		var i QuestionExplanation
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Lang,
+			&i.Explanation,
+			&i.OptionExplanations,
+			&i.UpdatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestions = `-- name: ListQuestions :many
+SELECT
+    question_id,
+    question_content,
+    question_type,
+    part_id,
+    paragraph_id,
+    question_order,
+    audio_url,
+    image_url,
+    toeic_question_section,
+    question_number_in_part,
+    answer_option,
+    correct_answer,
+    preparation_seconds,
+    response_seconds,
+    created_at,
+    updated_at
+FROM
+    Questions
+WHERE
+    deleted_at IS NULL
+`
+
+type ListQuestionsRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+}
+
+func (q *Queries) ListQuestions(ctx context.Context) ([]ListQuestionsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listQuestions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListQuestionsRow{}
+	for rows.Next() {
+		var i ListQuestionsRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.QuestionOrder,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionsByParagraphID = `-- name: ListQuestionsByParagraphID :many
+SELECT
+    question_id,
+    question_content,
+    question_type,
+    part_id,
+    paragraph_id,
+    question_order,
+    audio_url,
+    image_url,
+    toeic_question_section,
+    question_number_in_part,
+    answer_option,
+    correct_answer,
+    preparation_seconds,
+    response_seconds,
+    created_at,
+    updated_at
+FROM
+    Questions
+WHERE
+    paragraph_id = $1 AND deleted_at IS NULL
+Order By
+    question_order ASC,
+    question_number_in_part ASC,
+    question_id ASC
+`
+
+type ListQuestionsByParagraphIDRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+}
+
+func (q *Queries) ListQuestionsByParagraphID(ctx context.Context, paragraphID uuid.NullUUID) ([]ListQuestionsByParagraphIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listQuestionsByParagraphID, paragraphID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListQuestionsByParagraphIDRow{}
+	for rows.Next() {
+		var i ListQuestionsByParagraphIDRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.QuestionOrder,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionsByPartID = `-- name: ListQuestionsByPartID :many
+SELECT
+    question_id,
+    question_content,
+    question_type,
+    part_id,
+    paragraph_id,
+    question_order,
+    audio_url,
+    image_url,
+    toeic_question_section,
+    question_number_in_part,
+    answer_option,
+    correct_answer,
+    preparation_seconds,
+    response_seconds,
+    created_at,
+    updated_at
+FROM
+    Questions
+WHERE
+    part_id = $1 AND deleted_at IS NULL
+ORDER BY
+    question_order
+`
+
+type ListQuestionsByPartIDRow struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+	CreatedAt            sql.NullTime          `json:"created_at"`
+	UpdatedAt            sql.NullTime          `json:"updated_at"`
+}
+
+func (q *Queries) ListQuestionsByPartID(ctx context.Context, partID uuid.UUID) ([]ListQuestionsByPartIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, listQuestionsByPartID, partID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListQuestionsByPartIDRow{}
+	for rows.Next() {
+		var i ListQuestionsByPartIDRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.PartID,
+			&i.ParagraphID,
+			&i.QuestionOrder,
+			&i.AudioUrl,
+			&i.ImageUrl,
+			&i.ToeicQuestionSection,
+			&i.QuestionNumberInPart,
+			&i.AnswerOption,
+			&i.CorrectAnswer,
+			&i.PreparationSeconds,
+			&i.ResponseSeconds,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResponseRatings = `-- name: ListResponseRatings :many
+SELECT rating_id, attempt_id, question_id, rater_id, round, score, max_score, comment, assigned_at, rated_at
+FROM response_ratings
+WHERE attempt_id = $1
+  AND question_id = $2
+ORDER BY round
+`
+
+type ListResponseRatingsParams struct {
+	AttemptID  uuid.UUID `json:"attempt_id"`
+	QuestionID uuid.UUID `json:"question_id"`
+}
+
+// ListResponseRatings retrieves the ratings of a response in round order.
+func (q *Queries) ListResponseRatings(ctx context.Context, arg ListResponseRatingsParams) ([]ResponseRating, error) {
+	rows, err := q.db.QueryContext(ctx, listResponseRatings, arg.AttemptID, arg.QuestionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ResponseRating{}
+	for rows.Next() {
+		var i ResponseRating
+		if err := rows.Scan(
+			&i.RatingID,
+			&i.AttemptID,
+			&i.QuestionID,
+			&i.RaterID,
+			&i.Round,
+			&i.Score,
+			&i.MaxScore,
+			&i.Comment,
+			&i.AssignedAt,
+			&i.RatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listResponsesToAutoScore = `-- name: ListResponsesToAutoScore :many
+
+SELECT r.attempt_id,
+       r.question_id,
+       r.response_type,
+       r.audio_url,
+       r.text_response,
+       r.word_count,
+       q.question_content,
+       q.question_type,
+       q.toeic_question_section
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+JOIN attempt_questions aq ON aq.attempt_id = r.attempt_id AND aq.question_id = r.question_id
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND r.submitted_at IS NOT NULL
+  AND r.auto_scored_at IS NULL
+  AND r.auto_score_failures < $1
+  AND q.question_type = ANY ($2::varchar[])
+ORDER BY r.submitted_at
+LIMIT $3
+`
+
+type ListResponsesToAutoScoreParams struct {
+	MaxFailures   int32    `json:"max_failures"`
+	QuestionTypes []string `json:"question_types"`
+	BatchSize     int32    `json:"batch_size"`
+}
+
+type ListResponsesToAutoScoreRow struct {
+	AttemptID            uuid.UUID      `json:"attempt_id"`
+	QuestionID           uuid.UUID      `json:"question_id"`
+	ResponseType         string         `json:"response_type"`
+	AudioUrl             sql.NullString `json:"audio_url"`
+	TextResponse         sql.NullString `json:"text_response"`
+	WordCount            sql.NullInt32  `json:"word_count"`
+	QuestionContent      string         `json:"question_content"`
+	QuestionType         string         `json:"question_type"`
+	ToeicQuestionSection string         `json:"toeic_question_section"`
+}
+
+// -
+// Response Auto Scores Queries
+// -
+// ListResponsesToAutoScore retrieves the submitted responses of finalized attempts to the given question
+// types that the automated scorer has not handled yet, oldest first.
+func (q *Queries) ListResponsesToAutoScore(ctx context.Context, arg ListResponsesToAutoScoreParams) ([]ListResponsesToAutoScoreRow, error) {
+	rows, err := q.db.QueryContext(ctx, listResponsesToAutoScore, arg.MaxFailures, pq.Array(arg.QuestionTypes), arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListResponsesToAutoScoreRow{}
+	for rows.Next() {
+		var i ListResponsesToAutoScoreRow
+		if err := rows.Scan(
+			&i.AttemptID,
+			&i.QuestionID,
+			&i.ResponseType,
+			&i.AudioUrl,
+			&i.TextResponse,
+			&i.WordCount,
+			&i.QuestionContent,
+			&i.QuestionType,
+			&i.ToeicQuestionSection,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScoreConversionEntries = `-- name: ListScoreConversionEntries :many
+SELECT table_id, section, raw_score, scaled_score
+FROM score_conversion_entries
+WHERE table_id = $1
+ORDER BY section, raw_score
+`
+
+// ListScoreConversionEntries retrieves the raw-to-scaled rows of a conversion table.
+func (q *Queries) ListScoreConversionEntries(ctx context.Context, tableID uuid.UUID) ([]ScoreConversionEntry, error) {
+	rows, err := q.db.QueryContext(ctx, listScoreConversionEntries, tableID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScoreConversionEntry{}
+	for rows.Next() {
+		var i ScoreConversionEntry
+		if err := rows.Scan(
+			&i.TableID,
+			&i.Section,
+			&i.RawScore,
+			&i.ScaledScore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScoreConversionTables = `-- name: ListScoreConversionTables :many
+SELECT table_id, name, description, is_default, created_at, updated_at
+FROM score_conversion_tables
+ORDER BY is_default DESC, name
+`
+
+// ListScoreConversionTables retrieves all conversion tables.
+func (q *Queries) ListScoreConversionTables(ctx context.Context) ([]ScoreConversionTable, error) {
+	rows, err := q.db.QueryContext(ctx, listScoreConversionTables)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ScoreConversionTable{}
+	for rows.Next() {
+		var i ScoreConversionTable
+		if err := rows.Scan(
+			&i.TableID,
+			&i.Name,
+			&i.Description,
+			&i.IsDefault,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockNextResponseToRate = `-- name: LockNextResponseToRate :one
+SELECT r.attempt_id, r.question_id, r.rating_status
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND ea.user_id <> $1
+  AND r.submitted_at IS NOT NULL
+  AND r.rating_status <> 'RATED'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM response_ratings rr
+      WHERE rr.attempt_id = r.attempt_id
+        AND rr.question_id = r.question_id
+        AND rr.rater_id = $1
+  )
+  AND (SELECT COUNT(*) FROM response_ratings rr WHERE rr.attempt_id = r.attempt_id AND rr.question_id = r.question_id)
+      < CASE WHEN r.rating_status = 'ADJUDICATION' THEN 3 ELSE 2 END
+ORDER BY r.rating_status = 'ADJUDICATION' DESC, ea.submitted_at, r.submitted_at
+LIMIT 1
+FOR UPDATE OF r SKIP LOCKED
+`
+
+type LockNextResponseToRateRow struct {
+	AttemptID    uuid.UUID `json:"attempt_id"`
+	QuestionID   uuid.UUID `json:"question_id"`
+	RatingStatus string    `json:"rating_status"`
+}
+
+// LockNextResponseToRate picks the oldest submitted response that still needs a rating the rater may give:
+// not on their own attempt, not a response they already rated, adjudications first. The row stays locked
+// until the assignment is committed, so concurrent raters pick different responses.
+func (q *Queries) LockNextResponseToRate(ctx context.Context, raterID uuid.UUID) (LockNextResponseToRateRow, error) {
+	row := q.db.QueryRowContext(ctx, lockNextResponseToRate, raterID)
+	var i LockNextResponseToRateRow
+	err := row.Scan(&i.AttemptID, &i.QuestionID, &i.RatingStatus)
+	return i, err
+}
+
+const lockUser = `-- name: LockUser :execresult
+UPDATE users
+set is_locked=true,lock_reason=$1,locked_at=now()
+where id=$2
+`
+
+type LockUserParams struct {
+	LockReason sql.NullString `json:"lock_reason"`
+	ID         uuid.UUID      `json:"id"`
+}
+
+// LockUser to lock user account
+func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) (sql.Result, error) {
+	return q.db.ExecContext(ctx, lockUser, arg.LockReason, arg.ID)
+}
+
+const markEmailVerified = `-- name: MarkEmailVerified :execrows
+UPDATE users
+SET email_verified_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND email_verified_at IS NULL
+`
+
+// MarkEmailVerified records that the user confirmed their email; zero rows means it was already verified.
+func (q *Queries) MarkEmailVerified(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markEmailVerified, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markMailDeadLetterRequeued = `-- name: MarkMailDeadLetterRequeued :execrows
+UPDATE mail_dead_letters
+SET requeued_at = CURRENT_TIMESTAMP
+WHERE job_id = $1
+  AND requeued_at IS NULL
+`
+
+// MarkMailDeadLetterRequeued records that a failed mail was put back on the queue; zero rows means it already was.
+func (q *Queries) MarkMailDeadLetterRequeued(ctx context.Context, jobID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markMailDeadLetterRequeued, jobID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const markPaymentOrderPaid = `-- name: MarkPaymentOrderPaid :exec
+UPDATE payment_orders
+SET status          = 'PAID',
+    failure_reason  = '',
+    subscription_id = $2,
+    paid_at         = CURRENT_TIMESTAMP,
+    updated_at      = CURRENT_TIMESTAMP
+WHERE order_id = $1
+`
+
+type MarkPaymentOrderPaidParams struct {
+	OrderID        uuid.UUID     `json:"order_id"`
+	SubscriptionID uuid.NullUUID `json:"subscription_id"`
+}
+
+// MarkPaymentOrderPaid records the payment of an order and the subscription it activated.
+func (q *Queries) MarkPaymentOrderPaid(ctx context.Context, arg MarkPaymentOrderPaidParams) error {
+	_, err := q.db.ExecContext(ctx, markPaymentOrderPaid, arg.OrderID, arg.SubscriptionID)
+	return err
+}
+
+const permissionExists = `-- name: PermissionExists :one
+SELECT EXISTS(SELECT 1 FROM permissions WHERE id = $1)
+`
+
+// PermissionExists checks if a permission with the given ID exists.
+func (q *Queries) PermissionExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, permissionExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const purgeTrashedExamParts = `-- name: PurgeTrashedExamParts :execrows
+DELETE
+FROM exam_parts p
+WHERE p.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM paragraphs g WHERE g.part_id = p.part_id)
+  AND NOT EXISTS (SELECT 1 FROM questions q WHERE q.part_id = p.part_id)
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.part_id = p.part_id)
+`
+
+func (q *Queries) PurgeTrashedExamParts(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTrashedExamParts, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTrashedExams = `-- name: PurgeTrashedExams :execrows
+DELETE
+FROM exams e
+WHERE e.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.exam_id = e.exam_id)
+  AND NOT EXISTS (SELECT 1 FROM exam_attempts a WHERE a.exam_id = e.exam_id)
+`
+
+func (q *Queries) PurgeTrashedExams(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTrashedExams, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTrashedParagraphs = `-- name: PurgeTrashedParagraphs :execrows
+DELETE
+FROM paragraphs g
+WHERE g.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM questions q WHERE q.paragraph_id = g.paragraph_id)
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.paragraph_id = g.paragraph_id)
+`
+
+func (q *Queries) PurgeTrashedParagraphs(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTrashedParagraphs, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeTrashedQuestions = `-- name: PurgeTrashedQuestions :execrows
+DELETE
+FROM questions q
+WHERE q.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.question_id = q.question_id)
+`
+
+// PurgeTrashedQuestions and the queries below delete for good the content trashed before deleted_before,
+// children first. Content attempts refer to stays in the trash, and so do the parents of whatever stayed.
+func (q *Queries) PurgeTrashedQuestions(ctx context.Context, deletedBefore sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeTrashedQuestions, deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordPaymentEvent = `-- name: RecordPaymentEvent :execrows
+INSERT INTO payment_events (provider, event_id, event_type, order_id, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (provider, event_id) DO NOTHING
+`
+
+type RecordPaymentEventParams struct {
+	Provider  string          `json:"provider"`
+	EventID   string          `json:"event_id"`
+	EventType string          `json:"event_type"`
+	OrderID   uuid.NullUUID   `json:"order_id"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// RecordPaymentEvent stores a webhook event; zero rows means it was received before.
+func (q *Queries) RecordPaymentEvent(ctx context.Context, arg RecordPaymentEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, recordPaymentEvent,
+		arg.Provider,
+		arg.EventID,
+		arg.EventType,
+		arg.OrderID,
+		arg.Payload,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const recordResponseAutoScoreFailure = `-- name: RecordResponseAutoScoreFailure :exec
+UPDATE attempt_responses
+SET auto_score_failures = auto_score_failures + 1
+WHERE attempt_id = $1
+  AND question_id = $2
+`
+
+type RecordResponseAutoScoreFailureParams struct {
+	AttemptID  uuid.UUID `json:"attempt_id"`
+	QuestionID uuid.UUID `json:"question_id"`
+}
+
+// RecordResponseAutoScoreFailure counts a failed call to the scorer for a response.
+func (q *Queries) RecordResponseAutoScoreFailure(ctx context.Context, arg RecordResponseAutoScoreFailureParams) error {
+	_, err := q.db.ExecContext(ctx, recordResponseAutoScoreFailure, arg.AttemptID, arg.QuestionID)
+	return err
+}
+
+const releaseStaleRatingAssignments = `-- name: ReleaseStaleRatingAssignments :execrows
+
+DELETE FROM response_ratings
+WHERE score IS NULL
+  AND assigned_at < $1
+`
+
+// -
+// Response Ratings Queries
+// -
+// ReleaseStaleRatingAssignments gives back to the queue the responses assigned but not rated in time.
+func (q *Queries) ReleaseStaleRatingAssignments(ctx context.Context, assignedBefore time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, releaseStaleRatingAssignments, assignedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const resolveResponseRating = `-- name: ResolveResponseRating :exec
+UPDATE attempt_responses
+SET rating_status = $1,
+    final_score   = $2,
+    max_score     = $3,
+    rated_at      = CASE WHEN $1::varchar = 'RATED' THEN CURRENT_TIMESTAMP END
+WHERE attempt_id = $4
+  AND question_id = $5
+`
+
+type ResolveResponseRatingParams struct {
+	RatingStatus string        `json:"rating_status"`
+	FinalScore   sql.NullInt32 `json:"final_score"`
+	MaxScore     sql.NullInt32 `json:"max_score"`
+	AttemptID    uuid.UUID     `json:"attempt_id"`
+	QuestionID   uuid.UUID     `json:"question_id"`
+}
+
+// ResolveResponseRating moves a response to ADJUDICATION, or to RATED with its final score.
+func (q *Queries) ResolveResponseRating(ctx context.Context, arg ResolveResponseRatingParams) error {
+	_, err := q.db.ExecContext(ctx, resolveResponseRating,
+		arg.RatingStatus,
+		arg.FinalScore,
+		arg.MaxScore,
+		arg.AttemptID,
+		arg.QuestionID,
+	)
+	return err
+}
+
+const restoreParagraph = `-- name: RestoreParagraph :exec
+UPDATE paragraphs
+SET paragraph_content = $2,
+    title             = $3,
+    part_id           = $4,
+    paragraph_order   = $5,
+    paragraph_type    = $6,
+    audio_url         = $7,
+    image_url         = $8,
+    updated_at        = CURRENT_TIMESTAMP
+WHERE paragraph_id = $1
+`
+
+type RestoreParagraphParams struct {
+	ParagraphID      uuid.UUID      `json:"paragraph_id"`
+	ParagraphContent string         `json:"paragraph_content"`
+	Title            sql.NullString `json:"title"`
+	PartID           uuid.UUID      `json:"part_id"`
+	ParagraphOrder   int32          `json:"paragraph_order"`
+	ParagraphType    sql.NullString `json:"paragraph_type"`
+	AudioUrl         sql.NullString `json:"audio_url"`
+	ImageUrl         sql.NullString `json:"image_url"`
+}
+
+// RestoreParagraph puts every field of a paragraph back to the given values, media included.
+func (q *Queries) RestoreParagraph(ctx context.Context, arg RestoreParagraphParams) error {
+	_, err := q.db.ExecContext(ctx, restoreParagraph,
+		arg.ParagraphID,
+		arg.ParagraphContent,
+		arg.Title,
+		arg.PartID,
+		arg.ParagraphOrder,
+		arg.ParagraphType,
+		arg.AudioUrl,
+		arg.ImageUrl,
+	)
+	return err
+}
+
+const restoreQuestion = `-- name: RestoreQuestion :exec
+UPDATE questions
+SET question_content        = $2,
+    question_type           = $3,
+    part_id                 = $4,
+    paragraph_id            = $5,
+    question_order          = $6,
+    audio_url               = $7,
+    image_url               = $8,
+    toeic_question_section  = $9,
+    question_number_in_part = $10,
+    answer_option           = $11,
+    correct_answer          = $12,
+    preparation_seconds     = $13,
+    response_seconds        = $14,
+    updated_at              = CURRENT_TIMESTAMP
+WHERE question_id = $1
+`
+
+type RestoreQuestionParams struct {
+	QuestionID           uuid.UUID             `json:"question_id"`
+	QuestionContent      string                `json:"question_content"`
+	QuestionType         string                `json:"question_type"`
+	PartID               uuid.UUID             `json:"part_id"`
+	ParagraphID          uuid.NullUUID         `json:"paragraph_id"`
+	QuestionOrder        int32                 `json:"question_order"`
+	AudioUrl             sql.NullString        `json:"audio_url"`
+	ImageUrl             sql.NullString        `json:"image_url"`
+	ToeicQuestionSection string                `json:"toeic_question_section"`
+	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
+	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
+	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
+}
+
+// RestoreQuestion puts every field of a question back to the given values, media included.
+func (q *Queries) RestoreQuestion(ctx context.Context, arg RestoreQuestionParams) error {
+	_, err := q.db.ExecContext(ctx, restoreQuestion,
+		arg.QuestionID,
+		arg.QuestionContent,
+		arg.QuestionType,
+		arg.PartID,
+		arg.ParagraphID,
+		arg.QuestionOrder,
+		arg.AudioUrl,
+		arg.ImageUrl,
+		arg.ToeicQuestionSection,
+		arg.QuestionNumberInPart,
+		arg.AnswerOption,
+		arg.CorrectAnswer,
+		arg.PreparationSeconds,
+		arg.ResponseSeconds,
+	)
+	return err
+}
+
+const restoreTrashedExam = `-- name: RestoreTrashedExam :execrows
+UPDATE exams
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE exam_id = $1
+  AND deleted_at = $2
+`
+
+type RestoreTrashedExamParams struct {
+	ExamID    uuid.UUID    `json:"exam_id"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+// RestoreTrashedExam and the queries below take an item out of the trash along with the children trashed
+// at the same time; children deleted before their parent stay in the trash.
+func (q *Queries) RestoreTrashedExam(ctx context.Context, arg RestoreTrashedExamParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTrashedExam, arg.ExamID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTrashedExamPart = `-- name: RestoreTrashedExamPart :execrows
+UPDATE exam_parts
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = $1
+  AND deleted_at = $2
+`
+
+type RestoreTrashedExamPartParams struct {
+	PartID    uuid.UUID    `json:"part_id"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedExamPart(ctx context.Context, arg RestoreTrashedExamPartParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTrashedExamPart, arg.PartID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTrashedParagraph = `-- name: RestoreTrashedParagraph :execrows
+UPDATE paragraphs
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE paragraph_id = $1
+  AND deleted_at = $2
+`
+
+type RestoreTrashedParagraphParams struct {
+	ParagraphID uuid.UUID    `json:"paragraph_id"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedParagraph(ctx context.Context, arg RestoreTrashedParagraphParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTrashedParagraph, arg.ParagraphID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTrashedParagraphsOfParts = `-- name: RestoreTrashedParagraphsOfParts :exec
+UPDATE paragraphs
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = ANY ($1::uuid[])
+  AND deleted_at = $2
+`
+
+type RestoreTrashedParagraphsOfPartsParams struct {
+	PartIds   []uuid.UUID  `json:"part_ids"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedParagraphsOfParts(ctx context.Context, arg RestoreTrashedParagraphsOfPartsParams) error {
+	_, err := q.db.ExecContext(ctx, restoreTrashedParagraphsOfParts, pq.Array(arg.PartIds), arg.DeletedAt)
+	return err
+}
+
+const restoreTrashedPartsOfExam = `-- name: RestoreTrashedPartsOfExam :many
+UPDATE exam_parts
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE exam_id = $1
+  AND deleted_at = $2
+RETURNING part_id
+`
+
+type RestoreTrashedPartsOfExamParams struct {
+	ExamID    uuid.NullUUID `json:"exam_id"`
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedPartsOfExam(ctx context.Context, arg RestoreTrashedPartsOfExamParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, restoreTrashedPartsOfExam, arg.ExamID, arg.DeletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var part_id uuid.UUID
+		if err := rows.Scan(&part_id); err != nil {
+			return nil, err
+		}
+		items = append(items, part_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreTrashedQuestion = `-- name: RestoreTrashedQuestion :execrows
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE question_id = $1
+  AND deleted_at = $2
+`
+
+type RestoreTrashedQuestionParams struct {
+	QuestionID uuid.UUID    `json:"question_id"`
+	DeletedAt  sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedQuestion(ctx context.Context, arg RestoreTrashedQuestionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreTrashedQuestion, arg.QuestionID, arg.DeletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTrashedQuestionsOfParagraph = `-- name: RestoreTrashedQuestionsOfParagraph :exec
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE paragraph_id = $1
+  AND deleted_at = $2
+`
+
+type RestoreTrashedQuestionsOfParagraphParams struct {
+	ParagraphID uuid.NullUUID `json:"paragraph_id"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedQuestionsOfParagraph(ctx context.Context, arg RestoreTrashedQuestionsOfParagraphParams) error {
+	_, err := q.db.ExecContext(ctx, restoreTrashedQuestionsOfParagraph, arg.ParagraphID, arg.DeletedAt)
+	return err
+}
+
+const restoreTrashedQuestionsOfParts = `-- name: RestoreTrashedQuestionsOfParts :exec
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = ANY ($1::uuid[])
+  AND deleted_at = $2
+`
+
+type RestoreTrashedQuestionsOfPartsParams struct {
+	PartIds   []uuid.UUID  `json:"part_ids"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+func (q *Queries) RestoreTrashedQuestionsOfParts(ctx context.Context, arg RestoreTrashedQuestionsOfPartsParams) error {
+	_, err := q.db.ExecContext(ctx, restoreTrashedQuestionsOfParts, pq.Array(arg.PartIds), arg.DeletedAt)
+	return err
+}
+
+const revokeAllUserSessions = `-- name: RevokeAllUserSessions :many
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $2
+WHERE user_id = $1
+  AND revoked_at IS NULL
+RETURNING session_id
+`
+
+type RevokeAllUserSessionsParams struct {
+	UserID       uuid.UUID `json:"user_id"`
+	RevokeReason string    `json:"revoke_reason"`
+}
+
+// RevokeAllUserSessions closes every open session of a user and returns their ids.
+func (q *Queries) RevokeAllUserSessions(ctx context.Context, arg RevokeAllUserSessionsParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, revokeAllUserSessions, arg.UserID, arg.RevokeReason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var session_id uuid.UUID
+		if err := rows.Scan(&session_id); err != nil {
+			return nil, err
+		}
+		items = append(items, session_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeUserSession = `-- name: RevokeUserSession :execrows
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $2
+WHERE session_id = $1
+  AND revoked_at IS NULL
+`
+
+type RevokeUserSessionParams struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	RevokeReason string    `json:"revoke_reason"`
+}
+
+// RevokeUserSession closes a session so none of its refresh tokens can be exchanged again.
+func (q *Queries) RevokeUserSession(ctx context.Context, arg RevokeUserSessionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeUserSession, arg.SessionID, arg.RevokeReason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const revokeUserSessionOfUser = `-- name: RevokeUserSessionOfUser :execrows
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $3
+WHERE session_id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+`
+
+type RevokeUserSessionOfUserParams struct {
+	SessionID    uuid.UUID `json:"session_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	RevokeReason string    `json:"revoke_reason"`
+}
+
+// RevokeUserSessionOfUser closes one session, provided it belongs to the given user.
+func (q *Queries) RevokeUserSessionOfUser(ctx context.Context, arg RevokeUserSessionOfUserParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, revokeUserSessionOfUser, arg.SessionID, arg.UserID, arg.RevokeReason)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const roleExists = `-- name: RoleExists :one
+SELECT EXISTS(SELECT 1 FROM roles WHERE id = $1)
+`
+
+// RoleExists checks if a role with the given ID exists.
+func (q *Queries) RoleExists(ctx context.Context, id uuid.UUID) (bool, error) {
+	row := q.db.QueryRowContext(ctx, roleExists, id)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const saveAttemptAnswers = `-- name: SaveAttemptAnswers :execrows
+UPDATE attempt_questions aq
+SET
+    answer = NULLIF(a.answer, ''),
+    answered_at = CURRENT_TIMESTAMP
+FROM exam_attempts ea,
+     (SELECT unnest($2::uuid[]) AS question_id, unnest($3::text[]) AS answer) a
+WHERE
+    aq.attempt_id = ea.attempt_id AND
+    aq.attempt_id = $1 AND
+    aq.question_id = a.question_id AND
+    ea.status = 'IN_PROGRESS' AND
+    (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP)
+`
+
+type SaveAttemptAnswersParams struct {
+	AttemptID   uuid.UUID   `json:"attempt_id"`
+	QuestionIds []uuid.UUID `json:"question_ids"`
+	Answers     []string    `json:"answers"`
+}
+
+// SaveAttemptAnswers stores a batch of answers only while the attempt is in progress and before its deadline.
+// Being one statement, the batch is stored or refused as a whole; an empty answer clears the question.
+func (q *Queries) SaveAttemptAnswers(ctx context.Context, arg SaveAttemptAnswersParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, saveAttemptAnswers, arg.AttemptID, pq.Array(arg.QuestionIds), pq.Array(arg.Answers))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const saveAttemptResponse = `-- name: SaveAttemptResponse :execrows
+UPDATE attempt_responses r
+SET audio_object  = $1,
+    audio_url     = $2,
+    text_response = $3,
+    word_count    = $4,
+    submitted_at  = CURRENT_TIMESTAMP
+FROM exam_attempts ea
+WHERE r.attempt_id = ea.attempt_id
+  AND r.attempt_id = $5
+  AND r.question_id = $6
+  AND ea.status = 'IN_PROGRESS'
+  AND (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP)
+  AND CURRENT_TIMESTAMP >= r.started_at + make_interval(secs => r.preparation_seconds)
+  AND CURRENT_TIMESTAMP <= r.started_at + make_interval(secs => r.preparation_seconds + r.response_seconds + $7::int)
+`
+
+type SaveAttemptResponseParams struct {
+	AudioObject  sql.NullString `json:"audio_object"`
+	AudioUrl     sql.NullString `json:"audio_url"`
+	TextResponse sql.NullString `json:"text_response"`
+	WordCount    sql.NullInt32  `json:"word_count"`
+	AttemptID    uuid.UUID      `json:"attempt_id"`
+	QuestionID   uuid.UUID      `json:"question_id"`
+	GraceSeconds int32          `json:"grace_seconds"`
+}
+
+// SaveAttemptResponse stores a response from the end of the preparation window until the response window
+// closes, grace_seconds included, while the attempt is in progress and before its deadline.
+func (q *Queries) SaveAttemptResponse(ctx context.Context, arg SaveAttemptResponseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, saveAttemptResponse,
+		arg.AudioObject,
+		arg.AudioUrl,
+		arg.TextResponse,
+		arg.WordCount,
+		arg.AttemptID,
+		arg.QuestionID,
+		arg.GraceSeconds,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const saveMailDeadLetter = `-- name: SaveMailDeadLetter :exec
+INSERT INTO mail_dead_letters (job_id, recipients, subject, payload, attempts, last_error)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (job_id) DO UPDATE
+    SET recipients  = EXCLUDED.recipients,
+        subject     = EXCLUDED.subject,
+        payload     = EXCLUDED.payload,
+        attempts    = EXCLUDED.attempts,
+        last_error  = EXCLUDED.last_error,
+        failed_at   = CURRENT_TIMESTAMP,
+        requeued_at = NULL
+`
+
+type SaveMailDeadLetterParams struct {
+	JobID      uuid.UUID       `json:"job_id"`
+	Recipients string          `json:"recipients"`
+	Subject    string          `json:"subject"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int32           `json:"attempts"`
+	LastError  string          `json:"last_error"`
+}
+
+// SaveMailDeadLetter stores a mail the queue gave up on; a retried mail that fails again replaces its row.
+func (q *Queries) SaveMailDeadLetter(ctx context.Context, arg SaveMailDeadLetterParams) error {
+	_, err := q.db.ExecContext(ctx, saveMailDeadLetter,
+		arg.JobID,
+		arg.Recipients,
+		arg.Subject,
+		arg.Payload,
+		arg.Attempts,
+		arg.LastError,
+	)
+	return err
+}
+
+const saveResponseAutoScore = `-- name: SaveResponseAutoScore :execrows
+UPDATE attempt_responses
+SET auto_score     = $1,
+    auto_max_score = $2,
+    auto_feedback  = $3,
+    auto_scorer    = $4,
+    auto_scored_at = CURRENT_TIMESTAMP
+WHERE attempt_id = $5
+  AND question_id = $6
+  AND auto_scored_at IS NULL
+`
+
+type SaveResponseAutoScoreParams struct {
+	AutoScore    sql.NullInt32   `json:"auto_score"`
+	AutoMaxScore sql.NullInt32   `json:"auto_max_score"`
+	AutoFeedback json.RawMessage `json:"auto_feedback"`
+	AutoScorer   sql.NullString  `json:"auto_scorer"`
+	AttemptID    uuid.UUID       `json:"attempt_id"`
+	QuestionID   uuid.UUID       `json:"question_id"`
+}
+
+// SaveResponseAutoScore records the pre-score of a response once; auto_score is NULL when the scorer
+// cannot handle the response.
+func (q *Queries) SaveResponseAutoScore(ctx context.Context, arg SaveResponseAutoScoreParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, saveResponseAutoScore,
+		arg.AutoScore,
+		arg.AutoMaxScore,
+		arg.AutoFeedback,
+		arg.AutoScorer,
+		arg.AttemptID,
+		arg.QuestionID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setDefaultScoreConversionTable = `-- name: SetDefaultScoreConversionTable :execrows
+UPDATE score_conversion_tables
+SET is_default = TRUE
+WHERE table_id = $1
+`
+
+// SetDefaultScoreConversionTable marks a conversion table as the default one.
+func (q *Queries) SetDefaultScoreConversionTable(ctx context.Context, tableID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setDefaultScoreConversionTable, tableID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setExamScoreConversionTable = `-- name: SetExamScoreConversionTable :execrows
+UPDATE exams
+SET score_conversion_table_id = $2
+WHERE exam_id = $1
+`
+
+type SetExamScoreConversionTableParams struct {
+	ExamID                 uuid.UUID     `json:"exam_id"`
+	ScoreConversionTableID uuid.NullUUID `json:"score_conversion_table_id"`
+}
+
+// SetExamScoreConversionTable pins a conversion table to an exam; NULL reverts to the default table.
+func (q *Queries) SetExamScoreConversionTable(ctx context.Context, arg SetExamScoreConversionTableParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setExamScoreConversionTable, arg.ExamID, arg.ScoreConversionTableID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setPaymentOrderSession = `-- name: SetPaymentOrderSession :exec
+UPDATE payment_orders
+SET provider_session_id = $2,
+    updated_at          = CURRENT_TIMESTAMP
+WHERE order_id = $1
+`
+
+type SetPaymentOrderSessionParams struct {
+	OrderID           uuid.UUID      `json:"order_id"`
+	ProviderSessionID sql.NullString `json:"provider_session_id"`
+}
+
+// SetPaymentOrderSession stores the provider's checkout session of an order.
+func (q *Queries) SetPaymentOrderSession(ctx context.Context, arg SetPaymentOrderSessionParams) error {
+	_, err := q.db.ExecContext(ctx, setPaymentOrderSession, arg.OrderID, arg.ProviderSessionID)
+	return err
+}
+
+const startAttemptResponse = `-- name: StartAttemptResponse :execrows
+
+INSERT INTO attempt_responses (attempt_id, question_id, response_type, preparation_seconds, response_seconds)
+SELECT ea.attempt_id, $1::uuid, $2::varchar, $3::int, $4::int
+FROM exam_attempts ea
+WHERE ea.attempt_id = $5
+  AND ea.status = 'IN_PROGRESS'
+  AND (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP)
+ON CONFLICT (attempt_id, question_id) DO NOTHING
+`
+
+type StartAttemptResponseParams struct {
+	QuestionID         uuid.UUID `json:"question_id"`
+	ResponseType       string    `json:"response_type"`
+	PreparationSeconds int32     `json:"preparation_seconds"`
+	ResponseSeconds    int32     `json:"response_seconds"`
+	AttemptID          uuid.UUID `json:"attempt_id"`
+}
+
+// -
+// Attempt Responses Queries
+// -
+// StartAttemptResponse opens the windows of a spoken or written response while the attempt is in progress
+// and before its deadline; zero rows means they were already open or the attempt is closed.
+func (q *Queries) StartAttemptResponse(ctx context.Context, arg StartAttemptResponseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, startAttemptResponse,
+		arg.QuestionID,
+		arg.ResponseType,
+		arg.PreparationSeconds,
+		arg.ResponseSeconds,
+		arg.AttemptID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const submitRating = `-- name: SubmitRating :one
+UPDATE response_ratings
+SET score     = $1,
+    max_score = $2,
+    comment   = $3,
+    rated_at  = CURRENT_TIMESTAMP
+WHERE rating_id = $4
+  AND rater_id = $5
+  AND score IS NULL
+RETURNING rating_id, attempt_id, question_id, rater_id, round, score, max_score, comment, assigned_at, rated_at
+`
+
+type SubmitRatingParams struct {
+	Score    sql.NullInt32  `json:"score"`
+	MaxScore sql.NullInt32  `json:"max_score"`
+	Comment  sql.NullString `json:"comment"`
+	RatingID uuid.UUID      `json:"rating_id"`
+	RaterID  uuid.UUID      `json:"rater_id"`
+}
+
+// SubmitRating records the score of an open assignment of the rater.
+func (q *Queries) SubmitRating(ctx context.Context, arg SubmitRatingParams) (ResponseRating, error) {
+	row := q.db.QueryRowContext(ctx, submitRating,
+		arg.Score,
+		arg.MaxScore,
+		arg.Comment,
+		arg.RatingID,
+		arg.RaterID,
+	)
+	var i ResponseRating
+	err := row.Scan(
+		&i.RatingID,
+		&i.AttemptID,
+		&i.QuestionID,
+		&i.RaterID,
+		&i.Round,
+		&i.Score,
+		&i.MaxScore,
+		&i.Comment,
+		&i.AssignedAt,
+		&i.RatedAt,
+	)
+	return i, err
+}
+
+const touchUserSession = `-- name: TouchUserSession :exec
+UPDATE user_sessions
+SET last_used_at = CURRENT_TIMESTAMP,
+    expires_at   = $2
+WHERE session_id = $1
 `
 
-func (q *Queries) ListQuestionsByParagraphID(ctx context.Context, paragraphID uuid.NullUUID) ([]Question, error) {
-	rows, err := q.db.QueryContext(ctx, listQuestionsByParagraphID, paragraphID)
+type TouchUserSessionParams struct {
+	SessionID uuid.UUID `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// TouchUserSession extends a session after its refresh token was rotated.
+func (q *Queries) TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error {
+	_, err := q.db.ExecContext(ctx, touchUserSession, arg.SessionID, arg.ExpiresAt)
+	return err
+}
+
+const trashExam = `-- name: TrashExam :execrows
+UPDATE exams
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE exam_id = $3
+  AND deleted_at IS NULL
+`
+
+type TrashExamParams struct {
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	ExamID    uuid.UUID     `json:"exam_id"`
+}
+
+// TrashExam moves an exam to the trash; zero rows means it is missing or already there.
+func (q *Queries) TrashExam(ctx context.Context, arg TrashExamParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashExam, arg.DeletedAt, arg.DeletedBy, arg.ExamID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	items := []Question{}
-	for rows.Next() {
-		var i Question
-		if err := rows.Scan(
-			&i.QuestionID,
-			&i.QuestionContent,
-			&i.QuestionType,
-			&i.PartID,
-			&i.ParagraphID,
-			&i.QuestionOrder,
-			&i.AudioUrl,
-			&i.ImageUrl,
-			&i.ToeicQuestionSection,
-			&i.QuestionNumberInPart,
-			&i.AnswerOption,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
+		return 0, err
 	}
-	if err := rows.Close(); err != nil {
-		return nil, err
+	return result.RowsAffected()
+}
+
+const trashExamPart = `-- name: TrashExamPart :execrows
+UPDATE exam_parts
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE part_id = $3
+  AND deleted_at IS NULL
+`
+
+type TrashExamPartParams struct {
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	PartID    uuid.UUID     `json:"part_id"`
+}
+
+func (q *Queries) TrashExamPart(ctx context.Context, arg TrashExamPartParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashExamPart, arg.DeletedAt, arg.DeletedBy, arg.PartID)
+	if err != nil {
+		return 0, err
 	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+	return result.RowsAffected()
+}
+
+const trashParagraph = `-- name: TrashParagraph :execrows
+UPDATE paragraphs
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE paragraph_id = $3
+  AND deleted_at IS NULL
+`
+
+type TrashParagraphParams struct {
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	DeletedBy   uuid.NullUUID `json:"deleted_by"`
+	ParagraphID uuid.UUID     `json:"paragraph_id"`
+}
+
+func (q *Queries) TrashParagraph(ctx context.Context, arg TrashParagraphParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashParagraph, arg.DeletedAt, arg.DeletedBy, arg.ParagraphID)
+	if err != nil {
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected()
 }
 
-const listQuestionsByPartID = `-- name: ListQuestionsByPartID :many
-SELECT
-    question_id,
-    question_content,
-    question_type,
-    part_id,
-    paragraph_id,
-    question_order,
-    audio_url,
-    image_url,
-    toeic_question_section,
-    question_number_in_part,
-    answer_option,
-    correct_answer,
-    created_at,
-    updated_at
-FROM
-    Questions
-WHERE
-    part_id = $1
-ORDER BY
-    question_order
+const trashParagraphsOfParts = `-- name: TrashParagraphsOfParts :exec
+UPDATE paragraphs
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE part_id = ANY ($3::uuid[])
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) ListQuestionsByPartID(ctx context.Context, partID uuid.UUID) ([]Question, error) {
-	rows, err := q.db.QueryContext(ctx, listQuestionsByPartID, partID)
+type TrashParagraphsOfPartsParams struct {
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	PartIds   []uuid.UUID   `json:"part_ids"`
+}
+
+func (q *Queries) TrashParagraphsOfParts(ctx context.Context, arg TrashParagraphsOfPartsParams) error {
+	_, err := q.db.ExecContext(ctx, trashParagraphsOfParts, arg.DeletedAt, arg.DeletedBy, pq.Array(arg.PartIds))
+	return err
+}
+
+const trashPartsOfExam = `-- name: TrashPartsOfExam :many
+UPDATE exam_parts
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE exam_id = $3
+  AND deleted_at IS NULL
+RETURNING part_id
+`
+
+type TrashPartsOfExamParams struct {
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	ExamID    uuid.NullUUID `json:"exam_id"`
+}
+
+// TrashPartsOfExam moves the parts of an exam to the trash along with it and returns their ids.
+func (q *Queries) TrashPartsOfExam(ctx context.Context, arg TrashPartsOfExamParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, trashPartsOfExam, arg.DeletedAt, arg.DeletedBy, arg.ExamID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Question{}
+	items := []uuid.UUID{}
 	for rows.Next() {
-		var i Question
-		if err := rows.Scan(
-			&i.QuestionID,
-			&i.QuestionContent,
-			&i.QuestionType,
-			&i.PartID,
-			&i.ParagraphID,
-			&i.QuestionOrder,
-			&i.AudioUrl,
-			&i.ImageUrl,
-			&i.ToeicQuestionSection,
-			&i.QuestionNumberInPart,
-			&i.AnswerOption,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
+		var part_id uuid.UUID
+		if err := rows.Scan(&part_id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, part_id)
 	}
 	if err := rows.Close(); err != nil {
 		return nil, err
@@ -1471,44 +5777,64 @@ func (q *Queries) ListQuestionsByPartID(ctx context.Context, partID uuid.UUID) (
 	return items, nil
 }
 
-const lockUser = `-- name: LockUser :execresult
-UPDATE users
-set is_locked=true,lock_reason=$1,locked_at=now()
-where id=$2
+const trashQuestion = `-- name: TrashQuestion :execrows
+UPDATE questions
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE question_id = $3
+  AND deleted_at IS NULL
 `
 
-type LockUserParams struct {
-	LockReason sql.NullString `json:"lock_reason"`
-	ID         uuid.UUID      `json:"id"`
+type TrashQuestionParams struct {
+	DeletedAt  sql.NullTime  `json:"deleted_at"`
+	DeletedBy  uuid.NullUUID `json:"deleted_by"`
+	QuestionID uuid.UUID     `json:"question_id"`
 }
 
-// LockUser to lock user account
-func (q *Queries) LockUser(ctx context.Context, arg LockUserParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, lockUser, arg.LockReason, arg.ID)
+func (q *Queries) TrashQuestion(ctx context.Context, arg TrashQuestionParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, trashQuestion, arg.DeletedAt, arg.DeletedBy, arg.QuestionID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const permissionExists = `-- name: PermissionExists :one
-SELECT EXISTS(SELECT 1 FROM permissions WHERE id = $1)
+const trashQuestionsOfParagraph = `-- name: TrashQuestionsOfParagraph :exec
+UPDATE questions
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE paragraph_id = $3
+  AND deleted_at IS NULL
 `
 
-// PermissionExists checks if a permission with the given ID exists.
-func (q *Queries) PermissionExists(ctx context.Context, id uuid.UUID) (bool, error) {
-	row := q.db.QueryRowContext(ctx, permissionExists, id)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+type TrashQuestionsOfParagraphParams struct {
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+	DeletedBy   uuid.NullUUID `json:"deleted_by"`
+	ParagraphID uuid.NullUUID `json:"paragraph_id"`
 }
 
-const roleExists = `-- name: RoleExists :one
-SELECT EXISTS(SELECT 1 FROM roles WHERE id = $1)
+func (q *Queries) TrashQuestionsOfParagraph(ctx context.Context, arg TrashQuestionsOfParagraphParams) error {
+	_, err := q.db.ExecContext(ctx, trashQuestionsOfParagraph, arg.DeletedAt, arg.DeletedBy, arg.ParagraphID)
+	return err
+}
+
+const trashQuestionsOfParts = `-- name: TrashQuestionsOfParts :exec
+UPDATE questions
+SET deleted_at = $1,
+    deleted_by = $2
+WHERE part_id = ANY ($3::uuid[])
+  AND deleted_at IS NULL
 `
 
-// RoleExists checks if a role with the given ID exists.
-func (q *Queries) RoleExists(ctx context.Context, id uuid.UUID) (bool, error) {
-	row := q.db.QueryRowContext(ctx, roleExists, id)
-	var exists bool
-	err := row.Scan(&exists)
-	return exists, err
+type TrashQuestionsOfPartsParams struct {
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+	DeletedBy uuid.NullUUID `json:"deleted_by"`
+	PartIds   []uuid.UUID   `json:"part_ids"`
+}
+
+func (q *Queries) TrashQuestionsOfParts(ctx context.Context, arg TrashQuestionsOfPartsParams) error {
+	_, err := q.db.ExecContext(ctx, trashQuestionsOfParts, arg.DeletedAt, arg.DeletedBy, pq.Array(arg.PartIds))
+	return err
 }
 
 const unlockUser = `-- name: UnlockUser :execresult
@@ -1527,6 +5853,89 @@ func (q *Queries) UnlockUser(ctx context.Context, arg UnlockUserParams) (sql.Res
 	return q.db.ExecContext(ctx, unlockUser, arg.UnlockReason, arg.ID)
 }
 
+const updateAttemptQuestionCorrectness = `-- name: UpdateAttemptQuestionCorrectness :exec
+UPDATE attempt_questions
+SET is_correct = $3
+WHERE attempt_id = $1 AND question_id = $2
+`
+
+type UpdateAttemptQuestionCorrectnessParams struct {
+	AttemptID  uuid.UUID    `json:"attempt_id"`
+	QuestionID uuid.UUID    `json:"question_id"`
+	IsCorrect  sql.NullBool `json:"is_correct"`
+}
+
+// UpdateAttemptQuestionCorrectness stores the grading outcome of one answered question.
+func (q *Queries) UpdateAttemptQuestionCorrectness(ctx context.Context, arg UpdateAttemptQuestionCorrectnessParams) error {
+	_, err := q.db.ExecContext(ctx, updateAttemptQuestionCorrectness, arg.AttemptID, arg.QuestionID, arg.IsCorrect)
+	return err
+}
+
+const updateAttemptResultRatings = `-- name: UpdateAttemptResultRatings :one
+UPDATE attempt_results
+SET speaking_points     = $1,
+    speaking_max_points = $2,
+    speaking_pending    = $3,
+    speaking_scaled     = $4,
+    writing_points      = $5,
+    writing_max_points  = $6,
+    writing_pending     = $7,
+    writing_scaled      = $8
+WHERE attempt_id = $9
+RETURNING attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+          speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled
+`
+
+type UpdateAttemptResultRatingsParams struct {
+	SpeakingPoints    int32         `json:"speaking_points"`
+	SpeakingMaxPoints int32         `json:"speaking_max_points"`
+	SpeakingPending   int32         `json:"speaking_pending"`
+	SpeakingScaled    sql.NullInt32 `json:"speaking_scaled"`
+	WritingPoints     int32         `json:"writing_points"`
+	WritingMaxPoints  int32         `json:"writing_max_points"`
+	WritingPending    int32         `json:"writing_pending"`
+	WritingScaled     sql.NullInt32 `json:"writing_scaled"`
+	AttemptID         uuid.UUID     `json:"attempt_id"`
+}
+
+// UpdateAttemptResultRatings stores the rolled up Speaking and Writing scores. total_scaled stays the
+// Listening and Reading total, as on the TOEIC L&R score report.
+func (q *Queries) UpdateAttemptResultRatings(ctx context.Context, arg UpdateAttemptResultRatingsParams) (AttemptResult, error) {
+	row := q.db.QueryRowContext(ctx, updateAttemptResultRatings,
+		arg.SpeakingPoints,
+		arg.SpeakingMaxPoints,
+		arg.SpeakingPending,
+		arg.SpeakingScaled,
+		arg.WritingPoints,
+		arg.WritingMaxPoints,
+		arg.WritingPending,
+		arg.WritingScaled,
+		arg.AttemptID,
+	)
+	var i AttemptResult
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ConversionTableID,
+		&i.ListeningCorrect,
+		&i.ListeningTotal,
+		&i.ListeningScaled,
+		&i.ReadingCorrect,
+		&i.ReadingTotal,
+		&i.ReadingScaled,
+		&i.TotalScaled,
+		&i.ScoredAt,
+		&i.SpeakingPoints,
+		&i.SpeakingMaxPoints,
+		&i.SpeakingPending,
+		&i.SpeakingScaled,
+		&i.WritingPoints,
+		&i.WritingMaxPoints,
+		&i.WritingPending,
+		&i.WritingScaled,
+	)
+	return i, err
+}
+
 const updateExam = `-- name: UpdateExam :exec
 UPDATE Exams
 SET
@@ -1611,6 +6020,55 @@ func (q *Queries) UpdateExamPart(ctx context.Context, arg UpdateExamPartParams)
 	return err
 }
 
+const updateExamPartStatus = `-- name: UpdateExamPartStatus :execrows
+UPDATE exam_parts
+SET status       = $1,
+    published_at = CASE WHEN $1::varchar = 'PUBLISHED' THEN CURRENT_TIMESTAMP ELSE published_at END,
+    updated_at   = CURRENT_TIMESTAMP
+WHERE part_id = $2
+  AND status = $3
+`
+
+type UpdateExamPartStatusParams struct {
+	ToStatus   string    `json:"to_status"`
+	PartID     uuid.UUID `json:"part_id"`
+	FromStatus string    `json:"from_status"`
+}
+
+// UpdateExamPartStatus moves a part to a new status only if it is still in the expected one.
+func (q *Queries) UpdateExamPartStatus(ctx context.Context, arg UpdateExamPartStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExamPartStatus, arg.ToStatus, arg.PartID, arg.FromStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateExamStatus = `-- name: UpdateExamStatus :execrows
+UPDATE exams
+SET status       = $1,
+    published_at = CASE WHEN $1::varchar = 'PUBLISHED' THEN CURRENT_TIMESTAMP ELSE published_at END,
+    updated_at   = CURRENT_TIMESTAMP
+WHERE exam_id = $2
+  AND status = $3
+`
+
+type UpdateExamStatusParams struct {
+	ToStatus   string    `json:"to_status"`
+	ExamID     uuid.UUID `json:"exam_id"`
+	FromStatus string    `json:"from_status"`
+}
+
+// UpdateExamStatus moves an exam to a new status only if it is still in the expected one; zero rows means
+// the exam changed in the meantime.
+func (q *Queries) UpdateExamStatus(ctx context.Context, arg UpdateExamStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExamStatus, arg.ToStatus, arg.ExamID, arg.FromStatus)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateParagraph = `-- name: UpdateParagraph :exec
 UPDATE Paragraphs
 SET
@@ -1707,7 +6165,9 @@ SET
     toeic_question_section = $7,
     question_number_in_part = $8,
     answer_option = $9,
-    correct_answer = $10
+    correct_answer = $10,
+    preparation_seconds = $11,
+    response_seconds = $12
 WHERE
     question_id = $1
 `
@@ -1723,6 +6183,8 @@ type UpdateQuestionParams struct {
 	QuestionNumberInPart sql.NullInt32         `json:"question_number_in_part"`
 	AnswerOption         pqtype.NullRawMessage `json:"answer_option"`
 	CorrectAnswer        sql.NullString        `json:"correct_answer"`
+	PreparationSeconds   sql.NullInt32         `json:"preparation_seconds"`
+	ResponseSeconds      sql.NullInt32         `json:"response_seconds"`
 }
 
 func (q *Queries) UpdateQuestion(ctx context.Context, arg UpdateQuestionParams) error {
@@ -1737,6 +6199,8 @@ func (q *Queries) UpdateQuestion(ctx context.Context, arg UpdateQuestionParams)
 		arg.QuestionNumberInPart,
 		arg.AnswerOption,
 		arg.CorrectAnswer,
+		arg.PreparationSeconds,
+		arg.ResponseSeconds,
 	)
 	return err
 }
@@ -1777,6 +6241,60 @@ func (q *Queries) UpdateQuestionImageURL(ctx context.Context, arg UpdateQuestion
 	return err
 }
 
+const updateSubscriptionPlan = `-- name: UpdateSubscriptionPlan :one
+UPDATE subscription_plans
+SET name              = $2,
+    description       = $3,
+    duration_days     = $4,
+    grace_period_days = $5,
+    price_cents       = $6,
+    currency          = $7,
+    is_active         = $8,
+    updated_at        = CURRENT_TIMESTAMP
+WHERE plan_id = $1
+RETURNING plan_id, code, name, description, duration_days, grace_period_days, price_cents, currency, is_active, created_at, updated_at
+`
+
+type UpdateSubscriptionPlanParams struct {
+	PlanID          uuid.UUID `json:"plan_id"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	DurationDays    int32     `json:"duration_days"`
+	GracePeriodDays int32     `json:"grace_period_days"`
+	PriceCents      int64     `json:"price_cents"`
+	Currency        string    `json:"currency"`
+	IsActive        bool      `json:"is_active"`
+}
+
+// UpdateSubscriptionPlan changes a plan; existing subscriptions keep the period they were created with.
+func (q *Queries) UpdateSubscriptionPlan(ctx context.Context, arg UpdateSubscriptionPlanParams) (SubscriptionPlan, error) {
+	row := q.db.QueryRowContext(ctx, updateSubscriptionPlan,
+		arg.PlanID,
+		arg.Name,
+		arg.Description,
+		arg.DurationDays,
+		arg.GracePeriodDays,
+		arg.PriceCents,
+		arg.Currency,
+		arg.IsActive,
+	)
+	var i SubscriptionPlan
+	err := row.Scan(
+		&i.PlanID,
+		&i.Code,
+		&i.Name,
+		&i.Description,
+		&i.DurationDays,
+		&i.GracePeriodDays,
+		&i.PriceCents,
+		&i.Currency,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateUserAvatar = `-- name: UpdateUserAvatar :exec
 Update user_profiles
 set avatar_url=$1
@@ -1821,3 +6339,171 @@ func (q *Queries) UpdateUserProfile(ctx context.Context, arg UpdateUserProfilePa
 	)
 	return err
 }
+
+const updateUserProvider = `-- name: UpdateUserProvider :exec
+UPDATE user_providers
+SET email         = $3,
+    access_token  = $4,
+    refresh_token = $5,
+    avatar_url    = $6,
+    full_name     = $7
+WHERE provider = $1
+  AND provider_user_id = $2
+`
+
+type UpdateUserProviderParams struct {
+	Provider       string         `json:"provider"`
+	ProviderUserID string         `json:"provider_user_id"`
+	Email          sql.NullString `json:"email"`
+	AccessToken    sql.NullString `json:"access_token"`
+	RefreshToken   sql.NullString `json:"refresh_token"`
+	AvatarUrl      sql.NullString `json:"avatar_url"`
+	FullName       sql.NullString `json:"full_name"`
+}
+
+// UpdateUserProvider refreshes the tokens and profile data of a linked provider identity.
+func (q *Queries) UpdateUserProvider(ctx context.Context, arg UpdateUserProviderParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserProvider,
+		arg.Provider,
+		arg.ProviderUserID,
+		arg.Email,
+		arg.AccessToken,
+		arg.RefreshToken,
+		arg.AvatarUrl,
+		arg.FullName,
+	)
+	return err
+}
+
+const upsertAttemptResult = `-- name: UpsertAttemptResult :one
+INSERT INTO attempt_results (
+    attempt_id,
+    conversion_table_id,
+    listening_correct,
+    listening_total,
+    listening_scaled,
+    reading_correct,
+    reading_total,
+    reading_scaled,
+    total_scaled
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (attempt_id) DO UPDATE SET
+    conversion_table_id = EXCLUDED.conversion_table_id,
+    listening_correct = EXCLUDED.listening_correct,
+    listening_total = EXCLUDED.listening_total,
+    listening_scaled = EXCLUDED.listening_scaled,
+    reading_correct = EXCLUDED.reading_correct,
+    reading_total = EXCLUDED.reading_total,
+    reading_scaled = EXCLUDED.reading_scaled,
+    total_scaled = EXCLUDED.total_scaled,
+    scored_at = CURRENT_TIMESTAMP
+RETURNING attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+          speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled
+`
+
+type UpsertAttemptResultParams struct {
+	AttemptID         uuid.UUID     `json:"attempt_id"`
+	ConversionTableID uuid.NullUUID `json:"conversion_table_id"`
+	ListeningCorrect  int32         `json:"listening_correct"`
+	ListeningTotal    int32         `json:"listening_total"`
+	ListeningScaled   sql.NullInt32 `json:"listening_scaled"`
+	ReadingCorrect    int32         `json:"reading_correct"`
+	ReadingTotal      int32         `json:"reading_total"`
+	ReadingScaled     sql.NullInt32 `json:"reading_scaled"`
+	TotalScaled       sql.NullInt32 `json:"total_scaled"`
+}
+
+// UpsertAttemptResult stores the scores of an attempt, replacing a previous result.
+func (q *Queries) UpsertAttemptResult(ctx context.Context, arg UpsertAttemptResultParams) (AttemptResult, error) {
+	row := q.db.QueryRowContext(ctx, upsertAttemptResult,
+		arg.AttemptID,
+		arg.ConversionTableID,
+		arg.ListeningCorrect,
+		arg.ListeningTotal,
+		arg.ListeningScaled,
+		arg.ReadingCorrect,
+		arg.ReadingTotal,
+		arg.ReadingScaled,
+		arg.TotalScaled,
+	)
+	var i AttemptResult
+	err := row.Scan(
+		&i.AttemptID,
+		&i.ConversionTableID,
+		&i.ListeningCorrect,
+		&i.ListeningTotal,
+		&i.ListeningScaled,
+		&i.ReadingCorrect,
+		&i.ReadingTotal,
+		&i.ReadingScaled,
+		&i.TotalScaled,
+		&i.ScoredAt,
+		&i.SpeakingPoints,
+		&i.SpeakingMaxPoints,
+		&i.SpeakingPending,
+		&i.SpeakingScaled,
+		&i.WritingPoints,
+		&i.WritingMaxPoints,
+		&i.WritingPending,
+		&i.WritingScaled,
+	)
+	return i, err
+}
+
+const upsertQuestionExplanation = `-- name: UpsertQuestionExplanation :one
+INSERT INTO question_explanations (question_id, lang, explanation, option_explanations, updated_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (question_id, lang) DO UPDATE
+SET explanation         = EXCLUDED.explanation,
+    option_explanations = EXCLUDED.option_explanations,
+    updated_by          = EXCLUDED.updated_by
+RETURNING question_id, lang, explanation, option_explanations, updated_by, created_at, updated_at
+`
+
+type UpsertQuestionExplanationParams struct {
+	QuestionID         uuid.UUID       `json:"question_id"`
+	Lang               string          `json:"lang"`
+	Explanation        string          `json:"explanation"`
+	OptionExplanations json.RawMessage `json:"option_explanations"`
+	UpdatedBy          uuid.NullUUID   `json:"updated_by"`
+}
+
+// UpsertQuestionExplanation stores the explanations of a question in one language, replacing the previous ones.
+func (q *Queries) UpsertQuestionExplanation(ctx context.Context, arg UpsertQuestionExplanationParams) (QuestionExplanation, error) {
+	row := q.db.QueryRowContext(ctx, upsertQuestionExplanation,
+		arg.QuestionID,
+		arg.Lang,
+		arg.Explanation,
+		arg.OptionExplanations,
+		arg.UpdatedBy,
+	)
+	var i QuestionExplanation
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Lang,
+		&i.Explanation,
+		&i.OptionExplanations,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const useRefreshToken = `-- name: UseRefreshToken :execrows
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_id = $1
+  AND used_at IS NULL
+`
+
+// UseRefreshToken marks a refresh token as exchanged; zero rows means it was already used.
+func (q *Queries) UseRefreshToken(ctx context.Context, tokenID uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, useRefreshToken, tokenID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -1,11 +1,29 @@
 package main
 
 import (
+	"fmt"
+	"os"
 	"pirate-lang-go/core/logger"
 	"pirate-lang-go/core/server"
 )
 
 func main() {
+	// Command line tools
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := server.RunImport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "import:", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "autoscorer" {
+		if err := server.RunAutoScorer(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "autoscorer:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Echo instance
 
 	if err := server.Run(); err != nil {
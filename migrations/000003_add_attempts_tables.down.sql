@@ -0,0 +1,10 @@
+-- ======================
+-- Trigger
+-- ======================
+DROP TRIGGER IF EXISTS update_exam_attempts_updated_at ON exam_attempts;
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS attempt_questions;
+
+DROP TABLE IF EXISTS exam_attempts;
@@ -0,0 +1,54 @@
+-- ========================
+-- ExamAttempts
+-- ========================
+CREATE TABLE exam_attempts (
+                               attempt_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                               exam_id UUID NOT NULL,
+                               user_id UUID NOT NULL,
+                               status VARCHAR(20) NOT NULL DEFAULT 'IN_PROGRESS',
+
+                               started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               deadline_at TIMESTAMPTZ, -- NULL when the exam has no duration_minutes
+                               submitted_at TIMESTAMPTZ,
+
+                               created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                               updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                               CONSTRAINT FK_attempt_exam FOREIGN KEY (exam_id) REFERENCES exams (exam_id),
+                               CONSTRAINT FK_attempt_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                               CONSTRAINT chk_attempt_status CHECK (status IN (
+                                                                               'IN_PROGRESS',
+                                                                               'SUBMITTED',
+                                                                               'EXPIRED'
+                                   ))
+);
+CREATE INDEX idx_exam_attempts_user_exam ON exam_attempts (user_id, exam_id);
+
+-- ========================
+-- AttemptQuestions
+-- ========================
+-- Snapshot of the questions served in an attempt, together with the learner's answer.
+CREATE TABLE attempt_questions (
+                                   attempt_id UUID NOT NULL,
+                                   question_id UUID NOT NULL,
+                                   part_id UUID NOT NULL,
+                                   paragraph_id UUID,
+                                   sequence_number INT NOT NULL, -- position of the question inside the attempt
+
+                                   answer TEXT,
+                                   answered_at TIMESTAMPTZ,
+
+                                   PRIMARY KEY (attempt_id, question_id),
+                                   FOREIGN KEY (attempt_id) REFERENCES exam_attempts (attempt_id) ON DELETE CASCADE,
+                                   FOREIGN KEY (question_id) REFERENCES questions (question_id),
+                                   FOREIGN KEY (part_id) REFERENCES exam_parts (part_id),
+                                   FOREIGN KEY (paragraph_id) REFERENCES paragraphs (paragraph_id) ON DELETE SET NULL
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_exam_attempts_updated_at
+    BEFORE UPDATE ON exam_attempts
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,16 @@
+-- ======================
+-- Trigger
+-- ======================
+DROP TRIGGER IF EXISTS update_score_conversion_tables_updated_at ON score_conversion_tables;
+-- ======================
+-- Table
+-- ======================
+ALTER TABLE attempt_questions DROP COLUMN IF EXISTS is_correct;
+
+DROP TABLE IF EXISTS attempt_results;
+
+ALTER TABLE exams DROP COLUMN IF EXISTS score_conversion_table_id;
+
+DROP TABLE IF EXISTS score_conversion_entries;
+
+DROP TABLE IF EXISTS score_conversion_tables;
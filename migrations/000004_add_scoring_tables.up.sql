@@ -0,0 +1,82 @@
+-- ========================
+-- ScoreConversionTables
+-- ========================
+CREATE TABLE score_conversion_tables (
+                                         table_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                         name VARCHAR(100) NOT NULL UNIQUE,
+                                         description TEXT,
+                                         is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+                                         created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                         updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- Only one table can be the default one
+CREATE UNIQUE INDEX uq_score_conversion_tables_default ON score_conversion_tables (is_default) WHERE is_default;
+
+-- ========================
+-- ScoreConversionEntries
+-- ========================
+CREATE TABLE score_conversion_entries (
+                                          table_id UUID NOT NULL,
+                                          section VARCHAR(20) NOT NULL,
+                                          raw_score INT NOT NULL,
+                                          scaled_score INT NOT NULL,
+
+                                          PRIMARY KEY (table_id, section, raw_score),
+                                          FOREIGN KEY (table_id) REFERENCES score_conversion_tables (table_id) ON DELETE CASCADE,
+                                          CONSTRAINT chk_conversion_section CHECK (section IN ('Listening', 'Reading')),
+                                          CONSTRAINT chk_conversion_raw_score CHECK (raw_score >= 0),
+                                          CONSTRAINT chk_conversion_scaled_score CHECK (scaled_score BETWEEN 5 AND 495)
+);
+
+-- An exam may pin its own table, otherwise the default table is used
+ALTER TABLE exams ADD COLUMN score_conversion_table_id UUID REFERENCES score_conversion_tables (table_id) ON DELETE SET NULL;
+
+-- ========================
+-- AttemptResults
+-- ========================
+CREATE TABLE attempt_results (
+                                 attempt_id UUID PRIMARY KEY,
+                                 conversion_table_id UUID,
+
+                                 listening_correct INT NOT NULL DEFAULT 0,
+                                 listening_total INT NOT NULL DEFAULT 0,
+                                 listening_scaled INT,
+                                 reading_correct INT NOT NULL DEFAULT 0,
+                                 reading_total INT NOT NULL DEFAULT 0,
+                                 reading_scaled INT,
+                                 total_scaled INT,
+
+                                 scored_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                 FOREIGN KEY (attempt_id) REFERENCES exam_attempts (attempt_id) ON DELETE CASCADE,
+                                 FOREIGN KEY (conversion_table_id) REFERENCES score_conversion_tables (table_id) ON DELETE SET NULL
+);
+
+-- NULL until graded, stays NULL for question types that are not scored automatically
+ALTER TABLE attempt_questions ADD COLUMN is_correct BOOLEAN;
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_score_conversion_tables_updated_at
+    BEFORE UPDATE ON score_conversion_tables
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+-- ======================
+-- Seed
+-- ======================
+-- Approximate public TOEIC L&R conversion, 100 questions per section. Tune it through the admin API.
+INSERT INTO score_conversion_tables (name, description, is_default)
+VALUES ('TOEIC L&R Standard', 'Approximate conversion for 100 listening and 100 reading questions', TRUE);
+
+INSERT INTO score_conversion_entries (table_id, section, raw_score, scaled_score)
+SELECT t.table_id, 'Listening', r, LEAST(495, GREATEST(5, CASE WHEN r = 0 THEN 5 ELSE 5 * ROUND((r * 4.9 + 15) / 5.0)::int END))
+FROM score_conversion_tables t, generate_series(0, 100) AS r
+WHERE t.name = 'TOEIC L&R Standard';
+
+INSERT INTO score_conversion_entries (table_id, section, raw_score, scaled_score)
+SELECT t.table_id, 'Reading', r, LEAST(495, GREATEST(5, 5 * ROUND((r * 4.95 - 2) / 5.0)::int))
+FROM score_conversion_tables t, generate_series(0, 100) AS r
+WHERE t.name = 'TOEIC L&R Standard';
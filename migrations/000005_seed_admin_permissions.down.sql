@@ -0,0 +1,8 @@
+-- ======================
+-- Seed
+-- ======================
+DELETE FROM permissions
+WHERE name IN ('users:read', 'users:manage', 'rbac:manage', 'library:manage', 'scoring:manage');
+
+DELETE FROM roles
+WHERE name = 'admin';
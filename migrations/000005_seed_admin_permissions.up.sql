@@ -0,0 +1,24 @@
+-- ======================
+-- Seed
+-- ======================
+-- Permissions guarding the /v1/admin route groups
+INSERT INTO permissions (name, description)
+VALUES ('users:read', 'View user accounts and profiles'),
+       ('users:manage', 'Lock and unlock user accounts'),
+       ('rbac:manage', 'Manage roles, permissions and their assignments'),
+       ('library:manage', 'Manage exams, parts, paragraphs and questions'),
+       ('scoring:manage', 'Manage score conversion tables')
+ON CONFLICT (name) DO NOTHING;
+
+-- The admin role holds every permission above. Grant it to the first administrator with:
+--   INSERT INTO user_roles (user_id, role_id) SELECT '<user id>', id FROM roles WHERE name = 'admin';
+INSERT INTO roles (name, description)
+VALUES ('admin', 'Full access to the administration API')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin'
+  AND p.name IN ('users:read', 'users:manage', 'rbac:manage', 'library:manage', 'scoring:manage')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,20 @@
+-- ======================
+-- Seed
+-- ======================
+INSERT INTO permissions (name, description)
+VALUES ('library:manage', 'Manage exams, parts, paragraphs and questions')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT DISTINCT rp.role_id, p.id
+FROM role_permissions rp
+         JOIN permissions old ON old.id = rp.permission_id AND old.name = 'library:write'
+         CROSS JOIN permissions p
+WHERE p.name = 'library:manage'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM roles
+WHERE name IN ('content_editor', 'content_publisher');
+
+DELETE FROM permissions
+WHERE name IN ('library:read', 'library:write', 'library:publish');
@@ -0,0 +1,34 @@
+-- ======================
+-- Seed
+-- ======================
+-- Content-editor permissions replacing the single library:manage grant
+INSERT INTO permissions (name, description)
+VALUES ('library:read', 'View exams, parts, paragraphs and questions in the admin API'),
+       ('library:write', 'Create and edit exams, parts, paragraphs and questions'),
+       ('library:publish', 'Publish library content to learners')
+ON CONFLICT (name) DO NOTHING;
+
+-- Roles that could manage the library keep full access
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT rp.role_id, p.id
+FROM role_permissions rp
+         JOIN permissions old ON old.id = rp.permission_id AND old.name = 'library:manage'
+         CROSS JOIN permissions p
+WHERE p.name IN ('library:read', 'library:write', 'library:publish')
+ON CONFLICT DO NOTHING;
+
+DELETE FROM permissions
+WHERE name = 'library:manage';
+
+-- Editors prepare content, publishers also release it
+INSERT INTO roles (name, description)
+VALUES ('content_editor', 'Create and edit library content'),
+       ('content_publisher', 'Create, edit and publish library content')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE (r.name = 'content_editor' AND p.name IN ('library:read', 'library:write'))
+   OR (r.name = 'content_publisher' AND p.name IN ('library:read', 'library:write', 'library:publish'))
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS refresh_tokens;
+
+DROP TABLE IF EXISTS user_sessions;
@@ -0,0 +1,35 @@
+-- ========================
+-- UserSessions
+-- ========================
+-- One row per login. Every refresh token issued for the login belongs to the same session (token family).
+CREATE TABLE user_sessions (
+                               session_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                               user_id UUID NOT NULL,
+
+                               expires_at TIMESTAMPTZ NOT NULL,
+                               last_used_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               revoked_at TIMESTAMPTZ,
+                               revoke_reason VARCHAR(50) NOT NULL DEFAULT '',
+
+                               created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                               CONSTRAINT FK_session_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id);
+
+-- ========================
+-- RefreshTokens
+-- ========================
+-- token_id is the jti of the refresh token. A token may be exchanged once; presenting a used token revokes its session.
+CREATE TABLE refresh_tokens (
+                                token_id UUID PRIMARY KEY,
+                                session_id UUID NOT NULL,
+
+                                expires_at TIMESTAMPTZ NOT NULL,
+                                used_at TIMESTAMPTZ,
+
+                                created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                FOREIGN KEY (session_id) REFERENCES user_sessions (session_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_refresh_tokens_session ON refresh_tokens (session_id);
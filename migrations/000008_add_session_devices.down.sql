@@ -0,0 +1,6 @@
+-- ======================
+-- Column
+-- ======================
+ALTER TABLE user_sessions
+    DROP COLUMN IF EXISTS ip_address,
+    DROP COLUMN IF EXISTS user_agent;
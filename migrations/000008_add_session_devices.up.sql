@@ -0,0 +1,7 @@
+-- ========================
+-- UserSessions
+-- ========================
+-- Device details captured at login, shown in the active sessions list
+ALTER TABLE user_sessions
+    ADD COLUMN user_agent TEXT NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- ======================
+-- Column
+-- ======================
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at;
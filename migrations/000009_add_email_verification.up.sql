@@ -0,0 +1,11 @@
+-- ========================
+-- USERS
+-- ========================
+-- NULL until the owner confirms the address through the verification link
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+-- Social accounts were created from an address the provider had verified
+UPDATE users
+SET email_verified_at = created_at
+WHERE is_social_login = TRUE;
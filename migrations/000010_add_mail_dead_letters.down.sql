@@ -0,0 +1,10 @@
+-- ======================
+-- Seed
+-- ======================
+DELETE FROM permissions
+WHERE name = 'mail:manage';
+
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS mail_dead_letters;
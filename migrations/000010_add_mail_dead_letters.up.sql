@@ -0,0 +1,31 @@
+-- ========================
+-- MailDeadLetters
+-- ========================
+-- Mails the queue gave up on after exhausting its retries. job_id is the queue job id, so a
+-- retried mail that fails again updates its existing row.
+CREATE TABLE mail_dead_letters (
+                                   job_id UUID PRIMARY KEY,
+                                   recipients TEXT NOT NULL,
+                                   subject TEXT NOT NULL DEFAULT '',
+                                   payload JSONB NOT NULL,
+                                   attempts INT NOT NULL,
+                                   last_error TEXT NOT NULL DEFAULT '',
+
+                                   failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                   requeued_at TIMESTAMPTZ
+);
+CREATE INDEX idx_mail_dead_letters_failed_at ON mail_dead_letters (failed_at DESC);
+
+-- ======================
+-- Seed
+-- ======================
+INSERT INTO permissions (name, description)
+VALUES ('mail:manage', 'Inspect and retry failed outbound mails')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin'
+  AND p.name = 'mail:manage'
+ON CONFLICT DO NOTHING;
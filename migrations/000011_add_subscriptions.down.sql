@@ -0,0 +1,12 @@
+-- ======================
+-- Seed
+-- ======================
+DELETE FROM permissions
+WHERE name = 'subscriptions:manage';
+
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS user_subscriptions;
+
+DROP TABLE IF EXISTS subscription_plans;
@@ -0,0 +1,63 @@
+-- ========================
+-- SubscriptionPlans
+-- ========================
+CREATE TABLE subscription_plans (
+                                    plan_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    code VARCHAR(50) NOT NULL UNIQUE,
+                                    name VARCHAR(100) NOT NULL,
+                                    description TEXT NOT NULL DEFAULT '',
+                                    duration_days INT NOT NULL,
+                                    grace_period_days INT NOT NULL DEFAULT 0,
+                                    price_cents BIGINT NOT NULL DEFAULT 0,
+                                    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+                                    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+                                    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                    CONSTRAINT chk_plan_duration CHECK (duration_days > 0),
+                                    CONSTRAINT chk_plan_grace_period CHECK (grace_period_days >= 0),
+                                    CONSTRAINT chk_plan_price CHECK (price_cents >= 0)
+);
+
+-- ========================
+-- UserSubscriptions
+-- ========================
+-- A period during which the user may read SUBSCRIPTION content. Access lasts until grace_ends_at,
+-- which is fixed from the plan's grace period when the subscription is created.
+CREATE TABLE user_subscriptions (
+                                    subscription_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    user_id UUID NOT NULL,
+                                    plan_id UUID NOT NULL,
+                                    source VARCHAR(20) NOT NULL,
+                                    starts_at TIMESTAMPTZ NOT NULL,
+                                    ends_at TIMESTAMPTZ NOT NULL,
+                                    grace_ends_at TIMESTAMPTZ NOT NULL,
+                                    granted_by UUID,
+                                    note TEXT NOT NULL DEFAULT '',
+                                    canceled_at TIMESTAMPTZ,
+
+                                    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                    CONSTRAINT FK_subscription_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                                    CONSTRAINT FK_subscription_plan FOREIGN KEY (plan_id) REFERENCES subscription_plans (plan_id),
+                                    CONSTRAINT FK_subscription_granted_by FOREIGN KEY (granted_by) REFERENCES users (id) ON DELETE SET NULL,
+                                    CONSTRAINT chk_subscription_source CHECK (source IN ('ADMIN_GRANT', 'PURCHASE')),
+                                    CONSTRAINT chk_subscription_period CHECK (ends_at > starts_at AND grace_ends_at >= ends_at)
+);
+CREATE INDEX idx_user_subscriptions_user ON user_subscriptions (user_id, grace_ends_at DESC);
+
+-- ======================
+-- Seed
+-- ======================
+INSERT INTO permissions (name, description)
+VALUES ('subscriptions:manage', 'Manage subscription plans and grant or cancel user subscriptions')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name = 'admin'
+  AND p.name = 'subscriptions:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,6 @@
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS payment_events;
+
+DROP TABLE IF EXISTS payment_orders;
@@ -0,0 +1,45 @@
+-- ========================
+-- PaymentOrders
+-- ========================
+-- A purchase of a subscription plan through a payment provider's checkout page.
+CREATE TABLE payment_orders (
+                                order_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                user_id UUID NOT NULL,
+                                plan_id UUID NOT NULL,
+                                provider VARCHAR(30) NOT NULL,
+                                provider_session_id VARCHAR(255),
+                                amount_cents BIGINT NOT NULL,
+                                currency VARCHAR(3) NOT NULL,
+                                status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+                                failure_reason TEXT NOT NULL DEFAULT '',
+                                subscription_id UUID,
+                                paid_at TIMESTAMPTZ,
+
+                                created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                CONSTRAINT FK_payment_order_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                                CONSTRAINT FK_payment_order_plan FOREIGN KEY (plan_id) REFERENCES subscription_plans (plan_id),
+                                CONSTRAINT FK_payment_order_subscription FOREIGN KEY (subscription_id) REFERENCES user_subscriptions (subscription_id) ON DELETE SET NULL,
+                                CONSTRAINT chk_payment_order_status CHECK (status IN ('PENDING', 'PAID', 'FAILED', 'EXPIRED'))
+);
+CREATE INDEX idx_payment_orders_user ON payment_orders (user_id, created_at DESC);
+CREATE UNIQUE INDEX uq_payment_orders_session ON payment_orders (provider, provider_session_id) WHERE provider_session_id IS NOT NULL;
+
+-- ========================
+-- PaymentEvents
+-- ========================
+-- Every webhook event received, keyed by the provider's event id so that redelivered events are applied once.
+CREATE TABLE payment_events (
+                                provider VARCHAR(30) NOT NULL,
+                                event_id VARCHAR(255) NOT NULL,
+                                event_type VARCHAR(100) NOT NULL,
+                                order_id UUID,
+                                payload JSONB NOT NULL,
+
+                                received_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                PRIMARY KEY (provider, event_id),
+                                FOREIGN KEY (order_id) REFERENCES payment_orders (order_id) ON DELETE SET NULL
+);
+CREATE INDEX idx_payment_events_order ON payment_events (order_id);
@@ -0,0 +1,17 @@
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS content_status_changes;
+
+-- ======================
+-- Columns
+-- ======================
+ALTER TABLE exam_parts
+    DROP CONSTRAINT IF EXISTS chk_part_status,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
+
+ALTER TABLE exams
+    DROP CONSTRAINT IF EXISTS chk_exam_status,
+    DROP COLUMN IF EXISTS published_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,42 @@
+-- ========================
+-- EXAMS / EXAM_PARTS
+-- ========================
+-- Publishing workflow: DRAFT -> IN_REVIEW -> PUBLISHED -> ARCHIVED. Learners only see PUBLISHED content;
+-- the status of an exam part only matters for practice parts, exam parts follow their exam.
+ALTER TABLE exams
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_exam_status CHECK (status IN ('DRAFT', 'IN_REVIEW', 'PUBLISHED', 'ARCHIVED'));
+
+ALTER TABLE exam_parts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_part_status CHECK (status IN ('DRAFT', 'IN_REVIEW', 'PUBLISHED', 'ARCHIVED'));
+
+-- Content existing before the workflow was already live
+UPDATE exams
+SET status       = 'PUBLISHED',
+    published_at = CURRENT_TIMESTAMP;
+
+UPDATE exam_parts
+SET status       = 'PUBLISHED',
+    published_at = CURRENT_TIMESTAMP;
+
+-- ========================
+-- CONTENT_STATUS_CHANGES
+-- ========================
+-- Audit trail of every transition, with who made it and why.
+CREATE TABLE content_status_changes (
+                                        change_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                        content_type VARCHAR(20) NOT NULL,
+                                        content_id UUID NOT NULL,
+                                        from_status VARCHAR(20) NOT NULL,
+                                        to_status VARCHAR(20) NOT NULL,
+                                        changed_by UUID,
+                                        note TEXT NOT NULL DEFAULT '',
+                                        changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                        FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL,
+                                        CONSTRAINT chk_status_change_content_type CHECK (content_type IN ('EXAM', 'PART'))
+);
+CREATE INDEX idx_content_status_changes_content ON content_status_changes (content_type, content_id, changed_at DESC);
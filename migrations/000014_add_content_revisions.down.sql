@@ -0,0 +1,12 @@
+-- ======================
+-- Columns
+-- ======================
+ALTER TABLE attempt_questions
+    DROP COLUMN IF EXISTS paragraph_revision_id,
+    DROP COLUMN IF EXISTS question_revision_id;
+
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS paragraph_revisions;
+DROP TABLE IF EXISTS question_revisions;
@@ -0,0 +1,91 @@
+-- ========================
+-- QUESTION_REVISIONS
+-- ========================
+-- Immutable snapshots of a question, one per change. diff maps every changed field to its old and new value.
+CREATE TABLE question_revisions (
+                                    revision_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    question_id UUID NOT NULL,
+                                    revision_number INT NOT NULL,
+
+                                    question_content TEXT NOT NULL,
+                                    question_type VARCHAR(50) NOT NULL,
+                                    part_id UUID NOT NULL,
+                                    paragraph_id UUID,
+                                    question_order INT NOT NULL,
+                                    audio_url VARCHAR(255),
+                                    image_url VARCHAR(255),
+                                    toeic_question_section VARCHAR(20) NOT NULL,
+                                    question_number_in_part INT,
+                                    answer_option JSON,
+                                    correct_answer TEXT,
+
+                                    diff JSONB NOT NULL DEFAULT '{}',
+                                    restored_from INT, -- revision_number this revision restored, if any
+                                    created_by UUID,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                    UNIQUE (question_id, revision_number),
+                                    FOREIGN KEY (question_id) REFERENCES questions (question_id) ON DELETE CASCADE,
+                                    FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- ========================
+-- PARAGRAPH_REVISIONS
+-- ========================
+CREATE TABLE paragraph_revisions (
+                                     revision_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                     paragraph_id UUID NOT NULL,
+                                     revision_number INT NOT NULL,
+
+                                     paragraph_content TEXT NOT NULL,
+                                     title VARCHAR(255),
+                                     part_id UUID NOT NULL,
+                                     paragraph_order INT NOT NULL,
+                                     paragraph_type VARCHAR(50),
+                                     audio_url VARCHAR(255),
+                                     image_url VARCHAR(255),
+
+                                     diff JSONB NOT NULL DEFAULT '{}',
+                                     restored_from INT,
+                                     created_by UUID,
+                                     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                     UNIQUE (paragraph_id, revision_number),
+                                     FOREIGN KEY (paragraph_id) REFERENCES paragraphs (paragraph_id) ON DELETE CASCADE,
+                                     FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Content existing before revisions starts at revision 1
+INSERT INTO question_revisions (question_id, revision_number, question_content, question_type, part_id, paragraph_id,
+                                question_order, audio_url, image_url, toeic_question_section, question_number_in_part,
+                                answer_option, correct_answer)
+SELECT question_id, 1, question_content, question_type, part_id, paragraph_id,
+       question_order, audio_url, image_url, toeic_question_section, question_number_in_part,
+       answer_option, correct_answer
+FROM questions;
+
+INSERT INTO paragraph_revisions (paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order,
+                                 paragraph_type, audio_url, image_url)
+SELECT paragraph_id, 1, paragraph_content, title, part_id, paragraph_order,
+       paragraph_type, audio_url, image_url
+FROM paragraphs;
+
+-- ========================
+-- ATTEMPT_QUESTIONS
+-- ========================
+-- An attempt is served, reviewed and scored against the revisions current when it started.
+ALTER TABLE attempt_questions
+    ADD COLUMN question_revision_id UUID REFERENCES question_revisions (revision_id),
+    ADD COLUMN paragraph_revision_id UUID REFERENCES paragraph_revisions (revision_id) ON DELETE SET NULL;
+
+UPDATE attempt_questions aq
+SET question_revision_id = qr.revision_id
+FROM question_revisions qr
+WHERE qr.question_id = aq.question_id;
+
+UPDATE attempt_questions aq
+SET paragraph_revision_id = pr.revision_id
+FROM paragraph_revisions pr
+WHERE pr.paragraph_id = aq.paragraph_id;
+
+ALTER TABLE attempt_questions ALTER COLUMN question_revision_id SET NOT NULL;
@@ -0,0 +1,28 @@
+-- ======================
+-- View
+-- ======================
+DROP VIEW IF EXISTS trash_items;
+
+-- ======================
+-- Columns
+-- ======================
+DROP INDEX IF EXISTS idx_questions_deleted_at;
+DROP INDEX IF EXISTS idx_paragraphs_deleted_at;
+DROP INDEX IF EXISTS idx_exam_parts_deleted_at;
+DROP INDEX IF EXISTS idx_exams_deleted_at;
+
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE paragraphs
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE exam_parts
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE exams
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
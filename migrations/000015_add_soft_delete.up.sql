@@ -0,0 +1,54 @@
+-- ========================
+-- EXAMS / EXAM_PARTS / PARAGRAPHS / QUESTIONS
+-- ========================
+-- Deleted content goes to the trash first: it is hidden everywhere but can be restored until the purge
+-- removes it for good. Deleting an item trashes its children with the same deleted_at, which is how a
+-- restore finds the children to bring back.
+ALTER TABLE exams
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE exam_parts
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE paragraphs
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE questions
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_exams_deleted_at ON exams (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_exam_parts_deleted_at ON exam_parts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_paragraphs_deleted_at ON paragraphs (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_questions_deleted_at ON questions (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- ========================
+-- TRASH_ITEMS
+-- ========================
+-- The items deleted on their own; children trashed along with their parent only show through it.
+CREATE VIEW trash_items AS
+SELECT 'EXAM'::varchar AS content_type, e.exam_id AS content_id, e.exam_title::text AS title,
+       NULL::varchar AS parent_type, NULL::uuid AS parent_id, e.deleted_at, e.deleted_by
+FROM exams e
+WHERE e.deleted_at IS NOT NULL
+UNION ALL
+SELECT 'PART', p.part_id, p.part_title, CASE WHEN p.exam_id IS NULL THEN NULL ELSE 'EXAM' END, p.exam_id,
+       p.deleted_at, p.deleted_by
+FROM exam_parts p
+WHERE p.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exams e WHERE e.exam_id = p.exam_id AND e.deleted_at = p.deleted_at)
+UNION ALL
+SELECT 'PARAGRAPH', g.paragraph_id, COALESCE(g.title, ''), 'PART', g.part_id, g.deleted_at, g.deleted_by
+FROM paragraphs g
+WHERE g.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.part_id = g.part_id AND p.deleted_at = g.deleted_at)
+UNION ALL
+SELECT 'QUESTION', q.question_id, q.question_content, CASE WHEN q.paragraph_id IS NULL THEN 'PART' ELSE 'PARAGRAPH' END,
+       COALESCE(q.paragraph_id, q.part_id), q.deleted_at, q.deleted_by
+FROM questions q
+WHERE q.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.part_id = q.part_id AND p.deleted_at = q.deleted_at)
+  AND NOT EXISTS (SELECT 1 FROM paragraphs g WHERE g.paragraph_id = q.paragraph_id AND g.deleted_at = q.deleted_at);
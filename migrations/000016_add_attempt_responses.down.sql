@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS update_attempt_responses_updated_at ON attempt_responses;
+DROP TABLE IF EXISTS attempt_responses;
+
+ALTER TABLE question_revisions
+    DROP COLUMN IF EXISTS response_seconds,
+    DROP COLUMN IF EXISTS preparation_seconds;
+
+ALTER TABLE questions
+    DROP COLUMN IF EXISTS response_seconds,
+    DROP COLUMN IF EXISTS preparation_seconds;
@@ -0,0 +1,49 @@
+-- ========================
+-- QUESTIONS / QUESTION_REVISIONS
+-- ========================
+-- Time windows of a spoken or written response: the learner prepares for preparation_seconds, then
+-- answers within response_seconds. NULL falls back to the TOEIC timing of the question type.
+ALTER TABLE questions
+    ADD COLUMN preparation_seconds INT CHECK (preparation_seconds >= 0),
+    ADD COLUMN response_seconds INT CHECK (response_seconds > 0);
+
+ALTER TABLE question_revisions
+    ADD COLUMN preparation_seconds INT,
+    ADD COLUMN response_seconds INT;
+
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- The spoken or written response to a Speaking or Writing question of an attempt. The row is created
+-- when the learner opens the question, which starts the clock; the windows are copied so that later edits
+-- of the question do not move it.
+CREATE TABLE attempt_responses (
+                                   attempt_id UUID NOT NULL,
+                                   question_id UUID NOT NULL,
+                                   response_type VARCHAR(10) NOT NULL,
+
+                                   started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                   preparation_seconds INT NOT NULL,
+                                   response_seconds INT NOT NULL,
+
+                                   audio_object VARCHAR(255), -- object name in the attempt-responses bucket
+                                   audio_url VARCHAR(255),
+                                   text_response TEXT,
+                                   word_count INT,
+                                   submitted_at TIMESTAMPTZ,
+
+                                   created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                   updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                   PRIMARY KEY (attempt_id, question_id),
+                                   FOREIGN KEY (attempt_id, question_id) REFERENCES attempt_questions (attempt_id, question_id) ON DELETE CASCADE,
+                                   CONSTRAINT chk_response_type CHECK (response_type IN ('AUDIO', 'TEXT'))
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_attempt_responses_updated_at
+    BEFORE UPDATE ON attempt_responses
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,30 @@
+-- ======================
+-- Seed
+-- ======================
+DELETE FROM roles
+WHERE name = 'rater';
+
+DELETE FROM permissions
+WHERE name = 'responses:rate';
+
+-- ======================
+-- Table
+-- ======================
+DROP TABLE IF EXISTS response_ratings;
+
+ALTER TABLE attempt_results
+    DROP COLUMN IF EXISTS speaking_points,
+    DROP COLUMN IF EXISTS speaking_max_points,
+    DROP COLUMN IF EXISTS speaking_pending,
+    DROP COLUMN IF EXISTS speaking_scaled,
+    DROP COLUMN IF EXISTS writing_points,
+    DROP COLUMN IF EXISTS writing_max_points,
+    DROP COLUMN IF EXISTS writing_pending,
+    DROP COLUMN IF EXISTS writing_scaled;
+
+ALTER TABLE attempt_responses
+    DROP CONSTRAINT IF EXISTS chk_rating_status,
+    DROP COLUMN IF EXISTS rating_status,
+    DROP COLUMN IF EXISTS final_score,
+    DROP COLUMN IF EXISTS max_score,
+    DROP COLUMN IF EXISTS rated_at;
@@ -0,0 +1,75 @@
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- A submitted response is PENDING until two raters scored it. Equal scores make it RATED, different
+-- scores send it to ADJUDICATION, where a third rater's score is final. max_score is the top of the
+-- rubric the response was rated against.
+ALTER TABLE attempt_responses
+    ADD COLUMN rating_status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN final_score INT,
+    ADD COLUMN max_score INT,
+    ADD COLUMN rated_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_rating_status CHECK (rating_status IN ('PENDING', 'ADJUDICATION', 'RATED'));
+
+-- ========================
+-- RESPONSE_RATINGS
+-- ========================
+-- One rater's score of a response. The row is created when the response is assigned to the rater and
+-- scored later; rounds 1 and 2 are the double rating, round 3 the adjudication.
+CREATE TABLE response_ratings (
+                                  rating_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                  attempt_id UUID NOT NULL,
+                                  question_id UUID NOT NULL,
+                                  rater_id UUID NOT NULL,
+                                  round INT NOT NULL,
+
+                                  score INT,
+                                  max_score INT, -- top of the rubric, stored with the score
+                                  comment TEXT,
+
+                                  assigned_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  rated_at TIMESTAMPTZ,
+
+                                  FOREIGN KEY (attempt_id, question_id) REFERENCES attempt_responses (attempt_id, question_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (rater_id) REFERENCES users (id) ON DELETE CASCADE,
+                                  CONSTRAINT uq_response_ratings_round UNIQUE (attempt_id, question_id, round),
+                                  CONSTRAINT uq_response_ratings_rater UNIQUE (attempt_id, question_id, rater_id),
+                                  CONSTRAINT chk_rating_round CHECK (round BETWEEN 1 AND 3),
+                                  CONSTRAINT chk_rating_score CHECK (score BETWEEN 0 AND max_score)
+);
+CREATE INDEX idx_response_ratings_rater ON response_ratings (rater_id) WHERE score IS NULL;
+
+-- ========================
+-- ATTEMPT_RESULTS
+-- ========================
+-- Rubric points of the Speaking and Writing responses. The scaled score stays NULL while some responses
+-- of the section still wait for a rating.
+ALTER TABLE attempt_results
+    ADD COLUMN speaking_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_max_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_pending INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_scaled INT,
+    ADD COLUMN writing_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_max_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_pending INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_scaled INT;
+
+-- ======================
+-- Seed
+-- ======================
+INSERT INTO permissions (name, description)
+VALUES ('responses:rate', 'Rate the Speaking and Writing responses of submitted attempts')
+ON CONFLICT (name) DO NOTHING;
+
+-- Grant the rater role with:
+--   INSERT INTO user_roles (user_id, role_id) SELECT '<user id>', id FROM roles WHERE name = 'rater';
+INSERT INTO roles (name, description)
+VALUES ('rater', 'Rate Speaking and Writing responses')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r, permissions p
+WHERE r.name IN ('admin', 'rater')
+  AND p.name = 'responses:rate'
+ON CONFLICT DO NOTHING;
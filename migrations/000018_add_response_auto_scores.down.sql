@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_attempt_responses_auto_score;
+
+ALTER TABLE attempt_responses
+    DROP COLUMN IF EXISTS auto_score,
+    DROP COLUMN IF EXISTS auto_max_score,
+    DROP COLUMN IF EXISTS auto_feedback,
+    DROP COLUMN IF EXISTS auto_scorer,
+    DROP COLUMN IF EXISTS auto_scored_at,
+    DROP COLUMN IF EXISTS auto_score_failures;
@@ -0,0 +1,17 @@
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- Pre-score of an Essay or OpenResponse answer by the automated scorer, shown to raters and learners
+-- next to the human rating. auto_scored_at is set once the scorer is done with the response; the score
+-- stays NULL when the scorer cannot handle it. auto_score_failures counts the calls that failed, so a
+-- response the scorer keeps failing on is eventually left to the raters alone.
+ALTER TABLE attempt_responses
+    ADD COLUMN auto_score INT,
+    ADD COLUMN auto_max_score INT,
+    ADD COLUMN auto_feedback JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN auto_scorer VARCHAR(50),
+    ADD COLUMN auto_scored_at TIMESTAMPTZ,
+    ADD COLUMN auto_score_failures INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_attempt_responses_auto_score ON attempt_responses (submitted_at)
+    WHERE auto_scored_at IS NULL AND submitted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_question_explanations_updated_at ON question_explanations;
+DROP TABLE IF EXISTS question_explanations;
@@ -0,0 +1,28 @@
+-- ========================
+-- QUESTION_EXPLANATIONS
+-- ========================
+-- Why the correct answer is right, and why each option is right or wrong, in one language per row
+-- ('vn', 'eng', as for the transcripts). option_explanations maps an option key of answer_option to
+-- its rationale: {"A": "...", "C": "..."}. Learners only see them when reviewing a submitted attempt.
+CREATE TABLE question_explanations (
+                                       question_id UUID NOT NULL,
+                                       lang VARCHAR(10) NOT NULL,
+                                       explanation TEXT NOT NULL DEFAULT '',
+                                       option_explanations JSONB NOT NULL DEFAULT '{}',
+
+                                       updated_by UUID,
+                                       created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                       updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                       PRIMARY KEY (question_id, lang),
+                                       FOREIGN KEY (question_id) REFERENCES questions (question_id) ON DELETE CASCADE,
+                                       FOREIGN KEY (updated_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_question_explanations_updated_at
+    BEFORE UPDATE ON question_explanations
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
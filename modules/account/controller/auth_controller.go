@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"net/http"
 	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
 	"pirate-lang-go/core/utils"
 	"pirate-lang-go/modules/account/dto"
 	"pirate-lang-go/modules/account/validation"
@@ -23,7 +24,7 @@ func (controller *AccountController) Register(c echo.Context) error {
 		return controller.BadRequest("Invalid request data", resultValidator.Errors)
 	}
 
-	resultCreateAccount, err := controller.accountService.CreateAccount(ctx, requestData)
+	resultCreateAccount, err := controller.accountService.CreateAccount(ctx, requestData, sessionClient(c))
 	if err != nil {
 		return controller.InternalServerError("Internal server error", err)
 	}
@@ -43,32 +44,11 @@ func (controller *AccountController) Login(c echo.Context) error {
 		return controller.BadRequest("Invalid request data", resultValidator.Errors)
 	}
 	ctx := c.Request().Context()
-	resultLogin, err := controller.accountService.Login(ctx, requestData)
+	resultLogin, err := controller.accountService.Login(ctx, requestData, sessionClient(c))
 	if err != nil {
 		return controller.BadRequest("Wrong password", err)
 	}
-	accessCookie := new(http.Cookie)
-	accessCookie.Name = "access_token"
-	accessCookie.Value = resultLogin.AccessToken
-
-	accessCookie.Expires = time.Now().Add(constants.AccessTokenExpiry)
-	accessCookie.HttpOnly = true
-	accessCookie.Secure = false
-	accessCookie.Path = "/"
-	accessCookie.SameSite = http.SameSiteLaxMode
-
-	c.SetCookie(accessCookie)
-
-	refreshCookie := new(http.Cookie)
-	refreshCookie.Name = "refresh_token"
-	refreshCookie.Value = resultLogin.RefreshToken
-	refreshCookie.Expires = time.Now().Add(constants.RefreshTokenExpiry)
-	refreshCookie.HttpOnly = true
-	refreshCookie.Secure = false
-	refreshCookie.Path = "/"
-	refreshCookie.SameSite = http.SameSiteLaxMode
-
-	c.SetCookie(refreshCookie)
+	setAuthCookies(c, resultLogin)
 	return controller.SuccessResponse(c, resultLogin, "Login success")
 }
 func (controller *AccountController) ChangePassword(c echo.Context) error {
@@ -89,16 +69,35 @@ func (controller *AccountController) ChangePassword(c echo.Context) error {
 		return controller.BadRequest("Invalid request data", resultValidator.Errors)
 	}
 
-	err := controller.accountService.ChangePassword(ctx, token, nil)
+	err := controller.accountService.ChangePassword(ctx, token, requestData)
 	if err != nil {
 		return controller.InternalServerError("Internal server error", err)
 	}
 
 	return controller.SuccessResponse(c, nil, "Change password success")
 }
-func (controller *AccountController) RefreshToken(ctx echo.Context) error {
-	// TODO: Implement refresh token
-	return nil
+func (controller *AccountController) RefreshToken(c echo.Context) error {
+	requestData := new(dto.RefreshTokenRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err)
+	}
+	// Browsers send the refresh token as a cookie instead of in the body
+	if requestData.RefreshToken == "" {
+		if cookie, err := c.Cookie("refresh_token"); err == nil {
+			requestData.RefreshToken = cookie.Value
+		}
+	}
+	if requestData.RefreshToken == "" {
+		return controller.BadRequest("Invalid request data", "refresh_token is required")
+	}
+
+	ctx := c.Request().Context()
+	resultRefresh, err := controller.accountService.RefreshToken(ctx, requestData.RefreshToken)
+	if err != nil {
+		return controller.Unauthorized("Invalid refresh token", err)
+	}
+	setAuthCookies(c, resultRefresh)
+	return controller.SuccessResponse(c, resultRefresh, "Refresh token success")
 }
 func (controller *AccountController) Logout(c echo.Context) error {
 	ctx := c.Request().Context()
@@ -136,3 +135,109 @@ func (controller *AccountController) Logout(c echo.Context) error {
 
 	return controller.SuccessResponse(c, nil, "Logout successful")
 }
+
+func (controller *AccountController) ForgotPassword(c echo.Context) error {
+	requestData := new(dto.ForgotPasswordRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err)
+	}
+	resultValidator := validator.ValidateForgotPassword(*requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Invalid request data", resultValidator.Errors)
+	}
+	ctx := c.Request().Context()
+	if err := controller.accountService.ForgotPassword(ctx, requestData); err != nil {
+		if err.Code == errors.ErrLimitExceeded {
+			return controller.BadRequest("Too many reset requests, try again later", err)
+		}
+		return controller.InternalServerError("Error request password reset", err)
+	}
+	return controller.SuccessResponse(c, nil, "If the email is registered, a reset link has been sent")
+}
+
+func (controller *AccountController) ResetPassword(c echo.Context) error {
+	requestData := new(dto.ResetPasswordRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err)
+	}
+	resultValidator := validator.ValidateResetPassword(*requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Invalid request data", resultValidator.Errors)
+	}
+	ctx := c.Request().Context()
+	if err := controller.accountService.ResetPassword(ctx, requestData); err != nil {
+		if err.Code == errors.ErrInvalidInput {
+			return controller.BadRequest("Invalid or expired reset link", err)
+		}
+		return controller.InternalServerError("Error reset password", err)
+	}
+	return controller.SuccessResponse(c, nil, "Reset password success")
+}
+
+func (controller *AccountController) VerifyEmail(c echo.Context) error {
+	requestData := new(dto.VerifyEmailRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err)
+	}
+	if requestData.Token == "" {
+		return controller.BadRequest("Invalid request data", "token is required")
+	}
+	ctx := c.Request().Context()
+	if err := controller.accountService.VerifyEmail(ctx, requestData.Token); err != nil {
+		if err.Code == errors.ErrInvalidInput {
+			return controller.BadRequest("Invalid or expired verification link", err)
+		}
+		return controller.InternalServerError("Error verify email", err)
+	}
+	return controller.SuccessResponse(c, nil, "Verify email successfully")
+}
+
+func (controller *AccountController) ResendVerificationEmail(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	if err := controller.accountService.ResendVerificationEmail(ctx, token); err != nil {
+		switch err.Code {
+		case errors.ErrInvalidState, errors.ErrLimitExceeded:
+			return controller.BadRequest("Cannot resend verification email", err)
+		}
+		return controller.InternalServerError("Error resend verification email", err)
+	}
+	return controller.SuccessResponse(c, nil, "Verification email sent")
+}
+
+// sessionClient captures the device details recorded on a new session.
+func sessionClient(c echo.Context) *dto.SessionClient {
+	return &dto.SessionClient{
+		UserAgent: c.Request().UserAgent(),
+		IPAddress: c.RealIP(),
+	}
+}
+
+// setAuthCookies stores the issued token pair in http-only cookies.
+func setAuthCookies(c echo.Context, tokens *dto.LoginResponse) {
+	accessCookie := new(http.Cookie)
+	accessCookie.Name = "access_token"
+	accessCookie.Value = tokens.AccessToken
+
+	accessCookie.Expires = time.Now().Add(constants.AccessTokenExpiry)
+	accessCookie.HttpOnly = true
+	accessCookie.Secure = false
+	accessCookie.Path = "/"
+	accessCookie.SameSite = http.SameSiteLaxMode
+
+	c.SetCookie(accessCookie)
+
+	refreshCookie := new(http.Cookie)
+	refreshCookie.Name = "refresh_token"
+	refreshCookie.Value = tokens.RefreshToken
+	refreshCookie.Expires = time.Now().Add(constants.RefreshTokenExpiry)
+	refreshCookie.HttpOnly = true
+	refreshCookie.Secure = false
+	refreshCookie.Path = "/"
+	refreshCookie.SameSite = http.SameSiteLaxMode
+
+	c.SetCookie(refreshCookie)
+}
@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/modules/account/dto"
+)
+
+func (controller *AccountController) OAuthLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+	resultLogin, err := controller.accountService.GetOAuthLoginURL(ctx, c.Param("provider"))
+	if err != nil {
+		if err.Code == errors.ErrNotFound {
+			return controller.NotFound("Provider not supported", err)
+		}
+		return controller.InternalServerError("Error start oauth login", err)
+	}
+	return controller.SuccessResponse(c, resultLogin, "Get authorization url successfully")
+}
+
+func (controller *AccountController) OAuthCallback(c echo.Context) error {
+	requestData := new(dto.OAuthCallbackRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err)
+	}
+	if requestData.Error == "" && (requestData.Code == "" || requestData.State == "") {
+		return controller.BadRequest("Invalid request data", "code and state are required")
+	}
+
+	ctx := c.Request().Context()
+	resultLogin, err := controller.accountService.OAuthCallback(ctx, c.Param("provider"), requestData, sessionClient(c))
+	if err != nil {
+		switch err.Code {
+		case errors.ErrNotFound:
+			return controller.NotFound("Provider not supported", err)
+		case errors.ErrInvalidInput:
+			return controller.BadRequest("Invalid oauth state", err)
+		case errors.ErrUnauthorized, errors.ErrResourceLocked:
+			return controller.Unauthorized("OAuth login failed", err)
+		}
+		return controller.InternalServerError("OAuth login failed", err)
+	}
+	setAuthCookies(c, resultLogin)
+	return controller.SuccessResponse(c, resultLogin, "Login success")
+}
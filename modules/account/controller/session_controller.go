@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+)
+
+func (controller *AccountController) GetSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	sessions, err := controller.accountService.GetSessions(ctx, token)
+	if err != nil {
+		return controller.InternalServerError("Error get sessions", err)
+	}
+	return controller.SuccessResponse(c, sessions, "Get sessions successfully")
+}
+
+func (controller *AccountController) RevokeSession(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	sessionID, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid session ID format", err)
+	}
+	appErr := controller.accountService.RevokeSession(ctx, token, sessionID)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Session not found", appErr)
+		}
+		return controller.InternalServerError("Error revoke session", appErr)
+	}
+	return controller.SuccessResponse(c, nil, "Revoke session successfully")
+}
+
+func (controller *AccountController) RevokeAllSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	err := controller.accountService.RevokeAllSessions(ctx, token)
+	if err != nil {
+		return controller.InternalServerError("Error revoke sessions", err)
+	}
+	return controller.SuccessResponse(c, nil, "Revoke sessions successfully")
+}
+
+func (controller *AccountController) GetUserSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return controller.BadRequest("Invalid user ID format", err)
+	}
+	sessions, appErr := controller.accountService.GetUserSessions(ctx, userID)
+	if appErr != nil {
+		return controller.InternalServerError("Error get sessions", appErr)
+	}
+	return controller.SuccessResponse(c, sessions, "Get sessions successfully")
+}
+
+func (controller *AccountController) RevokeUserSessions(c echo.Context) error {
+	ctx := c.Request().Context()
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return controller.BadRequest("Invalid user ID format", err)
+	}
+	appErr := controller.accountService.RevokeUserSessions(ctx, userID)
+	if appErr != nil {
+		return controller.InternalServerError("Error revoke sessions", appErr)
+	}
+	return controller.SuccessResponse(c, nil, "Revoke sessions successfully")
+}
@@ -8,18 +8,19 @@ import (
 )
 
 type User struct {
-	ID            uuid.UUID  `db:"id"`
-	UserName      string     `db:"user_name"`
-	Email         string     `db:"email"`
-	Password      string     `db:"password"`
-	IsSocialLogin bool       `db:"is_social_login"`
-	IsLocked      bool       `db:"is_locked"`
-	LockedAt      *time.Time `db:"locked_at"`
-	LockReason    string     `db:"lock_reason"`
-	UnlockedAt    *time.Time `db:"unlocked_at"`
-	UnlockReason  string     `db:"unlock_reason"`
-	CreatedAt     time.Time  `db:"created_at"`
-	UpdatedAt     time.Time  `db:"updated_at"`
+	ID              uuid.UUID  `db:"id"`
+	UserName        string     `db:"user_name"`
+	Email           string     `db:"email"`
+	Password        string     `db:"password"`
+	IsSocialLogin   bool       `db:"is_social_login"`
+	IsLocked        bool       `db:"is_locked"`
+	LockedAt        *time.Time `db:"locked_at"`
+	LockReason      string     `db:"lock_reason"`
+	UnlockedAt      *time.Time `db:"unlocked_at"`
+	UnlockReason    string     `db:"unlock_reason"`
+	EmailVerifiedAt *time.Time `db:"email_verified_at"`
+	CreatedAt       time.Time  `db:"created_at"`
+	UpdatedAt       time.Time  `db:"updated_at"`
 }
 
 type PaginatedUsers = entity.Pagination[*User]
@@ -62,3 +63,40 @@ type UserRole struct {
 	UserId uuid.UUID `db:"user_id"`
 	RoleId uuid.UUID `db:"role_id"`
 }
+
+type UserSession struct {
+	SessionId    uuid.UUID  `db:"session_id"`
+	UserId       uuid.UUID  `db:"user_id"`
+	ExpiresAt    time.Time  `db:"expires_at"`
+	LastUsedAt   time.Time  `db:"last_used_at"`
+	RevokedAt    *time.Time `db:"revoked_at"`
+	RevokeReason string     `db:"revoke_reason"`
+	UserAgent    string     `db:"user_agent"`
+	IPAddress    string     `db:"ip_address"`
+	CreatedAt    time.Time  `db:"created_at"`
+}
+
+// RefreshToken is a refresh token issued for a session, identified by its jti.
+type RefreshToken struct {
+	TokenId   uuid.UUID  `db:"token_id"`
+	SessionId uuid.UUID  `db:"session_id"`
+	UserId    uuid.UUID  `db:"user_id"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"` // revocation time of the owning session
+}
+
+// UserProvider links an identity at an OAuth provider to a local user.
+type UserProvider struct {
+	Id             uuid.UUID `db:"id"`
+	UserId         uuid.UUID `db:"user_id"`
+	Provider       string    `db:"provider"`
+	ProviderUserId string    `db:"provider_user_id"`
+	Email          string    `db:"email"`
+	AccessToken    string    `db:"access_token"`
+	RefreshToken   string    `db:"refresh_token"`
+	AvatarUrl      string    `db:"avatar_url"`
+	FullName       string    `db:"full_name"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
@@ -152,3 +152,19 @@ func ToProfileResponse(profile *entity.UserProfile, user *entity.User, url strin
 	}
 	return &response
 }
+
+func ToSessionResponses(sessions []*entity.UserSession, currentSessionId uuid.UUID) []*dto.SessionResponse {
+	responses := make([]*dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, &dto.SessionResponse{
+			Id:         session.SessionId,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.SessionId == currentSessionId,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			CreatedAt:  session.CreatedAt,
+		})
+	}
+	return responses
+}
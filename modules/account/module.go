@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"pirate-lang-go/core/cache"
 	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/mailer"
 	"pirate-lang-go/core/middleware"
 	"pirate-lang-go/core/storage"
 	"pirate-lang-go/modules/account/controller"
@@ -12,9 +13,9 @@ import (
 	"pirate-lang-go/modules/account/service"
 )
 
-func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage) {
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, mailer mailer.IMailer) {
 	repository := repository.NewAccountRepository(db.DB())
-	accountService := service.NewAccountService(repository, cache, storage)
+	accountService := service.NewAccountService(repository, cache, storage, mailer)
 	middleware := middleware.NewMiddleware(accountService)
 	// Update: pass only the controller
 	router.NewAccountRouter(
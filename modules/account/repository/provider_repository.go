@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/account/entity"
+)
+
+func (r *AccountRepository) GetUserProvider(ctx context.Context, provider, providerUserID string) (*entity.UserProvider, error) {
+	row, err := r.Queries.GetUserProvider(ctx, database.GetUserProviderParams{
+		Provider:       provider,
+		ProviderUserID: providerUserID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AccountRepository:GetUserProvider:Error when get user provider", "provider", provider, "error", err)
+		return nil, err
+	}
+	return &entity.UserProvider{
+		Id:             row.ID,
+		UserId:         row.UserID,
+		Provider:       row.Provider,
+		ProviderUserId: row.ProviderUserID,
+		Email:          row.Email.String,
+		AccessToken:    row.AccessToken.String,
+		RefreshToken:   row.RefreshToken.String,
+		AvatarUrl:      row.AvatarUrl.String,
+		FullName:       row.FullName.String,
+		CreatedAt:      row.CreatedAt.Time,
+		UpdatedAt:      row.UpdatedAt.Time,
+	}, nil
+}
+
+func (r *AccountRepository) CreateUserProvider(ctx context.Context, userProvider *entity.UserProvider) error {
+	err := r.Queries.CreateUserProvider(ctx, toCreateUserProviderParams(userProvider))
+	if err != nil {
+		logger.Error("AccountRepository:CreateUserProvider:Error when create user provider", "provider", userProvider.Provider, "user_id", userProvider.UserId, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *AccountRepository) UpdateUserProvider(ctx context.Context, userProvider *entity.UserProvider) error {
+	err := r.Queries.UpdateUserProvider(ctx, database.UpdateUserProviderParams{
+		Provider:       userProvider.Provider,
+		ProviderUserID: userProvider.ProviderUserId,
+		Email:          sql.NullString{String: userProvider.Email, Valid: true},
+		AccessToken:    sql.NullString{String: userProvider.AccessToken, Valid: true},
+		RefreshToken:   sql.NullString{String: userProvider.RefreshToken, Valid: true},
+		AvatarUrl:      sql.NullString{String: userProvider.AvatarUrl, Valid: true},
+		FullName:       sql.NullString{String: userProvider.FullName, Valid: true},
+	})
+	if err != nil {
+		logger.Error("AccountRepository:UpdateUserProvider:Error when update user provider", "provider", userProvider.Provider, "error", err)
+		return err
+	}
+	return nil
+}
+
+// CreateSocialAccount creates the user, its profile and the provider link in one transaction.
+func (r *AccountRepository) CreateSocialAccount(ctx context.Context, user *entity.User, userProvider *entity.UserProvider) (*entity.User, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AccountRepository:CreateSocialAccount:Error when begin transaction", "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	dbUser, err := qtx.CreateSocialAccount(ctx, database.CreateSocialAccountParams{
+		UserName: user.UserName,
+		Email:    user.Email,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:CreateSocialAccount:Error when create user", "email", user.Email, "error", err)
+		return nil, err
+	}
+	err = qtx.CreateSocialUserProfile(ctx, database.CreateSocialUserProfileParams{
+		UserID:   dbUser.ID,
+		FullName: sql.NullString{String: userProvider.FullName, Valid: true},
+	})
+	if err != nil {
+		logger.Error("AccountRepository:CreateSocialAccount:Error when create profile", "user_id", dbUser.ID, "error", err)
+		return nil, err
+	}
+	userProvider.UserId = dbUser.ID
+	if err = qtx.CreateUserProvider(ctx, toCreateUserProviderParams(userProvider)); err != nil {
+		logger.Error("AccountRepository:CreateSocialAccount:Error when create user provider", "user_id", dbUser.ID, "error", err)
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AccountRepository:CreateSocialAccount:Error when commit transaction", "user_id", dbUser.ID, "error", err)
+		return nil, err
+	}
+	return &entity.User{
+		ID:            dbUser.ID,
+		UserName:      dbUser.UserName,
+		Email:         dbUser.Email,
+		IsSocialLogin: true,
+	}, nil
+}
+
+func toCreateUserProviderParams(userProvider *entity.UserProvider) database.CreateUserProviderParams {
+	return database.CreateUserProviderParams{
+		UserID:         userProvider.UserId,
+		Provider:       userProvider.Provider,
+		ProviderUserID: userProvider.ProviderUserId,
+		Email:          sql.NullString{String: userProvider.Email, Valid: true},
+		AccessToken:    sql.NullString{String: userProvider.AccessToken, Valid: true},
+		RefreshToken:   sql.NullString{String: userProvider.RefreshToken, Valid: true},
+		AvatarUrl:      sql.NullString{String: userProvider.AvatarUrl, Valid: true},
+		FullName:       sql.NullString{String: userProvider.FullName, Valid: true},
+	}
+}
@@ -151,3 +151,21 @@ func (r *AccountRepository) HasPermission(ctx context.Context, userID uuid.UUID,
 	}
 	return exists, nil
 }
+
+func (r *AccountRepository) GetUserPermissionNames(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	names, err := r.Queries.GetUserPermissionNames(ctx, userID)
+	if err != nil {
+		logger.Error("AccountRepository:GetUserPermissionNames:", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return names, nil
+}
+
+func (r *AccountRepository) GetUserIdsByRole(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error) {
+	userIDs, err := r.Queries.GetUserIdsByRole(ctx, roleID)
+	if err != nil {
+		logger.Error("AccountRepository:GetUserIdsByRole:", "role_id", roleID, "error", err)
+		return nil, err
+	}
+	return userIDs, nil
+}
@@ -47,9 +47,13 @@ func (r *AccountRepository) GetUserByEmailOrUserNameOrId(ctx context.Context, em
 		UserName:  dbUser.UserName,
 		Email:     dbUser.Email,
 		Password:  dbUser.Password,
+		IsLocked:  dbUser.IsLocked.Bool,
 		CreatedAt: dbUser.CreatedAt.Time,
 		UpdatedAt: dbUser.UpdatedAt.Time,
 	}
+	if dbUser.EmailVerifiedAt.Valid {
+		user.EmailVerifiedAt = &dbUser.EmailVerifiedAt.Time
+	}
 	return user, nil
 }
 
@@ -120,6 +124,7 @@ func (r *AccountRepository) UpdatePassword(ctx context.Context, user *entity.Use
 
 	params := database.UpdatePasswordParams{
 		Password: user.Password,
+		ID:       user.ID,
 	}
 	result, err := r.Queries.UpdatePassword(ctx, params)
 	if err != nil {
@@ -171,3 +176,12 @@ func (r *AccountRepository) UnlockUser(ctx context.Context, userId uuid.UUID, un
 	}
 	return nil
 }
+
+func (r *AccountRepository) MarkEmailVerified(ctx context.Context, userId uuid.UUID) (bool, error) {
+	rows, err := r.Queries.MarkEmailVerified(ctx, userId)
+	if err != nil {
+		logger.Error("AccountRepository:MarkEmailVerified:Error when mark email verified", "user_id", userId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
@@ -10,11 +10,13 @@ import (
 
 type AccountRepository struct {
 	Queries *database.Queries
+	db      *sql.DB
 }
 
 func NewAccountRepository(sqlDB *sql.DB) IAccountRepository {
 	return &AccountRepository{
 		Queries: database.New(sqlDB),
+		db:      sqlDB,
 	}
 }
 
@@ -22,6 +24,7 @@ type IAccountRepository interface {
 	GetUserByEmailOrUserNameOrId(ctx context.Context, email, userName string, userId uuid.UUID) (*entity.User, error)
 	CreateAccount(ctx context.Context, user *entity.User) (*entity.User, error)
 	UpdatePassword(ctx context.Context, user *entity.User) error
+	MarkEmailVerified(ctx context.Context, userId uuid.UUID) (bool, error)
 	GetUsers(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedUsers, error)
 	LockUser(ctx context.Context, userId uuid.UUID, lockReason string) error
 	UnlockUser(ctx context.Context, userId uuid.UUID, unlockReason string) error
@@ -43,4 +46,19 @@ type IAccountRepository interface {
 	HasPermission(ctx context.Context, userID uuid.UUID, permissionID uuid.UUID) (bool, error)
 	DeleteRole(ctx context.Context, roleID uuid.UUID) error
 	DeletePermission(ctx context.Context, permissionID uuid.UUID) error
+	GetUserPermissionNames(ctx context.Context, userID uuid.UUID) ([]string, error)
+	GetUserIdsByRole(ctx context.Context, roleID uuid.UUID) ([]uuid.UUID, error)
+	// Session
+	CreateSession(ctx context.Context, session *entity.UserSession, refreshToken *entity.RefreshToken) error
+	GetRefreshToken(ctx context.Context, tokenID uuid.UUID) (*entity.RefreshToken, error)
+	RotateRefreshToken(ctx context.Context, usedTokenID uuid.UUID, next *entity.RefreshToken) (bool, error)
+	RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) (bool, error)
+	GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*entity.UserSession, error)
+	RevokeSessionOfUser(ctx context.Context, sessionID, userID uuid.UUID, reason string) (bool, error)
+	RevokeAllSessions(ctx context.Context, userID uuid.UUID, reason string) ([]uuid.UUID, error)
+	// Providers
+	GetUserProvider(ctx context.Context, provider, providerUserID string) (*entity.UserProvider, error)
+	CreateUserProvider(ctx context.Context, userProvider *entity.UserProvider) error
+	UpdateUserProvider(ctx context.Context, userProvider *entity.UserProvider) error
+	CreateSocialAccount(ctx context.Context, user *entity.User, userProvider *entity.UserProvider) (*entity.User, error)
 }
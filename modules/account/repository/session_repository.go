@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/account/entity"
+)
+
+func (r *AccountRepository) CreateSession(ctx context.Context, session *entity.UserSession, refreshToken *entity.RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AccountRepository:CreateSession:Error when begin transaction", "error", err)
+		return err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	_, err = qtx.CreateUserSession(ctx, database.CreateUserSessionParams{
+		SessionID: session.SessionId,
+		UserID:    session.UserId,
+		ExpiresAt: session.ExpiresAt,
+		UserAgent: session.UserAgent,
+		IpAddress: session.IPAddress,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:CreateSession:Error when create session", "user_id", session.UserId, "error", err)
+		return err
+	}
+	err = qtx.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		TokenID:   refreshToken.TokenId,
+		SessionID: session.SessionId,
+		ExpiresAt: refreshToken.ExpiresAt,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:CreateSession:Error when create refresh token", "session_id", session.SessionId, "error", err)
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AccountRepository:CreateSession:Error when commit transaction", "session_id", session.SessionId, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *AccountRepository) GetRefreshToken(ctx context.Context, tokenID uuid.UUID) (*entity.RefreshToken, error) {
+	row, err := r.Queries.GetRefreshToken(ctx, tokenID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AccountRepository:GetRefreshToken:Error when get refresh token", "token_id", tokenID, "error", err)
+		return nil, err
+	}
+	refreshToken := &entity.RefreshToken{
+		TokenId:   row.TokenID,
+		SessionId: row.SessionID,
+		UserId:    row.UserID,
+		ExpiresAt: row.ExpiresAt,
+	}
+	if row.UsedAt.Valid {
+		refreshToken.UsedAt = &row.UsedAt.Time
+	}
+	if row.RevokedAt.Valid {
+		refreshToken.RevokedAt = &row.RevokedAt.Time
+	}
+	return refreshToken, nil
+}
+
+// RotateRefreshToken consumes usedTokenID and issues next in the same session.
+// It returns false when usedTokenID had already been consumed.
+func (r *AccountRepository) RotateRefreshToken(ctx context.Context, usedTokenID uuid.UUID, next *entity.RefreshToken) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AccountRepository:RotateRefreshToken:Error when begin transaction", "error", err)
+		return false, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	rows, err := qtx.UseRefreshToken(ctx, usedTokenID)
+	if err != nil {
+		logger.Error("AccountRepository:RotateRefreshToken:Error when use refresh token", "token_id", usedTokenID, "error", err)
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	err = qtx.CreateRefreshToken(ctx, database.CreateRefreshTokenParams{
+		TokenID:   next.TokenId,
+		SessionID: next.SessionId,
+		ExpiresAt: next.ExpiresAt,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:RotateRefreshToken:Error when create refresh token", "session_id", next.SessionId, "error", err)
+		return false, err
+	}
+	err = qtx.TouchUserSession(ctx, database.TouchUserSessionParams{
+		SessionID: next.SessionId,
+		ExpiresAt: next.ExpiresAt,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:RotateRefreshToken:Error when touch session", "session_id", next.SessionId, "error", err)
+		return false, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AccountRepository:RotateRefreshToken:Error when commit transaction", "session_id", next.SessionId, "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *AccountRepository) RevokeSession(ctx context.Context, sessionID uuid.UUID, reason string) (bool, error) {
+	rows, err := r.Queries.RevokeUserSession(ctx, database.RevokeUserSessionParams{
+		SessionID:    sessionID,
+		RevokeReason: reason,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:RevokeSession:Error when revoke session", "session_id", sessionID, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *AccountRepository) GetActiveSessions(ctx context.Context, userID uuid.UUID) ([]*entity.UserSession, error) {
+	rows, err := r.Queries.ListActiveUserSessions(ctx, userID)
+	if err != nil {
+		logger.Error("AccountRepository:GetActiveSessions:Error when list sessions", "user_id", userID, "error", err)
+		return nil, err
+	}
+	sessions := make([]*entity.UserSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, &entity.UserSession{
+			SessionId:    row.SessionID,
+			UserId:       row.UserID,
+			ExpiresAt:    row.ExpiresAt,
+			LastUsedAt:   row.LastUsedAt,
+			RevokeReason: row.RevokeReason,
+			UserAgent:    row.UserAgent,
+			IPAddress:    row.IpAddress,
+			CreatedAt:    row.CreatedAt.Time,
+		})
+	}
+	return sessions, nil
+}
+
+func (r *AccountRepository) RevokeSessionOfUser(ctx context.Context, sessionID, userID uuid.UUID, reason string) (bool, error) {
+	rows, err := r.Queries.RevokeUserSessionOfUser(ctx, database.RevokeUserSessionOfUserParams{
+		SessionID:    sessionID,
+		UserID:       userID,
+		RevokeReason: reason,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:RevokeSessionOfUser:Error when revoke session", "session_id", sessionID, "user_id", userID, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *AccountRepository) RevokeAllSessions(ctx context.Context, userID uuid.UUID, reason string) ([]uuid.UUID, error) {
+	sessionIDs, err := r.Queries.RevokeAllUserSessions(ctx, database.RevokeAllUserSessionsParams{
+		UserID:       userID,
+		RevokeReason: reason,
+	})
+	if err != nil {
+		logger.Error("AccountRepository:RevokeAllSessions:Error when revoke sessions", "user_id", userID, "error", err)
+		return nil, err
+	}
+	return sessionIDs, nil
+}
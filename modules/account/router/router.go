@@ -2,6 +2,7 @@ package router
 
 import (
 	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/constants"
 	"pirate-lang-go/core/middleware"
 	"pirate-lang-go/modules/account/controller"
 )
@@ -25,30 +26,45 @@ func (r *AccountRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
 	auth.POST("/register", r.controller.Register)
 	auth.POST("/login", r.controller.Login)
 	auth.POST("/refresh-token", r.controller.RefreshToken)
+	auth.POST("/forgot-password", r.controller.ForgotPassword)
+	auth.POST("/reset-password", r.controller.ResetPassword)
+	auth.GET("/verify-email", r.controller.VerifyEmail)
+	auth.POST("/verify-email", r.controller.VerifyEmail)
+	auth.GET("/oauth/:provider/login", r.controller.OAuthLogin)
+	auth.GET("/oauth/:provider/callback", r.controller.OAuthCallback)
 	// User routes - requires authentication
 	user := accounts.Group("")
 	user.Use(middleware.AuthMiddleware())
 	user.POST("/logout", r.controller.Logout)
 	user.PUT("/change-password", r.controller.ChangePassword)
+	user.POST("/verify-email/resend", r.controller.ResendVerificationEmail)
 	user.GET("/profile", r.controller.GetProfile)
 	user.POST("/profile", r.controller.CreateProfiles)
 	user.PUT("/profile", r.controller.UpdateProfiles)
 	user.POST("/profile/avatar", r.controller.UpdateAvatar)
+	user.GET("/sessions", r.controller.GetSessions)
+	user.DELETE("/sessions", r.controller.RevokeAllSessions)
+	user.DELETE("/sessions/:sessionId", r.controller.RevokeSession)
 	// Admin routes
 	admin := v1.Group("/admin")
 	admin.Use(middleware.AuthMiddleware())
 	// User management routes
 	users := admin.Group("/users")
-	users.GET("", r.controller.GetUsers)
-	users.GET("/:userId/profile", r.controller.GetDetailUser)
-	users.POST("/:userId/lock", r.controller.LockUser)
-	users.POST("/:userId/unlock", r.controller.UnlockUser)
+	canReadUsers := middleware.AnyPermissionMiddleware(constants.PermissionUsersRead, constants.PermissionUsersManage)
+	canManageUsers := middleware.PermissionMiddleware(constants.PermissionUsersManage)
+	users.GET("", r.controller.GetUsers, canReadUsers)
+	users.GET("/:userId/profile", r.controller.GetDetailUser, canReadUsers)
+	users.POST("/:userId/lock", r.controller.LockUser, canManageUsers)
+	users.POST("/:userId/unlock", r.controller.UnlockUser, canManageUsers)
+	users.GET("/:userId/sessions", r.controller.GetUserSessions, canReadUsers)
+	users.DELETE("/:userId/sessions", r.controller.RevokeUserSessions, canManageUsers)
 
 	test := v1.Group("/test")
 	test.GET("/hello", r.controller.HelloWorld)
 
 	// RBAC management routes
 	rbac := admin.Group("/rbac")
+	rbac.Use(middleware.PermissionMiddleware(constants.PermissionRbacManage))
 	rbac.GET("/roles", r.controller.GetRoles)
 	rbac.POST("/roles", r.controller.CreateRole)
 	rbac.GET("/permissions", r.controller.GetPermissions)
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"github.com/google/uuid"
+	"pirate-lang-go/core/constants"
 	"pirate-lang-go/core/errors"
 	"pirate-lang-go/core/logger"
 	"pirate-lang-go/core/utils"
@@ -31,6 +32,11 @@ func (s *AccountService) LockUser(ctx context.Context, requestData *dto.LockUser
 		logger.Error("AccountService:LockUser:Failed to lock user", "error", err)
 		return errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:user is already locked", err)
 	}
+	// Sign the user out everywhere so the lock takes effect immediately
+	if err = s.closeAllSessions(ctx, userId, constants.SessionRevokedUserLocked); err != nil {
+		logger.Error("AccountService:LockUser:Failed to revoke sessions", "user_id", userId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:LockUser:Failed to revoke sessions", err)
+	}
 	return nil
 }
 func (s *AccountService) UnlockUser(ctx context.Context, requestData *dto.UnlockUserRequest, userId uuid.UUID) *errors.AppError {
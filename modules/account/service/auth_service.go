@@ -13,7 +13,7 @@ import (
 	"time"
 )
 
-func (s *AccountService) CreateAccount(ctx context.Context, requestData *dto.CreateAccountRequest) (*dto.CreateAccountResponse, *errors.AppError) {
+func (s *AccountService) CreateAccount(ctx context.Context, requestData *dto.CreateAccountRequest, client *dto.SessionClient) (*dto.CreateAccountResponse, *errors.AppError) {
 
 	existingUser, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, requestData.Email, requestData.Username, uuid.Nil)
 	if err != nil {
@@ -43,18 +43,16 @@ func (s *AccountService) CreateAccount(ctx context.Context, requestData *dto.Cre
 		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:username or email already exists", err)
 	}
 
-	// Generate access token (expires in 1 day)
-	accessToken, err := utils.GenerateToken(createdUser.ID, createdUser.Email, createdUser.UserName, constants.AccessTokenExpiry)
+	// Open a session and issue its access and refresh tokens
+	accessToken, refreshToken, err := s.startSession(ctx, createdUser, client)
 	if err != nil {
-		logger.Error("AccountService:CreateAccount:Failed to generate access token", "error", err)
-		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:username or email already exists", err)
+		logger.Error("AccountService:CreateAccount:Failed to start session", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:CreateAccount:Failed to start session", err)
 	}
 
-	// Generate refresh token (expires in 7 days)
-	refreshToken, err := utils.GenerateToken(createdUser.ID, createdUser.Email, createdUser.UserName, constants.RefreshTokenExpiry)
-	if err != nil {
-		logger.Error("AccountService:CreateAccount:Failed to generate refresh token", "error", err)
-		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:username or email already exists", err)
+	// A failed email must not fail the registration; the user can ask for a new link
+	if err = s.sendVerificationEmail(ctx, createdUser); err != nil {
+		logger.Error("AccountService:CreateAccount:Failed to send verification email", "user_id", createdUser.ID, "error", err)
 	}
 
 	// Prepare response
@@ -67,7 +65,7 @@ func (s *AccountService) CreateAccount(ctx context.Context, requestData *dto.Cre
 
 	return response, nil
 }
-func (s *AccountService) Login(ctx context.Context, requestData *dto.LoginRequest) (*dto.LoginResponse, *errors.AppError) {
+func (s *AccountService) Login(ctx context.Context, requestData *dto.LoginRequest, client *dto.SessionClient) (*dto.LoginResponse, *errors.AppError) {
 
 	// Check rate limiting first
 	rateKey := fmt.Sprintf("login_rate:%s", requestData.Email)
@@ -108,17 +106,11 @@ func (s *AccountService) Login(ctx context.Context, requestData *dto.LoginReques
 		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:Wrong password", err)
 	}
 
-	// Generate access token (expires in 1 day)
-	accessToken, err := utils.GenerateToken(existingUser.ID, existingUser.Email, existingUser.UserName, 24*time.Duration(time.Hour))
-	if err != nil {
-		logger.Error("AccountService:Login:Failed to generate access token", "error", err)
-		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:cannot connect redis generate", err)
-	}
-	// Generate refresh token (expires in 7 days)
-	refreshToken, err := utils.GenerateToken(existingUser.ID, existingUser.Email, existingUser.UserName, 7*24*time.Duration(time.Hour))
+	// Open a session and issue its access and refresh tokens
+	accessToken, refreshToken, err := s.startSession(ctx, existingUser, client)
 	if err != nil {
-		logger.Error("AccountService:Login:Failed to generate refresh token", "error", err)
-		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:cannot connect redis refresh", err)
+		logger.Error("AccountService:Login:Failed to start session", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:Login:Failed to start session", err)
 	}
 	// Prepare response
 	response := &dto.LoginResponse{
@@ -130,19 +122,28 @@ func (s *AccountService) Login(ctx context.Context, requestData *dto.LoginReques
 }
 func (s *AccountService) Logout(ctx context.Context, token string) *errors.AppError {
 	// Add token to blacklist with expiry matching the token's expiry
-	claims, err := utils.ValidateAndParseToken(token)
+	claims, err := utils.ValidateToken(token)
 	if err != nil {
 		logger.Error("AccountService:Logout:Failed to validate token", "error", err)
-		return errors.NewAppError(errors.ErrAlreadyExists, "AccountService:CreateAccount:username or email already exists", err)
+		return errors.NewAppError(errors.ErrUnauthorized, "AccountService:Logout:Invalid token", err)
 	}
 	// Calculate remaining time until token expiry
-	expiryTime := time.Until(time.Unix(claims.ExpiresAt.Unix(), 0)).Seconds()
+	expiryTime := time.Until(claims.ExpiresAt.Time)
 	if expiryTime <= 0 {
 		return nil // Token already expired
 	}
 
-	// TODO: Add to blacklist
-	//s.cache.AddToBlacklist(ctx, token, time.Duration(expiryTime)*time.Second)
+	if err = s.cache.AddToBlacklist(ctx, claims.ID, expiryTime); err != nil {
+		logger.Error("AccountService:Logout:Failed to blacklist token", "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:Logout:Failed to blacklist token", err)
+	}
+	// Revoke the session so its refresh tokens stop working as well
+	if claims.SessionID != uuid.Nil {
+		if err = s.closeSession(ctx, claims.SessionID, constants.SessionRevokedLogout); err != nil {
+			logger.Error("AccountService:Logout:Failed to revoke session", "session_id", claims.SessionID, "error", err)
+			return errors.NewAppError(errors.ErrInternal, "AccountService:Logout:Failed to revoke session", err)
+		}
+	}
 	return nil
 }
 func (s *AccountService) ChangePassword(ctx context.Context, token string, requestData *dto.ChangePasswordRequest) *errors.AppError {
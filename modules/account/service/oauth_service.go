@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/oauth"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/dto"
+	"pirate-lang-go/modules/account/entity"
+	"strings"
+	"time"
+)
+
+// oauthState is what the login step remembers for the callback step.
+type oauthState struct {
+	Provider     string `json:"provider"`
+	CodeVerifier string `json:"code_verifier"`
+}
+
+func (s *AccountService) GetOAuthLoginURL(ctx context.Context, providerName string) (*dto.OAuthLoginResponse, *errors.AppError) {
+	provider, err := s.oauthProviders.Get(providerName)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AccountService:GetOAuthLoginURL:Unknown provider", err)
+	}
+
+	state, err := oauth.GenerateState()
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:GetOAuthLoginURL:Failed to generate state", err)
+	}
+	codeVerifier, err := oauth.GenerateCodeVerifier()
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:GetOAuthLoginURL:Failed to generate code verifier", err)
+	}
+	payload, err := json.Marshal(oauthState{Provider: provider.Name(), CodeVerifier: codeVerifier})
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:GetOAuthLoginURL:Failed to encode state", err)
+	}
+	if err = s.cache.Set(ctx, fmt.Sprintf(constants.OAuthStateKey, state), payload, constants.OAuthStateExpiry); err != nil {
+		logger.Error("AccountService:GetOAuthLoginURL:Failed to store state", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:GetOAuthLoginURL:Failed to store state", err)
+	}
+
+	return &dto.OAuthLoginResponse{
+		AuthorizationURL: provider.AuthCodeURL(state, oauth.CodeChallengeS256(codeVerifier)),
+		State:            state,
+	}, nil
+}
+
+func (s *AccountService) OAuthCallback(ctx context.Context, providerName string, requestData *dto.OAuthCallbackRequest, client *dto.SessionClient) (*dto.LoginResponse, *errors.AppError) {
+	provider, err := s.oauthProviders.Get(providerName)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AccountService:OAuthCallback:Unknown provider", err)
+	}
+	if requestData.Error != "" {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:OAuthCallback:Provider denied login: "+requestData.Error, nil)
+	}
+
+	state, appErr := s.consumeOAuthState(ctx, requestData.State)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if state.Provider != provider.Name() {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AccountService:OAuthCallback:State issued for another provider", nil)
+	}
+
+	ctx, cancel := utils.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	token, err := provider.Exchange(ctx, requestData.Code, state.CodeVerifier)
+	if err != nil {
+		logger.Error("AccountService:OAuthCallback:Failed to exchange code", "provider", provider.Name(), "error", err)
+		return nil, errors.NewAppError(errors.ErrThirdParty, "AccountService:OAuthCallback:Failed to exchange code", err)
+	}
+	info, err := provider.UserInfo(ctx, token)
+	if err != nil {
+		logger.Error("AccountService:OAuthCallback:Failed to get user info", "provider", provider.Name(), "error", err)
+		return nil, errors.NewAppError(errors.ErrThirdParty, "AccountService:OAuthCallback:Failed to get user info", err)
+	}
+
+	user, appErr := s.resolveOAuthUser(ctx, provider.Name(), token, info)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if user.IsLocked {
+		return nil, errors.NewAppError(errors.ErrResourceLocked, "AccountService:OAuthCallback:User is locked", nil)
+	}
+
+	accessToken, refreshToken, err := s.startSession(ctx, user, client)
+	if err != nil {
+		logger.Error("AccountService:OAuthCallback:Failed to start session", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:OAuthCallback:Failed to start session", err)
+	}
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// consumeOAuthState loads and deletes the state in one command so a callback can only be completed once.
+func (s *AccountService) consumeOAuthState(ctx context.Context, state string) (*oauthState, *errors.AppError) {
+	if state == "" {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AccountService:OAuthCallback:Missing state", nil)
+	}
+	key := fmt.Sprintf(constants.OAuthStateKey, state)
+	// GETDEL hands the state to one caller only, even when the callback is replayed concurrently
+	payload, err := s.cache.GetDel(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AccountService:OAuthCallback:Invalid or expired state", nil)
+	}
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:OAuthCallback:Failed to load state", err)
+	}
+
+	stored := new(oauthState)
+	if err = json.Unmarshal(payload, stored); err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:OAuthCallback:Failed to decode state", err)
+	}
+	return stored, nil
+}
+
+// resolveOAuthUser finds the local user for a provider identity. Unknown identities are linked
+// to the account with the same verified email, or get a new social account.
+func (s *AccountService) resolveOAuthUser(ctx context.Context, providerName string, token *oauth.Token, info *oauth.UserInfo) (*entity.User, *errors.AppError) {
+	userProvider := &entity.UserProvider{
+		Provider:       providerName,
+		ProviderUserId: info.ProviderUserID,
+		Email:          info.Email,
+		AccessToken:    token.AccessToken,
+		RefreshToken:   token.RefreshToken,
+		AvatarUrl:      info.AvatarURL,
+		FullName:       info.FullName,
+	}
+
+	link, err := s.repo.GetUserProvider(ctx, providerName, info.ProviderUserID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to get user provider", err)
+	}
+	if link != nil {
+		if err = s.repo.UpdateUserProvider(ctx, userProvider); err != nil {
+			return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to update user provider", err)
+		}
+		user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", link.UserId)
+		if err != nil {
+			return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to get user", err)
+		}
+		if user == nil {
+			return nil, errors.NewAppError(errors.ErrNotFound, "AccountService:OAuthCallback:User not found", nil)
+		}
+		return user, nil
+	}
+
+	// Only an email the provider has verified may be matched against local accounts
+	if info.Email == "" || !info.EmailVerified {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:OAuthCallback:Provider email is not verified", nil)
+	}
+
+	existingUser, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, info.Email, "", uuid.Nil)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to get user", err)
+	}
+	if existingUser != nil {
+		userProvider.UserId = existingUser.ID
+		if err = s.repo.CreateUserProvider(ctx, userProvider); err != nil {
+			return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to link user provider", err)
+		}
+		// The provider vouched for the address, so the local account is verified as well
+		if existingUser.EmailVerifiedAt == nil {
+			if _, err = s.repo.MarkEmailVerified(ctx, existingUser.ID); err != nil {
+				return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to mark email verified", err)
+			}
+		}
+		return existingUser, nil
+	}
+
+	user, err := s.repo.CreateSocialAccount(ctx, &entity.User{
+		UserName: socialUserName(info.Email),
+		Email:    info.Email,
+	}, userProvider)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:OAuthCallback:Failed to create account", err)
+	}
+	return user, nil
+}
+
+// socialUserName derives a unique user name from the local part of an email.
+func socialUserName(email string) string {
+	localPart, _, _ := strings.Cut(email, "@")
+	var builder strings.Builder
+	for _, ch := range strings.ToLower(localPart) {
+		if (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '.' || ch == '_' {
+			builder.WriteRune(ch)
+		}
+		if builder.Len() >= 40 {
+			break
+		}
+	}
+	if builder.Len() == 0 {
+		builder.WriteString("user")
+	}
+	return builder.String() + "_" + utils.GenerateID()
+}
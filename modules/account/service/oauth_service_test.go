@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/database/dbtest"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/oauth"
+	"pirate-lang-go/core/oauth/oauthtest"
+	"pirate-lang-go/modules/account/dto"
+	"pirate-lang-go/modules/account/entity"
+	"pirate-lang-go/modules/account/repository"
+)
+
+const callbackURL = "http://localhost/v1/auth/oauth/google/callback"
+
+type oauthFixture struct {
+	server  *oauthtest.Server
+	service *AccountService
+	repo    repository.IAccountRepository
+}
+
+func newOAuthFixture(t *testing.T) *oauthFixture {
+	t.Helper()
+	db := dbtest.Open(t)
+	server := oauthtest.NewServer()
+	t.Cleanup(server.Close)
+	redisServer.FlushAll()
+
+	repo := repository.NewAccountRepository(db)
+	return &oauthFixture{
+		server: server,
+		service: &AccountService{
+			repo:           repo,
+			cache:          cache.NewCache(redisServer.Addr(), "", 0),
+			oauthProviders: oauth.NewRegistry(oauth.NewGoogleProvider(server.ProviderConfig(callbackURL))),
+		},
+		repo: repo,
+	}
+}
+
+// authorize starts a login, signs in on the fake provider and returns the request its redirect
+// makes to the callback.
+func (f *oauthFixture) authorize(t *testing.T) *dto.OAuthCallbackRequest {
+	t.Helper()
+	login, appErr := f.service.GetOAuthLoginURL(context.Background(), oauth.ProviderGoogle)
+	if appErr != nil {
+		t.Fatalf("get login url: %v", appErr)
+	}
+	redirect, err := f.server.Authorize(login.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	query := redirect.Query()
+	if query.Get("state") != login.State {
+		t.Fatalf("callback state = %q, want %q", query.Get("state"), login.State)
+	}
+	return &dto.OAuthCallbackRequest{Code: query.Get("code"), State: query.Get("state")}
+}
+
+func (f *oauthFixture) callback(t *testing.T, request *dto.OAuthCallbackRequest) *dto.LoginResponse {
+	t.Helper()
+	response, appErr := f.service.OAuthCallback(context.Background(), oauth.ProviderGoogle, request, nil)
+	if appErr != nil {
+		t.Fatalf("callback: %v", appErr)
+	}
+	if response.AccessToken == "" || response.RefreshToken == "" {
+		t.Fatal("callback returned no tokens")
+	}
+	return response
+}
+
+// linkedUser returns the user the provider identity is linked to.
+func (f *oauthFixture) linkedUser(t *testing.T, subject string) uuid.UUID {
+	t.Helper()
+	link, err := f.repo.GetUserProvider(context.Background(), oauth.ProviderGoogle, subject)
+	if err != nil || link == nil {
+		t.Fatalf("get user provider %s: %v", subject, err)
+	}
+	return link.UserId
+}
+
+func (f *oauthFixture) user(t *testing.T, email string) *entity.User {
+	t.Helper()
+	user, err := f.repo.GetUserByEmailOrUserNameOrId(context.Background(), email, "", uuid.Nil)
+	if err != nil || user == nil {
+		t.Fatalf("get user %s: %v", email, err)
+	}
+	return user
+}
+
+func TestOAuthCallbackCreatesUser(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.server.SignInAs(oauthtest.User{Subject: "google-new", Email: "newcomer@example.com", EmailVerified: true, Name: "Newcomer"})
+	f.callback(t, f.authorize(t))
+
+	user := f.user(t, "newcomer@example.com")
+	if !user.IsSocialLogin {
+		t.Error("new user is not marked as a social login")
+	}
+	if linked := f.linkedUser(t, "google-new"); linked != user.ID {
+		t.Errorf("identity linked to %s, want the new user %s", linked, user.ID)
+	}
+}
+
+func TestOAuthCallbackLinksVerifiedEmail(t *testing.T) {
+	f := newOAuthFixture(t)
+	existing, err := f.repo.CreateAccount(context.Background(), &entity.User{UserName: "local", Email: "local@example.com", Password: "hash"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	f.server.SignInAs(oauthtest.User{Subject: "google-local", Email: "local@example.com", EmailVerified: true})
+	f.callback(t, f.authorize(t))
+
+	if linked := f.linkedUser(t, "google-local"); linked != existing.ID {
+		t.Errorf("identity linked to %s, want the existing user %s", linked, existing.ID)
+	}
+	if user := f.user(t, "local@example.com"); user.EmailVerifiedAt == nil {
+		t.Error("email of the linked user was not marked verified")
+	}
+}
+
+func TestOAuthCallbackRejectsReplayedState(t *testing.T) {
+	f := newOAuthFixture(t)
+	f.server.SignInAs(oauthtest.User{Subject: "google-replay", Email: "replay@example.com", EmailVerified: true})
+	request := f.authorize(t)
+	f.callback(t, request)
+
+	if redisServer.Exists(fmt.Sprintf(constants.OAuthStateKey, request.State)) {
+		t.Error("state is still stored after the callback")
+	}
+	_, appErr := f.service.OAuthCallback(context.Background(), oauth.ProviderGoogle, request, nil)
+	if appErr == nil || appErr.Code != errors.ErrInvalidInput {
+		t.Errorf("replayed callback error = %v, want code %d", appErr, errors.ErrInvalidInput)
+	}
+}
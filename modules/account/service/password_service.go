@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"net/url"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/dto"
+	"strings"
+	"time"
+)
+
+// ForgotPassword mails a reset link when the email belongs to an account.
+// It reports success for unknown emails too, so the endpoint cannot be used to discover accounts.
+func (s *AccountService) ForgotPassword(ctx context.Context, requestData *dto.ForgotPasswordRequest) *errors.AppError {
+	email := strings.ToLower(strings.TrimSpace(requestData.Email))
+
+	ctx, cancel := utils.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	rateKey := fmt.Sprintf(constants.PasswordResetRateKey, email)
+	count, err := s.cache.Incr(ctx, rateKey)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ForgotPassword:Cannot connect redis", err)
+	}
+	if count == 1 {
+		s.cache.Expire(ctx, rateKey, constants.PasswordResetRateTTL)
+	}
+	if count > constants.MaxPasswordResetRequests {
+		return errors.NewAppError(errors.ErrLimitExceeded, "AccountService:ForgotPassword:Reached rate limit", nil)
+	}
+
+	user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, email, "", uuid.Nil)
+	if err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:ForgotPassword:Failed to get user", err)
+	}
+	if user == nil || user.IsLocked {
+		return nil
+	}
+
+	token, tokenID, err := utils.GenerateSessionToken(user.ID, user.Email, user.UserName,
+		constants.TokenTypePasswordReset, uuid.Nil, constants.PasswordResetExpiry)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ForgotPassword:Failed to generate token", err)
+	}
+	// Storing the id invalidates links sent by earlier requests
+	if err = s.cache.Set(ctx, fmt.Sprintf(constants.PasswordResetKey, user.ID), tokenID.String(), constants.PasswordResetExpiry); err != nil {
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ForgotPassword:Failed to store token", err)
+	}
+
+	cfg := config.Get()
+	baseURL := cfg.Server.FrontendURL
+	if baseURL == "" {
+		baseURL = cfg.Server.BaseURL
+	}
+	err = s.mailer.SendMailWithTemplate(mailer.EmailData{
+		To:       []string{user.Email},
+		Subject:  "Reset your password",
+		Template: mailer.TemplateResetPassword,
+		TemplateData: map[string]any{
+			"UserName":         user.UserName,
+			"ResetURL":         baseURL + "/reset-password?token=" + url.QueryEscape(token),
+			"ExpiresInMinutes": int(constants.PasswordResetExpiry.Minutes()),
+		},
+	})
+	if err != nil {
+		logger.Error("AccountService:ForgotPassword:Failed to send reset email", "user_id", user.ID, "error", err)
+		return errors.NewAppError(errors.ErrThirdParty, "AccountService:ForgotPassword:Failed to send reset email", err)
+	}
+	return nil
+}
+
+func (s *AccountService) ResetPassword(ctx context.Context, requestData *dto.ResetPasswordRequest) *errors.AppError {
+	claims, err := utils.ValidateToken(requestData.Token)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:ResetPassword:Invalid or expired link", err)
+	}
+	if claims.TokenType != constants.TokenTypePasswordReset {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:ResetPassword:Invalid or expired link", nil)
+	}
+
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Consume the link before changing anything so it cannot be replayed. Only the link last sent
+	// holds the key, so a replaced link neither passes nor deletes it.
+	consumed, err := s.cache.DelIfValue(ctx, fmt.Sprintf(constants.PasswordResetKey, claims.UserID), claims.ID)
+	if err != nil {
+		logger.Error("AccountService:ResetPassword:Failed to consume reset token", "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ResetPassword:Failed to consume reset token", err)
+	}
+	if !consumed {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:ResetPassword:Link already used or replaced", nil)
+	}
+
+	user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", claims.UserID)
+	if err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:ResetPassword:Failed to get user", err)
+	}
+	if user == nil || user.IsLocked {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:ResetPassword:Invalid or expired link", nil)
+	}
+
+	hashedPassword, err := utils.HashPassword(requestData.NewPassword)
+	if err != nil {
+		logger.Error("AccountService:ResetPassword:Failed to hash new password", "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ResetPassword:Failed to hash new password", err)
+	}
+	user.Password = hashedPassword
+	if err = s.repo.UpdatePassword(ctx, user); err != nil {
+		logger.Error("AccountService:ResetPassword:Failed to update password", "user_id", user.ID, "error", err)
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:ResetPassword:Failed to update password", err)
+	}
+
+	// Whoever knew the old password must not stay signed in
+	if err = s.closeAllSessions(ctx, user.ID, constants.SessionRevokedPassword); err != nil {
+		logger.Error("AccountService:ResetPassword:Failed to revoke sessions", "user_id", user.ID, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ResetPassword:Failed to revoke sessions", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/dto"
+)
+
+// resetLink issues a password reset link for the user the way ForgotPassword does, replacing any
+// link sent before, and returns its token.
+func resetLink(t *testing.T, service *AccountService, user *utils.Claims) string {
+	t.Helper()
+	token, tokenID, err := utils.GenerateSessionToken(user.UserID, user.Email, user.UserName,
+		constants.TokenTypePasswordReset, uuid.Nil, constants.PasswordResetExpiry)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+	if err = service.cache.Set(context.Background(), fmt.Sprintf(constants.PasswordResetKey, user.UserID), tokenID.String(), constants.PasswordResetExpiry); err != nil {
+		t.Fatalf("store token: %v", err)
+	}
+	return token
+}
+
+func TestResetLinkWorksOnce(t *testing.T) {
+	service, accessToken, _ := signedIn(t)
+	token := resetLink(t, service, claimsOf(t, accessToken))
+
+	// Requests racing with the same link must not all get through
+	var wg sync.WaitGroup
+	results := make([]*errors.AppError, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = service.ResetPassword(context.Background(), &dto.ResetPasswordRequest{Token: token, NewPassword: fmt.Sprintf("NewPassword%d!", i)})
+		}()
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, appErr := range results {
+		switch {
+		case appErr == nil:
+			succeeded++
+		case appErr.Code != errors.ErrInvalidInput:
+			t.Errorf("error = %v, want code %d", appErr, errors.ErrInvalidInput)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d resets succeeded with the same link, want 1", succeeded)
+	}
+	if revoked, appErr := service.IsTokenRevoked(context.Background(), claimsOf(t, accessToken)); appErr != nil || !revoked {
+		t.Errorf("session revoked = %v (%v), want the reset to sign out", revoked, appErr)
+	}
+}
+
+func TestReplacedResetLinkLeavesNewerOne(t *testing.T) {
+	service, accessToken, _ := signedIn(t)
+	user := claimsOf(t, accessToken)
+	older := resetLink(t, service, user)
+	newer := resetLink(t, service, user)
+
+	if appErr := service.ResetPassword(context.Background(), &dto.ResetPasswordRequest{Token: older, NewPassword: "NewPassword1!"}); appErr == nil || appErr.Code != errors.ErrInvalidInput {
+		t.Fatalf("replaced link error = %v, want code %d", appErr, errors.ErrInvalidInput)
+	}
+	if appErr := service.ResetPassword(context.Background(), &dto.ResetPasswordRequest{Token: newer, NewPassword: "NewPassword2!"}); appErr != nil {
+		t.Errorf("newer link: %v", appErr)
+	}
+}
@@ -2,8 +2,13 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"pirate-lang-go/core/constants"
 	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
 	"pirate-lang-go/modules/account/dto"
 	"pirate-lang-go/modules/account/mapper"
 	"time"
@@ -75,6 +80,7 @@ func (s *AccountService) AssignRoleToUser(ctx context.Context, userID uuid.UUID,
 	if errAssignRoleToUser != nil {
 		return errors.NewAppError(errors.ErrInternal, "AccountService:AssignRoleToUser:internal server error", errAssignRoleToUser)
 	}
+	s.invalidatePermissionCache(ctx, userID)
 	return nil
 }
 
@@ -82,14 +88,6 @@ func (s *AccountService) AssignPermissionToRole(ctx context.Context, roleID uuid
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	userExists, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", uuid.Nil)
-	if err != nil {
-		return errors.NewAppError(errors.ErrInternal, "AccountService:AssignPermissionToRole:internal server error", err)
-	}
-	if userExists == nil {
-		return errors.NewAppError(errors.ErrNotFound, "AccountService:AssignPermissionToRole:user not found", nil)
-	}
-
 	roleExists, err := s.repo.RoleExists(ctx, roleID)
 	if err != nil {
 		return errors.NewAppError(errors.ErrInternal, "AccountService:AssignPermissionToRole:internal server error", err)
@@ -109,6 +107,12 @@ func (s *AccountService) AssignPermissionToRole(ctx context.Context, roleID uuid
 	if errAssignPermissionToRole != nil {
 		return errors.NewAppError(errors.ErrInternal, "AccountService:AssignPermissionToRole:internal server error", errAssignPermissionToRole)
 	}
+	// Every holder of the role gains the permission
+	userIDs, err := s.repo.GetUserIdsByRole(ctx, roleID)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "AccountService:AssignPermissionToRole:internal server error", err)
+	}
+	s.invalidatePermissionCache(ctx, userIDs...)
 	return nil
 }
 
@@ -136,3 +140,81 @@ func (s *AccountService) HasPermission(ctx context.Context, userID uuid.UUID, pe
 	}
 	return hasPermission, nil
 }
+
+// HasAllPermissions reports whether the user holds every one of the named permissions.
+func (s *AccountService) HasAllPermissions(ctx context.Context, userID uuid.UUID, permissionNames ...string) (bool, *errors.AppError) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	granted, appErr := s.getUserPermissions(ctx, userID)
+	if appErr != nil {
+		return false, appErr
+	}
+	for _, name := range permissionNames {
+		if !granted[name] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// HasAnyPermission reports whether the user holds at least one of the named permissions.
+func (s *AccountService) HasAnyPermission(ctx context.Context, userID uuid.UUID, permissionNames ...string) (bool, *errors.AppError) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if len(permissionNames) == 0 {
+		return true, nil
+	}
+	granted, appErr := s.getUserPermissions(ctx, userID)
+	if appErr != nil {
+		return false, appErr
+	}
+	for _, name := range permissionNames {
+		if granted[name] {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getUserPermissions resolves the permission names granted through the user's roles, cached in Redis.
+func (s *AccountService) getUserPermissions(ctx context.Context, userID uuid.UUID) (map[string]bool, *errors.AppError) {
+	key := fmt.Sprintf(constants.PermissionCacheKey, userID)
+
+	var names []string
+	cached, err := s.cache.Get(ctx, key).Result()
+	if err == nil && json.Unmarshal([]byte(cached), &names) == nil {
+		return toPermissionSet(names), nil
+	}
+	if err != nil && err != redis.Nil {
+		// Redis being down must not lock admins out; fall back to the database.
+		logger.Error("AccountService:getUserPermissions:Failed to read permission cache", "user_id", userID, "error", err)
+	}
+
+	names, err = s.repo.GetUserPermissionNames(ctx, userID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:getUserPermissions:internal server error", err)
+	}
+	encoded, _ := json.Marshal(names)
+	if err = s.cache.Set(ctx, key, encoded, constants.PermissionCacheExpiry); err != nil {
+		logger.Error("AccountService:getUserPermissions:Failed to write permission cache", "user_id", userID, "error", err)
+	}
+	return toPermissionSet(names), nil
+}
+
+func toPermissionSet(names []string) map[string]bool {
+	granted := make(map[string]bool, len(names))
+	for _, name := range names {
+		granted[name] = true
+	}
+	return granted
+}
+
+func (s *AccountService) invalidatePermissionCache(ctx context.Context, userIDs ...uuid.UUID) {
+	for _, userID := range userIDs {
+		if err := s.cache.Del(ctx, fmt.Sprintf(constants.PermissionCacheKey, userID)); err != nil {
+			logger.Error("AccountService:invalidatePermissionCache:Failed to clear permission cache", "user_id", userID, "error", err)
+		}
+	}
+}
@@ -5,40 +5,62 @@ import (
 	"github.com/google/uuid"
 	"mime/multipart"
 	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/config"
 	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/oauth"
 	"pirate-lang-go/core/storage"
+	"pirate-lang-go/core/utils"
 	"pirate-lang-go/modules/account/dto"
 	"pirate-lang-go/modules/account/repository"
 )
 
 type AccountService struct {
-	repo    repository.IAccountRepository
-	cache   cache.ICache
-	storage storage.IStorage
+	repo           repository.IAccountRepository
+	cache          cache.ICache
+	storage        storage.IStorage
+	mailer         mailer.IMailer
+	oauthProviders *oauth.Registry
 }
 
-func NewAccountService(repo repository.IAccountRepository, cache cache.ICache, storage storage.IStorage) IAccountService {
+func NewAccountService(repo repository.IAccountRepository, cache cache.ICache, storage storage.IStorage, mailer mailer.IMailer) IAccountService {
 
 	return &AccountService{
-		repo:    repo,
-		cache:   cache,
-		storage: storage,
+		repo:           repo,
+		cache:          cache,
+		storage:        storage,
+		mailer:         mailer,
+		oauthProviders: oauth.NewRegistryFromConfig(config.Get().OAuth),
 	}
 }
 
 type IAccountService interface {
 
 	// Auth API
-	CreateAccount(ctx context.Context, requestData *dto.CreateAccountRequest) (*dto.CreateAccountResponse, *errors.AppError)
+	CreateAccount(ctx context.Context, requestData *dto.CreateAccountRequest, client *dto.SessionClient) (*dto.CreateAccountResponse, *errors.AppError)
 	ChangePassword(ctx context.Context, token string, requestData *dto.ChangePasswordRequest) *errors.AppError
-	Login(ctx context.Context, requestData *dto.LoginRequest) (*dto.LoginResponse, *errors.AppError)
+	Login(ctx context.Context, requestData *dto.LoginRequest, client *dto.SessionClient) (*dto.LoginResponse, *errors.AppError)
 	Logout(ctx context.Context, token string) *errors.AppError
+	ForgotPassword(ctx context.Context, requestData *dto.ForgotPasswordRequest) *errors.AppError
+	ResetPassword(ctx context.Context, requestData *dto.ResetPasswordRequest) *errors.AppError
+	VerifyEmail(ctx context.Context, verificationToken string) *errors.AppError
+	ResendVerificationEmail(ctx context.Context, token string) *errors.AppError
+	RefreshToken(ctx context.Context, refreshToken string) (*dto.LoginResponse, *errors.AppError)
+	GetOAuthLoginURL(ctx context.Context, providerName string) (*dto.OAuthLoginResponse, *errors.AppError)
+	OAuthCallback(ctx context.Context, providerName string, requestData *dto.OAuthCallbackRequest, client *dto.SessionClient) (*dto.LoginResponse, *errors.AppError)
+	IsTokenRevoked(ctx context.Context, claims *utils.Claims) (bool, *errors.AppError)
+	// Sessions
+	GetSessions(ctx context.Context, token string) ([]*dto.SessionResponse, *errors.AppError)
+	RevokeSession(ctx context.Context, token string, sessionID uuid.UUID) *errors.AppError
+	RevokeAllSessions(ctx context.Context, token string) *errors.AppError
 
 	// Admin API
 	GetUsers(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedUsersResponse, *errors.AppError)
 	GetManagerProfile(ctx context.Context, userId uuid.UUID) (*dto.ProfileResponse, *errors.AppError)
 	LockUser(ctx context.Context, requestData *dto.LockUserRequest, userId uuid.UUID) *errors.AppError
 	UnlockUser(ctx context.Context, requestData *dto.UnlockUserRequest, userId uuid.UUID) *errors.AppError
+	GetUserSessions(ctx context.Context, userId uuid.UUID) ([]*dto.SessionResponse, *errors.AppError)
+	RevokeUserSessions(ctx context.Context, userId uuid.UUID) *errors.AppError
 	// UserProfile
 	GetProfile(ctx context.Context, token string) (*dto.ProfileResponse, *errors.AppError)
 	CreateProfile(ctx context.Context, token string, requestData *dto.CreateUserProfile) *errors.AppError
@@ -52,4 +74,6 @@ type IAccountService interface {
 	AssignPermissionToRole(ctx context.Context, roleID uuid.UUID, permissionID uuid.UUID) *errors.AppError
 	AssignRoleToUser(ctx context.Context, userID uuid.UUID, roleID uuid.UUID) *errors.AppError
 	HasPermission(ctx context.Context, userID uuid.UUID, permissionID uuid.UUID) (bool, *errors.AppError)
+	HasAllPermissions(ctx context.Context, userID uuid.UUID, permissionNames ...string) (bool, *errors.AppError)
+	HasAnyPermission(ctx context.Context, userID uuid.UUID, permissionNames ...string) (bool, *errors.AppError)
 }
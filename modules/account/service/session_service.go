@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/dto"
+	"pirate-lang-go/modules/account/entity"
+	"pirate-lang-go/modules/account/mapper"
+	"time"
+)
+
+// sessionBlacklistKey is the blacklist entry that invalidates every access token of a session.
+func sessionBlacklistKey(sessionID uuid.UUID) string {
+	return "session:" + sessionID.String()
+}
+
+// signTokenPair signs an access and a refresh token for a session.
+func signTokenPair(userID uuid.UUID, email, userName string, sessionID uuid.UUID) (string, string, uuid.UUID, error) {
+	accessToken, _, err := utils.GenerateSessionToken(userID, email, userName, constants.TokenTypeAccess, sessionID, constants.AccessTokenExpiry)
+	if err != nil {
+		return "", "", uuid.Nil, err
+	}
+	refreshToken, refreshTokenID, err := utils.GenerateSessionToken(userID, email, userName, constants.TokenTypeRefresh, sessionID, constants.RefreshTokenExpiry)
+	if err != nil {
+		return "", "", uuid.Nil, err
+	}
+	return accessToken, refreshToken, refreshTokenID, nil
+}
+
+// startSession opens a new login session for the user and returns its first token pair.
+func (s *AccountService) startSession(ctx context.Context, user *entity.User, client *dto.SessionClient) (string, string, error) {
+	sessionID := uuid.New()
+	accessToken, refreshToken, refreshTokenID, err := signTokenPair(user.ID, user.Email, user.UserName, sessionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	expiresAt := time.Now().Add(constants.RefreshTokenExpiry)
+	session := &entity.UserSession{
+		SessionId: sessionID,
+		UserId:    user.ID,
+		ExpiresAt: expiresAt,
+	}
+	if client != nil {
+		session.UserAgent = client.UserAgent
+		session.IPAddress = client.IPAddress
+	}
+	err = s.repo.CreateSession(ctx, session, &entity.RefreshToken{
+		TokenId:   refreshTokenID,
+		SessionId: sessionID,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return accessToken, refreshToken, nil
+}
+
+// closeSession revokes a session in the database and blacklists its access tokens until they expire.
+func (s *AccountService) closeSession(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	if _, err := s.repo.RevokeSession(ctx, sessionID, reason); err != nil {
+		return err
+	}
+	return s.cache.AddToBlacklist(ctx, sessionBlacklistKey(sessionID), constants.AccessTokenExpiry)
+}
+
+func (s *AccountService) RefreshToken(ctx context.Context, refreshToken string) (*dto.LoginResponse, *errors.AppError) {
+	claims, err := utils.ValidateToken(refreshToken)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Invalid refresh token", err)
+	}
+	if claims.TokenType != constants.TokenTypeRefresh {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Not a refresh token", nil)
+	}
+	tokenID, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Invalid token id", err)
+	}
+
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	stored, err := s.repo.GetRefreshToken(ctx, tokenID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:RefreshToken:Failed to get refresh token", err)
+	}
+	if stored == nil || stored.UserId != claims.UserID {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Unknown refresh token", nil)
+	}
+	if stored.RevokedAt != nil {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Session revoked", nil)
+	}
+	if stored.UsedAt != nil {
+		return nil, s.rejectReusedToken(ctx, stored)
+	}
+	user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", stored.UserId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:RefreshToken:Failed to get user", err)
+	}
+	if user == nil || user.IsLocked {
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:User is locked", nil)
+	}
+
+	accessToken, nextRefreshToken, nextTokenID, err := signTokenPair(user.ID, user.Email, user.UserName, stored.SessionId)
+	if err != nil {
+		logger.Error("AccountService:RefreshToken:Failed to generate tokens", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AccountService:RefreshToken:Failed to generate tokens", err)
+	}
+	rotated, err := s.repo.RotateRefreshToken(ctx, tokenID, &entity.RefreshToken{
+		TokenId:   nextTokenID,
+		SessionId: stored.SessionId,
+		ExpiresAt: time.Now().Add(constants.RefreshTokenExpiry),
+	})
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:RefreshToken:Failed to rotate refresh token", err)
+	}
+	if !rotated {
+		// Another request exchanged the same token in the meantime
+		return nil, s.rejectReusedToken(ctx, stored)
+	}
+
+	return &dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: nextRefreshToken,
+	}, nil
+}
+
+// rejectReusedToken revokes the whole session when an already exchanged refresh token is presented again.
+func (s *AccountService) rejectReusedToken(ctx context.Context, stored *entity.RefreshToken) *errors.AppError {
+	logger.Warn("AccountService:RefreshToken:Refresh token reuse detected", "session_id", stored.SessionId, "user_id", stored.UserId)
+	if err := s.closeSession(ctx, stored.SessionId, constants.SessionRevokedTokenReuse); err != nil {
+		logger.Error("AccountService:RefreshToken:Failed to revoke session", "session_id", stored.SessionId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:RefreshToken:Failed to revoke session", err)
+	}
+	return errors.NewAppError(errors.ErrUnauthorized, "AccountService:RefreshToken:Refresh token reuse detected", nil)
+}
+
+// closeAllSessions revokes every open session of a user.
+func (s *AccountService) closeAllSessions(ctx context.Context, userID uuid.UUID, reason string) error {
+	sessionIDs, err := s.repo.RevokeAllSessions(ctx, userID, reason)
+	if err != nil {
+		return err
+	}
+	for _, sessionID := range sessionIDs {
+		if err = s.cache.AddToBlacklist(ctx, sessionBlacklistKey(sessionID), constants.AccessTokenExpiry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AccountService) GetSessions(ctx context.Context, token string) ([]*dto.SessionResponse, *errors.AppError) {
+	claims, err := utils.ValidateToken(token)
+	if err != nil {
+		logger.Error("AccountService:GetSessions:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AccountService:GetSessions:Invalid token", err)
+	}
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	sessions, err := s.repo.GetActiveSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:GetSessions:Failed to get sessions", err)
+	}
+	return mapper.ToSessionResponses(sessions, claims.SessionID), nil
+}
+
+func (s *AccountService) RevokeSession(ctx context.Context, token string, sessionID uuid.UUID) *errors.AppError {
+	claims, err := utils.ValidateToken(token)
+	if err != nil {
+		logger.Error("AccountService:RevokeSession:Failed to validate token", "error", err)
+		return errors.NewAppError(errors.ErrUnauthorized, "AccountService:RevokeSession:Invalid token", err)
+	}
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	revoked, err := s.repo.RevokeSessionOfUser(ctx, sessionID, claims.UserID, constants.SessionRevokedSignOut)
+	if err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:RevokeSession:Failed to revoke session", err)
+	}
+	if !revoked {
+		return errors.NewAppError(errors.ErrNotFound, "AccountService:RevokeSession:Session not found", nil)
+	}
+	if err = s.cache.AddToBlacklist(ctx, sessionBlacklistKey(sessionID), constants.AccessTokenExpiry); err != nil {
+		logger.Error("AccountService:RevokeSession:Failed to blacklist session", "session_id", sessionID, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:RevokeSession:Failed to blacklist session", err)
+	}
+	return nil
+}
+
+func (s *AccountService) RevokeAllSessions(ctx context.Context, token string) *errors.AppError {
+	claims, err := utils.ValidateToken(token)
+	if err != nil {
+		logger.Error("AccountService:RevokeAllSessions:Failed to validate token", "error", err)
+		return errors.NewAppError(errors.ErrUnauthorized, "AccountService:RevokeAllSessions:Invalid token", err)
+	}
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err = s.closeAllSessions(ctx, claims.UserID, constants.SessionRevokedSignOut); err != nil {
+		logger.Error("AccountService:RevokeAllSessions:Failed to revoke sessions", "user_id", claims.UserID, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:RevokeAllSessions:Failed to revoke sessions", err)
+	}
+	return nil
+}
+
+func (s *AccountService) GetUserSessions(ctx context.Context, userId uuid.UUID) ([]*dto.SessionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	sessions, err := s.repo.GetActiveSessions(ctx, userId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrDatabase, "AccountService:GetUserSessions:Failed to get sessions", err)
+	}
+	return mapper.ToSessionResponses(sessions, uuid.Nil), nil
+}
+
+func (s *AccountService) RevokeUserSessions(ctx context.Context, userId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if err := s.closeAllSessions(ctx, userId, constants.SessionRevokedByAdmin); err != nil {
+		logger.Error("AccountService:RevokeUserSessions:Failed to revoke sessions", "user_id", userId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:RevokeUserSessions:Failed to revoke sessions", err)
+	}
+	return nil
+}
+
+func (s *AccountService) IsTokenRevoked(ctx context.Context, claims *utils.Claims) (bool, *errors.AppError) {
+	if claims.ID != "" {
+		revoked, err := s.cache.IsTokenBlacklisted(ctx, claims.ID)
+		if err != nil {
+			return false, errors.NewAppError(errors.ErrInternal, "AccountService:IsTokenRevoked:Failed to check token blacklist", err)
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+	if claims.SessionID != uuid.Nil {
+		revoked, err := s.cache.IsTokenBlacklisted(ctx, sessionBlacklistKey(claims.SessionID))
+		if err != nil {
+			return false, errors.NewAppError(errors.ErrInternal, "AccountService:IsTokenRevoked:Failed to check session blacklist", err)
+		}
+		return revoked, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/database/dbtest"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/entity"
+	"pirate-lang-go/modules/account/repository"
+)
+
+// redisServer backs the shared cache, which can only be created once per process.
+var redisServer *miniredis.Miniredis
+
+func TestMain(m *testing.M) {
+	os.Setenv("APP_JWT_SECRET", "account-service-test")
+	if err := logger.Init(logger.LogConfig{Level: logger.LogLevelError}); err != nil {
+		panic(err)
+	}
+	if err := config.Init(config.DevEnvironment); err != nil {
+		panic(err)
+	}
+	var err error
+	if redisServer, err = miniredis.Run(); err != nil {
+		panic(err)
+	}
+	code := m.Run()
+	redisServer.Close()
+	os.Exit(code)
+}
+
+// signedIn returns an account service on a fresh schema and an empty cache, with the tokens of a
+// session just opened for a new user.
+func signedIn(t *testing.T) (service *AccountService, accessToken, refreshToken string) {
+	t.Helper()
+	redisServer.FlushAll()
+	service = &AccountService{
+		repo:  repository.NewAccountRepository(dbtest.Open(t)),
+		cache: cache.NewCache(redisServer.Addr(), "", 0),
+	}
+	user, err := service.repo.CreateAccount(context.Background(), &entity.User{UserName: "learner", Email: "learner@example.com", Password: "hash"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	accessToken, refreshToken, err = service.startSession(context.Background(), user, nil)
+	if err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+	return service, accessToken, refreshToken
+}
+
+func claimsOf(t *testing.T, token string) *utils.Claims {
+	t.Helper()
+	claims, err := utils.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("validate token: %v", err)
+	}
+	return claims
+}
+
+func TestRefreshTokenRotates(t *testing.T) {
+	service, _, refreshToken := signedIn(t)
+	session := claimsOf(t, refreshToken).SessionID
+
+	for i := 1; i <= 3; i++ {
+		response, appErr := service.RefreshToken(context.Background(), refreshToken)
+		if appErr != nil {
+			t.Fatalf("refresh %d: %v", i, appErr)
+		}
+		previous, next := claimsOf(t, refreshToken), claimsOf(t, response.RefreshToken)
+		if next.ID == previous.ID {
+			t.Fatalf("refresh %d returned the token it was given", i)
+		}
+		if next.SessionID != session || claimsOf(t, response.AccessToken).SessionID != session {
+			t.Errorf("refresh %d issued tokens for another session", i)
+		}
+		refreshToken = response.RefreshToken
+	}
+}
+
+// Presenting an exchanged refresh token again means it leaked: the session is closed for whoever
+// holds its tokens, the legitimate client included.
+func TestReusedRefreshTokenRevokesSession(t *testing.T) {
+	service, accessToken, refreshToken := signedIn(t)
+	rotated, appErr := service.RefreshToken(context.Background(), refreshToken)
+	if appErr != nil {
+		t.Fatalf("refresh: %v", appErr)
+	}
+
+	if _, appErr = service.RefreshToken(context.Background(), refreshToken); appErr == nil || appErr.Code != errors.ErrUnauthorized {
+		t.Fatalf("reused token error = %v, want code %d", appErr, errors.ErrUnauthorized)
+	}
+	if _, appErr = service.RefreshToken(context.Background(), rotated.RefreshToken); appErr == nil {
+		t.Error("the rotated refresh token still works after the reuse")
+	}
+	for _, token := range []string{accessToken, rotated.AccessToken} {
+		revoked, appErr := service.IsTokenRevoked(context.Background(), claimsOf(t, token))
+		if appErr != nil || !revoked {
+			t.Errorf("access token revoked = %v (%v), want revoked", revoked, appErr)
+		}
+	}
+}
+
+func TestRefreshTokenRejectsAccessToken(t *testing.T) {
+	service, accessToken, _ := signedIn(t)
+
+	if _, appErr := service.RefreshToken(context.Background(), accessToken); appErr == nil || appErr.Code != errors.ErrUnauthorized {
+		t.Fatalf("error = %v, want code %d", appErr, errors.ErrUnauthorized)
+	}
+	if revoked, appErr := service.IsTokenRevoked(context.Background(), claimsOf(t, accessToken)); appErr != nil || revoked {
+		t.Errorf("access token revoked = %v (%v), want it left alone", revoked, appErr)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"net/url"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/account/entity"
+	"time"
+)
+
+// sendVerificationEmail issues a new verification link, which replaces any earlier one, and mails it to the user.
+func (s *AccountService) sendVerificationEmail(ctx context.Context, user *entity.User) error {
+	token, tokenID, err := utils.GenerateSessionToken(user.ID, user.Email, user.UserName,
+		constants.TokenTypeEmailVerification, uuid.Nil, constants.EmailVerificationExpiry)
+	if err != nil {
+		return err
+	}
+	key := fmt.Sprintf(constants.EmailVerificationKey, user.ID)
+	if err = s.cache.Set(ctx, key, tokenID.String(), constants.EmailVerificationExpiry); err != nil {
+		return err
+	}
+
+	verifyURL := config.Get().Server.BaseURL + "/v1/accounts/verify-email?token=" + url.QueryEscape(token)
+	return s.mailer.SendMailWithTemplate(mailer.EmailData{
+		To:       []string{user.Email},
+		Subject:  "Verify your email address",
+		Template: mailer.TemplateVerifyEmail,
+		TemplateData: map[string]any{
+			"UserName":       user.UserName,
+			"VerifyURL":      verifyURL,
+			"ExpiresInHours": int(constants.EmailVerificationExpiry.Hours()),
+		},
+	})
+}
+
+func (s *AccountService) VerifyEmail(ctx context.Context, verificationToken string) *errors.AppError {
+	claims, err := utils.ValidateToken(verificationToken)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:VerifyEmail:Invalid or expired link", err)
+	}
+	if claims.TokenType != constants.TokenTypeEmailVerification {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:VerifyEmail:Invalid or expired link", nil)
+	}
+
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Only the most recently issued link is valid, and only once
+	key := fmt.Sprintf(constants.EmailVerificationKey, claims.UserID)
+	currentID, err := s.cache.Get(ctx, key).Result()
+	if err == redis.Nil || (err == nil && currentID != claims.ID) {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:VerifyEmail:Link already used or replaced", nil)
+	}
+	if err != nil {
+		logger.Error("AccountService:VerifyEmail:Failed to load verification token", "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AccountService:VerifyEmail:Failed to load verification token", err)
+	}
+
+	user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", claims.UserID)
+	if err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:VerifyEmail:Failed to get user", err)
+	}
+	// The link is bound to the address it was sent to
+	if user == nil || user.Email != claims.Email {
+		return errors.NewAppError(errors.ErrInvalidInput, "AccountService:VerifyEmail:Invalid or expired link", nil)
+	}
+	if _, err = s.repo.MarkEmailVerified(ctx, user.ID); err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:VerifyEmail:Failed to mark email verified", err)
+	}
+	if err = s.cache.Del(ctx, key); err != nil {
+		logger.Error("AccountService:VerifyEmail:Failed to delete verification token", "user_id", user.ID, "error", err)
+	}
+	return nil
+}
+
+func (s *AccountService) ResendVerificationEmail(ctx context.Context, token string) *errors.AppError {
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AccountService:ResendVerificationEmail:Failed to validate token", "error", err)
+		return errors.NewAppError(errors.ErrUnauthorized, "AccountService:ResendVerificationEmail:Invalid token", err)
+	}
+
+	ctx, cancel := utils.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	user, err := s.repo.GetUserByEmailOrUserNameOrId(ctx, "", "", claims.UserID)
+	if err != nil {
+		return errors.NewAppError(errors.ErrDatabase, "AccountService:ResendVerificationEmail:Failed to get user", err)
+	}
+	if user == nil {
+		return errors.NewAppError(errors.ErrNotFound, "AccountService:ResendVerificationEmail:User not found", nil)
+	}
+	if user.EmailVerifiedAt != nil {
+		return errors.NewAppError(errors.ErrInvalidState, "AccountService:ResendVerificationEmail:Email already verified", nil)
+	}
+
+	rateKey := fmt.Sprintf(constants.EmailVerificationResendKey, user.ID)
+	count, err := s.cache.Incr(ctx, rateKey)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "AccountService:ResendVerificationEmail:Cannot connect redis", err)
+	}
+	if count == 1 {
+		s.cache.Expire(ctx, rateKey, constants.EmailVerificationResendTTL)
+	}
+	if count > constants.MaxEmailVerificationResends {
+		return errors.NewAppError(errors.ErrLimitExceeded, "AccountService:ResendVerificationEmail:Reached rate limit", nil)
+	}
+
+	if err = s.sendVerificationEmail(ctx, user); err != nil {
+		logger.Error("AccountService:ResendVerificationEmail:Failed to send verification email", "user_id", user.ID, "error", err)
+		return errors.NewAppError(errors.ErrThirdParty, "AccountService:ResendVerificationEmail:Failed to send verification email", err)
+	}
+	return nil
+}
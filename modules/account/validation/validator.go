@@ -71,6 +71,31 @@ func ValidateChangePassword(dataRequest dto.ChangePasswordRequest) *validation.V
 	return result
 }
 
+func ValidateForgotPassword(dataRequest dto.ForgotPasswordRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	switch {
+	case utils.IsEmpty(dataRequest.Email):
+		result.AddError("email", "Email is required")
+	case !utils.IsValidEmail(dataRequest.Email):
+		result.AddError("email", "Invalid email format")
+	}
+	return result
+}
+
+func ValidateResetPassword(dataRequest dto.ResetPasswordRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if utils.IsEmpty(dataRequest.Token) {
+		result.AddError("token", "Token is required")
+	}
+	if len(dataRequest.NewPassword) < 8 {
+		result.AddError("new_password", "New password must be at least 8 characters")
+	}
+	if dataRequest.NewPassword != dataRequest.ConfirmPassword {
+		result.AddError("confirm_password", "New password and confirmation do not match")
+	}
+	return result
+}
+
 func ValidateCreateRole(dataRequest *dto.CreateRoleRequest) *validation.ValidationResult {
 	if dataRequest == nil {
 		return nil
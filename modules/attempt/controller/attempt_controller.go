@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	validator "pirate-lang-go/modules/attempt/validation"
+)
+
+func (controller *AttemptController) StartAttempt(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.StartAttempt(ctx, token, examId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrForbidden {
+			return controller.Forbidden("Error start attempt", appErr.Error())
+		}
+		return controller.BadRequest("Error start attempt", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Start attempt successfully")
+}
+
+func (controller *AttemptController) GetAttempts(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.GetAttempts(ctx, token, examId)
+	if appErr != nil {
+		return controller.BadRequest("Error getting attempts", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get attempts successfully")
+}
+
+func (controller *AttemptController) GetAttempt(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	attemptId, err := uuid.Parse(c.Param("attemptId"))
+	if err != nil {
+		return controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.GetAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return controller.BadRequest("Error getting attempt", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get attempt successfully")
+}
+
+func (controller *AttemptController) SaveAnswers(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	attemptId, err := uuid.Parse(c.Param("attemptId"))
+	if err != nil {
+		return controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+	requestData := new(dto.SaveAnswersRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateSaveAnswers(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.attemptService.SaveAnswers(ctx, token, examId, attemptId, requestData)
+	if appErr != nil {
+		return controller.BadRequest("Error save answers", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Save answers successfully")
+}
+
+func (controller *AttemptController) SubmitAttempt(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	attemptId, err := uuid.Parse(c.Param("attemptId"))
+	if err != nil {
+		return controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.SubmitAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return controller.BadRequest("Error submit attempt", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Submit attempt successfully")
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"pirate-lang-go/core/controller"
+	"pirate-lang-go/modules/attempt/service"
+)
+
+type AttemptController struct {
+	controller.BaseController
+	attemptService service.IAttemptService
+}
+
+func NewAttemptController(service service.IAttemptService) *AttemptController {
+
+	return &AttemptController{
+		BaseController: controller.NewBaseController(),
+		attemptService: service,
+	}
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/modules/attempt/dto"
+	validator "pirate-lang-go/modules/attempt/validation"
+)
+
+func (controller *AttemptController) CreateConversionTable(c echo.Context) error {
+	ctx := c.Request().Context()
+	requestData := new(dto.CreateConversionTableRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateCreateConversionTable(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.attemptService.CreateConversionTable(ctx, requestData)
+	if appErr != nil {
+		return controller.BadRequest("Error create conversion table", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Create conversion table successfully")
+}
+
+func (controller *AttemptController) GetConversionTables(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	response, appErr := controller.attemptService.GetConversionTables(ctx)
+	if appErr != nil {
+		return controller.BadRequest("Error getting conversion tables", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get conversion tables successfully")
+}
+
+func (controller *AttemptController) GetConversionTable(c echo.Context) error {
+	ctx := c.Request().Context()
+	tableId, err := uuid.Parse(c.Param("tableId"))
+	if err != nil {
+		return controller.BadRequest("Invalid table ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.GetConversionTable(ctx, tableId)
+	if appErr != nil {
+		return controller.BadRequest("Error getting conversion table", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get conversion table successfully")
+}
+
+func (controller *AttemptController) UpdateConversionEntries(c echo.Context) error {
+	ctx := c.Request().Context()
+	tableId, err := uuid.Parse(c.Param("tableId"))
+	if err != nil {
+		return controller.BadRequest("Invalid table ID format", err.Error())
+	}
+	requestData := new(dto.UpdateConversionEntriesRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateUpdateConversionEntries(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	appErr := controller.attemptService.UpdateConversionEntries(ctx, tableId, requestData)
+	if appErr != nil {
+		return controller.BadRequest("Error update conversion entries", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Update conversion entries successfully")
+}
+
+func (controller *AttemptController) SetDefaultConversionTable(c echo.Context) error {
+	ctx := c.Request().Context()
+	tableId, err := uuid.Parse(c.Param("tableId"))
+	if err != nil {
+		return controller.BadRequest("Invalid table ID format", err.Error())
+	}
+
+	appErr := controller.attemptService.SetDefaultConversionTable(ctx, tableId)
+	if appErr != nil {
+		return controller.BadRequest("Error set default conversion table", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Set default conversion table successfully")
+}
+
+func (controller *AttemptController) SetExamConversionTable(c echo.Context) error {
+	ctx := c.Request().Context()
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	requestData := new(dto.SetExamConversionTableRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+
+	appErr := controller.attemptService.SetExamConversionTable(ctx, examId, requestData)
+	if appErr != nil {
+		return controller.BadRequest("Error set exam conversion table", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Set exam conversion table successfully")
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	validator "pirate-lang-go/modules/attempt/validation"
+)
+
+func (controller *AttemptController) GetRatingQueue(c echo.Context) error {
+	ctx := c.Request().Context()
+	response, appErr := controller.attemptService.GetRatingQueue(ctx)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting rating queue", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get rating queue successfully")
+}
+
+func (controller *AttemptController) AssignNextRating(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+
+	response, appErr := controller.attemptService.AssignNextRating(ctx, token)
+	if appErr != nil {
+		return controller.responseError("Error assigning rating", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Assign rating successfully")
+}
+
+func (controller *AttemptController) SubmitRating(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	ratingId, err := uuid.Parse(c.Param("ratingId"))
+	if err != nil {
+		return controller.BadRequest("Invalid rating ID format", err.Error())
+	}
+	requestData := new(dto.SubmitRatingRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateSubmitRating(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.attemptService.SubmitRating(ctx, token, ratingId, requestData)
+	if appErr != nil {
+		return controller.responseError("Error submitting rating", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Submit rating successfully")
+}
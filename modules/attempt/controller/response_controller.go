@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	validator "pirate-lang-go/modules/attempt/validation"
+)
+
+// responseParams reads the token and the ids of a response route.
+func (controller *AttemptController) responseParams(c echo.Context) (token string, examId, attemptId, questionId uuid.UUID, err error) {
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return "", uuid.Nil, uuid.Nil, uuid.Nil, controller.Unauthorized("Unauthorized", errToken)
+	}
+	if examId, err = uuid.Parse(c.Param("examId")); err != nil {
+		return "", uuid.Nil, uuid.Nil, uuid.Nil, controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	if attemptId, err = uuid.Parse(c.Param("attemptId")); err != nil {
+		return "", uuid.Nil, uuid.Nil, uuid.Nil, controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+	if questionId, err = uuid.Parse(c.Param("questionId")); err != nil {
+		return "", uuid.Nil, uuid.Nil, uuid.Nil, controller.BadRequest("Invalid question ID format", err.Error())
+	}
+	return token, examId, attemptId, questionId, nil
+}
+
+func (controller *AttemptController) StartResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, examId, attemptId, questionId, err := controller.responseParams(c)
+	if err != nil {
+		return err
+	}
+
+	response, appErr := controller.attemptService.StartResponse(ctx, token, examId, attemptId, questionId)
+	if appErr != nil {
+		return controller.responseError("Error start response", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Start response successfully")
+}
+
+// SubmitSpokenResponse stores the recording sent as the "audio" form file.
+func (controller *AttemptController) SubmitSpokenResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, examId, attemptId, questionId, err := controller.responseParams(c)
+	if err != nil {
+		return err
+	}
+	file, errFile := c.FormFile("audio")
+	if errFile != nil {
+		return controller.BadRequest("Error getting audio file", errFile.Error())
+	}
+
+	response, appErr := controller.attemptService.SubmitSpokenResponse(ctx, token, examId, attemptId, questionId, file)
+	if appErr != nil {
+		return controller.responseError("Error submit spoken response", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Submit spoken response successfully")
+}
+
+func (controller *AttemptController) SubmitWrittenResponse(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, examId, attemptId, questionId, err := controller.responseParams(c)
+	if err != nil {
+		return err
+	}
+	requestData := new(dto.SubmitWrittenResponseRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateSubmitWrittenResponse(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.attemptService.SubmitWrittenResponse(ctx, token, examId, attemptId, questionId, requestData)
+	if appErr != nil {
+		return controller.responseError("Error submit written response", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Submit written response successfully")
+}
+
+func (controller *AttemptController) responseError(message string, appErr *errors.AppError) error {
+	switch appErr.Code {
+	case errors.ErrNotFound:
+		return controller.NotFound(message, appErr.Error())
+	case errors.ErrUnauthorized:
+		return controller.Unauthorized(message, appErr.Error())
+	case errors.ErrInternal:
+		return controller.InternalServerError(message, appErr.Error())
+	}
+	return controller.BadRequest(message, appErr.Error())
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/utils"
+)
+
+func (controller *AttemptController) GetAttemptResult(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	attemptId, err := uuid.Parse(c.Param("attemptId"))
+	if err != nil {
+		return controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+
+	response, appErr := controller.attemptService.GetAttemptResult(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return controller.BadRequest("Error getting attempt result", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get attempt result successfully")
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/service"
+	libraryvalidation "pirate-lang-go/modules/library/validation"
+)
+
+// GetAttemptReview takes the explanation language from the lang query parameter, English by default.
+func (controller *AttemptController) GetAttemptReview(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+	attemptId, err := uuid.Parse(c.Param("attemptId"))
+	if err != nil {
+		return controller.BadRequest("Invalid attempt ID format", err.Error())
+	}
+	lang := c.QueryParam("lang")
+	if lang == "" {
+		lang = service.FallbackExplanationLang
+	}
+	if libraryvalidation.ValidateLang(lang) {
+		return controller.BadRequest("Invalid lang type")
+	}
+
+	response, appErr := controller.attemptService.GetAttemptReview(ctx, token, examId, attemptId, lang)
+	if appErr != nil {
+		return controller.responseError("Error getting attempt review", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Get attempt review successfully")
+}
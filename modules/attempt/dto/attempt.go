@@ -0,0 +1,122 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	librarydto "pirate-lang-go/modules/library/dto"
+	"time"
+)
+
+type AttemptResponse struct {
+	AttemptID        uuid.UUID                   `json:"attempt_id"`
+	ExamID           uuid.UUID                   `json:"exam_id"`
+	Status           string                      `json:"status"`
+	StartedAt        time.Time                   `json:"started_at"`
+	DeadlineAt       *time.Time                  `json:"deadline_at"`
+	SubmittedAt      *time.Time                  `json:"submitted_at"`
+	RemainingSeconds *int64                      `json:"remaining_seconds"`
+	Paragraphs       []*AttemptParagraphResponse `json:"paragraphs,omitempty"`
+	Questions        []*AttemptQuestionResponse  `json:"questions,omitempty"`
+	LockedPartIDs    []uuid.UUID                 `json:"locked_part_ids,omitempty"` // subscription parts hidden from the caller
+}
+type AttemptQuestionResponse struct {
+	QuestionID           uuid.UUID                 `json:"question_id"`
+	PartID               uuid.UUID                 `json:"part_id"`
+	ParagraphID          *uuid.UUID                `json:"paragraph_id"`
+	SequenceNumber       int32                     `json:"sequence_number"`
+	QuestionContent      string                    `json:"question_content"`
+	QuestionType         string                    `json:"question_type"`
+	AudioUrl             string                    `json:"audio_url"`
+	ImageUrl             string                    `json:"image_url"`
+	ToeicQuestionSection string                    `json:"toeic_question_section"`
+	QuestionNumberInPart int32                     `json:"question_number_in_part"`
+	AnswerOption         librarydto.AnswerOption   `json:"answer_option"`
+	Answer               *string                   `json:"answer"`
+	AnsweredAt           *time.Time                `json:"answered_at"`
+	PreparationSeconds   *int32                    `json:"preparation_seconds,omitempty"` // Speaking and Writing questions only
+	ResponseSeconds      *int32                    `json:"response_seconds,omitempty"`
+	Response             *CapturedResponseResponse `json:"response,omitempty"`
+}
+
+// CapturedResponseResponse is the recording or essay given to a Speaking or Writing question. The recording
+// or essay may be sent from PreparationEndsAt until ResponseEndsAt.
+type CapturedResponseResponse struct {
+	QuestionID        uuid.UUID          `json:"question_id"`
+	ResponseType      string             `json:"response_type"`
+	StartedAt         time.Time          `json:"started_at"`
+	PreparationEndsAt time.Time          `json:"preparation_ends_at"`
+	ResponseEndsAt    time.Time          `json:"response_ends_at"`
+	AudioUrl          string             `json:"audio_url,omitempty"`
+	TextResponse      string             `json:"text_response,omitempty"`
+	WordCount         *int32             `json:"word_count,omitempty"`
+	SubmittedAt       *time.Time         `json:"submitted_at"`
+	AutoScore         *AutoScoreResponse `json:"auto_score,omitempty"`
+}
+
+// AutoScoreResponse is the pre-score of an Essay or OpenResponse answer by the automated scorer. It only
+// guides raters and learners; the human rating decides the result. Score is null when the scorer could
+// not score the response.
+type AutoScoreResponse struct {
+	Score    *int32    `json:"score"`
+	MaxScore int32     `json:"max_score"`
+	Feedback []string  `json:"feedback"`
+	Scorer   string    `json:"scorer"`
+	ScoredAt time.Time `json:"scored_at"`
+}
+type AttemptParagraphResponse struct {
+	ParagraphID      uuid.UUID `json:"paragraph_id"`
+	ParagraphContent string    `json:"paragraph_content"`
+	Title            string    `json:"title"`
+	PartID           uuid.UUID `json:"part_id"`
+	ParagraphOrder   int32     `json:"paragraph_order"`
+	ParagraphType    string    `json:"paragraph_type"`
+	AudioUrl         string    `json:"audio_url"`
+	ImageUrl         string    `json:"image_url"`
+}
+type AnswerRequest struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Answer     string    `json:"answer"`
+}
+type SaveAnswersRequest struct {
+	Answers []AnswerRequest `json:"answers"`
+}
+type SubmitWrittenResponseRequest struct {
+	Text string `json:"text"`
+}
+
+// AttemptReviewResponse is a submitted attempt with the answer keys and explanations, in the language
+// asked for where the explanations were translated.
+type AttemptReviewResponse struct {
+	AttemptID     uuid.UUID                   `json:"attempt_id"`
+	ExamID        uuid.UUID                   `json:"exam_id"`
+	Status        string                      `json:"status"`
+	SubmittedAt   *time.Time                  `json:"submitted_at"`
+	Lang          string                      `json:"lang"`
+	Paragraphs    []*AttemptParagraphResponse `json:"paragraphs"`
+	Questions     []*ReviewQuestionResponse   `json:"questions"`
+	LockedPartIDs []uuid.UUID                 `json:"locked_part_ids,omitempty"` // subscription parts hidden from the caller
+}
+type ReviewQuestionResponse struct {
+	QuestionID           uuid.UUID               `json:"question_id"`
+	PartID               uuid.UUID               `json:"part_id"`
+	ParagraphID          *uuid.UUID              `json:"paragraph_id"`
+	SequenceNumber       int32                   `json:"sequence_number"`
+	QuestionContent      string                  `json:"question_content"`
+	QuestionType         string                  `json:"question_type"`
+	AudioUrl             string                  `json:"audio_url"`
+	ImageUrl             string                  `json:"image_url"`
+	ToeicQuestionSection string                  `json:"toeic_question_section"`
+	QuestionNumberInPart int32                   `json:"question_number_in_part"`
+	AnswerOption         librarydto.AnswerOption `json:"answer_option"`
+	Answer               *string                 `json:"answer"`
+	CorrectAnswer        *string                 `json:"correct_answer"`
+	IsCorrect            *bool                   `json:"is_correct"`
+	Explanation          *ExplanationResponse    `json:"explanation,omitempty"` // left out when none was written, or when the key changed since the attempt
+}
+
+// ExplanationResponse is in the language asked for, or in English when it was not translated; Lang tells
+// which.
+type ExplanationResponse struct {
+	Lang               string            `json:"lang"`
+	Explanation        string            `json:"explanation"`
+	OptionExplanations map[string]string `json:"option_explanations"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ConversionTableResponse struct {
+	TableID     uuid.UUID                  `json:"table_id"`
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	IsDefault   bool                       `json:"is_default"`
+	CreatedAt   time.Time                  `json:"created_at"`
+	UpdatedAt   time.Time                  `json:"updated_at"`
+	Entries     []*ConversionEntryResponse `json:"entries,omitempty"`
+}
+type ConversionEntryResponse struct {
+	Section     string `json:"section"`
+	RawScore    int32  `json:"raw_score"`
+	ScaledScore int32  `json:"scaled_score"`
+}
+type CreateConversionTableRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+type ConversionEntryRequest struct {
+	Section     string `json:"section"`
+	RawScore    int32  `json:"raw_score"`
+	ScaledScore int32  `json:"scaled_score"`
+}
+type UpdateConversionEntriesRequest struct {
+	Entries []ConversionEntryRequest `json:"entries"`
+}
+type SetExamConversionTableRequest struct {
+	TableID *uuid.UUID `json:"table_id"`
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type RubricBandResponse struct {
+	Score       int32  `json:"score"`
+	Description string `json:"description"`
+}
+type RubricResponse struct {
+	MaxScore int32                `json:"max_score"`
+	Criteria []string             `json:"criteria"`
+	Bands    []RubricBandResponse `json:"bands"`
+}
+
+// RatingTaskResponse is a response assigned to a rater. It leaves out who gave the response, so ratings
+// stay blind.
+type RatingTaskResponse struct {
+	RatingID             uuid.UUID          `json:"rating_id"`
+	Round                int32              `json:"round"`
+	AssignedAt           time.Time          `json:"assigned_at"`
+	QuestionID           uuid.UUID          `json:"question_id"`
+	QuestionContent      string             `json:"question_content"`
+	QuestionType         string             `json:"question_type"`
+	ToeicQuestionSection string             `json:"toeic_question_section"`
+	QuestionAudioUrl     string             `json:"question_audio_url"`
+	QuestionImageUrl     string             `json:"question_image_url"`
+	ResponseType         string             `json:"response_type"`
+	AudioUrl             string             `json:"audio_url,omitempty"`
+	TextResponse         string             `json:"text_response,omitempty"`
+	WordCount            int32              `json:"word_count"`
+	SubmittedAt          *time.Time         `json:"submitted_at"`
+	AutoScore            *AutoScoreResponse `json:"auto_score,omitempty"`
+	Rubric               RubricResponse     `json:"rubric"`
+}
+type SubmitRatingRequest struct {
+	Score   *int32 `json:"score"`
+	Comment string `json:"comment"`
+}
+
+// ResponseRatingResponse is a submitted rating with the rating status of the response it rates.
+type ResponseRatingResponse struct {
+	RatingID     uuid.UUID  `json:"rating_id"`
+	QuestionID   uuid.UUID  `json:"question_id"`
+	Round        int32      `json:"round"`
+	Score        *int32     `json:"score"`
+	MaxScore     int32      `json:"max_score"`
+	Comment      string     `json:"comment"`
+	RatedAt      *time.Time `json:"rated_at"`
+	RatingStatus string     `json:"rating_status"`
+}
+type RatingQueueResponse struct {
+	AwaitingRating       int32 `json:"awaiting_rating"`
+	AwaitingAdjudication int32 `json:"awaiting_adjudication"`
+	Assigned             int32 `json:"assigned"`
+}
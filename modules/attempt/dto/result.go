@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type SectionScoreResponse struct {
+	Correct int32  `json:"correct"`
+	Total   int32  `json:"total"`
+	Scaled  *int32 `json:"scaled"`
+}
+
+// RatedSectionScoreResponse is the rubric score of the Speaking or Writing responses of an attempt. Scaled
+// stays null while Pending responses wait for a rating.
+type RatedSectionScoreResponse struct {
+	Points    int32  `json:"points"`
+	MaxPoints int32  `json:"max_points"`
+	Pending   int32  `json:"pending"`
+	Scaled    *int32 `json:"scaled"`
+}
+
+// AttemptResultResponse keeps the two TOEIC score reports apart: TotalScaled adds up Listening and
+// Reading, SpeakingWritingScaled adds up Speaking and Writing once they are fully rated.
+type AttemptResultResponse struct {
+	AttemptID             uuid.UUID                  `json:"attempt_id"`
+	ExamID                uuid.UUID                  `json:"exam_id"`
+	Status                string                     `json:"status"`
+	Listening             SectionScoreResponse       `json:"listening"`
+	Reading               SectionScoreResponse       `json:"reading"`
+	Speaking              *RatedSectionScoreResponse `json:"speaking,omitempty"`
+	Writing               *RatedSectionScoreResponse `json:"writing,omitempty"`
+	TotalScaled           *int32                     `json:"total_scaled"`
+	SpeakingWritingScaled *int32                     `json:"speaking_writing_scaled,omitempty"`
+	ConversionTableID     *uuid.UUID                 `json:"conversion_table_id"`
+	ScoredAt              time.Time                  `json:"scored_at"`
+}
@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Attempt struct {
+	AttemptID   uuid.UUID  `json:"attempt_id"`
+	ExamID      uuid.UUID  `json:"exam_id"`
+	UserID      uuid.UUID  `json:"user_id"`
+	Status      string     `json:"status"`
+	StartedAt   time.Time  `json:"started_at"`
+	DeadlineAt  *time.Time `json:"deadline_at"`
+	SubmittedAt *time.Time `json:"submitted_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+type AttemptQuestion struct {
+	AttemptID            uuid.UUID         `json:"attempt_id"`
+	QuestionID           uuid.UUID         `json:"question_id"`
+	PartID               uuid.UUID         `json:"part_id"`
+	ParagraphID          uuid.UUID         `json:"paragraph_id"`
+	SequenceNumber       int32             `json:"sequence_number"`
+	Answer               string            `json:"answer"`
+	AnsweredAt           *time.Time        `json:"answered_at"`
+	QuestionContent      string            `json:"question_content"`
+	QuestionType         string            `json:"question_type"`
+	AudioUrl             string            `json:"audio_url"`
+	ImageUrl             string            `json:"image_url"`
+	ToeicQuestionSection string            `json:"toeic_question_section"`
+	QuestionNumberInPart int32             `json:"question_number_in_part"`
+	AnswerOption         string            `json:"answer_option"`
+	PreparationSeconds   *int32            `json:"preparation_seconds"` // nil uses the default of the question type
+	ResponseSeconds      *int32            `json:"response_seconds"`
+	Response             *CapturedResponse `json:"response"`
+}
+
+// CapturedResponse is the recording or essay given to a Speaking or Writing question. Its windows are
+// fixed when the learner starts the question, so a later edit of the question does not move them.
+type CapturedResponse struct {
+	AttemptID          uuid.UUID  `json:"attempt_id"`
+	QuestionID         uuid.UUID  `json:"question_id"`
+	ResponseType       string     `json:"response_type"`
+	StartedAt          time.Time  `json:"started_at"`
+	PreparationSeconds int32      `json:"preparation_seconds"`
+	ResponseSeconds    int32      `json:"response_seconds"`
+	AudioObject        string     `json:"audio_object"`
+	AudioUrl           string     `json:"audio_url"`
+	TextResponse       string     `json:"text_response"`
+	WordCount          int32      `json:"word_count"`
+	SubmittedAt        *time.Time `json:"submitted_at"`
+	AutoScore          *AutoScore `json:"auto_score"`
+}
+
+// AutoScore is the pre-score of a response by the automated scorer. Score is nil when the scorer could
+// not handle the response.
+type AutoScore struct {
+	Score    *int32    `json:"score"`
+	MaxScore int32     `json:"max_score"`
+	Feedback []string  `json:"feedback"`
+	Scorer   string    `json:"scorer"`
+	ScoredAt time.Time `json:"scored_at"`
+}
+
+// ResponseToAutoScore is a submitted response waiting for its pre-score, with the question it answers.
+type ResponseToAutoScore struct {
+	AttemptID            uuid.UUID `json:"attempt_id"`
+	QuestionID           uuid.UUID `json:"question_id"`
+	ResponseType         string    `json:"response_type"`
+	AudioUrl             string    `json:"audio_url"`
+	TextResponse         string    `json:"text_response"`
+	QuestionContent      string    `json:"question_content"`
+	QuestionType         string    `json:"question_type"`
+	ToeicQuestionSection string    `json:"toeic_question_section"`
+}
+
+func (r *CapturedResponse) PreparationEndsAt() time.Time {
+	return r.StartedAt.Add(time.Duration(r.PreparationSeconds) * time.Second)
+}
+
+func (r *CapturedResponse) ResponseEndsAt() time.Time {
+	return r.PreparationEndsAt().Add(time.Duration(r.ResponseSeconds) * time.Second)
+}
+
+// ReviewQuestion is a question of a submitted attempt with its answer key and the explanation in the
+// language asked for. ExplanationLang is empty when the question has no explanation.
+type ReviewQuestion struct {
+	QuestionID           uuid.UUID         `json:"question_id"`
+	PartID               uuid.UUID         `json:"part_id"`
+	ParagraphID          uuid.UUID         `json:"paragraph_id"`
+	SequenceNumber       int32             `json:"sequence_number"`
+	Answer               string            `json:"answer"`
+	IsCorrect            *bool             `json:"is_correct"` // nil for questions not graded automatically
+	QuestionContent      string            `json:"question_content"`
+	QuestionType         string            `json:"question_type"`
+	AudioUrl             string            `json:"audio_url"`
+	ImageUrl             string            `json:"image_url"`
+	ToeicQuestionSection string            `json:"toeic_question_section"`
+	QuestionNumberInPart int32             `json:"question_number_in_part"`
+	AnswerOption         string            `json:"answer_option"`
+	CorrectAnswer        string            `json:"correct_answer"`
+	ExplanationLang      string            `json:"explanation_lang"`
+	Explanation          string            `json:"explanation"`
+	OptionExplanations   map[string]string `json:"option_explanations"`
+}
+
+type AttemptParagraph struct {
+	ParagraphID      uuid.UUID `json:"paragraph_id"`
+	ParagraphContent string    `json:"paragraph_content"`
+	Title            string    `json:"title"`
+	PartID           uuid.UUID `json:"part_id"`
+	ParagraphOrder   int32     `json:"paragraph_order"`
+	ParagraphType    string    `json:"paragraph_type"`
+	AudioUrl         string    `json:"audio_url"`
+	ImageUrl         string    `json:"image_url"`
+}
+type AttemptAnswer struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	Answer     string    `json:"answer"`
+}
+
+const (
+	AttemptStatusInProgress = "IN_PROGRESS"
+	AttemptStatusSubmitted  = "SUBMITTED"
+	AttemptStatusExpired    = "EXPIRED"
+)
+
+const (
+	SectionListening = "Listening"
+	SectionReading   = "Reading"
+	SectionSpeaking  = "Speaking"
+	SectionWriting   = "Writing"
+)
+
+const (
+	ResponseTypeAudio = "AUDIO"
+	ResponseTypeText  = "TEXT"
+)
+
+type ScoringQuestion struct {
+	QuestionID           uuid.UUID `json:"question_id"`
+	Answer               string    `json:"answer"`
+	QuestionType         string    `json:"question_type"`
+	ToeicQuestionSection string    `json:"toeic_question_section"`
+	CorrectAnswer        string    `json:"correct_answer"`
+}
+type GradedQuestion struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	IsCorrect  bool      `json:"is_correct"`
+}
+type ScoringConfig struct {
+	ExamID            uuid.UUID `json:"exam_id"`
+	MaxListeningScore int32     `json:"max_listening_score"`
+	MaxReadingScore   int32     `json:"max_reading_score"`
+	MaxSpeakingScore  int32     `json:"max_speaking_score"`
+	MaxWritingScore   int32     `json:"max_writing_score"`
+	ConversionTableID uuid.UUID `json:"conversion_table_id"`
+}
+type AttemptResult struct {
+	AttemptID         uuid.UUID         `json:"attempt_id"`
+	ConversionTableID uuid.UUID         `json:"conversion_table_id"`
+	ListeningCorrect  int32             `json:"listening_correct"`
+	ListeningTotal    int32             `json:"listening_total"`
+	ListeningScaled   *int32            `json:"listening_scaled"`
+	ReadingCorrect    int32             `json:"reading_correct"`
+	ReadingTotal      int32             `json:"reading_total"`
+	ReadingScaled     *int32            `json:"reading_scaled"`
+	TotalScaled       *int32            `json:"total_scaled"`
+	ScoredAt          time.Time         `json:"scored_at"`
+	Speaking          RatedSectionScore `json:"speaking"`
+	Writing           RatedSectionScore `json:"writing"`
+}
+
+// SpeakingWritingScaled adds up the scaled Speaking and Writing scores, the total of a TOEIC S&W score
+// report. It is nil while a section of the exam is not fully rated, and when the exam has neither.
+func (r *AttemptResult) SpeakingWritingScaled() *int32 {
+	var total int32
+	sections := 0
+	for _, score := range []RatedSectionScore{r.Speaking, r.Writing} {
+		if score.MaxPoints == 0 {
+			continue
+		}
+		if score.Scaled == nil {
+			return nil
+		}
+		total += *score.Scaled
+		sections++
+	}
+	if sections == 0 {
+		return nil
+	}
+	return &total
+}
+
+// RatedSectionScore rolls up the rubric points of the rated responses of a section. Scaled stays nil
+// while Pending responses wait for a rating.
+type RatedSectionScore struct {
+	Points    int32  `json:"points"`
+	MaxPoints int32  `json:"max_points"`
+	Pending   int32  `json:"pending"`
+	Scaled    *int32 `json:"scaled"`
+}
+type ScoreConversionTable struct {
+	TableID     uuid.UUID               `json:"table_id"`
+	Name        string                  `json:"name"`
+	Description string                  `json:"description"`
+	IsDefault   bool                    `json:"is_default"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+	Entries     []*ScoreConversionEntry `json:"entries"`
+}
+type ScoreConversionEntry struct {
+	Section     string `json:"section"`
+	RawScore    int32  `json:"raw_score"`
+	ScaledScore int32  `json:"scaled_score"`
+}
+
+const (
+	RatingStatusPending      = "PENDING"
+	RatingStatusAdjudication = "ADJUDICATION"
+	RatingStatusRated        = "RATED"
+)
+
+// Rating rounds: two independent ratings, then an adjudication when they differ.
+const (
+	RatingRoundFirst        = 1
+	RatingRoundSecond       = 2
+	RatingRoundAdjudication = 3
+)
+
+type ResponseRating struct {
+	RatingID   uuid.UUID  `json:"rating_id"`
+	AttemptID  uuid.UUID  `json:"attempt_id"`
+	QuestionID uuid.UUID  `json:"question_id"`
+	RaterID    uuid.UUID  `json:"rater_id"`
+	Round      int32      `json:"round"`
+	Score      *int32     `json:"score"`
+	MaxScore   int32      `json:"max_score"`
+	Comment    string     `json:"comment"`
+	AssignedAt time.Time  `json:"assigned_at"`
+	RatedAt    *time.Time `json:"rated_at"`
+}
+
+// RatingTask is an assigned response with the question it answers, as shown to the rater.
+type RatingTask struct {
+	RatingID             uuid.UUID  `json:"rating_id"`
+	Round                int32      `json:"round"`
+	AssignedAt           time.Time  `json:"assigned_at"`
+	AttemptID            uuid.UUID  `json:"attempt_id"`
+	ExamID               uuid.UUID  `json:"exam_id"`
+	QuestionID           uuid.UUID  `json:"question_id"`
+	ResponseType         string     `json:"response_type"`
+	AudioUrl             string     `json:"audio_url"`
+	TextResponse         string     `json:"text_response"`
+	WordCount            int32      `json:"word_count"`
+	SubmittedAt          *time.Time `json:"submitted_at"`
+	QuestionContent      string     `json:"question_content"`
+	QuestionType         string     `json:"question_type"`
+	ToeicQuestionSection string     `json:"toeic_question_section"`
+	QuestionAudioUrl     string     `json:"question_audio_url"`
+	QuestionImageUrl     string     `json:"question_image_url"`
+	AutoScore            *AutoScore `json:"auto_score"`
+}
+
+// RatingState is a Speaking or Writing question of an attempt with the rating state of its response.
+type RatingState struct {
+	QuestionID           uuid.UUID `json:"question_id"`
+	QuestionType         string    `json:"question_type"`
+	ToeicQuestionSection string    `json:"toeic_question_section"`
+	Submitted            bool      `json:"submitted"`
+	RatingStatus         string    `json:"rating_status"`
+	FinalScore           int32     `json:"final_score"`
+	MaxScore             int32     `json:"max_score"`
+}
+
+type RatingQueueStats struct {
+	AwaitingRating       int32 `json:"awaiting_rating"`
+	AwaitingAdjudication int32 `json:"awaiting_adjudication"`
+	Assigned             int32 `json:"assigned"`
+}
+
+// Rubric is the scoring form of a task type: a score from 0 to MaxScore, each described by a band.
+type Rubric struct {
+	MaxScore int32        `json:"max_score"`
+	Criteria []string     `json:"criteria"`
+	Bands    []RubricBand `json:"bands"`
+}
+type RubricBand struct {
+	Score       int32  `json:"score"`
+	Description string `json:"description"`
+}
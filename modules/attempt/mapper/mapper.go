@@ -0,0 +1,373 @@
+package mapper
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	librarydto "pirate-lang-go/modules/library/dto"
+	libraryentity "pirate-lang-go/modules/library/entity"
+	librarymapper "pirate-lang-go/modules/library/mapper"
+	"time"
+)
+
+func ToAttemptResponse(attempt *entity.Attempt, now time.Time) *dto.AttemptResponse {
+	if attempt == nil {
+		return nil
+	}
+	response := &dto.AttemptResponse{
+		AttemptID:   attempt.AttemptID,
+		ExamID:      attempt.ExamID,
+		Status:      attempt.Status,
+		StartedAt:   attempt.StartedAt,
+		DeadlineAt:  attempt.DeadlineAt,
+		SubmittedAt: attempt.SubmittedAt,
+	}
+	if attempt.DeadlineAt != nil && attempt.Status == entity.AttemptStatusInProgress {
+		remaining := int64(attempt.DeadlineAt.Sub(now).Seconds())
+		if remaining < 0 {
+			remaining = 0
+		}
+		response.RemainingSeconds = &remaining
+	}
+	return response
+}
+
+func ToAttemptsResponse(attempts []*entity.Attempt, now time.Time) []*dto.AttemptResponse {
+	responses := make([]*dto.AttemptResponse, 0, len(attempts))
+	for _, attempt := range attempts {
+		responses = append(responses, ToAttemptResponse(attempt, now))
+	}
+	return responses
+}
+
+func ToAttemptQuestionResponse(question *entity.AttemptQuestion) *dto.AttemptQuestionResponse {
+	if question == nil {
+		return nil
+	}
+	answerOption, err := librarymapper.UnmarshalAnswerOption(question.AnswerOption)
+	if err != nil {
+		answerOption = librarydto.AnswerOption{}
+	}
+	response := &dto.AttemptQuestionResponse{
+		QuestionID:           question.QuestionID,
+		PartID:               question.PartID,
+		SequenceNumber:       question.SequenceNumber,
+		QuestionContent:      question.QuestionContent,
+		QuestionType:         question.QuestionType,
+		AudioUrl:             question.AudioUrl,
+		ImageUrl:             question.ImageUrl,
+		ToeicQuestionSection: question.ToeicQuestionSection,
+		QuestionNumberInPart: question.QuestionNumberInPart,
+		AnswerOption:         answerOption,
+		AnsweredAt:           question.AnsweredAt,
+	}
+	if question.ParagraphID != uuid.Nil {
+		paragraphID := question.ParagraphID
+		response.ParagraphID = &paragraphID
+	}
+	if question.Answer != "" {
+		answer := question.Answer
+		response.Answer = &answer
+	}
+	if question.ToeicQuestionSection == entity.SectionSpeaking || question.ToeicQuestionSection == entity.SectionWriting {
+		response.PreparationSeconds = question.PreparationSeconds
+		response.ResponseSeconds = question.ResponseSeconds
+		response.Response = ToCapturedResponseResponse(question.Response)
+	}
+	return response
+}
+
+func ToReviewQuestionResponse(question *entity.ReviewQuestion) *dto.ReviewQuestionResponse {
+	if question == nil {
+		return nil
+	}
+	answerOption, err := librarymapper.UnmarshalAnswerOption(question.AnswerOption)
+	if err != nil {
+		answerOption = librarydto.AnswerOption{}
+	}
+	response := &dto.ReviewQuestionResponse{
+		QuestionID:           question.QuestionID,
+		PartID:               question.PartID,
+		SequenceNumber:       question.SequenceNumber,
+		QuestionContent:      question.QuestionContent,
+		QuestionType:         question.QuestionType,
+		AudioUrl:             question.AudioUrl,
+		ImageUrl:             question.ImageUrl,
+		ToeicQuestionSection: question.ToeicQuestionSection,
+		QuestionNumberInPart: question.QuestionNumberInPart,
+		AnswerOption:         answerOption,
+		IsCorrect:            question.IsCorrect,
+	}
+	if question.ParagraphID != uuid.Nil {
+		paragraphID := question.ParagraphID
+		response.ParagraphID = &paragraphID
+	}
+	if question.Answer != "" {
+		answer := question.Answer
+		response.Answer = &answer
+	}
+	if question.CorrectAnswer != "" {
+		correctAnswer := question.CorrectAnswer
+		response.CorrectAnswer = &correctAnswer
+	}
+	if question.ExplanationLang != "" {
+		response.Explanation = &dto.ExplanationResponse{
+			Lang:               question.ExplanationLang,
+			Explanation:        question.Explanation,
+			OptionExplanations: question.OptionExplanations,
+		}
+	}
+	return response
+}
+
+func ToReviewQuestionsResponse(questions []*entity.ReviewQuestion) []*dto.ReviewQuestionResponse {
+	responses := make([]*dto.ReviewQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		responses = append(responses, ToReviewQuestionResponse(question))
+	}
+	return responses
+}
+
+func ToCapturedResponseResponse(captured *entity.CapturedResponse) *dto.CapturedResponseResponse {
+	if captured == nil {
+		return nil
+	}
+	response := &dto.CapturedResponseResponse{
+		QuestionID:        captured.QuestionID,
+		ResponseType:      captured.ResponseType,
+		StartedAt:         captured.StartedAt,
+		PreparationEndsAt: captured.PreparationEndsAt(),
+		ResponseEndsAt:    captured.ResponseEndsAt(),
+		AudioUrl:          captured.AudioUrl,
+		TextResponse:      captured.TextResponse,
+		SubmittedAt:       captured.SubmittedAt,
+		AutoScore:         ToAutoScoreResponse(captured.AutoScore),
+	}
+	if captured.ResponseType == entity.ResponseTypeText && captured.SubmittedAt != nil {
+		wordCount := captured.WordCount
+		response.WordCount = &wordCount
+	}
+	return response
+}
+
+func ToAttemptQuestionsResponse(questions []*entity.AttemptQuestion) []*dto.AttemptQuestionResponse {
+	responses := make([]*dto.AttemptQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		responses = append(responses, ToAttemptQuestionResponse(question))
+	}
+	return responses
+}
+
+// ToAttemptParagraphsResponse leaves out the content of audio scripts, which would give away
+// the listening answers while the attempt is taken.
+func ToAttemptParagraphsResponse(paragraphs []*entity.AttemptParagraph) []*dto.AttemptParagraphResponse {
+	responses := make([]*dto.AttemptParagraphResponse, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		response := toAttemptParagraphResponse(paragraph)
+		if paragraph.ParagraphType == libraryentity.ParagraphTypeAudioScript {
+			response.ParagraphContent = ""
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// ToReviewParagraphsResponse includes audio scripts, for reviewing a submitted attempt.
+func ToReviewParagraphsResponse(paragraphs []*entity.AttemptParagraph) []*dto.AttemptParagraphResponse {
+	responses := make([]*dto.AttemptParagraphResponse, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		responses = append(responses, toAttemptParagraphResponse(paragraph))
+	}
+	return responses
+}
+
+func toAttemptParagraphResponse(paragraph *entity.AttemptParagraph) *dto.AttemptParagraphResponse {
+	return &dto.AttemptParagraphResponse{
+		ParagraphID:      paragraph.ParagraphID,
+		ParagraphContent: paragraph.ParagraphContent,
+		Title:            paragraph.Title,
+		PartID:           paragraph.PartID,
+		ParagraphOrder:   paragraph.ParagraphOrder,
+		ParagraphType:    paragraph.ParagraphType,
+		AudioUrl:         paragraph.AudioUrl,
+		ImageUrl:         paragraph.ImageUrl,
+	}
+}
+
+func ToAttemptAnswerEntities(request *dto.SaveAnswersRequest) []*entity.AttemptAnswer {
+	if request == nil {
+		return nil
+	}
+	answers := make([]*entity.AttemptAnswer, 0, len(request.Answers))
+	for _, answer := range request.Answers {
+		answers = append(answers, &entity.AttemptAnswer{
+			QuestionID: answer.QuestionID,
+			Answer:     answer.Answer,
+		})
+	}
+	return answers
+}
+
+func ToAttemptResultResponse(attempt *entity.Attempt, result *entity.AttemptResult) *dto.AttemptResultResponse {
+	if attempt == nil || result == nil {
+		return nil
+	}
+	response := &dto.AttemptResultResponse{
+		AttemptID: attempt.AttemptID,
+		ExamID:    attempt.ExamID,
+		Status:    attempt.Status,
+		Listening: dto.SectionScoreResponse{
+			Correct: result.ListeningCorrect,
+			Total:   result.ListeningTotal,
+			Scaled:  result.ListeningScaled,
+		},
+		Reading: dto.SectionScoreResponse{
+			Correct: result.ReadingCorrect,
+			Total:   result.ReadingTotal,
+			Scaled:  result.ReadingScaled,
+		},
+		TotalScaled: result.TotalScaled,
+		ScoredAt:    result.ScoredAt,
+	}
+	if result.ConversionTableID != uuid.Nil {
+		tableID := result.ConversionTableID
+		response.ConversionTableID = &tableID
+	}
+	response.Speaking = toRatedSectionScoreResponse(result.Speaking)
+	response.Writing = toRatedSectionScoreResponse(result.Writing)
+	response.SpeakingWritingScaled = result.SpeakingWritingScaled()
+	return response
+}
+
+// toRatedSectionScoreResponse leaves out the sections the exam has no question for.
+func toRatedSectionScoreResponse(score entity.RatedSectionScore) *dto.RatedSectionScoreResponse {
+	if score.MaxPoints == 0 {
+		return nil
+	}
+	return &dto.RatedSectionScoreResponse{
+		Points:    score.Points,
+		MaxPoints: score.MaxPoints,
+		Pending:   score.Pending,
+		Scaled:    score.Scaled,
+	}
+}
+
+func ToRubricResponse(rubric entity.Rubric) dto.RubricResponse {
+	response := dto.RubricResponse{
+		MaxScore: rubric.MaxScore,
+		Criteria: rubric.Criteria,
+		Bands:    make([]dto.RubricBandResponse, 0, len(rubric.Bands)),
+	}
+	for _, band := range rubric.Bands {
+		response.Bands = append(response.Bands, dto.RubricBandResponse{Score: band.Score, Description: band.Description})
+	}
+	return response
+}
+
+func ToRatingTaskResponse(task *entity.RatingTask, rubric entity.Rubric) *dto.RatingTaskResponse {
+	if task == nil {
+		return nil
+	}
+	return &dto.RatingTaskResponse{
+		RatingID:             task.RatingID,
+		Round:                task.Round,
+		AssignedAt:           task.AssignedAt,
+		QuestionID:           task.QuestionID,
+		QuestionContent:      task.QuestionContent,
+		QuestionType:         task.QuestionType,
+		ToeicQuestionSection: task.ToeicQuestionSection,
+		QuestionAudioUrl:     task.QuestionAudioUrl,
+		QuestionImageUrl:     task.QuestionImageUrl,
+		ResponseType:         task.ResponseType,
+		AudioUrl:             task.AudioUrl,
+		TextResponse:         task.TextResponse,
+		WordCount:            task.WordCount,
+		SubmittedAt:          task.SubmittedAt,
+		AutoScore:            ToAutoScoreResponse(task.AutoScore),
+		Rubric:               ToRubricResponse(rubric),
+	}
+}
+
+func ToAutoScoreResponse(autoScore *entity.AutoScore) *dto.AutoScoreResponse {
+	if autoScore == nil {
+		return nil
+	}
+	return &dto.AutoScoreResponse{
+		Score:    autoScore.Score,
+		MaxScore: autoScore.MaxScore,
+		Feedback: autoScore.Feedback,
+		Scorer:   autoScore.Scorer,
+		ScoredAt: autoScore.ScoredAt,
+	}
+}
+
+func ToResponseRatingResponse(rating *entity.ResponseRating, status string) *dto.ResponseRatingResponse {
+	if rating == nil {
+		return nil
+	}
+	return &dto.ResponseRatingResponse{
+		RatingID:     rating.RatingID,
+		QuestionID:   rating.QuestionID,
+		Round:        rating.Round,
+		Score:        rating.Score,
+		MaxScore:     rating.MaxScore,
+		Comment:      rating.Comment,
+		RatedAt:      rating.RatedAt,
+		RatingStatus: status,
+	}
+}
+
+func ToConversionTableResponse(table *entity.ScoreConversionTable) *dto.ConversionTableResponse {
+	if table == nil {
+		return nil
+	}
+	response := &dto.ConversionTableResponse{
+		TableID:     table.TableID,
+		Name:        table.Name,
+		Description: table.Description,
+		IsDefault:   table.IsDefault,
+		CreatedAt:   table.CreatedAt,
+		UpdatedAt:   table.UpdatedAt,
+	}
+	for _, entry := range table.Entries {
+		response.Entries = append(response.Entries, &dto.ConversionEntryResponse{
+			Section:     entry.Section,
+			RawScore:    entry.RawScore,
+			ScaledScore: entry.ScaledScore,
+		})
+	}
+	return response
+}
+
+func ToConversionTablesResponse(tables []*entity.ScoreConversionTable) []*dto.ConversionTableResponse {
+	responses := make([]*dto.ConversionTableResponse, 0, len(tables))
+	for _, table := range tables {
+		responses = append(responses, ToConversionTableResponse(table))
+	}
+	return responses
+}
+
+func ToCreateConversionTableEntity(request *dto.CreateConversionTableRequest) *entity.ScoreConversionTable {
+	if request == nil {
+		return nil
+	}
+	return &entity.ScoreConversionTable{
+		Name:        request.Name,
+		Description: request.Description,
+	}
+}
+
+func ToConversionEntryEntities(request *dto.UpdateConversionEntriesRequest) []*entity.ScoreConversionEntry {
+	if request == nil {
+		return nil
+	}
+	entries := make([]*entity.ScoreConversionEntry, 0, len(request.Entries))
+	for _, entry := range request.Entries {
+		entries = append(entries, &entity.ScoreConversionEntry{
+			Section:     entry.Section,
+			RawScore:    entry.RawScore,
+			ScaledScore: entry.ScaledScore,
+		})
+	}
+	return entries
+}
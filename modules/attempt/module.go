@@ -0,0 +1,54 @@
+package attempt
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	accountservice "pirate-lang-go/modules/account/service"
+	"pirate-lang-go/modules/attempt/controller"
+	"pirate-lang-go/modules/attempt/repository"
+	"pirate-lang-go/modules/attempt/router"
+	"pirate-lang-go/modules/attempt/scorer"
+	"pirate-lang-go/modules/attempt/service"
+	libraryrepo "pirate-lang-go/modules/library/repository"
+	subscriptionrepo "pirate-lang-go/modules/subscription/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+)
+
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, mailer mailer.IMailer) {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	accountService := accountservice.NewAccountService(accountRepository, cache, storage, mailer)
+	middleware := middleware.NewMiddleware(accountService)
+	router.NewAttemptRouter(
+		controller.NewAttemptController(NewService(db, cache, storage)),
+	).Setup(e, middleware)
+}
+
+// NewService builds the attempt service, for the HTTP routes and the background workers.
+func NewService(db database.Database, cache *cache.Cache, storage *storage.Storage) service.IAttemptService {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	subscriptionService := subscriptionservice.NewSubscriptionService(subscriptionrepo.NewSubscriptionRepository(db.DB()), accountRepository, cache)
+	return service.NewAttemptService(repository.NewAttemptRepository(db.DB()), libraryrepo.NewLibraryRepository(db.DB()), accountRepository, cache, subscriptionService, storage, newAutoScorer())
+}
+
+// NewAutoScoreWorker builds the background pre-scoring of the Essay and OpenResponse answers.
+func NewAutoScoreWorker(db database.Database, cache *cache.Cache, storage *storage.Storage) *service.AutoScoreWorker {
+	return service.NewAutoScoreWorker(NewService(db, cache, storage), service.DefaultAutoScoreConfig())
+}
+
+// newAutoScorer builds the configured scorer; a misconfigured one falls back to the heuristic scorer, so
+// responses still get a pre-score.
+func newAutoScorer() scorer.AutoScorer {
+	autoScorer, err := scorer.NewFromConfig(config.Get().AutoScoring)
+	if err != nil {
+		logger.Error("Invalid auto scoring config, using the heuristic scorer", "error", err)
+		return scorer.NewHeuristicScorer()
+	}
+	return autoScorer
+}
@@ -0,0 +1,255 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+	"time"
+)
+
+func (r *AttemptRepository) CreateAttempt(ctx context.Context, examId, userId uuid.UUID, durationMinutes int32, questions []*entity.AttemptQuestion) (*entity.Attempt, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AttemptRepository.CreateAttempt: failed to begin transaction", "exam_id", examId, "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	attemptDB, err := qtx.CreateExamAttempt(ctx, database.CreateExamAttemptParams{
+		ExamID:          examId,
+		UserID:          userId,
+		DurationMinutes: sql.NullInt32{Int32: durationMinutes, Valid: durationMinutes > 0},
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.CreateAttempt: failed to create attempt", "exam_id", examId, "user_id", userId, "error", err)
+		return nil, err
+	}
+	for _, question := range questions {
+		err = qtx.CreateAttemptQuestion(ctx, database.CreateAttemptQuestionParams{
+			AttemptID:      attemptDB.AttemptID,
+			QuestionID:     question.QuestionID,
+			PartID:         question.PartID,
+			ParagraphID:    uuid.NullUUID{UUID: question.ParagraphID, Valid: question.ParagraphID != uuid.Nil},
+			SequenceNumber: question.SequenceNumber,
+		})
+		if err != nil {
+			logger.Error("AttemptRepository.CreateAttempt: failed to add question to attempt",
+				"attempt_id", attemptDB.AttemptID,
+				"question_id", question.QuestionID,
+				"error", err)
+			return nil, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AttemptRepository.CreateAttempt: failed to commit transaction", "exam_id", examId, "error", err)
+		return nil, err
+	}
+	return toAttemptEntity(attemptDB), nil
+}
+
+func (r *AttemptRepository) GetAttempt(ctx context.Context, attemptId, userId uuid.UUID) (*entity.Attempt, error) {
+	attemptDB, err := r.Queries.GetExamAttempt(ctx, database.GetExamAttemptParams{
+		AttemptID: attemptId,
+		UserID:    userId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetAttempt: failed to get attempt", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	return toAttemptEntity(attemptDB), nil
+}
+
+func (r *AttemptRepository) GetInProgressAttempt(ctx context.Context, userId, examId uuid.UUID) (*entity.Attempt, error) {
+	attemptDB, err := r.Queries.GetInProgressExamAttempt(ctx, database.GetInProgressExamAttemptParams{
+		UserID: userId,
+		ExamID: examId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetInProgressAttempt: failed to get attempt", "exam_id", examId, "error", err)
+		return nil, err
+	}
+	return toAttemptEntity(attemptDB), nil
+}
+
+func (r *AttemptRepository) GetAttemptsByUser(ctx context.Context, userId, examId uuid.UUID) ([]*entity.Attempt, error) {
+	attemptDBs, err := r.Queries.ListExamAttemptsByUser(ctx, database.ListExamAttemptsByUserParams{
+		UserID: userId,
+		ExamID: examId,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.GetAttemptsByUser: failed to get attempts", "exam_id", examId, "error", err)
+		return nil, err
+	}
+	attempts := make([]*entity.Attempt, 0, len(attemptDBs))
+	for _, attemptDB := range attemptDBs {
+		attempts = append(attempts, toAttemptEntity(attemptDB))
+	}
+	return attempts, nil
+}
+
+func (r *AttemptRepository) GetAttemptQuestions(ctx context.Context, attemptId uuid.UUID) ([]*entity.AttemptQuestion, error) {
+	questionDBs, err := r.Queries.ListAttemptQuestions(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetAttemptQuestions: failed to get questions", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	questions := make([]*entity.AttemptQuestion, 0, len(questionDBs))
+	for _, questionDB := range questionDBs {
+		questions = append(questions, &entity.AttemptQuestion{
+			AttemptID:            questionDB.AttemptID,
+			QuestionID:           questionDB.QuestionID,
+			PartID:               questionDB.PartID,
+			ParagraphID:          questionDB.ParagraphID.UUID,
+			SequenceNumber:       questionDB.SequenceNumber,
+			Answer:               questionDB.Answer.String,
+			AnsweredAt:           nullTimeToPtr(questionDB.AnsweredAt),
+			QuestionContent:      questionDB.QuestionContent,
+			QuestionType:         questionDB.QuestionType,
+			AudioUrl:             questionDB.AudioUrl.String,
+			ImageUrl:             questionDB.ImageUrl.String,
+			ToeicQuestionSection: questionDB.ToeicQuestionSection,
+			QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
+			AnswerOption:         string(questionDB.AnswerOption.RawMessage),
+			PreparationSeconds:   nullInt32ToPtr(questionDB.PreparationSeconds),
+			ResponseSeconds:      nullInt32ToPtr(questionDB.ResponseSeconds),
+		})
+	}
+	return questions, nil
+}
+
+func (r *AttemptRepository) GetAttemptParagraphs(ctx context.Context, attemptId uuid.UUID) ([]*entity.AttemptParagraph, error) {
+	paragraphDBs, err := r.Queries.ListAttemptParagraphs(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetAttemptParagraphs: failed to get paragraphs", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	paragraphs := make([]*entity.AttemptParagraph, 0, len(paragraphDBs))
+	for _, paragraphDB := range paragraphDBs {
+		paragraphs = append(paragraphs, &entity.AttemptParagraph{
+			ParagraphID:      paragraphDB.ParagraphID,
+			ParagraphContent: paragraphDB.ParagraphContent,
+			Title:            paragraphDB.Title.String,
+			PartID:           paragraphDB.PartID,
+			ParagraphOrder:   paragraphDB.ParagraphOrder,
+			ParagraphType:    paragraphDB.ParagraphType.String,
+			AudioUrl:         paragraphDB.AudioUrl.String,
+			ImageUrl:         paragraphDB.ImageUrl.String,
+		})
+	}
+	return paragraphs, nil
+}
+
+// GetReviewQuestions returns the questions of an attempt with the explanation in lang, else in fallbackLang.
+func (r *AttemptRepository) GetReviewQuestions(ctx context.Context, attemptId uuid.UUID, lang, fallbackLang string) ([]*entity.ReviewQuestion, error) {
+	questionDBs, err := r.Queries.ListAttemptReviewQuestions(ctx, database.ListAttemptReviewQuestionsParams{
+		Lang:         lang,
+		FallbackLang: fallbackLang,
+		AttemptID:    attemptId,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.GetReviewQuestions: failed to get questions", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	questions := make([]*entity.ReviewQuestion, 0, len(questionDBs))
+	for _, questionDB := range questionDBs {
+		optionExplanations := map[string]string{}
+		if err := json.Unmarshal(questionDB.OptionExplanations, &optionExplanations); err != nil {
+			logger.Warn("AttemptRepository.GetReviewQuestions: malformed option explanations", "question_id", questionDB.QuestionID, "error", err)
+		}
+		var isCorrect *bool
+		if questionDB.IsCorrect.Valid {
+			isCorrect = &questionDB.IsCorrect.Bool
+		}
+		questions = append(questions, &entity.ReviewQuestion{
+			QuestionID:           questionDB.QuestionID,
+			PartID:               questionDB.PartID,
+			ParagraphID:          questionDB.ParagraphID.UUID,
+			SequenceNumber:       questionDB.SequenceNumber,
+			Answer:               questionDB.Answer.String,
+			IsCorrect:            isCorrect,
+			QuestionContent:      questionDB.QuestionContent,
+			QuestionType:         questionDB.QuestionType,
+			AudioUrl:             questionDB.AudioUrl.String,
+			ImageUrl:             questionDB.ImageUrl.String,
+			ToeicQuestionSection: questionDB.ToeicQuestionSection,
+			QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
+			AnswerOption:         string(questionDB.AnswerOption.RawMessage),
+			CorrectAnswer:        questionDB.CorrectAnswer.String,
+			ExplanationLang:      questionDB.ExplanationLang,
+			Explanation:          questionDB.Explanation,
+			OptionExplanations:   optionExplanations,
+		})
+	}
+	return questions, nil
+}
+
+// SaveAnswers stores the answers in a single statement. It returns false, having stored none, when the attempt
+// is closed or past its deadline.
+func (r *AttemptRepository) SaveAnswers(ctx context.Context, attemptId uuid.UUID, answers []*entity.AttemptAnswer) (bool, error) {
+	questionIds := make([]uuid.UUID, len(answers))
+	values := make([]string, len(answers))
+	for i, answer := range answers {
+		questionIds[i] = answer.QuestionID
+		values[i] = answer.Answer
+	}
+	rows, err := r.Queries.SaveAttemptAnswers(ctx, database.SaveAttemptAnswersParams{
+		AttemptID:   attemptId,
+		QuestionIds: questionIds,
+		Answers:     values,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.SaveAnswers: failed to save answers",
+			"attempt_id", attemptId,
+			"answers", len(answers),
+			"error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// FinalizeAttempt returns false when the attempt had already been finalized.
+func (r *AttemptRepository) FinalizeAttempt(ctx context.Context, attemptId uuid.UUID, status string) (bool, error) {
+	rows, err := r.Queries.FinalizeExamAttempt(ctx, database.FinalizeExamAttemptParams{
+		AttemptID: attemptId,
+		Status:    status,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.FinalizeAttempt: failed to finalize attempt", "attempt_id", attemptId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toAttemptEntity(attemptDB database.ExamAttempt) *entity.Attempt {
+	return &entity.Attempt{
+		AttemptID:   attemptDB.AttemptID,
+		ExamID:      attemptDB.ExamID,
+		UserID:      attemptDB.UserID,
+		Status:      attemptDB.Status,
+		StartedAt:   attemptDB.StartedAt,
+		DeadlineAt:  nullTimeToPtr(attemptDB.DeadlineAt),
+		SubmittedAt: nullTimeToPtr(attemptDB.SubmittedAt),
+		CreatedAt:   attemptDB.CreatedAt.Time,
+		UpdatedAt:   attemptDB.UpdatedAt.Time,
+	}
+}
+
+func nullTimeToPtr(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	t := value.Time
+	return &t
+}
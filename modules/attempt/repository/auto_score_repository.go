@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+)
+
+// GetResponsesToAutoScore returns up to limit submitted responses to the given question types that still
+// need a pre-score, leaving out those the scorer failed on maxFailures times.
+func (r *AttemptRepository) GetResponsesToAutoScore(ctx context.Context, questionTypes []string, maxFailures, limit int32) ([]*entity.ResponseToAutoScore, error) {
+	responseDBs, err := r.Queries.ListResponsesToAutoScore(ctx, database.ListResponsesToAutoScoreParams{
+		QuestionTypes: questionTypes,
+		MaxFailures:   maxFailures,
+		BatchSize:     limit,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.GetResponsesToAutoScore: failed to get responses", "error", err)
+		return nil, err
+	}
+	responses := make([]*entity.ResponseToAutoScore, 0, len(responseDBs))
+	for _, responseDB := range responseDBs {
+		responses = append(responses, &entity.ResponseToAutoScore{
+			AttemptID:            responseDB.AttemptID,
+			QuestionID:           responseDB.QuestionID,
+			ResponseType:         responseDB.ResponseType,
+			AudioUrl:             responseDB.AudioUrl.String,
+			TextResponse:         responseDB.TextResponse.String,
+			QuestionContent:      responseDB.QuestionContent,
+			QuestionType:         responseDB.QuestionType,
+			ToeicQuestionSection: responseDB.ToeicQuestionSection,
+		})
+	}
+	return responses, nil
+}
+
+// SaveAutoScore records the pre-score of a response. It returns false when the response already has one.
+func (r *AttemptRepository) SaveAutoScore(ctx context.Context, attemptId, questionId uuid.UUID, autoScore *entity.AutoScore) (bool, error) {
+	feedback := autoScore.Feedback
+	if feedback == nil {
+		feedback = []string{}
+	}
+	feedbackJSON, err := json.Marshal(feedback)
+	if err != nil {
+		logger.Error("AttemptRepository.SaveAutoScore: failed to encode feedback", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return false, err
+	}
+	params := database.SaveResponseAutoScoreParams{
+		AttemptID:    attemptId,
+		QuestionID:   questionId,
+		AutoScore:    int32PtrToNull(autoScore.Score),
+		AutoFeedback: feedbackJSON,
+		AutoScorer:   sql.NullString{String: autoScore.Scorer, Valid: true},
+	}
+	if autoScore.Score != nil {
+		params.AutoMaxScore = sql.NullInt32{Int32: autoScore.MaxScore, Valid: true}
+	}
+	rows, err := r.Queries.SaveResponseAutoScore(ctx, params)
+	if err != nil {
+		logger.Error("AttemptRepository.SaveAutoScore: failed to save auto score", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *AttemptRepository) RecordAutoScoreFailure(ctx context.Context, attemptId, questionId uuid.UUID) error {
+	err := r.Queries.RecordResponseAutoScoreFailure(ctx, database.RecordResponseAutoScoreFailureParams{
+		AttemptID:  attemptId,
+		QuestionID: questionId,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.RecordAutoScoreFailure: failed to record failure", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return err
+	}
+	return nil
+}
+
+// toAutoScoreEntity returns nil until the scorer is done with the response.
+func toAutoScoreEntity(score, maxScore sql.NullInt32, feedbackJSON json.RawMessage, scorer sql.NullString, scoredAt sql.NullTime) *entity.AutoScore {
+	if !scoredAt.Valid {
+		return nil
+	}
+	var feedback []string
+	if err := json.Unmarshal(feedbackJSON, &feedback); err != nil {
+		logger.Warn("AttemptRepository.toAutoScoreEntity: invalid feedback", "error", err)
+	}
+	return &entity.AutoScore{
+		Score:    nullInt32ToPtr(score),
+		MaxScore: maxScore.Int32,
+		Feedback: feedback,
+		Scorer:   scorer.String,
+		ScoredAt: scoredAt.Time,
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+)
+
+func (r *AttemptRepository) CreateConversionTable(ctx context.Context, table *entity.ScoreConversionTable) (*entity.ScoreConversionTable, error) {
+	tableDB, err := r.Queries.CreateScoreConversionTable(ctx, database.CreateScoreConversionTableParams{
+		Name:        table.Name,
+		Description: sql.NullString{String: table.Description, Valid: table.Description != ""},
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.CreateConversionTable: failed to create table", "name", table.Name, "error", err)
+		return nil, err
+	}
+	return toConversionTableEntity(tableDB), nil
+}
+
+func (r *AttemptRepository) GetConversionTables(ctx context.Context) ([]*entity.ScoreConversionTable, error) {
+	tableDBs, err := r.Queries.ListScoreConversionTables(ctx)
+	if err != nil {
+		logger.Error("AttemptRepository.GetConversionTables: failed to get tables", "error", err)
+		return nil, err
+	}
+	tables := make([]*entity.ScoreConversionTable, 0, len(tableDBs))
+	for _, tableDB := range tableDBs {
+		tables = append(tables, toConversionTableEntity(tableDB))
+	}
+	return tables, nil
+}
+
+// GetConversionTable returns the table with its entries, or nil when it does not exist.
+func (r *AttemptRepository) GetConversionTable(ctx context.Context, tableId uuid.UUID) (*entity.ScoreConversionTable, error) {
+	tableDB, err := r.Queries.GetScoreConversionTable(ctx, tableId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetConversionTable: failed to get table", "table_id", tableId, "error", err)
+		return nil, err
+	}
+	entryDBs, err := r.Queries.ListScoreConversionEntries(ctx, tableId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetConversionTable: failed to get entries", "table_id", tableId, "error", err)
+		return nil, err
+	}
+	table := toConversionTableEntity(tableDB)
+	table.Entries = make([]*entity.ScoreConversionEntry, 0, len(entryDBs))
+	for _, entryDB := range entryDBs {
+		table.Entries = append(table.Entries, &entity.ScoreConversionEntry{
+			Section:     entryDB.Section,
+			RawScore:    entryDB.RawScore,
+			ScaledScore: entryDB.ScaledScore,
+		})
+	}
+	return table, nil
+}
+
+func (r *AttemptRepository) ReplaceConversionEntries(ctx context.Context, tableId uuid.UUID, entries []*entity.ScoreConversionEntry) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AttemptRepository.ReplaceConversionEntries: failed to begin transaction", "table_id", tableId, "error", err)
+		return err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	if err = qtx.DeleteScoreConversionEntries(ctx, tableId); err != nil {
+		logger.Error("AttemptRepository.ReplaceConversionEntries: failed to clear entries", "table_id", tableId, "error", err)
+		return err
+	}
+	for _, entry := range entries {
+		err = qtx.CreateScoreConversionEntry(ctx, database.CreateScoreConversionEntryParams{
+			TableID:     tableId,
+			Section:     entry.Section,
+			RawScore:    entry.RawScore,
+			ScaledScore: entry.ScaledScore,
+		})
+		if err != nil {
+			logger.Error("AttemptRepository.ReplaceConversionEntries: failed to create entry",
+				"table_id", tableId,
+				"section", entry.Section,
+				"raw_score", entry.RawScore,
+				"error", err)
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AttemptRepository.ReplaceConversionEntries: failed to commit transaction", "table_id", tableId, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *AttemptRepository) SetDefaultConversionTable(ctx context.Context, tableId uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AttemptRepository.SetDefaultConversionTable: failed to begin transaction", "table_id", tableId, "error", err)
+		return false, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	if err = qtx.ClearDefaultScoreConversionTable(ctx); err != nil {
+		logger.Error("AttemptRepository.SetDefaultConversionTable: failed to clear default", "error", err)
+		return false, err
+	}
+	rows, err := qtx.SetDefaultScoreConversionTable(ctx, tableId)
+	if err != nil {
+		logger.Error("AttemptRepository.SetDefaultConversionTable: failed to set default", "table_id", tableId, "error", err)
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AttemptRepository.SetDefaultConversionTable: failed to commit transaction", "table_id", tableId, "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// SetExamConversionTable pins tableId to the exam; uuid.Nil reverts the exam to the default table.
+func (r *AttemptRepository) SetExamConversionTable(ctx context.Context, examId uuid.UUID, tableId uuid.UUID) (bool, error) {
+	rows, err := r.Queries.SetExamScoreConversionTable(ctx, database.SetExamScoreConversionTableParams{
+		ExamID:                 examId,
+		ScoreConversionTableID: uuid.NullUUID{UUID: tableId, Valid: tableId != uuid.Nil},
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.SetExamConversionTable: failed to set table", "exam_id", examId, "table_id", tableId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toConversionTableEntity(tableDB database.ScoreConversionTable) *entity.ScoreConversionTable {
+	return &entity.ScoreConversionTable{
+		TableID:     tableDB.TableID,
+		Name:        tableDB.Name,
+		Description: tableDB.Description.String,
+		IsDefault:   tableDB.IsDefault,
+		CreatedAt:   tableDB.CreatedAt.Time,
+		UpdatedAt:   tableDB.UpdatedAt.Time,
+	}
+}
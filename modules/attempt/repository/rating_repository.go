@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+	"time"
+)
+
+// AssignNextResponse returns the open assignment of the rater, or assigns them the next response waiting
+// for a rating. Assignments made before assignedBefore and still not rated go back to the queue first.
+// It returns nil when no response is waiting.
+func (r *AttemptRepository) AssignNextResponse(ctx context.Context, raterId uuid.UUID, assignedBefore time.Time) (*entity.ResponseRating, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to begin transaction", "rater_id", raterId, "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	if _, err = qtx.ReleaseStaleRatingAssignments(ctx, assignedBefore); err != nil {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to release stale assignments", "error", err)
+		return nil, err
+	}
+	openDB, err := qtx.GetOpenRatingAssignment(ctx, raterId)
+	if err == nil {
+		if err = tx.Commit(); err != nil {
+			logger.Error("AttemptRepository.AssignNextResponse: failed to commit transaction", "rater_id", raterId, "error", err)
+			return nil, err
+		}
+		return toResponseRatingEntity(openDB), nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to get open assignment", "rater_id", raterId, "error", err)
+		return nil, err
+	}
+
+	next, err := qtx.LockNextResponseToRate(ctx, raterId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tx.Commit()
+		}
+		logger.Error("AttemptRepository.AssignNextResponse: failed to pick a response", "rater_id", raterId, "error", err)
+		return nil, err
+	}
+	ratingDBs, err := qtx.ListResponseRatings(ctx, database.ListResponseRatingsParams{
+		AttemptID:  next.AttemptID,
+		QuestionID: next.QuestionID,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to get ratings", "attempt_id", next.AttemptID, "question_id", next.QuestionID, "error", err)
+		return nil, err
+	}
+	// A released assignment leaves a gap, so take the first free round of the double rating.
+	round := int32(entity.RatingRoundAdjudication)
+	if next.RatingStatus == entity.RatingStatusPending {
+		round = entity.RatingRoundFirst
+		for _, ratingDB := range ratingDBs {
+			if ratingDB.Round == round {
+				round = entity.RatingRoundSecond
+			}
+		}
+	}
+	ratingDB, err := qtx.CreateRatingAssignment(ctx, database.CreateRatingAssignmentParams{
+		AttemptID:  next.AttemptID,
+		QuestionID: next.QuestionID,
+		RaterID:    raterId,
+		Round:      round,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to assign response",
+			"attempt_id", next.AttemptID,
+			"question_id", next.QuestionID,
+			"rater_id", raterId,
+			"error", err)
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AttemptRepository.AssignNextResponse: failed to commit transaction", "rater_id", raterId, "error", err)
+		return nil, err
+	}
+	return toResponseRatingEntity(ratingDB), nil
+}
+
+func (r *AttemptRepository) GetRatingTask(ctx context.Context, ratingId uuid.UUID) (*entity.RatingTask, error) {
+	taskDB, err := r.Queries.GetRatingTask(ctx, ratingId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetRatingTask: failed to get rating task", "rating_id", ratingId, "error", err)
+		return nil, err
+	}
+	return &entity.RatingTask{
+		RatingID:             taskDB.RatingID,
+		Round:                taskDB.Round,
+		AssignedAt:           taskDB.AssignedAt,
+		AttemptID:            taskDB.AttemptID,
+		ExamID:               taskDB.ExamID,
+		QuestionID:           taskDB.QuestionID,
+		ResponseType:         taskDB.ResponseType,
+		AudioUrl:             taskDB.AudioUrl.String,
+		TextResponse:         taskDB.TextResponse.String,
+		WordCount:            taskDB.WordCount.Int32,
+		SubmittedAt:          nullTimeToPtr(taskDB.SubmittedAt),
+		QuestionContent:      taskDB.QuestionContent,
+		QuestionType:         taskDB.QuestionType,
+		ToeicQuestionSection: taskDB.ToeicQuestionSection,
+		QuestionAudioUrl:     taskDB.QuestionAudioUrl.String,
+		QuestionImageUrl:     taskDB.QuestionImageUrl.String,
+		AutoScore:            toAutoScoreEntity(taskDB.AutoScore, taskDB.AutoMaxScore, taskDB.AutoFeedback, taskDB.AutoScorer, taskDB.AutoScoredAt),
+	}, nil
+}
+
+// SubmitRating stores the score of an open assignment of the rater. It returns nil when the rating is not
+// assigned to the rater, was already submitted or went back to the queue.
+func (r *AttemptRepository) SubmitRating(ctx context.Context, rating *entity.ResponseRating) (*entity.ResponseRating, error) {
+	ratingDB, err := r.Queries.SubmitRating(ctx, database.SubmitRatingParams{
+		RatingID: rating.RatingID,
+		RaterID:  rating.RaterID,
+		Score:    int32PtrToNull(rating.Score),
+		MaxScore: sql.NullInt32{Int32: rating.MaxScore, Valid: true},
+		Comment:  sql.NullString{String: rating.Comment, Valid: rating.Comment != ""},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.SubmitRating: failed to submit rating", "rating_id", rating.RatingID, "error", err)
+		return nil, err
+	}
+	return toResponseRatingEntity(ratingDB), nil
+}
+
+func (r *AttemptRepository) GetResponseRatings(ctx context.Context, attemptId, questionId uuid.UUID) ([]*entity.ResponseRating, error) {
+	ratingDBs, err := r.Queries.ListResponseRatings(ctx, database.ListResponseRatingsParams{
+		AttemptID:  attemptId,
+		QuestionID: questionId,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.GetResponseRatings: failed to get ratings", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, err
+	}
+	ratings := make([]*entity.ResponseRating, 0, len(ratingDBs))
+	for _, ratingDB := range ratingDBs {
+		ratings = append(ratings, toResponseRatingEntity(ratingDB))
+	}
+	return ratings, nil
+}
+
+func (r *AttemptRepository) ResolveResponseRating(ctx context.Context, attemptId, questionId uuid.UUID, status string, finalScore, maxScore *int32) error {
+	err := r.Queries.ResolveResponseRating(ctx, database.ResolveResponseRatingParams{
+		AttemptID:    attemptId,
+		QuestionID:   questionId,
+		RatingStatus: status,
+		FinalScore:   int32PtrToNull(finalScore),
+		MaxScore:     int32PtrToNull(maxScore),
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.ResolveResponseRating: failed to resolve rating",
+			"attempt_id", attemptId,
+			"question_id", questionId,
+			"status", status,
+			"error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *AttemptRepository) GetRatingQueueStats(ctx context.Context) (*entity.RatingQueueStats, error) {
+	statsDB, err := r.Queries.GetRatingQueueStats(ctx)
+	if err != nil {
+		logger.Error("AttemptRepository.GetRatingQueueStats: failed to get queue stats", "error", err)
+		return nil, err
+	}
+	return &entity.RatingQueueStats{
+		AwaitingRating:       statsDB.AwaitingRating,
+		AwaitingAdjudication: statsDB.AwaitingAdjudication,
+		Assigned:             statsDB.Assigned,
+	}, nil
+}
+
+func (r *AttemptRepository) GetRatingStates(ctx context.Context, attemptId uuid.UUID) ([]*entity.RatingState, error) {
+	stateDBs, err := r.Queries.ListAttemptRatingStates(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetRatingStates: failed to get rating states", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	states := make([]*entity.RatingState, 0, len(stateDBs))
+	for _, stateDB := range stateDBs {
+		states = append(states, &entity.RatingState{
+			QuestionID:           stateDB.QuestionID,
+			QuestionType:         stateDB.QuestionType,
+			ToeicQuestionSection: stateDB.ToeicQuestionSection,
+			Submitted:            stateDB.ResponseSubmittedAt.Valid,
+			RatingStatus:         stateDB.RatingStatus.String,
+			FinalScore:           stateDB.FinalScore.Int32,
+			MaxScore:             stateDB.MaxScore.Int32,
+		})
+	}
+	return states, nil
+}
+
+// SaveRatedScores stores the Speaking and Writing scores of a result. It returns nil when the attempt has
+// not been scored yet.
+func (r *AttemptRepository) SaveRatedScores(ctx context.Context, result *entity.AttemptResult) (*entity.AttemptResult, error) {
+	resultDB, err := r.Queries.UpdateAttemptResultRatings(ctx, database.UpdateAttemptResultRatingsParams{
+		AttemptID:         result.AttemptID,
+		SpeakingPoints:    result.Speaking.Points,
+		SpeakingMaxPoints: result.Speaking.MaxPoints,
+		SpeakingPending:   result.Speaking.Pending,
+		SpeakingScaled:    int32PtrToNull(result.Speaking.Scaled),
+		WritingPoints:     result.Writing.Points,
+		WritingMaxPoints:  result.Writing.MaxPoints,
+		WritingPending:    result.Writing.Pending,
+		WritingScaled:     int32PtrToNull(result.Writing.Scaled),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.SaveRatedScores: failed to store rated scores", "attempt_id", result.AttemptID, "error", err)
+		return nil, err
+	}
+	return toAttemptResultEntity(resultDB), nil
+}
+
+func toResponseRatingEntity(ratingDB database.ResponseRating) *entity.ResponseRating {
+	return &entity.ResponseRating{
+		RatingID:   ratingDB.RatingID,
+		AttemptID:  ratingDB.AttemptID,
+		QuestionID: ratingDB.QuestionID,
+		RaterID:    ratingDB.RaterID,
+		Round:      ratingDB.Round,
+		Score:      nullInt32ToPtr(ratingDB.Score),
+		MaxScore:   ratingDB.MaxScore.Int32,
+		Comment:    ratingDB.Comment.String,
+		AssignedAt: ratingDB.AssignedAt,
+		RatedAt:    nullTimeToPtr(ratingDB.RatedAt),
+	}
+}
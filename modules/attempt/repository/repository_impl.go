@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+	"time"
+)
+
+type AttemptRepository struct {
+	Queries *database.Queries
+	db      *sql.DB
+}
+
+func NewAttemptRepository(sqlDB *sql.DB) IAttemptRepository {
+	return &AttemptRepository{
+		Queries: database.New(sqlDB),
+		db:      sqlDB,
+	}
+}
+
+type IAttemptRepository interface {
+	CreateAttempt(ctx context.Context, examId, userId uuid.UUID, durationMinutes int32, questions []*entity.AttemptQuestion) (*entity.Attempt, error)
+	GetAttempt(ctx context.Context, attemptId, userId uuid.UUID) (*entity.Attempt, error)
+	GetInProgressAttempt(ctx context.Context, userId, examId uuid.UUID) (*entity.Attempt, error)
+	GetAttemptsByUser(ctx context.Context, userId, examId uuid.UUID) ([]*entity.Attempt, error)
+	GetAttemptQuestions(ctx context.Context, attemptId uuid.UUID) ([]*entity.AttemptQuestion, error)
+	GetAttemptParagraphs(ctx context.Context, attemptId uuid.UUID) ([]*entity.AttemptParagraph, error)
+	GetReviewQuestions(ctx context.Context, attemptId uuid.UUID, lang, fallbackLang string) ([]*entity.ReviewQuestion, error)
+	SaveAnswers(ctx context.Context, attemptId uuid.UUID, answers []*entity.AttemptAnswer) (bool, error)
+	FinalizeAttempt(ctx context.Context, attemptId uuid.UUID, status string) (bool, error)
+	// Spoken and written responses
+	StartResponse(ctx context.Context, response *entity.CapturedResponse) (bool, error)
+	GetResponse(ctx context.Context, attemptId, questionId uuid.UUID) (*entity.CapturedResponse, error)
+	GetResponses(ctx context.Context, attemptId uuid.UUID) ([]*entity.CapturedResponse, error)
+	SaveResponse(ctx context.Context, response *entity.CapturedResponse, graceSeconds int32) (bool, error)
+	// Scoring
+	GetScoringQuestions(ctx context.Context, attemptId uuid.UUID) ([]*entity.ScoringQuestion, error)
+	GetScoringConfig(ctx context.Context, examId uuid.UUID) (*entity.ScoringConfig, error)
+	SaveResult(ctx context.Context, result *entity.AttemptResult, graded []*entity.GradedQuestion) (*entity.AttemptResult, error)
+	GetResult(ctx context.Context, attemptId uuid.UUID) (*entity.AttemptResult, error)
+	// Ratings
+	AssignNextResponse(ctx context.Context, raterId uuid.UUID, assignedBefore time.Time) (*entity.ResponseRating, error)
+	GetRatingTask(ctx context.Context, ratingId uuid.UUID) (*entity.RatingTask, error)
+	SubmitRating(ctx context.Context, rating *entity.ResponseRating) (*entity.ResponseRating, error)
+	GetResponseRatings(ctx context.Context, attemptId, questionId uuid.UUID) ([]*entity.ResponseRating, error)
+	ResolveResponseRating(ctx context.Context, attemptId, questionId uuid.UUID, status string, finalScore, maxScore *int32) error
+	GetRatingQueueStats(ctx context.Context) (*entity.RatingQueueStats, error)
+	GetRatingStates(ctx context.Context, attemptId uuid.UUID) ([]*entity.RatingState, error)
+	SaveRatedScores(ctx context.Context, result *entity.AttemptResult) (*entity.AttemptResult, error)
+	// Automated scores
+	GetResponsesToAutoScore(ctx context.Context, questionTypes []string, maxFailures, limit int32) ([]*entity.ResponseToAutoScore, error)
+	SaveAutoScore(ctx context.Context, attemptId, questionId uuid.UUID, autoScore *entity.AutoScore) (bool, error)
+	RecordAutoScoreFailure(ctx context.Context, attemptId, questionId uuid.UUID) error
+	// Conversion tables
+	CreateConversionTable(ctx context.Context, table *entity.ScoreConversionTable) (*entity.ScoreConversionTable, error)
+	GetConversionTables(ctx context.Context) ([]*entity.ScoreConversionTable, error)
+	GetConversionTable(ctx context.Context, tableId uuid.UUID) (*entity.ScoreConversionTable, error)
+	ReplaceConversionEntries(ctx context.Context, tableId uuid.UUID, entries []*entity.ScoreConversionEntry) error
+	SetDefaultConversionTable(ctx context.Context, tableId uuid.UUID) (bool, error)
+	SetExamConversionTable(ctx context.Context, examId uuid.UUID, tableId uuid.UUID) (bool, error)
+}
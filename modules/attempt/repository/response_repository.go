@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+)
+
+// StartResponse opens the windows of a response; it returns false when they were already open or the
+// attempt is closed or past its deadline.
+func (r *AttemptRepository) StartResponse(ctx context.Context, response *entity.CapturedResponse) (bool, error) {
+	rows, err := r.Queries.StartAttemptResponse(ctx, database.StartAttemptResponseParams{
+		AttemptID:          response.AttemptID,
+		QuestionID:         response.QuestionID,
+		ResponseType:       response.ResponseType,
+		PreparationSeconds: response.PreparationSeconds,
+		ResponseSeconds:    response.ResponseSeconds,
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.StartResponse: failed to start response",
+			"attempt_id", response.AttemptID,
+			"question_id", response.QuestionID,
+			"error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *AttemptRepository) GetResponse(ctx context.Context, attemptId, questionId uuid.UUID) (*entity.CapturedResponse, error) {
+	responseDB, err := r.Queries.GetAttemptResponse(ctx, database.GetAttemptResponseParams{
+		AttemptID:  attemptId,
+		QuestionID: questionId,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetResponse: failed to get response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, err
+	}
+	return toCapturedResponseEntity(responseDB), nil
+}
+
+func (r *AttemptRepository) GetResponses(ctx context.Context, attemptId uuid.UUID) ([]*entity.CapturedResponse, error) {
+	responseDBs, err := r.Queries.ListAttemptResponses(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetResponses: failed to get responses", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	responses := make([]*entity.CapturedResponse, 0, len(responseDBs))
+	for _, responseDB := range responseDBs {
+		responses = append(responses, toCapturedResponseEntity(responseDB))
+	}
+	return responses, nil
+}
+
+// SaveResponse returns false when the response was rejected because it is outside its windows, grace
+// included, or the attempt is closed or past its deadline.
+func (r *AttemptRepository) SaveResponse(ctx context.Context, response *entity.CapturedResponse, graceSeconds int32) (bool, error) {
+	params := database.SaveAttemptResponseParams{
+		AttemptID:    response.AttemptID,
+		QuestionID:   response.QuestionID,
+		GraceSeconds: graceSeconds,
+	}
+	if response.ResponseType == entity.ResponseTypeAudio {
+		params.AudioObject = sql.NullString{String: response.AudioObject, Valid: true}
+		params.AudioUrl = sql.NullString{String: response.AudioUrl, Valid: true}
+	} else {
+		params.TextResponse = sql.NullString{String: response.TextResponse, Valid: true}
+		params.WordCount = sql.NullInt32{Int32: response.WordCount, Valid: true}
+	}
+	rows, err := r.Queries.SaveAttemptResponse(ctx, params)
+	if err != nil {
+		logger.Error("AttemptRepository.SaveResponse: failed to save response",
+			"attempt_id", response.AttemptID,
+			"question_id", response.QuestionID,
+			"error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toCapturedResponseEntity(responseDB database.AttemptResponse) *entity.CapturedResponse {
+	return &entity.CapturedResponse{
+		AttemptID:          responseDB.AttemptID,
+		QuestionID:         responseDB.QuestionID,
+		ResponseType:       responseDB.ResponseType,
+		StartedAt:          responseDB.StartedAt,
+		PreparationSeconds: responseDB.PreparationSeconds,
+		ResponseSeconds:    responseDB.ResponseSeconds,
+		AudioObject:        responseDB.AudioObject.String,
+		AudioUrl:           responseDB.AudioUrl.String,
+		TextResponse:       responseDB.TextResponse.String,
+		WordCount:          responseDB.WordCount.Int32,
+		SubmittedAt:        nullTimeToPtr(responseDB.SubmittedAt),
+		AutoScore:          toAutoScoreEntity(responseDB.AutoScore, responseDB.AutoMaxScore, responseDB.AutoFeedback, responseDB.AutoScorer, responseDB.AutoScoredAt),
+	}
+}
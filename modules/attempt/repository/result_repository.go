@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/attempt/entity"
+)
+
+func (r *AttemptRepository) GetScoringQuestions(ctx context.Context, attemptId uuid.UUID) ([]*entity.ScoringQuestion, error) {
+	questionDBs, err := r.Queries.ListAttemptQuestionsForScoring(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetScoringQuestions: failed to get questions", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	questions := make([]*entity.ScoringQuestion, 0, len(questionDBs))
+	for _, questionDB := range questionDBs {
+		questions = append(questions, &entity.ScoringQuestion{
+			QuestionID:           questionDB.QuestionID,
+			Answer:               questionDB.Answer.String,
+			QuestionType:         questionDB.QuestionType,
+			ToeicQuestionSection: questionDB.ToeicQuestionSection,
+			CorrectAnswer:        questionDB.CorrectAnswer.String,
+		})
+	}
+	return questions, nil
+}
+
+func (r *AttemptRepository) GetScoringConfig(ctx context.Context, examId uuid.UUID) (*entity.ScoringConfig, error) {
+	configDB, err := r.Queries.GetExamScoringConfig(ctx, examId)
+	if err != nil {
+		logger.Error("AttemptRepository.GetScoringConfig: failed to get scoring config", "exam_id", examId, "error", err)
+		return nil, err
+	}
+	return &entity.ScoringConfig{
+		ExamID:            configDB.ExamID,
+		MaxListeningScore: configDB.MaxListeningScore.Int32,
+		MaxReadingScore:   configDB.MaxReadingScore.Int32,
+		MaxSpeakingScore:  configDB.MaxSpeakingScore.Int32,
+		MaxWritingScore:   configDB.MaxWritingScore.Int32,
+		ConversionTableID: configDB.ConversionTableID.UUID,
+	}, nil
+}
+
+func (r *AttemptRepository) SaveResult(ctx context.Context, result *entity.AttemptResult, graded []*entity.GradedQuestion) (*entity.AttemptResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("AttemptRepository.SaveResult: failed to begin transaction", "attempt_id", result.AttemptID, "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	for _, question := range graded {
+		err = qtx.UpdateAttemptQuestionCorrectness(ctx, database.UpdateAttemptQuestionCorrectnessParams{
+			AttemptID:  result.AttemptID,
+			QuestionID: question.QuestionID,
+			IsCorrect:  sql.NullBool{Bool: question.IsCorrect, Valid: true},
+		})
+		if err != nil {
+			logger.Error("AttemptRepository.SaveResult: failed to store grading",
+				"attempt_id", result.AttemptID,
+				"question_id", question.QuestionID,
+				"error", err)
+			return nil, err
+		}
+	}
+	resultDB, err := qtx.UpsertAttemptResult(ctx, database.UpsertAttemptResultParams{
+		AttemptID:         result.AttemptID,
+		ConversionTableID: uuid.NullUUID{UUID: result.ConversionTableID, Valid: result.ConversionTableID != uuid.Nil},
+		ListeningCorrect:  result.ListeningCorrect,
+		ListeningTotal:    result.ListeningTotal,
+		ListeningScaled:   int32PtrToNull(result.ListeningScaled),
+		ReadingCorrect:    result.ReadingCorrect,
+		ReadingTotal:      result.ReadingTotal,
+		ReadingScaled:     int32PtrToNull(result.ReadingScaled),
+		TotalScaled:       int32PtrToNull(result.TotalScaled),
+	})
+	if err != nil {
+		logger.Error("AttemptRepository.SaveResult: failed to store result", "attempt_id", result.AttemptID, "error", err)
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("AttemptRepository.SaveResult: failed to commit transaction", "attempt_id", result.AttemptID, "error", err)
+		return nil, err
+	}
+	return toAttemptResultEntity(resultDB), nil
+}
+
+func (r *AttemptRepository) GetResult(ctx context.Context, attemptId uuid.UUID) (*entity.AttemptResult, error) {
+	resultDB, err := r.Queries.GetAttemptResult(ctx, attemptId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("AttemptRepository.GetResult: failed to get result", "attempt_id", attemptId, "error", err)
+		return nil, err
+	}
+	return toAttemptResultEntity(resultDB), nil
+}
+
+func toAttemptResultEntity(resultDB database.AttemptResult) *entity.AttemptResult {
+	return &entity.AttemptResult{
+		AttemptID:         resultDB.AttemptID,
+		ConversionTableID: resultDB.ConversionTableID.UUID,
+		ListeningCorrect:  resultDB.ListeningCorrect,
+		ListeningTotal:    resultDB.ListeningTotal,
+		ListeningScaled:   nullInt32ToPtr(resultDB.ListeningScaled),
+		ReadingCorrect:    resultDB.ReadingCorrect,
+		ReadingTotal:      resultDB.ReadingTotal,
+		ReadingScaled:     nullInt32ToPtr(resultDB.ReadingScaled),
+		TotalScaled:       nullInt32ToPtr(resultDB.TotalScaled),
+		ScoredAt:          resultDB.ScoredAt,
+		Speaking: entity.RatedSectionScore{
+			Points:    resultDB.SpeakingPoints,
+			MaxPoints: resultDB.SpeakingMaxPoints,
+			Pending:   resultDB.SpeakingPending,
+			Scaled:    nullInt32ToPtr(resultDB.SpeakingScaled),
+		},
+		Writing: entity.RatedSectionScore{
+			Points:    resultDB.WritingPoints,
+			MaxPoints: resultDB.WritingMaxPoints,
+			Pending:   resultDB.WritingPending,
+			Scaled:    nullInt32ToPtr(resultDB.WritingScaled),
+		},
+	}
+}
+
+func int32PtrToNull(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{Valid: false}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
+
+func nullInt32ToPtr(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int32
+	return &v
+}
@@ -0,0 +1,53 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/modules/attempt/controller"
+)
+
+type AttemptRouter struct {
+	controller *controller.AttemptController
+}
+
+func NewAttemptRouter(controller *controller.AttemptController) *AttemptRouter {
+	return &AttemptRouter{
+		controller: controller,
+	}
+}
+func (r *AttemptRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
+	// API v1 group
+	v1 := e.Group("/v1")
+	// Attempt routes - requires authentication
+	attempts := v1.Group("/exams/:examId/attempts")
+	attempts.Use(middleware.AuthMiddleware())
+	attempts.POST("", r.controller.StartAttempt)
+	attempts.GET("", r.controller.GetAttempts)
+	attempts.GET("/:attemptId", r.controller.GetAttempt)
+	attempts.PUT("/:attemptId/answers", r.controller.SaveAnswers)
+	attempts.POST("/:attemptId/submit", r.controller.SubmitAttempt)
+	attempts.GET("/:attemptId/result", r.controller.GetAttemptResult)
+	attempts.GET("/:attemptId/review", r.controller.GetAttemptReview)
+	attempts.POST("/:attemptId/questions/:questionId/response/start", r.controller.StartResponse)
+	attempts.POST("/:attemptId/questions/:questionId/response/audio", r.controller.SubmitSpokenResponse)
+	attempts.PUT("/:attemptId/questions/:questionId/response/text", r.controller.SubmitWrittenResponse)
+	// Rating routes - raters score the Speaking and Writing responses
+	ratings := v1.Group("/ratings")
+	ratings.Use(middleware.AuthMiddleware(), middleware.PermissionMiddleware(constants.PermissionResponsesRate))
+	ratings.GET("/queue", r.controller.GetRatingQueue)
+	ratings.POST("/next", r.controller.AssignNextRating)
+	ratings.PUT("/:ratingId", r.controller.SubmitRating)
+	// Admin routes
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(), middleware.PermissionMiddleware(constants.PermissionScoringManage))
+	// Score conversion routes
+	conversionTables := admin.Group("/score-conversion-tables")
+	conversionTables.GET("", r.controller.GetConversionTables)
+	conversionTables.POST("", r.controller.CreateConversionTable)
+	conversionTables.GET("/:tableId", r.controller.GetConversionTable)
+	conversionTables.PUT("/:tableId/entries", r.controller.UpdateConversionEntries)
+	conversionTables.POST("/:tableId/default", r.controller.SetDefaultConversionTable)
+	examsAdmin := admin.Group("/exams")
+	examsAdmin.PUT("/:examId/score-conversion-table", r.controller.SetExamConversionTable)
+}
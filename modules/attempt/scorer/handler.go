@@ -0,0 +1,48 @@
+package scorer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// NewHandler serves a scorer over the protocol HTTPScorer speaks. Running it in front of the heuristic
+// scorer gives a local stand-in for an external scoring service; requests must carry apiKey as a bearer
+// token when it is set.
+func NewHandler(scorer AutoScorer, apiKey string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ScorePath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if apiKey != "" && r.Header.Get("Authorization") != "Bearer "+apiKey {
+			writeError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+		var request Request
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&request); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+			return
+		}
+
+		result, err := scorer.Score(r.Context(), &request)
+		if errors.Is(err, ErrUnsupported) {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result)
+	})
+	return mux
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
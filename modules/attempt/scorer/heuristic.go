@@ -0,0 +1,188 @@
+package scorer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// targetWordCounts is the length of a complete answer to each task type; the TOEIC essay asks for at
+// least 300 words.
+var targetWordCounts = map[string]int{
+	"Essay":              300,
+	"OpenResponse":       100,
+	"PictureDescription": 10,
+}
+
+const defaultTargetWordCount = 50
+
+// Weights of the length, lexical diversity and grammar parts of the heuristic score.
+const (
+	lengthWeight    = 0.4
+	diversityWeight = 0.3
+	grammarWeight   = 0.3
+)
+
+// Guiraud index (distinct words over the square root of the word count) at which the vocabulary counts
+// as poor and as varied. Unlike the plain ratio of distinct words it does not drop for longer answers.
+const (
+	poorGuiraudIndex   = 4.0
+	variedGuiraudIndex = 8.0
+)
+
+// maxGrammarErrorsPerHundredWords is the error rate at which the grammar part of the score reaches zero.
+const maxGrammarErrorsPerHundredWords = 10.0
+
+// maxGrammarExamples bounds the grammar issues quoted in the feedback.
+const maxGrammarExamples = 3
+
+var (
+	wordPattern     = regexp.MustCompile(`[A-Za-z]+(?:'[A-Za-z]+)?`)
+	sentencePattern = regexp.MustCompile(`[^.!?]+[.!?]*`)
+)
+
+// agreementErrors are subject-verb pairs that never agree.
+var agreementErrors = map[string]bool{
+	"i is": true, "i are": true, "i has": true, "i does": true,
+	"he are": true, "he have": true, "he don't": true, "he were": true,
+	"she are": true, "she have": true, "she don't": true, "she were": true,
+	"it are": true, "it have": true, "it don't": true, "it were": true,
+	"we is": true, "we was": true, "we has": true, "we does": true,
+	"they is": true, "they was": true, "they has": true, "they does": true,
+	"you is": true, "you was": true, "you has": true, "you does": true,
+}
+
+// Words whose article does not follow their first letter.
+var (
+	anBeforeConsonantLetter = map[string]bool{"hour": true, "hours": true, "honest": true, "honor": true, "heir": true}
+	aBeforeVowelLetter      = map[string]bool{"one": true, "once": true, "university": true, "user": true, "useful": true, "usual": true, "european": true, "unique": true, "unit": true}
+)
+
+// HeuristicScorer scores a written text, or the transcript of a spoken one, from its length, the variety
+// of its vocabulary and a set of grammar rules. The same text always gets the same score.
+type HeuristicScorer struct{}
+
+func NewHeuristicScorer() *HeuristicScorer {
+	return &HeuristicScorer{}
+}
+
+func (s *HeuristicScorer) Name() string {
+	return ScorerHeuristic
+}
+
+// Score returns ErrUnsupported for a recording without a transcript.
+func (s *HeuristicScorer) Score(ctx context.Context, request *Request) (*Result, error) {
+	text := strings.TrimSpace(request.Text)
+	if text == "" && request.AudioUrl != "" {
+		return nil, ErrUnsupported
+	}
+	if request.MaxScore <= 0 {
+		return nil, fmt.Errorf("scorer: max score must be positive")
+	}
+	words := wordPattern.FindAllString(text, -1)
+	if len(words) == 0 {
+		return &Result{Score: 0, MaxScore: request.MaxScore, Feedback: []string{"No response was given."}}, nil
+	}
+
+	var feedback []string
+	target, ok := targetWordCounts[request.TaskType]
+	if !ok {
+		target = defaultTargetWordCount
+	}
+	length := math.Min(float64(len(words))/float64(target), 1)
+	if length < 1 {
+		feedback = append(feedback, fmt.Sprintf("The answer has %d words; a complete answer has about %d.", len(words), target))
+	}
+
+	distinct := make(map[string]bool, len(words))
+	for _, word := range words {
+		distinct[strings.ToLower(word)] = true
+	}
+	guiraud := float64(len(distinct)) / math.Sqrt(float64(len(words)))
+	diversity := math.Max(0, math.Min((guiraud-poorGuiraudIndex)/(variedGuiraudIndex-poorGuiraudIndex), 1))
+	if diversity < 0.5 {
+		feedback = append(feedback, "The vocabulary is repetitive; use a wider range of words.")
+	}
+
+	issues := grammarIssues(text)
+	errorRate := float64(len(issues)) * 100 / float64(len(words))
+	grammar := math.Max(0, 1-errorRate/maxGrammarErrorsPerHundredWords)
+	if len(issues) > 0 {
+		examples := issues
+		if len(examples) > maxGrammarExamples {
+			examples = examples[:maxGrammarExamples]
+		}
+		feedback = append(feedback, fmt.Sprintf("%d possible grammar or mechanics issues, such as: %s.", len(issues), strings.Join(examples, "; ")))
+	}
+	if len(feedback) == 0 {
+		feedback = append(feedback, "The answer is complete, varied and free of common errors.")
+	}
+
+	weighted := lengthWeight*length + diversityWeight*diversity + grammarWeight*grammar
+	score := clamp(int32(math.Round(weighted*float64(request.MaxScore))), request.MaxScore)
+	return &Result{Score: score, MaxScore: request.MaxScore, Feedback: feedback}, nil
+}
+
+// grammarIssues applies rule-based checks to the text and describes every issue found, in order.
+func grammarIssues(text string) []string {
+	var issues []string
+	for _, sentence := range sentencePattern.FindAllString(text, -1) {
+		sentence = strings.TrimSpace(sentence)
+		if sentence == "" {
+			continue
+		}
+		if first := []rune(sentence)[0]; unicode.IsLetter(first) && !unicode.IsUpper(first) {
+			issues = append(issues, fmt.Sprintf("sentence should start with a capital letter (%q)", firstWords(sentence, 3)))
+		}
+	}
+	if last := []rune(strings.TrimSpace(text)); len(last) > 0 && !strings.ContainsRune(".!?\"'", last[len(last)-1]) {
+		issues = append(issues, "the last sentence has no final punctuation")
+	}
+	if strings.Contains(text, " ,") || strings.Contains(text, " .") {
+		issues = append(issues, "space before a punctuation mark")
+	}
+
+	words := wordPattern.FindAllString(text, -1)
+	for i, word := range words {
+		lower := strings.ToLower(word)
+		if word == "i" {
+			issues = append(issues, `"i" should be written "I"`)
+		}
+		if i+1 >= len(words) {
+			continue
+		}
+		next := strings.ToLower(words[i+1])
+		switch {
+		case lower == next:
+			issues = append(issues, fmt.Sprintf("repeated word %q", lower+" "+next))
+		case agreementErrors[lower+" "+next]:
+			issues = append(issues, fmt.Sprintf("subject and verb do not agree (%q)", lower+" "+next))
+		case lower == "a" && startsWithVowelSound(next):
+			issues = append(issues, fmt.Sprintf("use \"an\" before %q", next))
+		case lower == "an" && !startsWithVowelSound(next):
+			issues = append(issues, fmt.Sprintf("use \"a\" before %q", next))
+		}
+	}
+	return issues
+}
+
+func startsWithVowelSound(word string) bool {
+	if anBeforeConsonantLetter[word] {
+		return true
+	}
+	if aBeforeVowelLetter[word] {
+		return false
+	}
+	return word != "" && strings.ContainsRune("aeiou", rune(word[0]))
+}
+
+func firstWords(sentence string, count int) string {
+	fields := strings.Fields(sentence)
+	if len(fields) > count {
+		fields = fields[:count]
+	}
+	return strings.Join(fields, " ")
+}
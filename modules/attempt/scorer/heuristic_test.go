@@ -0,0 +1,168 @@
+package scorer
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// distinctWords returns a sentence of count words cycling through that many different ones.
+func distinctWords(count, distinct int) string {
+	words := make([]string, count)
+	for i := range words {
+		n := i % distinct
+		words[i] = "w" + string(rune('a'+n/26)) + string(rune('a'+n%26))
+	}
+	words[0] = strings.ToUpper(words[0][:1]) + words[0][1:]
+	return strings.Join(words, " ") + "."
+}
+
+func TestHeuristicScorerScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		taskType string
+		text     string
+		want     *Result
+	}{
+		{
+			name:     "complete and varied",
+			taskType: "OpenResponse",
+			text:     distinctWords(100, 100),
+			want:     &Result{Score: 100, MaxScore: 100, Feedback: []string{"The answer is complete, varied and free of common errors."}},
+		},
+		{
+			name:     "half the target length",
+			taskType: "OpenResponse",
+			text:     distinctWords(50, 50),
+			want:     &Result{Score: 73, MaxScore: 100, Feedback: []string{"The answer has 50 words; a complete answer has about 100."}},
+		},
+		{
+			name:     "essay target",
+			taskType: "Essay",
+			text:     distinctWords(150, 150),
+			want:     &Result{Score: 80, MaxScore: 100, Feedback: []string{"The answer has 150 words; a complete answer has about 300."}},
+		},
+		{
+			name:     "default target",
+			taskType: "Unknown",
+			text:     distinctWords(50, 50),
+			want:     &Result{Score: 93, MaxScore: 100, Feedback: []string{"The answer is complete, varied and free of common errors."}},
+		},
+		{
+			// A Guiraud index of 8 counts as varied, 6 half way and 4 as poor
+			name:     "varied vocabulary",
+			taskType: "PictureDescription",
+			text:     distinctWords(64, 64),
+			want:     &Result{Score: 100, MaxScore: 100, Feedback: []string{"The answer is complete, varied and free of common errors."}},
+		},
+		{
+			name:     "middling vocabulary",
+			taskType: "PictureDescription",
+			text:     distinctWords(64, 48),
+			want:     &Result{Score: 85, MaxScore: 100, Feedback: []string{"The answer is complete, varied and free of common errors."}},
+		},
+		{
+			name:     "repetitive vocabulary",
+			taskType: "PictureDescription",
+			text:     distinctWords(64, 32),
+			want:     &Result{Score: 70, MaxScore: 100, Feedback: []string{"The vocabulary is repetitive; use a wider range of words."}},
+		},
+		{
+			name:     "grammar issue",
+			taskType: "OpenResponse",
+			text:     distinctWords(98, 98) + " They is.",
+			want: &Result{Score: 97, MaxScore: 100, Feedback: []string{
+				`1 possible grammar or mechanics issues, such as: subject and verb do not agree ("they is").`,
+			}},
+		},
+		{
+			name:     "no words",
+			taskType: "OpenResponse",
+			text:     " ... ",
+			want:     &Result{Score: 0, MaxScore: 100, Feedback: []string{"No response was given."}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewHeuristicScorer().Score(context.Background(), &Request{TaskType: tt.taskType, Text: tt.text, MaxScore: 100})
+			if err != nil {
+				t.Fatalf("score: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("result = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeuristicScorerStaysOnRubric(t *testing.T) {
+	for _, maxScore := range []int32{1, 5, 200} {
+		result, err := NewHeuristicScorer().Score(context.Background(), &Request{TaskType: "OpenResponse", Text: distinctWords(150, 150), MaxScore: maxScore})
+		if err != nil {
+			t.Fatalf("score: %v", err)
+		}
+		if result.Score != maxScore || result.MaxScore != maxScore {
+			t.Errorf("score = %d/%d, want %d/%d", result.Score, result.MaxScore, maxScore, maxScore)
+		}
+	}
+}
+
+func TestHeuristicScorerRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     *Request
+		unsupported bool
+	}{
+		{name: "recording without transcript", request: &Request{AudioUrl: "https://cdn.example.com/answers/1.mp3", MaxScore: 5}, unsupported: true},
+		{name: "blank transcript", request: &Request{Text: "  ", AudioUrl: "https://cdn.example.com/answers/1.mp3", MaxScore: 5}, unsupported: true},
+		{name: "no rubric", request: &Request{Text: "I prefer working from home.", MaxScore: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewHeuristicScorer().Score(context.Background(), tt.request)
+			if err == nil {
+				t.Fatal("score succeeded, want an error")
+			}
+			if errors.Is(err, ErrUnsupported) != tt.unsupported {
+				t.Errorf("error = %v, unsupported = %v", err, tt.unsupported)
+			}
+		})
+	}
+}
+
+func TestGrammarIssues(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{name: "clean", text: "I prefer working from home.", want: nil},
+		{name: "lowercase sentence", text: "It rains. we stay home.", want: []string{`sentence should start with a capital letter ("we stay home.")`}},
+		{name: "no final punctuation", text: "It rains", want: []string{"the last sentence has no final punctuation"}},
+		{name: "space before punctuation", text: "It rains , so we stay home.", want: []string{"space before a punctuation mark"}},
+		{name: "lowercase i", text: "Yesterday i stayed home.", want: []string{`"i" should be written "I"`}},
+		{name: "repeated word", text: "We stayed at at home.", want: []string{`repeated word "at at"`}},
+		{name: "agreement", text: "They is at home.", want: []string{`subject and verb do not agree ("they is")`}},
+		{name: "a before vowel", text: "It was a idea.", want: []string{`use "an" before "idea"`}},
+		{name: "an before consonant", text: "It was an book.", want: []string{`use "a" before "book"`}},
+		{name: "article exceptions", text: "It took an hour to reach a university.", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := grammarIssues(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("grammarIssues(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct{ score, want int32 }{{-1, 0}, {0, 0}, {3, 3}, {5, 5}, {7, 5}}
+	for _, tt := range tests {
+		if got := clamp(tt.score, 5); got != tt.want {
+			t.Errorf("clamp(%d, 5) = %d, want %d", tt.score, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package scorer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pirate-lang-go/core/config"
+	"strings"
+	"time"
+)
+
+const (
+	// ScorePath is where a scoring service receives the requests to score, as JSON Requests answered with
+	// JSON Results. A service answers 422 Unprocessable Entity to a response it cannot score.
+	ScorePath             = "/v1/score"
+	defaultHTTPTimeoutSec = 30
+)
+
+// HTTPScorer sends responses to a scoring service, such as an external model behind ScorePath or the
+// local stand-in served by Handler.
+type HTTPScorer struct {
+	url        string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewHTTPScorer(cfg config.AutoScoringConfig) *HTTPScorer {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeoutSec
+	}
+	return &HTTPScorer{
+		url:        strings.TrimRight(cfg.URL, "/") + ScorePath,
+		apiKey:     cfg.APIKey,
+		httpClient: &http.Client{Timeout: time.Duration(timeout) * time.Second},
+	}
+}
+
+func (s *HTTPScorer) Name() string {
+	return ScorerHTTP
+}
+
+func (s *HTTPScorer) Score(ctx context.Context, request *Request) (*Result, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("scorer: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnprocessableEntity:
+		return nil, ErrUnsupported
+	default:
+		return nil, fmt.Errorf("scorer: unexpected status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var result Result
+	if err = json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("scorer: invalid response: %w", err)
+	}
+	// Never record a score off the rubric of the request.
+	result.MaxScore = request.MaxScore
+	result.Score = clamp(result.Score, request.MaxScore)
+	return &result, nil
+}
@@ -0,0 +1,92 @@
+package scorer_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/modules/attempt/scorer"
+	"pirate-lang-go/modules/attempt/scorer/scorertest"
+)
+
+func essay(text string) *scorer.Request {
+	return &scorer.Request{
+		Section:      "Writing",
+		TaskType:     "Essay",
+		Prompt:       "Do you prefer working from home or at the office?",
+		ResponseType: "Text",
+		Text:         text,
+		MaxScore:     5,
+	}
+}
+
+func TestHTTPScorerMatchesScoringService(t *testing.T) {
+	server := scorertest.NewServer()
+	defer server.Close()
+	httpScorer, err := scorer.NewFromConfig(server.ScoringConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := essay("I prefer working from home. It saves an hour of travel every day, and he have more time for the family.")
+	got, err := httpScorer.Score(context.Background(), request)
+	if err != nil {
+		t.Fatalf("score: %v", err)
+	}
+	want, err := scorer.NewHeuristicScorer().Score(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("result = %+v, want the service's %+v", got, want)
+	}
+}
+
+func TestHTTPScorerReportsUnsupportedResponse(t *testing.T) {
+	server := scorertest.NewServer()
+	defer server.Close()
+
+	request := essay("")
+	request.ResponseType = "Audio"
+	request.AudioUrl = "https://cdn.example.com/answers/1.mp3"
+	if _, err := scorer.NewHTTPScorer(server.ScoringConfig()).Score(context.Background(), request); !errors.Is(err, scorer.ErrUnsupported) {
+		t.Errorf("error = %v, want %v", err, scorer.ErrUnsupported)
+	}
+}
+
+func TestHTTPScorerRejectedAPIKey(t *testing.T) {
+	server := scorertest.NewServer()
+	defer server.Close()
+	cfg := server.ScoringConfig()
+	cfg.APIKey = "wrong-key"
+
+	_, err := scorer.NewHTTPScorer(cfg).Score(context.Background(), essay("I prefer working from home."))
+	if err == nil || errors.Is(err, scorer.ErrUnsupported) {
+		t.Errorf("error = %v, want the rejected request", err)
+	}
+}
+
+// generousScorer ignores the rubric of the request, as a misbehaving service might.
+type generousScorer struct{}
+
+func (generousScorer) Name() string { return "generous" }
+
+func (generousScorer) Score(ctx context.Context, request *scorer.Request) (*scorer.Result, error) {
+	return &scorer.Result{Score: 12, MaxScore: 100, Feedback: []string{"Perfect."}}, nil
+}
+
+func TestHTTPScorerKeepsScoreOnRubric(t *testing.T) {
+	server := httptest.NewServer(scorer.NewHandler(generousScorer{}, ""))
+	defer server.Close()
+
+	result, err := scorer.NewHTTPScorer(config.AutoScoringConfig{Scorer: scorer.ScorerHTTP, URL: server.URL}).Score(context.Background(), essay("Anything."))
+	if err != nil {
+		t.Fatalf("score: %v", err)
+	}
+	if result.Score != 5 || result.MaxScore != 5 {
+		t.Errorf("score = %d/%d, want 5/5", result.Score, result.MaxScore)
+	}
+}
@@ -0,0 +1,71 @@
+// Package scorer pre-scores Speaking and Writing responses before human raters see them.
+package scorer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pirate-lang-go/core/config"
+)
+
+var (
+	ErrUnknownScorer = errors.New("scorer: unknown scorer")
+	// ErrUnsupported is returned for a response the scorer cannot score, such as a recording sent to a
+	// scorer that only reads text.
+	ErrUnsupported = errors.New("scorer: response not supported")
+)
+
+// Request is a response to score, with the task it answers. MaxScore is the top of the rubric the
+// response is rated against.
+type Request struct {
+	Section      string `json:"section"`
+	TaskType     string `json:"task_type"`
+	Prompt       string `json:"prompt"`
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text,omitempty"`
+	AudioUrl     string `json:"audio_url,omitempty"`
+	MaxScore     int32  `json:"max_score"`
+}
+
+// Result is the pre-score of a response with the feedback explaining it.
+type Result struct {
+	Score    int32    `json:"score"`
+	MaxScore int32    `json:"max_score"`
+	Feedback []string `json:"feedback"`
+}
+
+// AutoScorer scores a response the way a rater would, on the rubric scale of the request.
+type AutoScorer interface {
+	Name() string
+	Score(ctx context.Context, request *Request) (*Result, error)
+}
+
+const (
+	ScorerHeuristic = "heuristic"
+	ScorerHTTP      = "http"
+)
+
+// NewFromConfig builds the configured scorer. The heuristic scorer is used unless another one is set.
+func NewFromConfig(cfg config.AutoScoringConfig) (AutoScorer, error) {
+	switch cfg.Scorer {
+	case "", ScorerHeuristic:
+		return NewHeuristicScorer(), nil
+	case ScorerHTTP:
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("scorer: the http scorer needs a URL")
+		}
+		return NewHTTPScorer(cfg), nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownScorer, cfg.Scorer)
+}
+
+// clamp keeps a score on the rubric scale.
+func clamp(score, maxScore int32) int32 {
+	if score < 0 {
+		return 0
+	}
+	if score > maxScore {
+		return maxScore
+	}
+	return score
+}
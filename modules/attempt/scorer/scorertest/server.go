@@ -0,0 +1,30 @@
+// Package scorertest provides a local scoring service, backed by the heuristic scorer, for exercising
+// the HTTP scorer without reaching an external model.
+package scorertest
+
+import (
+	"net/http/httptest"
+
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/modules/attempt/scorer"
+)
+
+const APIKey = "scorertest-key"
+
+// Server serves POST /v1/score with the heuristic scorer.
+type Server struct {
+	*httptest.Server
+}
+
+func NewServer() *Server {
+	return &Server{Server: httptest.NewServer(scorer.NewHandler(scorer.NewHeuristicScorer(), APIKey))}
+}
+
+// ScoringConfig returns a scoring config pointing at the server.
+func (s *Server) ScoringConfig() config.AutoScoringConfig {
+	return config.AutoScoringConfig{
+		Scorer: scorer.ScorerHTTP,
+		URL:    s.URL,
+		APIKey: APIKey,
+	}
+}
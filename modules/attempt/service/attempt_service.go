@@ -0,0 +1,492 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/mapper"
+	librarydto "pirate-lang-go/modules/library/dto"
+	libraryentity "pirate-lang-go/modules/library/entity"
+	librarymapper "pirate-lang-go/modules/library/mapper"
+	"sort"
+	"time"
+)
+
+// separateQuestionsPageSize is the page size used to walk the standalone questions of a part.
+const separateQuestionsPageSize = 100
+
+func (s *AttemptService) StartAttempt(ctx context.Context, token string, examId uuid.UUID) (*dto.AttemptResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AttemptService:StartAttempt:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AttemptService:StartAttempt:Failed to get user", err)
+	}
+	exam, err := s.libraryRepo.GetExam(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:StartAttempt:Exam not found", err)
+		}
+		logger.Error("AttemptService:StartAttempt:Failed to get exam", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartAttempt:Failed to get exam", err)
+	}
+
+	// Resume the running attempt instead of opening a second clock on the same exam.
+	current, err := s.repo.GetInProgressAttempt(ctx, claims.UserID, examId)
+	if err != nil {
+		logger.Error("AttemptService:StartAttempt:Failed to get running attempt", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartAttempt:Failed to get running attempt", err)
+	}
+	if current != nil {
+		current, appErr := s.expireIfOverdue(ctx, current)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if current.Status == entity.AttemptStatusInProgress {
+			return s.buildAttemptDetail(ctx, current)
+		}
+	}
+
+	// A running attempt may still be resumed after its exam is archived, but new attempts need a published exam.
+	if exam.Status != libraryentity.ContentStatusPublished {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:StartAttempt:Exam not found", nil)
+	}
+	if appErr := s.checkContentAccess(ctx, claims.UserID, examId); appErr != nil {
+		return nil, appErr
+	}
+	questions, appErr := s.collectExamQuestions(ctx, examId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(questions) == 0 {
+		return nil, errors.NewAppError(errors.ErrInvalidState, "AttemptService:StartAttempt:Exam has no questions", nil)
+	}
+
+	attempt, err := s.repo.CreateAttempt(ctx, examId, claims.UserID, exam.DurationMinutes, questions)
+	if err != nil {
+		logger.Error("AttemptService:StartAttempt:Failed to create attempt", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartAttempt:Failed to create attempt", err)
+	}
+	return s.buildAttemptDetail(ctx, attempt)
+}
+
+func (s *AttemptService) GetAttempts(ctx context.Context, token string, examId uuid.UUID) ([]*dto.AttemptResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AttemptService:GetAttempts:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AttemptService:GetAttempts:Failed to get user", err)
+	}
+	attempts, err := s.repo.GetAttemptsByUser(ctx, claims.UserID, examId)
+	if err != nil {
+		logger.Error("AttemptService:GetAttempts:Failed to get attempts", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetAttempts:Failed to get attempts", err)
+	}
+	for i, attempt := range attempts {
+		if expired, appErr := s.expireIfOverdue(ctx, attempt); appErr == nil {
+			attempts[i] = expired
+		}
+	}
+	return mapper.ToAttemptsResponse(attempts, time.Now()), nil
+}
+
+func (s *AttemptService) GetAttempt(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return s.buildAttemptDetail(ctx, attempt)
+}
+
+func (s *AttemptService) SaveAnswers(ctx context.Context, token string, examId, attemptId uuid.UUID, request *dto.SaveAnswersRequest) (*dto.AttemptResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr = checkAnswerable(attempt); appErr != nil {
+		return nil, appErr
+	}
+
+	questions, err := s.repo.GetAttemptQuestions(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:SaveAnswers:Failed to get attempt questions", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:SaveAnswers:Failed to get attempt questions", err)
+	}
+	questionsById := make(map[uuid.UUID]*entity.AttemptQuestion, len(questions))
+	for _, question := range questions {
+		questionsById[question.QuestionID] = question
+	}
+
+	answers := mapper.ToAttemptAnswerEntities(request)
+	for _, answer := range answers {
+		question, ok := questionsById[answer.QuestionID]
+		if !ok {
+			return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SaveAnswers:Question "+answer.QuestionID.String()+" is not part of this attempt", nil)
+		}
+		if _, ok := responseTypes[question.ToeicQuestionSection]; ok {
+			return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SaveAnswers:Question "+answer.QuestionID.String()+" takes a spoken or written response", nil)
+		}
+		if !isValidAnswer(question, answer.Answer) {
+			return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SaveAnswers:Answer does not fit the options of question "+answer.QuestionID.String(), nil)
+		}
+	}
+
+	saved, err := s.repo.SaveAnswers(ctx, attemptId, answers)
+	if err != nil {
+		logger.Error("AttemptService:SaveAnswers:Failed to save answers", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:SaveAnswers:Failed to save answers", err)
+	}
+	if !saved {
+		// The database refused the batch: the deadline passed or the attempt was closed meanwhile.
+		attempt, appErr = s.expireIfOverdue(ctx, attempt)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if appErr = checkAnswerable(attempt); appErr != nil {
+			return nil, appErr
+		}
+		return nil, errors.NewAppError(errors.ErrResourceExpired, "AttemptService:SaveAnswers:Attempt deadline has passed", nil)
+	}
+	return mapper.ToAttemptResponse(attempt, time.Now()), nil
+}
+
+func (s *AttemptService) SubmitAttempt(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if attempt.Status != entity.AttemptStatusInProgress {
+		return nil, errors.NewAppError(errors.ErrInvalidState, "AttemptService:SubmitAttempt:Attempt is already "+attempt.Status, nil)
+	}
+	attempt, appErr = s.finalize(ctx, attempt, entity.AttemptStatusSubmitted)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return mapper.ToAttemptResponse(attempt, time.Now()), nil
+}
+
+// loadAttempt resolves the caller from the token and returns their attempt, expiring it first when its deadline has passed.
+func (s *AttemptService) loadAttempt(ctx context.Context, token string, examId, attemptId uuid.UUID) (*entity.Attempt, *errors.AppError) {
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AttemptService:loadAttempt:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AttemptService:loadAttempt:Failed to get user", err)
+	}
+	attempt, err := s.repo.GetAttempt(ctx, attemptId, claims.UserID)
+	if err != nil {
+		logger.Error("AttemptService:loadAttempt:Failed to get attempt", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:loadAttempt:Failed to get attempt", err)
+	}
+	if attempt == nil || attempt.ExamID != examId {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:loadAttempt:Attempt not found", nil)
+	}
+	return s.expireIfOverdue(ctx, attempt)
+}
+
+// expireIfOverdue closes an in-progress attempt whose deadline has passed. Answers saved before the deadline are kept.
+func (s *AttemptService) expireIfOverdue(ctx context.Context, attempt *entity.Attempt) (*entity.Attempt, *errors.AppError) {
+	if attempt.Status != entity.AttemptStatusInProgress || attempt.DeadlineAt == nil || time.Now().Before(*attempt.DeadlineAt) {
+		return attempt, nil
+	}
+	return s.finalize(ctx, attempt, entity.AttemptStatusExpired)
+}
+
+func (s *AttemptService) finalize(ctx context.Context, attempt *entity.Attempt, status string) (*entity.Attempt, *errors.AppError) {
+	closed, err := s.repo.FinalizeAttempt(ctx, attempt.AttemptID, status)
+	if err != nil {
+		logger.Error("AttemptService:finalize:Failed to finalize attempt", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:finalize:Failed to finalize attempt", err)
+	}
+	// Reload so a concurrent finalization wins consistently.
+	finalized, err := s.repo.GetAttempt(ctx, attempt.AttemptID, attempt.UserID)
+	if err != nil || finalized == nil {
+		logger.Error("AttemptService:finalize:Failed to reload attempt", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:finalize:Failed to reload attempt", err)
+	}
+	if closed {
+		// A scoring failure must not undo the submission; the result is computed again on first read.
+		if _, appErr := s.scoreAttempt(ctx, finalized); appErr != nil {
+			logger.Error("AttemptService:finalize:Failed to score attempt", "attempt_id", attempt.AttemptID, "error", appErr)
+		}
+	}
+	return finalized, nil
+}
+
+func (s *AttemptService) buildAttemptDetail(ctx context.Context, attempt *entity.Attempt) (*dto.AttemptResponse, *errors.AppError) {
+	questions, err := s.repo.GetAttemptQuestions(ctx, attempt.AttemptID)
+	if err != nil {
+		logger.Error("AttemptService:buildAttemptDetail:Failed to get attempt questions", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:buildAttemptDetail:Failed to get attempt questions", err)
+	}
+	paragraphs, err := s.repo.GetAttemptParagraphs(ctx, attempt.AttemptID)
+	if err != nil {
+		logger.Error("AttemptService:buildAttemptDetail:Failed to get attempt paragraphs", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:buildAttemptDetail:Failed to get attempt paragraphs", err)
+	}
+	if appErr := s.withResponses(ctx, attempt.AttemptID, questions); appErr != nil {
+		return nil, appErr
+	}
+	response := mapper.ToAttemptResponse(attempt, time.Now())
+
+	locked, appErr := s.lockedParts(ctx, attempt)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(locked) > 0 {
+		questions, paragraphs = withoutLockedParts(questions, paragraphs, locked)
+		response.LockedPartIDs = sortedPartIDs(locked)
+	}
+	response.Questions = mapper.ToAttemptQuestionsResponse(questions)
+	response.Paragraphs = mapper.ToAttemptParagraphsResponse(paragraphs)
+	return response, nil
+}
+
+// lockedParts returns the subscription parts of an attempt to hide once the subscription that allowed
+// starting it has lapsed.
+func (s *AttemptService) lockedParts(ctx context.Context, attempt *entity.Attempt) (map[uuid.UUID]bool, *errors.AppError) {
+	subscriptionParts, appErr := s.subscriptionParts(ctx, attempt.ExamID)
+	if appErr != nil || len(subscriptionParts) == 0 {
+		return nil, appErr
+	}
+	if appErr = s.checkSubscriptionAccess(ctx, attempt.UserID); appErr != nil {
+		if appErr.Code != errors.ErrForbidden {
+			return nil, appErr
+		}
+		return subscriptionParts, nil
+	}
+	return nil, nil
+}
+
+func sortedPartIDs(parts map[uuid.UUID]bool) []uuid.UUID {
+	partIds := make([]uuid.UUID, 0, len(parts))
+	for partId := range parts {
+		partIds = append(partIds, partId)
+	}
+	sort.Slice(partIds, func(i, j int) bool {
+		return partIds[i].String() < partIds[j].String()
+	})
+	return partIds
+}
+
+func withoutLockedParts(questions []*entity.AttemptQuestion, paragraphs []*entity.AttemptParagraph, locked map[uuid.UUID]bool) ([]*entity.AttemptQuestion, []*entity.AttemptParagraph) {
+	visibleQuestions := make([]*entity.AttemptQuestion, 0, len(questions))
+	for _, question := range questions {
+		if !locked[question.PartID] {
+			visibleQuestions = append(visibleQuestions, question)
+		}
+	}
+	visibleParagraphs := make([]*entity.AttemptParagraph, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		if !locked[paragraph.PartID] {
+			visibleParagraphs = append(visibleParagraphs, paragraph)
+		}
+	}
+	return visibleQuestions, visibleParagraphs
+}
+
+// checkContentAccess refuses exams with subscription parts to users who may not read subscription content.
+func (s *AttemptService) checkContentAccess(ctx context.Context, userId, examId uuid.UUID) *errors.AppError {
+	subscriptionParts, appErr := s.subscriptionParts(ctx, examId)
+	if appErr != nil || len(subscriptionParts) == 0 {
+		return appErr
+	}
+	return s.checkSubscriptionAccess(ctx, userId)
+}
+
+// subscriptionParts returns the ids of the SUBSCRIPTION parts of an exam.
+func (s *AttemptService) subscriptionParts(ctx context.Context, examId uuid.UUID) (map[uuid.UUID]bool, *errors.AppError) {
+	parts, err := s.libraryRepo.GetExamPartsByExamId(ctx, examId)
+	if err != nil {
+		logger.Error("AttemptService:subscriptionParts:Failed to get exam parts", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:subscriptionParts:Failed to get exam parts", err)
+	}
+	subscriptionParts := make(map[uuid.UUID]bool)
+	for _, part := range parts {
+		if part.PlanType == libraryentity.PlanTypeSubscription {
+			subscriptionParts[part.PartID] = true
+		}
+	}
+	return subscriptionParts, nil
+}
+
+// checkSubscriptionAccess requires a running subscription and a verified email to read subscription content.
+func (s *AttemptService) checkSubscriptionAccess(ctx context.Context, userId uuid.UUID) *errors.AppError {
+	entitlement, appErr := s.subscriptionService.GetEntitlement(ctx, userId)
+	if appErr != nil {
+		logger.Error("AttemptService:checkSubscriptionAccess:Failed to get entitlement", "user_id", userId, "error", appErr)
+		return appErr
+	}
+	if !entitlement.CanAccess(libraryentity.PlanTypeSubscription) {
+		return errors.NewAppError(errors.ErrForbidden, "AttemptService:checkSubscriptionAccess:A subscription is required to access this content", nil)
+	}
+
+	user, err := s.accountRepo.GetUserByEmailOrUserNameOrId(ctx, "", "", userId)
+	if err != nil {
+		logger.Error("AttemptService:checkSubscriptionAccess:Failed to get user", "user_id", userId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:checkSubscriptionAccess:Failed to get user", err)
+	}
+	if user == nil || user.EmailVerifiedAt == nil {
+		return errors.NewAppError(errors.ErrForbidden, "AttemptService:checkSubscriptionAccess:Verify your email to access subscription content", nil)
+	}
+	return nil
+}
+
+// collectExamQuestions assembles the questions of an exam in part order, then question order inside each part.
+func (s *AttemptService) collectExamQuestions(ctx context.Context, examId uuid.UUID) ([]*entity.AttemptQuestion, *errors.AppError) {
+	parts, err := s.libraryRepo.GetExamPartsByExamId(ctx, examId)
+	if err != nil {
+		logger.Error("AttemptService:collectExamQuestions:Failed to get exam parts", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:collectExamQuestions:Failed to get exam parts", err)
+	}
+
+	var questions []*entity.AttemptQuestion
+	for _, part := range parts {
+		var partQuestions []*entity.AttemptQuestion
+		orders := make(map[uuid.UUID][2]int32)
+
+		paragraphs, err := s.libraryRepo.GetParagraphsByPartId(ctx, part.PartID)
+		if err != nil {
+			logger.Error("AttemptService:collectExamQuestions:Failed to get paragraphs", "part_id", part.PartID, "error", err)
+			return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:collectExamQuestions:Failed to get paragraphs", err)
+		}
+		for _, paragraph := range paragraphs {
+			paragraphQuestions, err := s.libraryRepo.GetQuestionsByParagraph(ctx, paragraph.ParagraphID)
+			if err != nil {
+				logger.Error("AttemptService:collectExamQuestions:Failed to get paragraph questions", "paragraph_id", paragraph.ParagraphID, "error", err)
+				return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:collectExamQuestions:Failed to get paragraph questions", err)
+			}
+			for _, question := range paragraphQuestions {
+				orders[question.QuestionID] = [2]int32{question.QuestionOrder, question.QuestionNumberInPart}
+				partQuestions = append(partQuestions, &entity.AttemptQuestion{
+					QuestionID:  question.QuestionID,
+					PartID:      part.PartID,
+					ParagraphID: paragraph.ParagraphID,
+				})
+			}
+		}
+
+		for pageNumber := 1; ; pageNumber++ {
+			page, err := s.libraryRepo.GetSeparateQuestionsByPart(ctx, part.PartID, pageNumber, separateQuestionsPageSize)
+			if err != nil {
+				logger.Error("AttemptService:collectExamQuestions:Failed to get part questions", "part_id", part.PartID, "error", err)
+				return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:collectExamQuestions:Failed to get part questions", err)
+			}
+			for _, question := range page.Items {
+				orders[question.QuestionID] = [2]int32{question.QuestionOrder, question.QuestionNumberInPart}
+				partQuestions = append(partQuestions, &entity.AttemptQuestion{
+					QuestionID: question.QuestionID,
+					PartID:     part.PartID,
+				})
+			}
+			if int64(pageNumber) >= page.TotalPages {
+				break
+			}
+		}
+
+		sort.SliceStable(partQuestions, func(i, j int) bool {
+			a, b := orders[partQuestions[i].QuestionID], orders[partQuestions[j].QuestionID]
+			if a[0] != b[0] {
+				return a[0] < b[0]
+			}
+			return a[1] < b[1]
+		})
+		questions = append(questions, partQuestions...)
+	}
+
+	for i, question := range questions {
+		question.SequenceNumber = int32(i + 1)
+	}
+	return questions, nil
+}
+
+func checkAnswerable(attempt *entity.Attempt) *errors.AppError {
+	switch attempt.Status {
+	case entity.AttemptStatusInProgress:
+		return nil
+	case entity.AttemptStatusExpired:
+		return errors.NewAppError(errors.ErrResourceExpired, "AttemptService:checkAnswerable:Attempt deadline has passed", nil)
+	default:
+		return errors.NewAppError(errors.ErrInvalidState, "AttemptService:checkAnswerable:Attempt is already "+attempt.Status, nil)
+	}
+}
+
+// isValidAnswer accepts an empty answer (clearing it) or an answer fitting the options of the question:
+// one of the option letters of a choice question, a JSON object of prompt keys to choice keys for
+// Matching, a JSON array of distinct item keys for Ordering and a JSON array with the text of every
+// blank for FillInTheBlank. Matching, Ordering and FillInTheBlank answers may be partial.
+func isValidAnswer(question *entity.AttemptQuestion, answer string) bool {
+	if answer == "" {
+		return true
+	}
+	options, err := librarymapper.UnmarshalAnswerOption(question.AnswerOption)
+	if err != nil {
+		return true
+	}
+	keys := librarymapper.AnswerOptionKeys(options)
+	switch question.QuestionType {
+	case libraryentity.QuestionTypeMatching:
+		pairs, ok := unmarshalMatchingAnswer(answer)
+		if !ok {
+			return false
+		}
+		prompts, choices := optionItemKeys(options.Prompts), optionItemKeys(options.Choices)
+		for prompt, choice := range pairs {
+			if !prompts[prompt] || !choices[choice] {
+				return false
+			}
+		}
+		return true
+	case libraryentity.QuestionTypeOrdering:
+		order, ok := unmarshalOrderingAnswer(answer)
+		if !ok {
+			return false
+		}
+		items := optionItemKeys(options.Items)
+		seen := make(map[string]bool, len(order))
+		for _, item := range order {
+			if !items[item] || seen[item] {
+				return false
+			}
+			seen[item] = true
+		}
+		return true
+	case libraryentity.QuestionTypeFillInTheBlank:
+		blanks, ok := unmarshalBlanksAnswer(answer)
+		return ok && (options.Blanks == 0 || int32(len(blanks)) <= options.Blanks)
+	}
+	if !libraryentity.ChoiceQuestionTypes[question.QuestionType] {
+		return true
+	}
+	// Listening items often carry their options only in the audio, so any letter is accepted.
+	if len(keys) == 0 {
+		return answer == "A" || answer == "B" || answer == "C" || answer == "D"
+	}
+	return keys[answer]
+}
+
+func optionItemKeys(items []librarydto.OptionItem) map[string]bool {
+	keys := make(map[string]bool, len(items))
+	for _, item := range items {
+		keys[item.Key] = true
+	}
+	return keys
+}
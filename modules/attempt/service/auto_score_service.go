@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/scorer"
+	"sync"
+	"time"
+)
+
+// autoScoredQuestionTypes lists the tasks pre-scored by the automated scorer: the Writing essay and the
+// Speaking open response.
+var autoScoredQuestionTypes = []string{"Essay", "OpenResponse"}
+
+// maxAutoScoreFailures stops calling the scorer for a response it keeps failing on.
+const maxAutoScoreFailures = 3
+
+// AutoScoreSummary counts the responses handled by a pre-scoring run.
+type AutoScoreSummary struct {
+	Scored      int
+	Unsupported int
+	Failed      int
+}
+
+// AutoScoreResponses pre-scores up to batchSize submitted Essay and OpenResponse answers on the scale of
+// their rubric. The pre-score and its feedback are shown to raters and learners; they never replace the
+// human rating.
+func (s *AttemptService) AutoScoreResponses(ctx context.Context, batchSize int32) (*AutoScoreSummary, *errors.AppError) {
+	responses, err := s.repo.GetResponsesToAutoScore(ctx, autoScoredQuestionTypes, maxAutoScoreFailures, batchSize)
+	if err != nil {
+		logger.Error("AttemptService:AutoScoreResponses:Failed to get responses", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:AutoScoreResponses:Failed to get responses", err)
+	}
+
+	summary := &AutoScoreSummary{}
+	for _, response := range responses {
+		if ctx.Err() != nil {
+			break
+		}
+		rubric := rubricFor(response.ToeicQuestionSection, response.QuestionType)
+		result, err := s.autoScorer.Score(ctx, &scorer.Request{
+			Section:      response.ToeicQuestionSection,
+			TaskType:     response.QuestionType,
+			Prompt:       response.QuestionContent,
+			ResponseType: response.ResponseType,
+			Text:         response.TextResponse,
+			AudioUrl:     response.AudioUrl,
+			MaxScore:     rubric.MaxScore,
+		})
+		autoScore := &entity.AutoScore{Scorer: s.autoScorer.Name()}
+		switch {
+		case stderrors.Is(err, scorer.ErrUnsupported):
+			// Recorded without a score, so the scorer is not asked again.
+			summary.Unsupported++
+		case err != nil && ctx.Err() != nil:
+			// Stopping: the response is scored on the next run.
+			return summary, nil
+		case err != nil:
+			logger.Warn("AttemptService:AutoScoreResponses:Failed to score response",
+				"attempt_id", response.AttemptID,
+				"question_id", response.QuestionID,
+				"scorer", s.autoScorer.Name(),
+				"error", err)
+			summary.Failed++
+			if err = s.repo.RecordAutoScoreFailure(ctx, response.AttemptID, response.QuestionID); err != nil {
+				return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:AutoScoreResponses:Failed to record failure", err)
+			}
+			continue
+		default:
+			summary.Scored++
+			autoScore.Score, autoScore.MaxScore, autoScore.Feedback = &result.Score, result.MaxScore, result.Feedback
+		}
+		if _, err = s.repo.SaveAutoScore(ctx, response.AttemptID, response.QuestionID, autoScore); err != nil {
+			return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:AutoScoreResponses:Failed to save auto score", err)
+		}
+	}
+	return summary, nil
+}
+
+type AutoScoreConfig struct {
+	Interval  time.Duration // time between two runs
+	BatchSize int32         // responses scored per run at most
+}
+
+func DefaultAutoScoreConfig() AutoScoreConfig {
+	return AutoScoreConfig{
+		Interval:  time.Minute,
+		BatchSize: 50,
+	}
+}
+
+// AutoScoreWorker pre-scores the submitted responses in the background. Every instance runs its own; a
+// response scored twice keeps the first score.
+type AutoScoreWorker struct {
+	service IAttemptService
+	config  AutoScoreConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewAutoScoreWorker(service IAttemptService, config AutoScoreConfig) *AutoScoreWorker {
+	defaults := DefaultAutoScoreConfig()
+	if config.Interval <= 0 {
+		config.Interval = defaults.Interval
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaults.BatchSize
+	}
+	return &AutoScoreWorker{
+		service: service,
+		config:  config,
+	}
+}
+
+// Start scores the waiting responses right away, then every Interval until Stop is called.
+func (a *AutoScoreWorker) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+
+	a.wg.Add(1)
+	go a.run(ctx)
+	logger.Info("Auto score worker started", "interval", a.config.Interval, "batch_size", a.config.BatchSize)
+}
+
+// Stop waits for a running batch to finish.
+func (a *AutoScoreWorker) Stop() {
+	if a.cancel == nil {
+		return
+	}
+	a.cancel()
+	a.wg.Wait()
+	logger.Info("Auto score worker stopped")
+}
+
+func (a *AutoScoreWorker) run(ctx context.Context) {
+	defer a.wg.Done()
+	ticker := time.NewTicker(a.config.Interval)
+	defer ticker.Stop()
+	for {
+		a.score(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *AutoScoreWorker) score(ctx context.Context) {
+	summary, appErr := a.service.AutoScoreResponses(ctx, a.config.BatchSize)
+	if appErr != nil {
+		if ctx.Err() == nil {
+			logger.Error("AutoScoreWorker:score:Failed to score responses", "error", appErr)
+		}
+		return
+	}
+	if summary.Scored+summary.Unsupported+summary.Failed > 0 {
+		logger.Info("Responses auto scored",
+			"scored", summary.Scored,
+			"unsupported", summary.Unsupported,
+			"failed", summary.Failed)
+	}
+}
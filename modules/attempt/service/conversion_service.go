@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/mapper"
+	"time"
+)
+
+func (s *AttemptService) CreateConversionTable(ctx context.Context, request *dto.CreateConversionTableRequest) (*dto.ConversionTableResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	table, err := s.repo.CreateConversionTable(ctx, mapper.ToCreateConversionTableEntity(request))
+	if err != nil {
+		logger.Error("AttemptService:CreateConversionTable:Failed to create table", "error", err)
+		return nil, errors.NewAppError(errors.ErrAlreadyExists, "AttemptService:CreateConversionTable:Failed to create table", err)
+	}
+	return mapper.ToConversionTableResponse(table), nil
+}
+
+func (s *AttemptService) GetConversionTables(ctx context.Context) ([]*dto.ConversionTableResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tables, err := s.repo.GetConversionTables(ctx)
+	if err != nil {
+		logger.Error("AttemptService:GetConversionTables:Failed to get tables", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetConversionTables:Failed to get tables", err)
+	}
+	return mapper.ToConversionTablesResponse(tables), nil
+}
+
+func (s *AttemptService) GetConversionTable(ctx context.Context, tableId uuid.UUID) (*dto.ConversionTableResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	table, err := s.repo.GetConversionTable(ctx, tableId)
+	if err != nil {
+		logger.Error("AttemptService:GetConversionTable:Failed to get table", "table_id", tableId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetConversionTable:Failed to get table", err)
+	}
+	if table == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:GetConversionTable:Table not found", nil)
+	}
+	return mapper.ToConversionTableResponse(table), nil
+}
+
+func (s *AttemptService) UpdateConversionEntries(ctx context.Context, tableId uuid.UUID, request *dto.UpdateConversionEntriesRequest) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	table, err := s.repo.GetConversionTable(ctx, tableId)
+	if err != nil {
+		logger.Error("AttemptService:UpdateConversionEntries:Failed to get table", "table_id", tableId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:UpdateConversionEntries:Failed to get table", err)
+	}
+	if table == nil {
+		return errors.NewAppError(errors.ErrNotFound, "AttemptService:UpdateConversionEntries:Table not found", nil)
+	}
+	if err = s.repo.ReplaceConversionEntries(ctx, tableId, mapper.ToConversionEntryEntities(request)); err != nil {
+		logger.Error("AttemptService:UpdateConversionEntries:Failed to update entries", "table_id", tableId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:UpdateConversionEntries:Failed to update entries", err)
+	}
+	return nil
+}
+
+func (s *AttemptService) SetDefaultConversionTable(ctx context.Context, tableId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	updated, err := s.repo.SetDefaultConversionTable(ctx, tableId)
+	if err != nil {
+		logger.Error("AttemptService:SetDefaultConversionTable:Failed to set default table", "table_id", tableId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:SetDefaultConversionTable:Failed to set default table", err)
+	}
+	if !updated {
+		return errors.NewAppError(errors.ErrNotFound, "AttemptService:SetDefaultConversionTable:Table not found", nil)
+	}
+	return nil
+}
+
+func (s *AttemptService) SetExamConversionTable(ctx context.Context, examId uuid.UUID, request *dto.SetExamConversionTableRequest) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tableId := uuid.Nil
+	if request.TableID != nil {
+		tableId = *request.TableID
+		table, err := s.repo.GetConversionTable(ctx, tableId)
+		if err != nil {
+			logger.Error("AttemptService:SetExamConversionTable:Failed to get table", "table_id", tableId, "error", err)
+			return errors.NewAppError(errors.ErrInternal, "AttemptService:SetExamConversionTable:Failed to get table", err)
+		}
+		if table == nil {
+			return errors.NewAppError(errors.ErrNotFound, "AttemptService:SetExamConversionTable:Table not found", nil)
+		}
+	}
+	updated, err := s.repo.SetExamConversionTable(ctx, examId, tableId)
+	if err != nil {
+		logger.Error("AttemptService:SetExamConversionTable:Failed to set exam table", "exam_id", examId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:SetExamConversionTable:Failed to set exam table", err)
+	}
+	if !updated {
+		return errors.NewAppError(errors.ErrNotFound, "AttemptService:SetExamConversionTable:Exam not found", nil)
+	}
+	return nil
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"math"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/mapper"
+	"time"
+)
+
+const (
+	// ratingAssignmentTimeout hands a response left unrated by its rater to the next one.
+	ratingAssignmentTimeout = 2 * time.Hour
+	// defaultRatedSectionMaxScore is the TOEIC Speaking and Writing scale, used when the exam sets none.
+	defaultRatedSectionMaxScore = 200
+)
+
+var threePointBands = []entity.RubricBand{
+	{Score: 3, Description: "Fully answers the task; clear and appropriate language with only minor errors"},
+	{Score: 2, Description: "Answers the task; errors sometimes get in the way of understanding"},
+	{Score: 1, Description: "Answers the task in part; frequent errors make it hard to understand"},
+	{Score: 0, Description: "No response, or the response is unrelated to the task"},
+}
+
+var fivePointBands = []entity.RubricBand{
+	{Score: 5, Description: "Fully developed and well organized; varied vocabulary and grammar with only minor errors"},
+	{Score: 4, Description: "Well developed and organized; some noticeable errors that do not get in the way of understanding"},
+	{Score: 3, Description: "Addresses the task with limited development; errors sometimes get in the way of understanding"},
+	{Score: 2, Description: "Addresses the task in part; little development and frequent errors"},
+	{Score: 1, Description: "Barely addresses the task; serious errors throughout"},
+	{Score: 0, Description: "No response, or the response is unrelated to the task"},
+}
+
+// rubrics are the scoring forms of the TOEIC Speaking and Writing tasks, by section and question type.
+var rubrics = map[string]map[string]entity.Rubric{
+	entity.SectionSpeaking: {
+		"ReadAloud":          {MaxScore: 3, Criteria: []string{"Pronunciation", "Intonation and stress"}, Bands: threePointBands},
+		"PictureDescription": {MaxScore: 3, Criteria: []string{"Pronunciation", "Intonation and stress", "Grammar", "Vocabulary", "Cohesion"}, Bands: threePointBands},
+		"QuestionResponse":   {MaxScore: 3, Criteria: []string{"Pronunciation", "Intonation and stress", "Grammar", "Vocabulary", "Cohesion", "Relevance of content", "Completeness of content"}, Bands: threePointBands},
+		"OpenResponse":       {MaxScore: 5, Criteria: []string{"Pronunciation", "Intonation and stress", "Grammar", "Vocabulary", "Cohesion", "Relevance of content", "Completeness of content"}, Bands: fivePointBands},
+	},
+	entity.SectionWriting: {
+		"PictureDescription": {MaxScore: 3, Criteria: []string{"Grammar", "Relevance of the sentences to the picture"}, Bands: threePointBands},
+		"Essay":              {MaxScore: 5, Criteria: []string{"Support of the opinion with reasons and examples", "Organization", "Grammar", "Vocabulary"}, Bands: fivePointBands},
+	},
+}
+
+// fallbackRubric applies to the other question types of the Speaking and Writing sections.
+var fallbackRubric = entity.Rubric{
+	MaxScore: 3,
+	Criteria: []string{"Task completion", "Grammar", "Vocabulary"},
+	Bands:    threePointBands,
+}
+
+func (s *AttemptService) GetRatingQueue(ctx context.Context) (*dto.RatingQueueResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	stats, err := s.repo.GetRatingQueueStats(ctx)
+	if err != nil {
+		logger.Error("AttemptService:GetRatingQueue:Failed to get queue stats", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetRatingQueue:Failed to get queue stats", err)
+	}
+	return &dto.RatingQueueResponse{
+		AwaitingRating:       stats.AwaitingRating,
+		AwaitingAdjudication: stats.AwaitingAdjudication,
+		Assigned:             stats.Assigned,
+	}, nil
+}
+
+// AssignNextRating gives the rater the next response to rate, with the rubric to rate it by. A rater who
+// has not submitted their current rating gets it again instead.
+func (s *AttemptService) AssignNextRating(ctx context.Context, token string) (*dto.RatingTaskResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AttemptService:AssignNextRating:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AttemptService:AssignNextRating:Failed to get user", err)
+	}
+	rating, err := s.repo.AssignNextResponse(ctx, claims.UserID, time.Now().Add(-ratingAssignmentTimeout))
+	if err != nil {
+		logger.Error("AttemptService:AssignNextRating:Failed to assign response", "rater_id", claims.UserID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:AssignNextRating:Failed to assign response", err)
+	}
+	if rating == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:AssignNextRating:No response is waiting for a rating", nil)
+	}
+	task, appErr := s.getRatingTask(ctx, rating.RatingID, "AssignNextRating")
+	if appErr != nil {
+		return nil, appErr
+	}
+	return mapper.ToRatingTaskResponse(task, rubricFor(task.ToeicQuestionSection, task.QuestionType)), nil
+}
+
+// SubmitRating stores the score of an assigned response. Two equal ratings settle the score of a response;
+// two different ones send it to a third rater, whose score is final.
+func (s *AttemptService) SubmitRating(ctx context.Context, token string, ratingId uuid.UUID, request *dto.SubmitRatingRequest) (*dto.ResponseRatingResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("AttemptService:SubmitRating:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "AttemptService:SubmitRating:Failed to get user", err)
+	}
+	task, appErr := s.getRatingTask(ctx, ratingId, "SubmitRating")
+	if appErr != nil {
+		return nil, appErr
+	}
+	rubric := rubricFor(task.ToeicQuestionSection, task.QuestionType)
+	if *request.Score > rubric.MaxScore {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, fmt.Sprintf("AttemptService:SubmitRating:Score must be between 0 and %d", rubric.MaxScore), nil)
+	}
+
+	rating, err := s.repo.SubmitRating(ctx, &entity.ResponseRating{
+		RatingID: ratingId,
+		RaterID:  claims.UserID,
+		Score:    request.Score,
+		MaxScore: rubric.MaxScore,
+		Comment:  request.Comment,
+	})
+	if err != nil {
+		logger.Error("AttemptService:SubmitRating:Failed to submit rating", "rating_id", ratingId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:SubmitRating:Failed to submit rating", err)
+	}
+	if rating == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:SubmitRating:Rating is not assigned to you or was already submitted", nil)
+	}
+	status, appErr := s.resolveRatings(ctx, task.AttemptID, task.QuestionID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if status == entity.RatingStatusRated {
+		if _, appErr = s.rollUpRatings(ctx, task.AttemptID, task.ExamID); appErr != nil {
+			return nil, appErr
+		}
+	}
+	return mapper.ToResponseRatingResponse(rating, status), nil
+}
+
+func (s *AttemptService) getRatingTask(ctx context.Context, ratingId uuid.UUID, method string) (*entity.RatingTask, *errors.AppError) {
+	task, err := s.repo.GetRatingTask(ctx, ratingId)
+	if err != nil {
+		logger.Error("AttemptService:"+method+":Failed to get rating task", "rating_id", ratingId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:"+method+":Failed to get rating task", err)
+	}
+	if task == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:"+method+":Rating not found", nil)
+	}
+	return task, nil
+}
+
+// resolveRatings settles the rating status of a response from the ratings submitted so far.
+func (s *AttemptService) resolveRatings(ctx context.Context, attemptId, questionId uuid.UUID) (string, *errors.AppError) {
+	ratings, err := s.repo.GetResponseRatings(ctx, attemptId, questionId)
+	if err != nil {
+		logger.Error("AttemptService:resolveRatings:Failed to get ratings", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return "", errors.NewAppError(errors.ErrInternal, "AttemptService:resolveRatings:Failed to get ratings", err)
+	}
+	rated := make(map[int32]*entity.ResponseRating, len(ratings))
+	for _, rating := range ratings {
+		if rating.Score != nil {
+			rated[rating.Round] = rating
+		}
+	}
+
+	var final *entity.ResponseRating
+	status := entity.RatingStatusPending
+	first, second := rated[entity.RatingRoundFirst], rated[entity.RatingRoundSecond]
+	switch {
+	case rated[entity.RatingRoundAdjudication] != nil:
+		final, status = rated[entity.RatingRoundAdjudication], entity.RatingStatusRated
+	case first != nil && second != nil && *first.Score == *second.Score:
+		final, status = first, entity.RatingStatusRated
+	case first != nil && second != nil:
+		status = entity.RatingStatusAdjudication
+	default:
+		return status, nil
+	}
+
+	var finalScore, maxScore *int32
+	if final != nil {
+		finalScore, maxScore = final.Score, &final.MaxScore
+	}
+	if err = s.repo.ResolveResponseRating(ctx, attemptId, questionId, status, finalScore, maxScore); err != nil {
+		logger.Error("AttemptService:resolveRatings:Failed to resolve rating", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return "", errors.NewAppError(errors.ErrInternal, "AttemptService:resolveRatings:Failed to resolve rating", err)
+	}
+	return status, nil
+}
+
+// rollUpRatings adds the final scores of the rated responses of an attempt into its Speaking and Writing
+// scores. A question left unanswered counts as zero; a section is only scaled once all of its responses
+// are rated. It returns nil when the attempt has no Speaking or Writing question or is not scored yet.
+func (s *AttemptService) rollUpRatings(ctx context.Context, attemptId, examId uuid.UUID) (*entity.AttemptResult, *errors.AppError) {
+	states, err := s.repo.GetRatingStates(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:rollUpRatings:Failed to get rating states", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:rollUpRatings:Failed to get rating states", err)
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+	config, err := s.repo.GetScoringConfig(ctx, examId)
+	if err != nil {
+		logger.Error("AttemptService:rollUpRatings:Failed to get scoring config", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:rollUpRatings:Failed to get scoring config", err)
+	}
+
+	result := &entity.AttemptResult{AttemptID: attemptId}
+	for _, state := range states {
+		score := &result.Speaking
+		if state.ToeicQuestionSection == entity.SectionWriting {
+			score = &result.Writing
+		}
+		switch {
+		case !state.Submitted:
+			score.MaxPoints += rubricFor(state.ToeicQuestionSection, state.QuestionType).MaxScore
+		case state.RatingStatus == entity.RatingStatusRated:
+			score.Points += state.FinalScore
+			score.MaxPoints += state.MaxScore
+		default:
+			score.Pending++
+			score.MaxPoints += rubricFor(state.ToeicQuestionSection, state.QuestionType).MaxScore
+		}
+	}
+	result.Speaking.Scaled = scaleRatedSection(result.Speaking, config.MaxSpeakingScore)
+	result.Writing.Scaled = scaleRatedSection(result.Writing, config.MaxWritingScore)
+
+	saved, err := s.repo.SaveRatedScores(ctx, result)
+	if err != nil {
+		logger.Error("AttemptService:rollUpRatings:Failed to save rated scores", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:rollUpRatings:Failed to save rated scores", err)
+	}
+	return saved, nil
+}
+
+// scaleRatedSection projects the rubric points of a fully rated section onto the section's scale.
+func scaleRatedSection(score entity.RatedSectionScore, maxScore int32) *int32 {
+	if score.MaxPoints == 0 || score.Pending > 0 {
+		return nil
+	}
+	if maxScore <= 0 {
+		maxScore = defaultRatedSectionMaxScore
+	}
+	scaled := int32(math.Round(float64(score.Points) * float64(maxScore) / float64(score.MaxPoints)))
+	return &scaled
+}
+
+// rubricFor returns the rubric of a question type, or the fallback rubric.
+func rubricFor(section, questionType string) entity.Rubric {
+	if rubric, ok := rubrics[section][questionType]; ok {
+		return rubric
+	}
+	return fallbackRubric
+}
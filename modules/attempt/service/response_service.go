@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"mime/multipart"
+	"path"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/storage"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/mapper"
+	"strings"
+	"time"
+)
+
+const (
+	// responseGraceSeconds lets a recording made in time finish uploading after the response window closes.
+	responseGraceSeconds = 30
+	maxResponseAudioSize = 20 << 20
+)
+
+// responseAudioExtensions lists the recordings accepted for a spoken response, browsers recording webm or ogg.
+var responseAudioExtensions = map[string]bool{
+	".mp3":  true,
+	".wav":  true,
+	".webm": true,
+	".ogg":  true,
+	".m4a":  true,
+}
+
+// responseWindow is the preparation and response time of a question, in seconds.
+type responseWindow struct {
+	preparation int32
+	response    int32
+}
+
+// defaultResponseWindows follow the TOEIC Speaking and Writing timings. A question sets its own windows
+// through preparation_seconds and response_seconds.
+var defaultResponseWindows = map[string]map[string]responseWindow{
+	entity.SectionSpeaking: {
+		"ReadAloud":          {preparation: 45, response: 45},
+		"PictureDescription": {preparation: 45, response: 30},
+		"QuestionResponse":   {preparation: 3, response: 30},
+		"OpenResponse":       {preparation: 30, response: 60},
+	},
+	entity.SectionWriting: {
+		"PictureDescription": {preparation: 0, response: 120},
+		"Essay":              {preparation: 0, response: 1800},
+	},
+}
+
+// fallbackResponseWindows apply to the other question types of a section.
+var fallbackResponseWindows = map[string]responseWindow{
+	entity.SectionSpeaking: {preparation: 30, response: 60},
+	entity.SectionWriting:  {preparation: 0, response: 600},
+}
+
+// responseTypes tells how each section is answered; the questions of other sections take choice answers.
+var responseTypes = map[string]string{
+	entity.SectionSpeaking: entity.ResponseTypeAudio,
+	entity.SectionWriting:  entity.ResponseTypeText,
+}
+
+// StartResponse opens the preparation window of a Speaking or Writing question. Starting it again returns
+// the windows opened the first time, so reloading the page does not restart the clock.
+func (s *AttemptService) StartResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID) (*dto.CapturedResponseResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, question, appErr := s.loadResponseQuestion(ctx, token, examId, attemptId, questionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	captured, err := s.repo.GetResponse(ctx, attemptId, questionId)
+	if err != nil {
+		logger.Error("AttemptService:StartResponse:Failed to get response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartResponse:Failed to get response", err)
+	}
+	if captured != nil {
+		return mapper.ToCapturedResponseResponse(captured), nil
+	}
+
+	window := questionResponseWindow(question)
+	started, err := s.repo.StartResponse(ctx, &entity.CapturedResponse{
+		AttemptID:          attemptId,
+		QuestionID:         questionId,
+		ResponseType:       responseTypes[question.ToeicQuestionSection],
+		PreparationSeconds: window.preparation,
+		ResponseSeconds:    window.response,
+	})
+	if err != nil {
+		logger.Error("AttemptService:StartResponse:Failed to start response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartResponse:Failed to start response", err)
+	}
+	// Not started means a concurrent start won, or the attempt was closed meanwhile.
+	captured, err = s.repo.GetResponse(ctx, attemptId, questionId)
+	if err != nil {
+		logger.Error("AttemptService:StartResponse:Failed to reload response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:StartResponse:Failed to reload response", err)
+	}
+	if !started && captured == nil {
+		return nil, s.attemptClosedError(ctx, attempt, "StartResponse")
+	}
+	return mapper.ToCapturedResponseResponse(captured), nil
+}
+
+// SubmitSpokenResponse stores the recording of a Speaking question. It may be sent again, replacing the
+// previous recording, until the response window closes.
+func (s *AttemptService) SubmitSpokenResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID, file *multipart.FileHeader) (*dto.CapturedResponseResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	if file.Size > maxResponseAudioSize {
+		return nil, errors.NewAppError(errors.ErrLimitExceeded, fmt.Sprintf("AttemptService:SubmitSpokenResponse:Recording is larger than %d MB", maxResponseAudioSize>>20), nil)
+	}
+	if !responseAudioExtensions[strings.ToLower(path.Ext(file.Filename))] {
+		return nil, errors.NewAppError(errors.ErrInvalidFormat, "AttemptService:SubmitSpokenResponse:Recording must be a .mp3, .wav, .webm, .ogg or .m4a file", nil)
+	}
+	attempt, question, appErr := s.loadResponseQuestion(ctx, token, examId, attemptId, questionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if responseTypes[question.ToeicQuestionSection] != entity.ResponseTypeAudio {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SubmitSpokenResponse:Question takes a written response", nil)
+	}
+	// Refuse early so a late recording is not uploaded for nothing; the save below checks again.
+	captured, appErr := s.openResponse(ctx, attemptId, questionId, "SubmitSpokenResponse")
+	if appErr != nil {
+		return nil, appErr
+	}
+	previousObject := captured.AudioObject
+
+	src, err := file.Open()
+	if err != nil {
+		logger.Error("AttemptService:SubmitSpokenResponse:Failed to open recording", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SubmitSpokenResponse:Failed to read recording", err)
+	}
+	defer src.Close()
+	objectName, objectURL, err := s.storage.UploadResponseAudio(ctx, attemptId, questionId, src, file.Size, file.Filename)
+	if err != nil {
+		logger.Error("AttemptService:SubmitSpokenResponse:Failed to upload recording", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:SubmitSpokenResponse:Failed to upload recording", err)
+	}
+
+	captured.AudioObject, captured.AudioUrl = objectName, objectURL
+	if appErr = s.saveResponse(ctx, attempt, captured, "SubmitSpokenResponse"); appErr != nil {
+		s.deleteRecording(ctx, objectName)
+		return nil, appErr
+	}
+	if previousObject != "" {
+		s.deleteRecording(ctx, previousObject)
+	}
+	return s.getCapturedResponse(ctx, attemptId, questionId, "SubmitSpokenResponse")
+}
+
+// SubmitWrittenResponse stores the text of a Writing question. It may be sent again, replacing the previous
+// text, until the response window closes.
+func (s *AttemptService) SubmitWrittenResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID, request *dto.SubmitWrittenResponseRequest) (*dto.CapturedResponseResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, question, appErr := s.loadResponseQuestion(ctx, token, examId, attemptId, questionId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if responseTypes[question.ToeicQuestionSection] != entity.ResponseTypeText {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:SubmitWrittenResponse:Question takes a spoken response", nil)
+	}
+	captured, appErr := s.openResponse(ctx, attemptId, questionId, "SubmitWrittenResponse")
+	if appErr != nil {
+		return nil, appErr
+	}
+	captured.TextResponse = request.Text
+	captured.WordCount = int32(len(strings.Fields(request.Text)))
+	if appErr = s.saveResponse(ctx, attempt, captured, "SubmitWrittenResponse"); appErr != nil {
+		return nil, appErr
+	}
+	return s.getCapturedResponse(ctx, attemptId, questionId, "SubmitWrittenResponse")
+}
+
+// loadResponseQuestion returns an answerable attempt of the caller with its Speaking or Writing question.
+func (s *AttemptService) loadResponseQuestion(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID) (*entity.Attempt, *entity.AttemptQuestion, *errors.AppError) {
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+	if appErr = checkAnswerable(attempt); appErr != nil {
+		return nil, nil, appErr
+	}
+	questions, err := s.repo.GetAttemptQuestions(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:loadResponseQuestion:Failed to get attempt questions", "attempt_id", attemptId, "error", err)
+		return nil, nil, errors.NewAppError(errors.ErrInternal, "AttemptService:loadResponseQuestion:Failed to get attempt questions", err)
+	}
+	for _, question := range questions {
+		if question.QuestionID != questionId {
+			continue
+		}
+		if _, ok := responseTypes[question.ToeicQuestionSection]; !ok {
+			return nil, nil, errors.NewAppError(errors.ErrInvalidInput, "AttemptService:loadResponseQuestion:Question takes a choice answer", nil)
+		}
+		return attempt, question, nil
+	}
+	return nil, nil, errors.NewAppError(errors.ErrNotFound, "AttemptService:loadResponseQuestion:Question is not part of this attempt", nil)
+}
+
+// openResponse returns the started response of a question when its response window is open.
+func (s *AttemptService) openResponse(ctx context.Context, attemptId, questionId uuid.UUID, method string) (*entity.CapturedResponse, *errors.AppError) {
+	captured, err := s.repo.GetResponse(ctx, attemptId, questionId)
+	if err != nil {
+		logger.Error("AttemptService:"+method+":Failed to get response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:"+method+":Failed to get response", err)
+	}
+	if appErr := checkResponseWindow(captured, time.Now(), method); appErr != nil {
+		return nil, appErr
+	}
+	return captured, nil
+}
+
+func (s *AttemptService) saveResponse(ctx context.Context, attempt *entity.Attempt, captured *entity.CapturedResponse, method string) *errors.AppError {
+	saved, err := s.repo.SaveResponse(ctx, captured, responseGraceSeconds)
+	if err != nil {
+		logger.Error("AttemptService:"+method+":Failed to save response", "attempt_id", attempt.AttemptID, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:"+method+":Failed to save response", err)
+	}
+	if saved {
+		return nil
+	}
+	// The database refused the write: the window closed or the attempt was closed meanwhile.
+	if appErr := checkResponseWindow(captured, time.Now(), method); appErr != nil {
+		return appErr
+	}
+	return s.attemptClosedError(ctx, attempt, method)
+}
+
+func (s *AttemptService) getCapturedResponse(ctx context.Context, attemptId, questionId uuid.UUID, method string) (*dto.CapturedResponseResponse, *errors.AppError) {
+	captured, err := s.repo.GetResponse(ctx, attemptId, questionId)
+	if err != nil || captured == nil {
+		logger.Error("AttemptService:"+method+":Failed to reload response", "attempt_id", attemptId, "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:"+method+":Failed to reload response", err)
+	}
+	return mapper.ToCapturedResponseResponse(captured), nil
+}
+
+// attemptClosedError explains a write refused because the attempt was closed or its deadline passed.
+func (s *AttemptService) attemptClosedError(ctx context.Context, attempt *entity.Attempt, method string) *errors.AppError {
+	attempt, appErr := s.expireIfOverdue(ctx, attempt)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = checkAnswerable(attempt); appErr != nil {
+		return appErr
+	}
+	return errors.NewAppError(errors.ErrResourceExpired, "AttemptService:"+method+":Attempt deadline has passed", nil)
+}
+
+// deleteRecording removes a recording that is no longer on record; a failure only leaves an unused object.
+func (s *AttemptService) deleteRecording(ctx context.Context, objectName string) {
+	if err := s.storage.DeleteObject(ctx, storage.ResponseBucket, objectName); err != nil {
+		logger.Warn("AttemptService:deleteRecording:Failed to delete recording", "object", objectName, "error", err)
+	}
+}
+
+func checkResponseWindow(captured *entity.CapturedResponse, now time.Time, method string) *errors.AppError {
+	if captured == nil {
+		return errors.NewAppError(errors.ErrInvalidState, "AttemptService:"+method+":Response has not been started", nil)
+	}
+	if now.Before(captured.PreparationEndsAt()) {
+		return errors.NewAppError(errors.ErrInvalidState, "AttemptService:"+method+":Preparation time is not over", nil)
+	}
+	if now.After(captured.ResponseEndsAt().Add(responseGraceSeconds * time.Second)) {
+		return errors.NewAppError(errors.ErrResourceExpired, "AttemptService:"+method+":Response time is over", nil)
+	}
+	return nil
+}
+
+// questionResponseWindow returns the windows set on a question, or the default of its type.
+func questionResponseWindow(question *entity.AttemptQuestion) responseWindow {
+	window, ok := defaultResponseWindows[question.ToeicQuestionSection][question.QuestionType]
+	if !ok {
+		window = fallbackResponseWindows[question.ToeicQuestionSection]
+	}
+	if question.PreparationSeconds != nil {
+		window.preparation = *question.PreparationSeconds
+	}
+	if question.ResponseSeconds != nil {
+		window.response = *question.ResponseSeconds
+	}
+	return window
+}
+
+// withResponses fills in the windows and responses of the Speaking and Writing questions of an attempt.
+func (s *AttemptService) withResponses(ctx context.Context, attemptId uuid.UUID, questions []*entity.AttemptQuestion) *errors.AppError {
+	responses, err := s.repo.GetResponses(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:withResponses:Failed to get responses", "attempt_id", attemptId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "AttemptService:withResponses:Failed to get responses", err)
+	}
+	responsesByQuestion := make(map[uuid.UUID]*entity.CapturedResponse, len(responses))
+	for _, captured := range responses {
+		responsesByQuestion[captured.QuestionID] = captured
+	}
+	for _, question := range questions {
+		if _, ok := responseTypes[question.ToeicQuestionSection]; !ok {
+			continue
+		}
+		window := questionResponseWindow(question)
+		if captured, ok := responsesByQuestion[question.QuestionID]; ok {
+			// A started question keeps the windows it was started with.
+			window = responseWindow{preparation: captured.PreparationSeconds, response: captured.ResponseSeconds}
+			question.Response = captured
+		}
+		question.PreparationSeconds, question.ResponseSeconds = &window.preparation, &window.response
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/mapper"
+	"time"
+)
+
+// FallbackExplanationLang is used for questions whose explanation was not translated to the language
+// asked for.
+const FallbackExplanationLang = "eng"
+
+// GetAttemptReview reveals the answer keys, explanations and audio scripts of a submitted attempt. It is
+// the only place learners see them.
+func (s *AttemptService) GetAttemptReview(ctx context.Context, token string, examId, attemptId uuid.UUID, lang string) (*dto.AttemptReviewResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if attempt.Status == entity.AttemptStatusInProgress {
+		return nil, errors.NewAppError(errors.ErrInvalidState, "AttemptService:GetAttemptReview:Attempt has not been submitted", nil)
+	}
+	questions, err := s.repo.GetReviewQuestions(ctx, attemptId, lang, FallbackExplanationLang)
+	if err != nil {
+		logger.Error("AttemptService:GetAttemptReview:Failed to get questions", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetAttemptReview:Failed to get questions", err)
+	}
+	paragraphs, err := s.repo.GetAttemptParagraphs(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:GetAttemptReview:Failed to get paragraphs", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetAttemptReview:Failed to get paragraphs", err)
+	}
+
+	response := &dto.AttemptReviewResponse{
+		AttemptID:   attempt.AttemptID,
+		ExamID:      attempt.ExamID,
+		Status:      attempt.Status,
+		SubmittedAt: attempt.SubmittedAt,
+		Lang:        lang,
+	}
+	locked, appErr := s.lockedParts(ctx, attempt)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if len(locked) > 0 {
+		visible := make([]*entity.ReviewQuestion, 0, len(questions))
+		for _, question := range questions {
+			if !locked[question.PartID] {
+				visible = append(visible, question)
+			}
+		}
+		questions = visible
+		_, paragraphs = withoutLockedParts(nil, paragraphs, locked)
+		response.LockedPartIDs = sortedPartIDs(locked)
+	}
+	response.Questions = mapper.ToReviewQuestionsResponse(questions)
+	response.Paragraphs = mapper.ToReviewParagraphsResponse(paragraphs)
+	return response, nil
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"maps"
+	"math"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/entity"
+	"pirate-lang-go/modules/attempt/mapper"
+	libraryentity "pirate-lang-go/modules/library/entity"
+	librarymapper "pirate-lang-go/modules/library/mapper"
+	"slices"
+	"strings"
+	"time"
+)
+
+// objectiveQuestionTypes lists the question types graded automatically against correct_answer.
+var objectiveQuestionTypes = map[string]bool{
+	"MultipleChoice":   true,
+	"PhotoDescription": true,
+	"QuestionResponse": true,
+	"TrueFalse":        true,
+	"Matching":         true,
+	"Ordering":         true,
+	"FillInTheBlank":   true,
+	"ShortAnswer":      true,
+}
+
+func (s *AttemptService) GetAttemptResult(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResultResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	attempt, appErr := s.loadAttempt(ctx, token, examId, attemptId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if attempt.Status == entity.AttemptStatusInProgress {
+		return nil, errors.NewAppError(errors.ErrInvalidState, "AttemptService:GetAttemptResult:Attempt has not been submitted", nil)
+	}
+	result, err := s.repo.GetResult(ctx, attemptId)
+	if err != nil {
+		logger.Error("AttemptService:GetAttemptResult:Failed to get result", "attempt_id", attemptId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:GetAttemptResult:Failed to get result", err)
+	}
+	if result == nil {
+		// Scoring right after submission failed or predates the scoring engine; grade now.
+		result, appErr = s.scoreAttempt(ctx, attempt)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+	return mapper.ToAttemptResultResponse(attempt, result), nil
+}
+
+// scoreAttempt grades the objective questions of a finalized attempt and stores the raw and scaled scores.
+func (s *AttemptService) scoreAttempt(ctx context.Context, attempt *entity.Attempt) (*entity.AttemptResult, *errors.AppError) {
+	questions, err := s.repo.GetScoringQuestions(ctx, attempt.AttemptID)
+	if err != nil {
+		logger.Error("AttemptService:scoreAttempt:Failed to get questions", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:scoreAttempt:Failed to get questions", err)
+	}
+	config, err := s.repo.GetScoringConfig(ctx, attempt.ExamID)
+	if err != nil {
+		logger.Error("AttemptService:scoreAttempt:Failed to get scoring config", "exam_id", attempt.ExamID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:scoreAttempt:Failed to get scoring config", err)
+	}
+	var conversion *entity.ScoreConversionTable
+	if config.ConversionTableID != uuid.Nil {
+		conversion, err = s.repo.GetConversionTable(ctx, config.ConversionTableID)
+		if err != nil {
+			logger.Error("AttemptService:scoreAttempt:Failed to get conversion table", "table_id", config.ConversionTableID, "error", err)
+			return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:scoreAttempt:Failed to get conversion table", err)
+		}
+	}
+
+	result := &entity.AttemptResult{AttemptID: attempt.AttemptID}
+	graded := make([]*entity.GradedQuestion, 0, len(questions))
+	for _, question := range questions {
+		if !objectiveQuestionTypes[question.QuestionType] || strings.TrimSpace(question.CorrectAnswer) == "" {
+			continue
+		}
+		isCorrect := isCorrectAnswer(question.QuestionType, question.CorrectAnswer, question.Answer)
+		graded = append(graded, &entity.GradedQuestion{QuestionID: question.QuestionID, IsCorrect: isCorrect})
+
+		switch question.ToeicQuestionSection {
+		case entity.SectionListening:
+			result.ListeningTotal++
+			if isCorrect {
+				result.ListeningCorrect++
+			}
+		case entity.SectionReading:
+			result.ReadingTotal++
+			if isCorrect {
+				result.ReadingCorrect++
+			}
+		}
+	}
+
+	if conversion != nil {
+		result.ConversionTableID = conversion.TableID
+		result.ListeningScaled = scaleSection(conversion.Entries, entity.SectionListening, result.ListeningCorrect, result.ListeningTotal, config.MaxListeningScore)
+		result.ReadingScaled = scaleSection(conversion.Entries, entity.SectionReading, result.ReadingCorrect, result.ReadingTotal, config.MaxReadingScore)
+		if result.ListeningScaled != nil || result.ReadingScaled != nil {
+			var total int32
+			if result.ListeningScaled != nil {
+				total += *result.ListeningScaled
+			}
+			if result.ReadingScaled != nil {
+				total += *result.ReadingScaled
+			}
+			result.TotalScaled = &total
+		}
+	}
+
+	saved, err := s.repo.SaveResult(ctx, result, graded)
+	if err != nil {
+		logger.Error("AttemptService:scoreAttempt:Failed to save result", "attempt_id", attempt.AttemptID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "AttemptService:scoreAttempt:Failed to save result", err)
+	}
+	// Re-scoring keeps the ratings already given; roll them up again so the result carries them.
+	rated, appErr := s.rollUpRatings(ctx, attempt.AttemptID, attempt.ExamID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if rated != nil {
+		return rated, nil
+	}
+	return saved, nil
+}
+
+// isCorrectAnswer grades an answer against the key of its question type. Texts are compared ignoring
+// case and extra spaces; Matching, Ordering and FillInTheBlank answers are only correct when complete.
+func isCorrectAnswer(questionType, correctAnswer, answer string) bool {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return false
+	}
+	key, err := librarymapper.UnmarshalAnswerKey(questionType, correctAnswer)
+	if err != nil {
+		return false
+	}
+	switch questionType {
+	case libraryentity.QuestionTypeTrueFalse:
+		return librarymapper.NormalizeTrueFalse(answer) == key.Choice
+	case libraryentity.QuestionTypeMatching:
+		pairs, ok := unmarshalMatchingAnswer(answer)
+		return ok && len(key.Pairs) > 0 && maps.Equal(pairs, key.Pairs)
+	case libraryentity.QuestionTypeOrdering:
+		order, ok := unmarshalOrderingAnswer(answer)
+		return ok && len(key.Order) > 0 && slices.Equal(order, key.Order)
+	case libraryentity.QuestionTypeFillInTheBlank:
+		blanks, ok := unmarshalBlanksAnswer(answer)
+		if !ok || len(blanks) != len(key.Blanks) {
+			return false
+		}
+		for i, blank := range blanks {
+			if !matchesVariant(blank, key.Blanks[i]) {
+				return false
+			}
+		}
+		return true
+	case libraryentity.QuestionTypeShortAnswer:
+		return matchesVariant(answer, key.Accepted)
+	}
+	return strings.EqualFold(answer, key.Choice)
+}
+
+// matchesVariant reports whether an answer is one of the accepted variants.
+func matchesVariant(answer string, variants []string) bool {
+	answer = normalizeText(answer)
+	for _, variant := range variants {
+		if answer != "" && answer == normalizeText(variant) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeText(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+func unmarshalMatchingAnswer(answer string) (map[string]string, bool) {
+	var pairs map[string]string
+	if err := json.Unmarshal([]byte(answer), &pairs); err != nil {
+		return nil, false
+	}
+	return pairs, true
+}
+
+func unmarshalOrderingAnswer(answer string) ([]string, bool) {
+	var order []string
+	if err := json.Unmarshal([]byte(answer), &order); err != nil {
+		return nil, false
+	}
+	return order, true
+}
+
+// unmarshalBlanksAnswer reads the text of every blank; plain text answers a single blank.
+func unmarshalBlanksAnswer(answer string) ([]string, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(answer), "[") {
+		return []string{answer}, true
+	}
+	var blanks []string
+	if err := json.Unmarshal([]byte(answer), &blanks); err != nil {
+		return nil, false
+	}
+	return blanks, true
+}
+
+// scaleSection converts a raw section score through the table. Tables describe a full 100-question
+// section, so raw scores of shorter tests are projected onto the table's range first.
+func scaleSection(entries []*entity.ScoreConversionEntry, section string, correct, total, maxScore int32) *int32 {
+	if total == 0 {
+		return nil
+	}
+	var sectionEntries []*entity.ScoreConversionEntry
+	var maxRaw int32
+	for _, entry := range entries {
+		if entry.Section != section {
+			continue
+		}
+		sectionEntries = append(sectionEntries, entry)
+		if entry.RawScore > maxRaw {
+			maxRaw = entry.RawScore
+		}
+	}
+	if len(sectionEntries) == 0 {
+		return nil
+	}
+
+	raw := correct
+	if maxRaw > 0 && total != maxRaw {
+		raw = int32(math.Round(float64(correct) * float64(maxRaw) / float64(total)))
+	}
+
+	// Use the highest row at or below the raw score, so sparse tables still convert.
+	var matched *entity.ScoreConversionEntry
+	for _, entry := range sectionEntries {
+		if entry.RawScore <= raw && (matched == nil || entry.RawScore > matched.RawScore) {
+			matched = entry
+		}
+	}
+	if matched == nil {
+		return nil
+	}
+	scaled := matched.ScaledScore
+	if maxScore > 0 && scaled > maxScore {
+		scaled = maxScore
+	}
+	return &scaled
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"mime/multipart"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	"pirate-lang-go/modules/attempt/dto"
+	"pirate-lang-go/modules/attempt/repository"
+	"pirate-lang-go/modules/attempt/scorer"
+	libraryrepo "pirate-lang-go/modules/library/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+)
+
+type AttemptService struct {
+	repo        repository.IAttemptRepository
+	libraryRepo libraryrepo.ILibraryRepository
+	accountRepo accountrepo.IAccountRepository
+	cache       cache.ICache
+	// subscriptionService decides who may read SUBSCRIPTION parts
+	subscriptionService subscriptionservice.ISubscriptionService
+	// storage keeps the recordings of spoken responses
+	storage storage.IStorage
+	// autoScorer pre-scores the Essay and OpenResponse answers
+	autoScorer scorer.AutoScorer
+}
+
+func NewAttemptService(repo repository.IAttemptRepository, libraryRepo libraryrepo.ILibraryRepository, accountRepo accountrepo.IAccountRepository, cache cache.ICache, subscriptionService subscriptionservice.ISubscriptionService, storage storage.IStorage, autoScorer scorer.AutoScorer) IAttemptService {
+
+	return &AttemptService{
+		repo:                repo,
+		libraryRepo:         libraryRepo,
+		accountRepo:         accountRepo,
+		cache:               cache,
+		subscriptionService: subscriptionService,
+		storage:             storage,
+		autoScorer:          autoScorer,
+	}
+}
+
+type IAttemptService interface {
+	StartAttempt(ctx context.Context, token string, examId uuid.UUID) (*dto.AttemptResponse, *errors.AppError)
+	GetAttempts(ctx context.Context, token string, examId uuid.UUID) ([]*dto.AttemptResponse, *errors.AppError)
+	GetAttempt(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResponse, *errors.AppError)
+	SaveAnswers(ctx context.Context, token string, examId, attemptId uuid.UUID, request *dto.SaveAnswersRequest) (*dto.AttemptResponse, *errors.AppError)
+	SubmitAttempt(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResponse, *errors.AppError)
+	GetAttemptResult(ctx context.Context, token string, examId, attemptId uuid.UUID) (*dto.AttemptResultResponse, *errors.AppError)
+	GetAttemptReview(ctx context.Context, token string, examId, attemptId uuid.UUID, lang string) (*dto.AttemptReviewResponse, *errors.AppError)
+	// Spoken and written responses
+	StartResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID) (*dto.CapturedResponseResponse, *errors.AppError)
+	SubmitSpokenResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID, file *multipart.FileHeader) (*dto.CapturedResponseResponse, *errors.AppError)
+	SubmitWrittenResponse(ctx context.Context, token string, examId, attemptId, questionId uuid.UUID, request *dto.SubmitWrittenResponseRequest) (*dto.CapturedResponseResponse, *errors.AppError)
+	// Ratings
+	GetRatingQueue(ctx context.Context) (*dto.RatingQueueResponse, *errors.AppError)
+	AssignNextRating(ctx context.Context, token string) (*dto.RatingTaskResponse, *errors.AppError)
+	SubmitRating(ctx context.Context, token string, ratingId uuid.UUID, request *dto.SubmitRatingRequest) (*dto.ResponseRatingResponse, *errors.AppError)
+	// Automated scores
+	AutoScoreResponses(ctx context.Context, batchSize int32) (*AutoScoreSummary, *errors.AppError)
+	// Conversion tables
+	CreateConversionTable(ctx context.Context, request *dto.CreateConversionTableRequest) (*dto.ConversionTableResponse, *errors.AppError)
+	GetConversionTables(ctx context.Context) ([]*dto.ConversionTableResponse, *errors.AppError)
+	GetConversionTable(ctx context.Context, tableId uuid.UUID) (*dto.ConversionTableResponse, *errors.AppError)
+	UpdateConversionEntries(ctx context.Context, tableId uuid.UUID, request *dto.UpdateConversionEntriesRequest) *errors.AppError
+	SetDefaultConversionTable(ctx context.Context, tableId uuid.UUID) *errors.AppError
+	SetExamConversionTable(ctx context.Context, examId uuid.UUID, request *dto.SetExamConversionTableRequest) *errors.AppError
+}
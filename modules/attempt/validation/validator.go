@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/core/validation"
+	"pirate-lang-go/modules/attempt/dto"
+	"sort"
+	"unicode/utf8"
+)
+
+const MaxAnswersPerRequest = 200
+
+func ValidateSaveAnswers(dataRequest *dto.SaveAnswersRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || len(dataRequest.Answers) == 0 {
+		result.AddError("answers", "At least one answer is required")
+		return result
+	}
+	if len(dataRequest.Answers) > MaxAnswersPerRequest {
+		result.AddError("answers", fmt.Sprintf("At most %d answers can be saved at once", MaxAnswersPerRequest))
+	}
+	seen := make(map[uuid.UUID]bool, len(dataRequest.Answers))
+	for i, answer := range dataRequest.Answers {
+		field := fmt.Sprintf("answers[%d].question_id", i)
+		if answer.QuestionID == uuid.Nil {
+			result.AddError(field, "Question ID is required")
+			continue
+		}
+		if seen[answer.QuestionID] {
+			result.AddError(field, "Question is answered more than once")
+		}
+		seen[answer.QuestionID] = true
+	}
+	return result
+}
+
+var ValidConversionSections = map[string]bool{
+	"Listening": true,
+	"Reading":   true,
+}
+
+func ValidateCreateConversionTable(dataRequest *dto.CreateConversionTableRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || utils.IsEmpty(dataRequest.Name) {
+		result.AddError("name", "Name is required")
+	} else if len(dataRequest.Name) > 100 {
+		result.AddError("name", "Name must be at most 100 characters")
+	}
+	return result
+}
+
+// ValidateUpdateConversionEntries checks that scaled scores stay in the TOEIC 5-495 range, in steps of 5,
+// and never decrease as the raw score grows.
+func ValidateUpdateConversionEntries(dataRequest *dto.UpdateConversionEntriesRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || len(dataRequest.Entries) == 0 {
+		result.AddError("entries", "At least one entry is required")
+		return result
+	}
+	type key struct {
+		section string
+		raw     int32
+	}
+	scaledByKey := make(map[key]int32, len(dataRequest.Entries))
+	for i, entry := range dataRequest.Entries {
+		field := fmt.Sprintf("entries[%d]", i)
+		if !ValidConversionSections[entry.Section] {
+			result.AddError(field+".section", "Section must be 'Listening' or 'Reading'")
+			continue
+		}
+		if entry.RawScore < 0 {
+			result.AddError(field+".raw_score", "Raw score must not be negative")
+		}
+		if entry.ScaledScore < 5 || entry.ScaledScore > 495 || entry.ScaledScore%5 != 0 {
+			result.AddError(field+".scaled_score", "Scaled score must be a multiple of 5 between 5 and 495")
+		}
+		k := key{entry.Section, entry.RawScore}
+		if _, exists := scaledByKey[k]; exists {
+			result.AddError(field+".raw_score", "Raw score is listed more than once for this section")
+		}
+		scaledByKey[k] = entry.ScaledScore
+	}
+	if !result.Valid {
+		return result
+	}
+	for section := range ValidConversionSections {
+		var raws []int32
+		for k := range scaledByKey {
+			if k.section == section {
+				raws = append(raws, k.raw)
+			}
+		}
+		sort.Slice(raws, func(i, j int) bool { return raws[i] < raws[j] })
+		for i := 1; i < len(raws); i++ {
+			if scaledByKey[key{section, raws[i]}] < scaledByKey[key{section, raws[i-1]}] {
+				result.AddError("entries", fmt.Sprintf("%s scaled scores must not decrease as the raw score grows (raw score %d)", section, raws[i]))
+				break
+			}
+		}
+	}
+	return result
+}
+
+// MaxWrittenResponseLength bounds an essay, in characters.
+const MaxWrittenResponseLength = 20000
+
+func ValidateSubmitWrittenResponse(dataRequest *dto.SubmitWrittenResponseRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || utils.IsEmpty(dataRequest.Text) {
+		result.AddError("text", "Text is required")
+	} else if utf8.RuneCountInString(dataRequest.Text) > MaxWrittenResponseLength {
+		result.AddError("text", fmt.Sprintf("Text must be at most %d characters", MaxWrittenResponseLength))
+	}
+	return result
+}
+
+const MaxRatingCommentLength = 2000
+
+// ValidateSubmitRating checks the form of a rating; the service checks the score against the rubric.
+func ValidateSubmitRating(dataRequest *dto.SubmitRatingRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || dataRequest.Score == nil {
+		result.AddError("score", "Score is required")
+		return result
+	}
+	if *dataRequest.Score < 0 {
+		result.AddError("score", "Score must not be negative")
+	}
+	if utf8.RuneCountInString(dataRequest.Comment) > MaxRatingCommentLength {
+		result.AddError("comment", fmt.Sprintf("Comment must be at most %d characters", MaxRatingCommentLength))
+	}
+	return result
+}
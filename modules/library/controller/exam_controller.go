@@ -4,6 +4,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 
+	"pirate-lang-go/core/errors"
 	"pirate-lang-go/core/utils"
 	"pirate-lang-go/modules/library/dto"
 	validator "pirate-lang-go/modules/library/validation"
@@ -75,10 +76,31 @@ func (controller *LibraryController) GetExams(c echo.Context) error {
 	ctx := c.Request().Context()
 	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
 	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+	status := c.QueryParam("status")
+	if status != "" && !validator.ValidContentStatuses[status] {
+		return controller.BadRequest("Invalid status", status)
+	}
 
-	response, appErr := controller.libraryService.GetExams(ctx, pageNumber, pageSize)
+	response, appErr := controller.libraryService.GetExams(ctx, status, pageNumber, pageSize)
 	if appErr != nil {
 		return controller.BadRequest("Error getting exams", appErr.Error())
 	}
 	return controller.SuccessResponse(c, response, "Get Exams successfully")
 }
+
+func (controller *LibraryController) GetExamTree(c echo.Context) error {
+	ctx := c.Request().Context()
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetExamTree(ctx, examId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error getting exam tree", appErr.Error())
+		}
+		return controller.InternalServerError("Error getting exam tree", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Exam Tree successfully")
+}
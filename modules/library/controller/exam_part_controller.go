@@ -74,8 +74,12 @@ func (controller *LibraryController) GetPracticeParts(c echo.Context) error {
 	ctx := c.Request().Context()
 	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
 	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+	status := c.QueryParam("status")
+	if status != "" && !validator.ValidContentStatuses[status] {
+		return controller.BadRequest("Invalid status", status)
+	}
 
-	response, appErr := controller.libraryService.GetPracticeExamParts(ctx, pageNumber, pageSize)
+	response, appErr := controller.libraryService.GetPracticeExamParts(ctx, status, pageNumber, pageSize)
 	if appErr != nil {
 		return controller.BadRequest("Error getting exams", appErr.Error())
 	}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	validator "pirate-lang-go/modules/library/validation"
+)
+
+func (controller *LibraryController) GetQuestionExplanations(c echo.Context) error {
+	ctx := c.Request().Context()
+	questionId, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid question ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetQuestionExplanations(ctx, questionId)
+	if appErr != nil {
+		return controller.explanationError("Error getting explanations", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Get Explanations successfully")
+}
+
+func (controller *LibraryController) SaveQuestionExplanation(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	questionId, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid question ID format", err.Error())
+	}
+	lang := c.Param("lang")
+	if validator.ValidateLang(lang) {
+		return controller.BadRequest("Invalid lang type")
+	}
+	requestData := new(dto.QuestionExplanationRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateQuestionExplanation(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Invalid request data", resultValidator.Errors)
+	}
+
+	response, appErr := controller.libraryService.SaveQuestionExplanation(ctx, token, questionId, lang, requestData)
+	if appErr != nil {
+		return controller.explanationError("Error saving explanation", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Save Explanation successfully")
+}
+
+func (controller *LibraryController) DeleteQuestionExplanation(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	questionId, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid question ID format", err.Error())
+	}
+	lang := c.Param("lang")
+	if validator.ValidateLang(lang) {
+		return controller.BadRequest("Invalid lang type")
+	}
+
+	if appErr := controller.libraryService.DeleteQuestionExplanation(ctx, token, questionId, lang); appErr != nil {
+		return controller.explanationError("Error deleting explanation", appErr)
+	}
+	return controller.SuccessResponse(c, nil, "Delete Explanation successfully")
+}
+
+func (controller *LibraryController) explanationError(message string, appErr *errors.AppError) error {
+	switch appErr.Code {
+	case errors.ErrNotFound:
+		return controller.NotFound(message, appErr.Error())
+	case errors.ErrUnauthorized:
+		return controller.Unauthorized(message, appErr.Error())
+	case errors.ErrInternal:
+		return controller.InternalServerError(message, appErr.Error())
+	}
+	return controller.BadRequest(message, appErr.Error())
+}
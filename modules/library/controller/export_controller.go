@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"io"
+	"net/http"
+	"pirate-lang-go/core/errors"
+)
+
+type exportFunc func(ctx context.Context, id uuid.UUID, w io.Writer) *errors.AppError
+
+func (controller *LibraryController) ExportExamPackage(c echo.Context) error {
+	return controller.export(c, "examId", "exam-%s.zip", controller.libraryService.ExportExamPackage)
+}
+
+func (controller *LibraryController) ExportExamQTI(c echo.Context) error {
+	return controller.export(c, "examId", "exam-%s-qti.zip", controller.libraryService.ExportExamQTI)
+}
+
+func (controller *LibraryController) ExportPracticePartPackage(c echo.Context) error {
+	return controller.export(c, "partId", "practice-part-%s.zip", controller.libraryService.ExportPracticePartPackage)
+}
+
+func (controller *LibraryController) ExportPracticePartQTI(c echo.Context) error {
+	return controller.export(c, "partId", "practice-part-%s-qti.zip", controller.libraryService.ExportPracticePartQTI)
+}
+
+// export streams a package as an attachment. Headers go out with the first byte, so a failure before
+// the service starts writing still gets a regular error response.
+func (controller *LibraryController) export(c echo.Context, param, filename string, export exportFunc) error {
+	ctx := c.Request().Context()
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return controller.BadRequest("Invalid ID format", err.Error())
+	}
+
+	appErr := export(ctx, id, &attachmentWriter{c: c, filename: fmt.Sprintf(filename, id)})
+	if appErr != nil {
+		switch appErr.Code {
+		case errors.ErrNotFound:
+			return controller.NotFound("Error exporting package", appErr.Error())
+		case errors.ErrInternal:
+			return controller.InternalServerError("Error exporting package", appErr.Error())
+		}
+		return controller.BadRequest("Error exporting package", appErr.Error())
+	}
+	return nil
+}
+
+type attachmentWriter struct {
+	c        echo.Context
+	filename string
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+	response := w.c.Response()
+	if !response.Committed {
+		response.Header().Set(echo.HeaderContentType, "application/zip")
+		response.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", w.filename))
+		response.WriteHeader(http.StatusOK)
+	}
+	return response.Write(p)
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"strconv"
+)
+
+// ImportExamPackage imports an exam from a zip package sent as the "package" form file. When the
+// package has problems nothing is imported and the report listing them is sent with the 400 response.
+func (controller *LibraryController) ImportExamPackage(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	file, errFile := c.FormFile("package")
+	if errFile != nil {
+		return controller.BadRequest("Error getting package file", errFile.Error())
+	}
+
+	report, appErr := controller.libraryService.ImportExamPackage(ctx, token, file)
+	if appErr != nil {
+		switch appErr.Code {
+		case errors.ErrUnauthorized:
+			return controller.Unauthorized("Error importing exam", appErr.Error())
+		case errors.ErrInternal:
+			return controller.InternalServerError("Error importing exam", appErr.Error())
+		}
+		return controller.BadRequest("Error importing exam", appErr.Error())
+	}
+	if !report.Imported {
+		return controller.BadRequest("Exam package has errors", report)
+	}
+	return controller.SuccessResponse(c, report, "Import Exam successfully")
+}
+
+// ImportQuestionSheet adds the questions of a CSV or XLSX sheet, sent as the "sheet" form file, to a
+// part. It is a dry run unless dryRun=false is given; rows with errors are listed in a 400 response.
+func (controller *LibraryController) ImportQuestionSheet(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	partId, err := uuid.Parse(c.Param("partId"))
+	if err != nil {
+		return controller.BadRequest("Invalid part ID format", err.Error())
+	}
+	file, errFile := c.FormFile("sheet")
+	if errFile != nil {
+		return controller.BadRequest("Error getting sheet file", errFile.Error())
+	}
+	dryRun := true
+	if value := c.QueryParam("dryRun"); value != "" {
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			return controller.BadRequest("Invalid dryRun value", err.Error())
+		}
+	}
+
+	report, appErr := controller.libraryService.ImportQuestionSheet(ctx, token, partId, file, dryRun)
+	if appErr != nil {
+		switch appErr.Code {
+		case errors.ErrUnauthorized:
+			return controller.Unauthorized("Error importing questions", appErr.Error())
+		case errors.ErrNotFound:
+			return controller.NotFound("Error importing questions", appErr.Error())
+		case errors.ErrInternal:
+			return controller.InternalServerError("Error importing questions", appErr.Error())
+		}
+		return controller.BadRequest("Error importing questions", appErr.Error())
+	}
+	if len(report.Errors) > 0 {
+		return controller.BadRequest("Question sheet has errors", report)
+	}
+	if dryRun {
+		return controller.SuccessResponse(c, report, "Validate Questions successfully")
+	}
+	return controller.SuccessResponse(c, report, "Import Questions successfully")
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+)
+
+// learnerToken returns the caller's token on routes behind AuthMiddleware and an empty token on
+// public routes, where any Authorization header is ignored.
+func learnerToken(c echo.Context) string {
+	if c.Get("user") == nil {
+		return ""
+	}
+	token, _ := utils.GetTokenFromHeader(c)
+	return token
+}
+
+func (controller *LibraryController) GetLearnerExams(c echo.Context) error {
+	ctx := c.Request().Context()
+	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
+	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+
+	response, appErr := controller.libraryService.GetLearnerExams(ctx, pageNumber, pageSize)
+	if appErr != nil {
+		return controller.BadRequest("Error getting exams", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Exams successfully")
+}
+
+func (controller *LibraryController) GetLearnerExam(c echo.Context) error {
+	ctx := c.Request().Context()
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetLearnerExam(ctx, learnerToken(c), examId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error getting exam", appErr.Error())
+		}
+		return controller.BadRequest("Error getting exam", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Exam successfully")
+}
+
+func (controller *LibraryController) GetLearnerPracticeParts(c echo.Context) error {
+	ctx := c.Request().Context()
+	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
+	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+
+	response, appErr := controller.libraryService.GetLearnerPracticeParts(ctx, pageNumber, pageSize)
+	if appErr != nil {
+		return controller.BadRequest("Error getting practice parts", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Practice Parts successfully")
+}
+
+func (controller *LibraryController) GetLearnerPracticePart(c echo.Context) error {
+	ctx := c.Request().Context()
+	partId, err := uuid.Parse(c.Param("partId"))
+	if err != nil {
+		return controller.BadRequest("Invalid part ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetLearnerPracticePart(ctx, learnerToken(c), partId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error getting practice part", appErr.Error())
+		}
+		return controller.BadRequest("Error getting practice part", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Practice Part successfully")
+}
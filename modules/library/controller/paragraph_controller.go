@@ -11,6 +11,10 @@ import (
 
 func (controller *LibraryController) CreateParagraph(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	// Parse request body
 	requestData := new(dto.CreateParagraphRequest)
 	if err := c.Bind(requestData); err != nil {
@@ -22,7 +26,7 @@ func (controller *LibraryController) CreateParagraph(c echo.Context) error {
 		return controller.BadRequest("Validation failed", resultValidator.Errors)
 	}
 
-	appErr := controller.libraryService.CreateParagraph(ctx, requestData)
+	appErr := controller.libraryService.CreateParagraph(ctx, token, requestData)
 	if appErr != nil {
 		return controller.BadRequest("Error create exams", appErr.Error())
 	}
@@ -31,6 +35,10 @@ func (controller *LibraryController) CreateParagraph(c echo.Context) error {
 
 func (controller *LibraryController) UpdateParagraph(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	// Parse exam ID from path
 	examIdStr := c.Param("paragraphId")
 	examId, err := uuid.Parse(examIdStr)
@@ -49,7 +57,7 @@ func (controller *LibraryController) UpdateParagraph(c echo.Context) error {
 		return controller.BadRequest("Validation failed", resultValidator.Errors)
 	}
 
-	appErr := controller.libraryService.UpdateParagraph(ctx, requestData, examId)
+	appErr := controller.libraryService.UpdateParagraph(ctx, token, requestData, examId)
 	if appErr != nil {
 		return controller.BadRequest("Error update paragraphs", appErr.Error())
 	}
@@ -87,6 +95,10 @@ func (controller *LibraryController) GetParagraphsByPart(c echo.Context) error {
 }
 func (controller *LibraryController) UploadAudioParagraph(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	// Parse request body
 	groupIdStr := c.Param("paragraphId")
 	groupId, err := uuid.Parse(groupIdStr)
@@ -102,7 +114,7 @@ func (controller *LibraryController) UploadAudioParagraph(c echo.Context) error
 		return controller.BadRequest("Invalid file type. Only MP3 files are allowed.")
 	}
 
-	resultUpdateAudio, errUpload := controller.libraryService.UploadAudioParagraph(ctx, file, groupId)
+	resultUpdateAudio, errUpload := controller.libraryService.UploadAudioParagraph(ctx, token, file, groupId)
 	if errUpload != nil {
 		return controller.BadRequest(fmt.Sprintf("Error updating audio: %v", err))
 	}
@@ -139,6 +151,10 @@ func (controller *LibraryController) UploadTranscriptAudioParagraph(c echo.Conte
 }
 func (controller *LibraryController) UploadImageParagraph(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	idStr := c.Param("paragraphId")
 	groupId, errParse := uuid.Parse(idStr)
 	if errParse != nil {
@@ -152,7 +168,7 @@ func (controller *LibraryController) UploadImageParagraph(c echo.Context) error
 	if !utils.IsImageContentType(contentType) {
 		return controller.BadRequest("Invalid file type. Only image files (JPEG, PNG) are allowed.")
 	}
-	resultUpdateAvatar, err := controller.libraryService.UploadImageParagraph(ctx, file, groupId)
+	resultUpdateAvatar, err := controller.libraryService.UploadImageParagraph(ctx, token, file, groupId)
 	if err != nil {
 		return controller.BadRequest(fmt.Sprintf("Error updating image: %v", err))
 	}
@@ -11,6 +11,10 @@ import (
 
 func (controller *LibraryController) UploadAudioGroup(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	// Parse request body
 	idStr := c.Param("questionId")
 	questionId, errParse := uuid.Parse(idStr)
@@ -26,7 +30,7 @@ func (controller *LibraryController) UploadAudioGroup(c echo.Context) error {
 		return controller.BadRequest("Invalid file type. Only MP3 files are allowed.")
 	}
 
-	resultUpdateAudio, errUpload := controller.libraryService.UploadAudioQuestion(ctx, file, questionId)
+	resultUpdateAudio, errUpload := controller.libraryService.UploadAudioQuestion(ctx, token, file, questionId)
 	if errUpload != nil {
 		return controller.BadRequest(fmt.Sprintf("Error updating audio: %v", errUpload))
 	}
@@ -63,6 +67,10 @@ func (controller *LibraryController) UploadTranscriptAudioGroup(c echo.Context)
 }
 func (controller *LibraryController) UploadImageGroup(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	idStr := c.Param("questionId")
 	questionId, errParse := uuid.Parse(idStr)
 	if errParse != nil {
@@ -76,7 +84,7 @@ func (controller *LibraryController) UploadImageGroup(c echo.Context) error {
 	if !utils.IsImageContentType(contentType) {
 		return controller.BadRequest("Invalid file type. Only image files (JPEG, PNG) are allowed.")
 	}
-	resultUpdateAvatar, err := controller.libraryService.UploadImageQuestion(ctx, file, questionId)
+	resultUpdateAvatar, err := controller.libraryService.UploadImageQuestion(ctx, token, file, questionId)
 	if err != nil {
 		return controller.BadRequest(fmt.Sprintf("Error updating image: %v", err))
 	}
@@ -113,6 +121,10 @@ func (controller *LibraryController) GetQuestionsPart(c echo.Context) error {
 }
 func (controller *LibraryController) CreateQuestion(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	requestData := new(dto.CreateQuestionRequest)
 	if err := c.Bind(requestData); err != nil {
 		return controller.BadRequest("Invalid request data", err)
@@ -121,7 +133,7 @@ func (controller *LibraryController) CreateQuestion(c echo.Context) error {
 	if !resultValidator.Valid {
 		return controller.BadRequest("Invalid request data", resultValidator.Errors)
 	}
-	question, err := controller.libraryService.CreateQuestion(ctx, requestData)
+	question, err := controller.libraryService.CreateQuestion(ctx, token, requestData)
 	if err != nil {
 		return controller.BadRequest("Error create question", err)
 	}
@@ -129,6 +141,10 @@ func (controller *LibraryController) CreateQuestion(c echo.Context) error {
 }
 func (controller *LibraryController) UpdateQuestion(c echo.Context) error {
 	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
 	idStr := c.Param("questionId")
 	questionId, errParse := uuid.Parse(idStr)
 	if errParse != nil {
@@ -142,7 +158,7 @@ func (controller *LibraryController) UpdateQuestion(c echo.Context) error {
 	if !resultValidator.Valid {
 		return controller.BadRequest("Invalid request data", resultValidator.Errors)
 	}
-	err := controller.libraryService.UpdateQuestion(ctx, requestData, questionId)
+	err := controller.libraryService.UpdateQuestion(ctx, token, requestData, questionId)
 	if err != nil {
 		return controller.BadRequest("Error create question", err)
 	}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+)
+
+func (controller *LibraryController) GetQuestionRevisions(c echo.Context) error {
+	ctx := c.Request().Context()
+	questionId, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid question ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetQuestionRevisions(ctx, questionId)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting revisions", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Revisions successfully")
+}
+
+func (controller *LibraryController) RestoreQuestionRevision(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	questionId, err := uuid.Parse(c.Param("questionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid question ID format", err.Error())
+	}
+	revisionId, err := uuid.Parse(c.Param("revisionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid revision ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.RestoreQuestionRevision(ctx, token, questionId, revisionId)
+	if appErr != nil {
+		return controller.restoreError(appErr)
+	}
+	return controller.SuccessResponse(c, response, "Restore Revision successfully")
+}
+
+func (controller *LibraryController) GetParagraphRevisions(c echo.Context) error {
+	ctx := c.Request().Context()
+	paragraphId, err := uuid.Parse(c.Param("paragraphId"))
+	if err != nil {
+		return controller.BadRequest("Invalid paragraph ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetParagraphRevisions(ctx, paragraphId)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting revisions", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Revisions successfully")
+}
+
+func (controller *LibraryController) RestoreParagraphRevision(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	paragraphId, err := uuid.Parse(c.Param("paragraphId"))
+	if err != nil {
+		return controller.BadRequest("Invalid paragraph ID format", err.Error())
+	}
+	revisionId, err := uuid.Parse(c.Param("revisionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid revision ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.RestoreParagraphRevision(ctx, token, paragraphId, revisionId)
+	if appErr != nil {
+		return controller.restoreError(appErr)
+	}
+	return controller.SuccessResponse(c, response, "Restore Revision successfully")
+}
+
+func (controller *LibraryController) restoreError(appErr *errors.AppError) error {
+	switch appErr.Code {
+	case errors.ErrNotFound:
+		return controller.NotFound("Error restoring revision", appErr.Error())
+	case errors.ErrUnauthorized:
+		return controller.Unauthorized("Error restoring revision", appErr.Error())
+	case errors.ErrInternal:
+		return controller.InternalServerError("Error restoring revision", appErr.Error())
+	}
+	return controller.BadRequest("Error restoring revision", appErr.Error())
+}
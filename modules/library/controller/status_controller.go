@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+)
+
+// TransitionExam returns the handler applying one workflow action to an exam, so that every action
+// can be registered with its own permission.
+func (controller *LibraryController) TransitionExam(action string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		token, errToken := utils.GetTokenFromHeader(c)
+		if errToken != nil {
+			return controller.Unauthorized("Unauthorized", errToken)
+		}
+		examId, err := uuid.Parse(c.Param("examId"))
+		if err != nil {
+			return controller.BadRequest("Invalid exam ID format", err.Error())
+		}
+		requestData := new(dto.ChangeStatusRequest)
+		if err := c.Bind(requestData); err != nil {
+			return controller.BadRequest("Invalid request data", err.Error())
+		}
+
+		response, appErr := controller.libraryService.TransitionExam(ctx, token, examId, action, requestData)
+		if appErr != nil {
+			return controller.transitionError(appErr)
+		}
+		return controller.SuccessResponse(c, response, "Change Exam status successfully")
+	}
+}
+
+// TransitionPracticePart returns the handler applying one workflow action to a practice part.
+func (controller *LibraryController) TransitionPracticePart(action string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := c.Request().Context()
+		token, errToken := utils.GetTokenFromHeader(c)
+		if errToken != nil {
+			return controller.Unauthorized("Unauthorized", errToken)
+		}
+		partId, err := uuid.Parse(c.Param("partId"))
+		if err != nil {
+			return controller.BadRequest("Invalid part ID format", err.Error())
+		}
+		requestData := new(dto.ChangeStatusRequest)
+		if err := c.Bind(requestData); err != nil {
+			return controller.BadRequest("Invalid request data", err.Error())
+		}
+
+		response, appErr := controller.libraryService.TransitionPracticePart(ctx, token, partId, action, requestData)
+		if appErr != nil {
+			return controller.transitionError(appErr)
+		}
+		return controller.SuccessResponse(c, response, "Change Practice Part status successfully")
+	}
+}
+
+func (controller *LibraryController) transitionError(appErr *errors.AppError) error {
+	switch appErr.Code {
+	case errors.ErrNotFound:
+		return controller.NotFound("Error changing status", appErr.Error())
+	case errors.ErrUnauthorized:
+		return controller.Unauthorized("Error changing status", appErr.Error())
+	case errors.ErrInternal:
+		return controller.InternalServerError("Error changing status", appErr.Error())
+	}
+	return controller.BadRequest("Error changing status", appErr.Error())
+}
+
+func (controller *LibraryController) CheckExamPublishable(c echo.Context) error {
+	ctx := c.Request().Context()
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.CheckExamPublishable(ctx, examId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error checking exam", appErr.Error())
+		}
+		return controller.InternalServerError("Error checking exam", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Check Exam successfully")
+}
+
+func (controller *LibraryController) CheckPracticePartPublishable(c echo.Context) error {
+	ctx := c.Request().Context()
+	partId, err := uuid.Parse(c.Param("partId"))
+	if err != nil {
+		return controller.BadRequest("Invalid part ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.CheckPracticePartPublishable(ctx, partId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error checking practice part", appErr.Error())
+		}
+		return controller.InternalServerError("Error checking practice part", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Check Practice Part successfully")
+}
+
+func (controller *LibraryController) GetExamStatusHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+	examId, err := uuid.Parse(c.Param("examId"))
+	if err != nil {
+		return controller.BadRequest("Invalid exam ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetExamStatusHistory(ctx, examId)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting status history", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Status History successfully")
+}
+
+func (controller *LibraryController) GetPracticePartStatusHistory(c echo.Context) error {
+	ctx := c.Request().Context()
+	partId, err := uuid.Parse(c.Param("partId"))
+	if err != nil {
+		return controller.BadRequest("Invalid part ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.GetPracticePartStatusHistory(ctx, partId)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting status history", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Status History successfully")
+}
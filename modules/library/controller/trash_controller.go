@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	validator "pirate-lang-go/modules/library/validation"
+	"strings"
+)
+
+type deleteFunc func(ctx context.Context, token string, id uuid.UUID) *errors.AppError
+
+func (controller *LibraryController) DeleteExam(c echo.Context) error {
+	return controller.delete(c, "examId", "Delete Exam successfully", controller.libraryService.DeleteExam)
+}
+
+func (controller *LibraryController) DeleteExamPart(c echo.Context) error {
+	return controller.delete(c, "partId", "Delete Part successfully", controller.libraryService.DeleteExamPart)
+}
+
+func (controller *LibraryController) DeleteParagraph(c echo.Context) error {
+	return controller.delete(c, "paragraphId", "Delete Paragraph successfully", controller.libraryService.DeleteParagraph)
+}
+
+func (controller *LibraryController) DeleteQuestion(c echo.Context) error {
+	return controller.delete(c, "questionId", "Delete Question successfully", controller.libraryService.DeleteQuestion)
+}
+
+// delete moves the content named by the route parameter to the trash.
+func (controller *LibraryController) delete(c echo.Context, param, message string, delete deleteFunc) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return controller.BadRequest("Invalid ID format", err.Error())
+	}
+
+	if appErr := delete(ctx, token, id); appErr != nil {
+		return controller.trashError("Error deleting content", appErr)
+	}
+	return controller.SuccessResponse(c, nil, message)
+}
+
+func (controller *LibraryController) GetTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
+	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+	contentType := strings.ToUpper(c.QueryParam("type"))
+	if contentType != "" && !validator.ValidTrashContentTypes[contentType] {
+		return controller.BadRequest("Invalid content type", contentType)
+	}
+
+	response, appErr := controller.libraryService.GetTrash(ctx, contentType, pageNumber, pageSize)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting trash", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get Trash successfully")
+}
+
+func (controller *LibraryController) RestoreFromTrash(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	contentType := strings.ToUpper(c.Param("contentType"))
+	if !validator.ValidTrashContentTypes[contentType] {
+		return controller.BadRequest("Invalid content type", contentType)
+	}
+	contentId, err := uuid.Parse(c.Param("contentId"))
+	if err != nil {
+		return controller.BadRequest("Invalid content ID format", err.Error())
+	}
+
+	response, appErr := controller.libraryService.RestoreFromTrash(ctx, token, contentType, contentId)
+	if appErr != nil {
+		return controller.trashError("Error restoring content", appErr)
+	}
+	return controller.SuccessResponse(c, response, "Restore Content successfully")
+}
+
+func (controller *LibraryController) trashError(message string, appErr *errors.AppError) error {
+	switch appErr.Code {
+	case errors.ErrNotFound:
+		return controller.NotFound(message, appErr.Error())
+	case errors.ErrUnauthorized:
+		return controller.Unauthorized(message, appErr.Error())
+	case errors.ErrInternal:
+		return controller.InternalServerError(message, appErr.Error())
+	}
+	return controller.BadRequest(message, appErr.Error())
+}
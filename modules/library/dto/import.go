@@ -0,0 +1,77 @@
+package dto
+
+import "github.com/google/uuid"
+
+// ExamManifest is manifest.json (or manifest.yaml) at the root of an exam package. Audio and Image are
+// paths of media files inside the package.
+type ExamManifest struct {
+	ExamTitle         string          `json:"exam_title"`
+	Description       string          `json:"description"`
+	DurationMinutes   int32           `json:"duration_minutes"`
+	ExamType          string          `json:"exam_type"`
+	MaxListeningScore int32           `json:"max_listening_score"`
+	MaxReadingScore   int32           `json:"max_reading_score"`
+	MaxSpeakingScore  int32           `json:"max_speaking_score"`
+	MaxWritingScore   int32           `json:"max_writing_score"`
+	Parts             []*PartManifest `json:"parts"`
+}
+type PartManifest struct {
+	PartTitle       string               `json:"part_title"`
+	PartOrder       int32                `json:"part_order"`
+	Description     string               `json:"description"`
+	PlanType        string               `json:"plan_type"`
+	ToeicPartNumber int32                `json:"toeic_part_number"`
+	Paragraphs      []*ParagraphManifest `json:"paragraphs"`
+	Questions       []*QuestionManifest  `json:"questions"` // questions outside any paragraph
+}
+type ParagraphManifest struct {
+	ParagraphContent string              `json:"paragraph_content"`
+	Title            string              `json:"title"`
+	ParagraphOrder   int32               `json:"paragraph_order"`
+	ParagraphType    string              `json:"paragraph_type"`
+	Audio            string              `json:"audio"`
+	Image            string              `json:"image"`
+	Questions        []*QuestionManifest `json:"questions"`
+}
+type QuestionManifest struct {
+	QuestionContent      string        `json:"question_content"`
+	QuestionType         string        `json:"question_type"`
+	QuestionOrder        int32         `json:"question_order"`
+	ToeicQuestionSection string        `json:"toeic_question_section"`
+	QuestionNumberInPart int32         `json:"question_number_in_part"`
+	AnswerOption         *AnswerOption `json:"answer_option"`
+	CorrectAnswer        string        `json:"correct_answer"`
+	PreparationSeconds   *int32        `json:"preparation_seconds,omitempty"`
+	ResponseSeconds      *int32        `json:"response_seconds,omitempty"`
+	Audio                string        `json:"audio"`
+	Image                string        `json:"image"`
+}
+
+// ImportItemError is a problem with one item of a package; Item is a path such as "parts[2].questions[4]".
+type ImportItemError struct {
+	Item    string `json:"item"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReport is the outcome of an import. Nothing is imported unless Errors is empty.
+type ImportReport struct {
+	Imported   bool              `json:"imported"`
+	ExamID     uuid.UUID         `json:"exam_id"`
+	Parts      int               `json:"parts"`
+	Paragraphs int               `json:"paragraphs"`
+	Questions  int               `json:"questions"`
+	MediaFiles int               `json:"media_files"`
+	Errors     []ImportItemError `json:"errors"`
+}
+
+// QuestionSheetReport is the outcome of a question sheet import; Item in Errors is a sheet row such as
+// "row 4", or "sheet" for the file as a whole. Nothing is imported on a dry run or when Errors is not empty.
+type QuestionSheetReport struct {
+	PartID    uuid.UUID         `json:"part_id"`
+	DryRun    bool              `json:"dry_run"`
+	Imported  bool              `json:"imported"`
+	Rows      int               `json:"rows"`
+	Questions int               `json:"questions"`
+	Errors    []ImportItemError `json:"errors"`
+}
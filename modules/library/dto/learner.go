@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/core/entity"
+)
+
+// Learner read model. These types are only ever filled by the learner mappers and have no field
+// for correct answers or audio transcripts, so the admin DTOs above can change without leaking them.
+
+type LearnerExamResponse struct {
+	ExamID          uuid.UUID `json:"exam_id"`
+	ExamTitle       string    `json:"exam_title"`
+	Description     string    `json:"description"`
+	DurationMinutes int32     `json:"duration_minutes"`
+	ExamType        string    `json:"exam_type"`
+	TotalScore      int32     `json:"total_score"`
+}
+type PaginatedLearnerExamResponse = entity.Pagination[*LearnerExamResponse]
+type LearnerExamDetailResponse struct {
+	LearnerExamResponse
+	Parts []*LearnerPartResponse `json:"parts"`
+}
+type LearnerPartResponse struct {
+	PartID          uuid.UUID `json:"part_id"`
+	PartTitle       string    `json:"part_title"`
+	PartOrder       int32     `json:"part_order"`
+	Description     string    `json:"description"`
+	PlanType        string    `json:"plan_type"`
+	ToeicPartNumber int32     `json:"toeic_part_number"`
+	// Locked parts come without content; the caller needs a subscription to read them.
+	Locked     bool                        `json:"locked"`
+	Paragraphs []*LearnerParagraphResponse `json:"paragraphs,omitempty"`
+	Questions  []*LearnerQuestionResponse  `json:"questions,omitempty"` // questions outside any paragraph
+}
+type PaginatedLearnerPartResponse = entity.Pagination[*LearnerPartResponse]
+type LearnerParagraphResponse struct {
+	ParagraphID      uuid.UUID                  `json:"paragraph_id"`
+	Title            string                     `json:"title"`
+	ParagraphOrder   int32                      `json:"paragraph_order"`
+	ParagraphType    string                     `json:"paragraph_type"`
+	ParagraphContent string                     `json:"paragraph_content,omitempty"` // empty for audio scripts
+	AudioUrl         string                     `json:"audio_url"`
+	ImageUrl         string                     `json:"image_url"`
+	Questions        []*LearnerQuestionResponse `json:"questions"`
+}
+type LearnerQuestionResponse struct {
+	QuestionID           uuid.UUID    `json:"question_id"`
+	QuestionContent      string       `json:"question_content"`
+	QuestionType         string       `json:"question_type"`
+	QuestionOrder        int32        `json:"question_order"`
+	AudioUrl             string       `json:"audio_url"`
+	ImageUrl             string       `json:"image_url"`
+	ToeicQuestionSection string       `json:"toeic_question_section"`
+	QuestionNumberInPart int32        `json:"question_number_in_part"`
+	AnswerOption         AnswerOption `json:"answer_option"`
+}
@@ -6,25 +6,40 @@ import (
 	"time"
 )
 
+// AnswerOption is the answer_option of a question. Choice questions use the letters A to D, Matching
+// questions Prompts and Choices, Ordering questions Items, listed in the order shown to learners, and
+// FillInTheBlank questions the number of Blanks in their content.
 type AnswerOption struct {
-	A *string `json:"A,omitempty"`
-	B *string `json:"B,omitempty"`
-	C *string `json:"C,omitempty"`
-	D *string `json:"D,omitempty"`
+	A       *string      `json:"A,omitempty"`
+	B       *string      `json:"B,omitempty"`
+	C       *string      `json:"C,omitempty"`
+	D       *string      `json:"D,omitempty"`
+	Prompts []OptionItem `json:"prompts,omitempty"`
+	Choices []OptionItem `json:"choices,omitempty"`
+	Items   []OptionItem `json:"items,omitempty"`
+	Blanks  int32        `json:"blanks,omitempty"`
+}
+
+// OptionItem is an entry of a Matching or Ordering question; answers refer to it by Key.
+type OptionItem struct {
+	Key  string `json:"key"`
+	Text string `json:"text"`
 }
 type ExamResponse struct {
-	ExamID            uuid.UUID `json:"exam_id"`
-	ExamTitle         string    `json:"exam_title"`
-	Description       string    `json:"description"`
-	DurationMinutes   int32     `json:"duration_minutes"`
-	ExamType          string    `json:"exam_type"`
-	MaxListeningScore int32     `json:"max_listening_score"`
-	MaxReadingScore   int32     `json:"max_reading_score"`
-	MaxSpeakingScore  int32     `json:"max_speaking_score"`
-	MaxWritingScore   int32     `json:"max_writing_score"`
-	TotalScore        int32     `json:"total_score"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ExamID            uuid.UUID  `json:"exam_id"`
+	ExamTitle         string     `json:"exam_title"`
+	Description       string     `json:"description"`
+	DurationMinutes   int32      `json:"duration_minutes"`
+	ExamType          string     `json:"exam_type"`
+	MaxListeningScore int32      `json:"max_listening_score"`
+	MaxReadingScore   int32      `json:"max_reading_score"`
+	MaxSpeakingScore  int32      `json:"max_speaking_score"`
+	MaxWritingScore   int32      `json:"max_writing_score"`
+	TotalScore        int32      `json:"total_score"`
+	Status            string     `json:"status"`
+	PublishedAt       *time.Time `json:"published_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 type PaginatedExamResponse = entity.Pagination[*ExamResponse]
 type CreateExamRequest struct {
@@ -49,14 +64,18 @@ type UpdateExamRequest struct {
 }
 
 type ExamPartResponse struct {
-	PartTitle           string    `json:"part_title"`
-	PartOrder           int32     `json:"part_order"`
-	Description         string    `json:"description"`
-	IsPracticeComponent bool      `json:"is_practice_component"`
-	PlanType            string    `json:"plan_type"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
-	ToeicPartNumber     int32     `json:"toeic_part_number"`
+	PartID              uuid.UUID  `json:"part_id"`
+	ExamID              uuid.UUID  `json:"exam_id"`
+	PartTitle           string     `json:"part_title"`
+	PartOrder           int32      `json:"part_order"`
+	Description         string     `json:"description"`
+	IsPracticeComponent bool       `json:"is_practice_component"`
+	PlanType            string     `json:"plan_type"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	ToeicPartNumber     int32      `json:"toeic_part_number"`
+	Status              string     `json:"status"`
+	PublishedAt         *time.Time `json:"published_at"`
 }
 type PaginatedExamPartResponse = entity.Pagination[*ExamPartResponse]
 type CreateExamPartRequest struct {
@@ -125,6 +144,8 @@ type QuestionResponse struct {
 	QuestionNumberInPart int32        `json:"question_number_in_part"`
 	AnswerOption         AnswerOption `json:"answer_option"`
 	CorrectAnswer        string       `json:"correct_answer"`
+	PreparationSeconds   *int32       `json:"preparation_seconds,omitempty"`
+	ResponseSeconds      *int32       `json:"response_seconds,omitempty"`
 	CreatedAt            time.Time    `json:"created_at"`
 	UpdatedAt            time.Time    `json:"updated_at"`
 }
@@ -138,6 +159,8 @@ type CreateQuestionRequest struct {
 	QuestionNumberInPart int32     `json:"question_number_in_part"`
 	AnswerOption         string    `json:"answer_option"`
 	CorrectAnswer        string    `json:"correct_answer"`
+	PreparationSeconds   *int32    `json:"preparation_seconds"` // Speaking and Writing only; omitted uses the default of the question type
+	ResponseSeconds      *int32    `json:"response_seconds"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
@@ -151,7 +174,102 @@ type UpdateQuestionRequest struct {
 	QuestionNumberInPart int32     `json:"question_number_in_part"`
 	AnswerOption         string    `json:"answer_option"`
 	CorrectAnswer        string    `json:"correct_answer"`
+	PreparationSeconds   *int32    `json:"preparation_seconds"` // Speaking and Writing only; omitted uses the default of the question type
+	ResponseSeconds      *int32    `json:"response_seconds"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
 type PaginatedQuestionResponse = entity.Pagination[*QuestionResponse]
+
+// ExamTreeResponse is an exam with its parts, paragraphs and questions, as seen by editors.
+type ExamTreeResponse struct {
+	ExamResponse
+	Parts []*ExamTreePartResponse `json:"parts"`
+}
+type ExamTreePartResponse struct {
+	ExamPartResponse
+	Paragraphs []*ExamTreeParagraphResponse `json:"paragraphs"`
+	Questions  []*QuestionResponse          `json:"questions"` // questions outside any paragraph
+}
+type ExamTreeParagraphResponse struct {
+	ParagraphResponse
+	Questions []*QuestionResponse `json:"questions"`
+}
+
+// ChangeStatusRequest is the body of a publishing workflow transition.
+type ChangeStatusRequest struct {
+	Note string `json:"note"`
+}
+type ContentStatusResponse struct {
+	ContentType string     `json:"content_type"`
+	ContentID   uuid.UUID  `json:"content_id"`
+	Status      string     `json:"status"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// PublishCheckResponse lists what stops an exam or practice part from being published.
+type PublishCheckResponse struct {
+	Publishable bool     `json:"publishable"`
+	Issues      []string `json:"issues"`
+}
+type StatusChangeResponse struct {
+	ChangeID   uuid.UUID `json:"change_id"`
+	FromStatus string    `json:"from_status"`
+	ToStatus   string    `json:"to_status"`
+	ChangedBy  uuid.UUID `json:"changed_by"`
+	Note       string    `json:"note"`
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// FieldChange is one field of a revision diff; From is empty on a question's or paragraph's first revision.
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+type QuestionRevisionResponse struct {
+	RevisionID     uuid.UUID              `json:"revision_id"`
+	RevisionNumber int32                  `json:"revision_number"`
+	Question       *QuestionResponse      `json:"question"`
+	Diff           map[string]FieldChange `json:"diff"`
+	RestoredFrom   int32                  `json:"restored_from,omitempty"`
+	CreatedBy      uuid.UUID              `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+type ParagraphRevisionResponse struct {
+	RevisionID     uuid.UUID              `json:"revision_id"`
+	RevisionNumber int32                  `json:"revision_number"`
+	Paragraph      *ParagraphResponse     `json:"paragraph"`
+	Diff           map[string]FieldChange `json:"diff"`
+	RestoredFrom   int32                  `json:"restored_from,omitempty"`
+	CreatedBy      uuid.UUID              `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// QuestionExplanationRequest sets the explanations of a question in one language. OptionExplanations
+// is keyed by answer option ("A", "B", ... or the key of a Matching or Ordering entry) and may leave
+// options out.
+type QuestionExplanationRequest struct {
+	Explanation        string            `json:"explanation"`
+	OptionExplanations map[string]string `json:"option_explanations"`
+}
+type QuestionExplanationResponse struct {
+	QuestionID         uuid.UUID         `json:"question_id"`
+	Lang               string            `json:"lang"`
+	Explanation        string            `json:"explanation"`
+	OptionExplanations map[string]string `json:"option_explanations"`
+	UpdatedBy          uuid.UUID         `json:"updated_by"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// TrashItemResponse is content deleted on its own; ParentType and ParentID are left out for exams and
+// practice parts outside any exam.
+type TrashItemResponse struct {
+	ContentType string     `json:"content_type"`
+	ContentID   uuid.UUID  `json:"content_id"`
+	Title       string     `json:"title"`
+	ParentType  string     `json:"parent_type,omitempty"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	DeletedAt   time.Time  `json:"deleted_at"`
+	DeletedBy   uuid.UUID  `json:"deleted_by"`
+}
+type PaginatedTrashItemResponse = entity.Pagination[*TrashItemResponse]
@@ -7,34 +7,82 @@ import (
 )
 
 type Exam struct {
-	ExamID            uuid.UUID `db:"exam_id"`
-	ExamTitle         string    `db:"exam_title"`
-	Description       string    `db:"description"`
-	DurationMinutes   int32     `db:"duration_minutes"`
-	ExamType          string    `db:"exam_type"`
-	MaxListeningScore int32     `db:"max_listening_score"`
-	MaxReadingScore   int32     `db:"max_reading_score"`
-	MaxSpeakingScore  int32     `db:"max_speaking_score"`
-	MaxWritingScore   int32     `db:"max_writing_score"`
-	TotalScore        int32     `db:"total_score"`
-	CreatedAt         time.Time `db:"created_at"`
-	UpdatedAt         time.Time `db:"updated_at"`
+	ExamID            uuid.UUID  `db:"exam_id"`
+	ExamTitle         string     `db:"exam_title"`
+	Description       string     `db:"description"`
+	DurationMinutes   int32      `db:"duration_minutes"`
+	ExamType          string     `db:"exam_type"`
+	MaxListeningScore int32      `db:"max_listening_score"`
+	MaxReadingScore   int32      `db:"max_reading_score"`
+	MaxSpeakingScore  int32      `db:"max_speaking_score"`
+	MaxWritingScore   int32      `db:"max_writing_score"`
+	TotalScore        int32      `db:"total_score"`
+	Status            string     `db:"status"`
+	PublishedAt       *time.Time `db:"published_at"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
 }
 type PaginatedExams = entity.Pagination[*Exam]
 
 type ExamPart struct {
-	PartID              uuid.UUID `json:"part_id"`
-	ExamID              uuid.UUID `json:"exam_id"`
-	PartTitle           string    `json:"part_title"`
-	PartOrder           int32     `json:"part_order"`
-	Description         string    `json:"description"`
-	IsPracticeComponent bool      `json:"is_practice_component"`
-	PlanType            string    `json:"plan_type"`
-	CreatedAt           time.Time `json:"created_at"`
-	UpdatedAt           time.Time `json:"updated_at"`
-	ToeicPartNumber     int32     `json:"toeic_part_number"`
+	PartID              uuid.UUID  `json:"part_id"`
+	ExamID              uuid.UUID  `json:"exam_id"`
+	PartTitle           string     `json:"part_title"`
+	PartOrder           int32      `json:"part_order"`
+	Description         string     `json:"description"`
+	IsPracticeComponent bool       `json:"is_practice_component"`
+	PlanType            string     `json:"plan_type"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	ToeicPartNumber     int32      `json:"toeic_part_number"`
+	Status              string     `json:"status"`
+	PublishedAt         *time.Time `json:"published_at"`
 }
 type PaginatedExamPart = entity.Pagination[*ExamPart]
+
+// Content statuses of an exam or practice part. Only published content is shown to learners.
+const (
+	ContentStatusDraft     = "DRAFT"
+	ContentStatusInReview  = "IN_REVIEW"
+	ContentStatusPublished = "PUBLISHED"
+	ContentStatusArchived  = "ARCHIVED"
+)
+
+// Actions of the publishing workflow
+const (
+	StatusActionSubmit  = "submit"  // DRAFT to IN_REVIEW
+	StatusActionReject  = "reject"  // IN_REVIEW back to DRAFT
+	StatusActionPublish = "publish" // IN_REVIEW to PUBLISHED
+	StatusActionArchive = "archive" // PUBLISHED to ARCHIVED
+	StatusActionRestore = "restore" // ARCHIVED back to DRAFT
+)
+
+// Content types recorded in the status history; paragraphs and questions only appear in the trash
+const (
+	ContentTypeExam      = "EXAM"
+	ContentTypePart      = "PART"
+	ContentTypeParagraph = "PARAGRAPH"
+	ContentTypeQuestion  = "QUESTION"
+)
+
+// StatusChange is a status transition of an exam or practice part.
+type StatusChange struct {
+	ChangeID    uuid.UUID `json:"change_id"`
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+	FromStatus  string    `json:"from_status"`
+	ToStatus    string    `json:"to_status"`
+	ChangedBy   uuid.UUID `json:"changed_by"`
+	Note        string    `json:"note"`
+	ChangedAt   time.Time `json:"changed_at"`
+}
+
+// Plan types of an exam part
+const (
+	PlanTypeSubscription = "SUBSCRIPTION"
+	PlanTypeFree         = "FREE"
+)
+
 type Paragraph struct {
 	ParagraphID      uuid.UUID `json:"paragraph_id"`
 	ParagraphContent string    `json:"paragraph_content"`
@@ -48,6 +96,10 @@ type Paragraph struct {
 	UpdatedAt        time.Time `json:"updated_at"`
 }
 type PaginatedParagraph = entity.Pagination[*Paragraph]
+
+// ParagraphTypeAudioScript is the transcript of the paragraph's audio; learners must not see it while answering.
+const ParagraphTypeAudioScript = "Audio Script"
+
 type Question struct {
 	QuestionID           uuid.UUID `json:"question_id"`
 	QuestionContent      string    `json:"question_content"`
@@ -61,7 +113,125 @@ type Question struct {
 	QuestionNumberInPart int32     `json:"question_number_in_part"`
 	AnswerOption         string    `json:"answer_option"`
 	CorrectAnswer        string    `json:"correct_answer"`
+	PreparationSeconds   *int32    `json:"preparation_seconds,omitempty"` // nil keeps the default of the question type
+	ResponseSeconds      *int32    `json:"response_seconds,omitempty"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
 }
 type PaginatedQuestion = entity.Pagination[*Question]
+
+// Question types whose answer_option and correct_answer follow a schema of their own
+const (
+	QuestionTypeTrueFalse      = "TrueFalse"
+	QuestionTypeMatching       = "Matching"
+	QuestionTypeOrdering       = "Ordering"
+	QuestionTypeFillInTheBlank = "FillInTheBlank"
+	QuestionTypeShortAnswer    = "ShortAnswer"
+)
+
+// ChoiceQuestionTypes lists the question types answered with one of the letters A to D.
+var ChoiceQuestionTypes = map[string]bool{
+	"MultipleChoice":   true,
+	"PhotoDescription": true,
+	"QuestionResponse": true,
+}
+
+// AnswerKey is the correct_answer of a question read for its type; only the field of that type is set.
+type AnswerKey struct {
+	Choice   string            // choice questions: the option letter; TrueFalse: "true" or "false"
+	Pairs    map[string]string // Matching: the choice key of every prompt key
+	Order    []string          // Ordering: the item keys in the correct order
+	Blanks   [][]string        // FillInTheBlank: the accepted variants of every blank, in order
+	Accepted []string          // ShortAnswer: the accepted variants
+}
+
+// ExamTree is an exam with all of its content, loaded in a fixed number of queries.
+type ExamTree struct {
+	Exam  *Exam       `json:"exam"`
+	Parts []*PartTree `json:"parts"`
+}
+type PartTree struct {
+	Part       *ExamPart        `json:"part"`
+	Paragraphs []*ParagraphTree `json:"paragraphs"`
+	Questions  []*Question      `json:"questions"` // questions outside any paragraph
+}
+type ParagraphTree struct {
+	Paragraph *Paragraph  `json:"paragraph"`
+	Questions []*Question `json:"questions"`
+}
+
+// FieldChange is the value of a field before and after a revision.
+type FieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// QuestionRevision is an immutable snapshot of a question, recorded on every change.
+type QuestionRevision struct {
+	RevisionID     uuid.UUID              `json:"revision_id"`
+	RevisionNumber int32                  `json:"revision_number"`
+	Question       *Question              `json:"question"`
+	Diff           map[string]FieldChange `json:"diff"`
+	RestoredFrom   int32                  `json:"restored_from"` // revision number restored by this revision, 0 if none
+	CreatedBy      uuid.UUID              `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// QuestionExplanation is the rationale of a question's answer in one language, with the reason each
+// answer option is right or wrong keyed by option ("A", "B", ...). Learners only see it when reviewing a
+// submitted attempt.
+type QuestionExplanation struct {
+	QuestionID         uuid.UUID         `json:"question_id"`
+	Lang               string            `json:"lang"`
+	Explanation        string            `json:"explanation"`
+	OptionExplanations map[string]string `json:"option_explanations"`
+	UpdatedBy          uuid.UUID         `json:"updated_by"`
+	UpdatedAt          time.Time         `json:"updated_at"`
+}
+
+// ParagraphRevision is an immutable snapshot of a paragraph, recorded on every change.
+type ParagraphRevision struct {
+	RevisionID     uuid.UUID              `json:"revision_id"`
+	RevisionNumber int32                  `json:"revision_number"`
+	Paragraph      *Paragraph             `json:"paragraph"`
+	Diff           map[string]FieldChange `json:"diff"`
+	RestoredFrom   int32                  `json:"restored_from"`
+	CreatedBy      uuid.UUID              `json:"created_by"`
+	CreatedAt      time.Time              `json:"created_at"`
+}
+
+// ImportError is an error about one item of an imported package, such as "parts[2].questions[4]".
+type ImportError struct {
+	Item string
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return e.Item + ": " + e.Err.Error()
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// TrashItem is an exam, part, paragraph or question deleted on its own. It can be restored, along with
+// the children deleted with it, until the purge removes it.
+type TrashItem struct {
+	ContentType string    `json:"content_type"`
+	ContentID   uuid.UUID `json:"content_id"`
+	Title       string    `json:"title"`
+	ParentType  string    `json:"parent_type"`
+	ParentID    uuid.UUID `json:"parent_id"`
+	DeletedAt   time.Time `json:"deleted_at"`
+	DeletedBy   uuid.UUID `json:"deleted_by"`
+}
+type PaginatedTrashItem = entity.Pagination[*TrashItem]
+
+// PurgeResult counts what a purge of the trash deleted for good.
+type PurgeResult struct {
+	Exams      int64 `json:"exams"`
+	Parts      int64 `json:"parts"`
+	Paragraphs int64 `json:"paragraphs"`
+	Questions  int64 `json:"questions"`
+	MediaFiles int   `json:"media_files"`
+}
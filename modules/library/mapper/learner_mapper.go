@@ -0,0 +1,121 @@
+package mapper
+
+import (
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+)
+
+// Learner mappers build the read model served to learners. They copy fields one by one into
+// the learner DTOs and never touch CorrectAnswer or audio script content.
+
+func ToLearnerExamResponse(exam *entity.Exam) *dto.LearnerExamResponse {
+	if exam == nil {
+		return nil
+	}
+	return &dto.LearnerExamResponse{
+		ExamID:          exam.ExamID,
+		ExamTitle:       exam.ExamTitle,
+		Description:     exam.Description,
+		DurationMinutes: exam.DurationMinutes,
+		ExamType:        exam.ExamType,
+		TotalScore:      exam.TotalScore,
+	}
+}
+
+func ToPaginatedLearnerExamsResponse(exams *entity.PaginatedExams) *dto.PaginatedLearnerExamResponse {
+	if exams == nil {
+		return nil
+	}
+	items := make([]*dto.LearnerExamResponse, 0, len(exams.Items))
+	for _, exam := range exams.Items {
+		items = append(items, ToLearnerExamResponse(exam))
+	}
+	return &dto.PaginatedLearnerExamResponse{
+		Items:       items,
+		TotalItems:  exams.TotalItems,
+		TotalPages:  exams.TotalPages,
+		CurrentPage: exams.CurrentPage,
+		PageSize:    exams.PageSize,
+	}
+}
+
+// ToLearnerPartResponse maps a part with its content, or without it when the part is locked for the caller.
+func ToLearnerPartResponse(part *entity.PartTree, locked bool) *dto.LearnerPartResponse {
+	if part == nil {
+		return nil
+	}
+	response := ToLearnerPartSummaryResponse(part.Part, locked)
+	if locked {
+		return response
+	}
+	response.Paragraphs = make([]*dto.LearnerParagraphResponse, 0, len(part.Paragraphs))
+	for _, paragraph := range part.Paragraphs {
+		response.Paragraphs = append(response.Paragraphs, ToLearnerParagraphResponse(paragraph.Paragraph, paragraph.Questions))
+	}
+	response.Questions = ToLearnerQuestionsResponse(part.Questions)
+	return response
+}
+
+// ToLearnerPartSummaryResponse maps a part without its content, for catalogues.
+func ToLearnerPartSummaryResponse(part *entity.ExamPart, locked bool) *dto.LearnerPartResponse {
+	if part == nil {
+		return nil
+	}
+	return &dto.LearnerPartResponse{
+		PartID:          part.PartID,
+		PartTitle:       part.PartTitle,
+		PartOrder:       part.PartOrder,
+		Description:     part.Description,
+		PlanType:        part.PlanType,
+		ToeicPartNumber: part.ToeicPartNumber,
+		Locked:          locked,
+	}
+}
+
+func ToLearnerParagraphResponse(paragraph *entity.Paragraph, questions []*entity.Question) *dto.LearnerParagraphResponse {
+	if paragraph == nil {
+		return nil
+	}
+	response := &dto.LearnerParagraphResponse{
+		ParagraphID:    paragraph.ParagraphID,
+		Title:          paragraph.Title,
+		ParagraphOrder: paragraph.ParagraphOrder,
+		ParagraphType:  paragraph.ParagraphType,
+		AudioUrl:       paragraph.AudioUrl,
+		ImageUrl:       paragraph.ImageUrl,
+		Questions:      ToLearnerQuestionsResponse(questions),
+	}
+	if paragraph.ParagraphType != entity.ParagraphTypeAudioScript {
+		response.ParagraphContent = paragraph.ParagraphContent
+	}
+	return response
+}
+
+func ToLearnerQuestionResponse(question *entity.Question) *dto.LearnerQuestionResponse {
+	if question == nil {
+		return nil
+	}
+	answerOption, err := UnmarshalAnswerOption(question.AnswerOption)
+	if err != nil {
+		answerOption = dto.AnswerOption{}
+	}
+	return &dto.LearnerQuestionResponse{
+		QuestionID:           question.QuestionID,
+		QuestionContent:      question.QuestionContent,
+		QuestionType:         question.QuestionType,
+		QuestionOrder:        question.QuestionOrder,
+		AudioUrl:             question.AudioUrl,
+		ImageUrl:             question.ImageUrl,
+		ToeicQuestionSection: question.ToeicQuestionSection,
+		QuestionNumberInPart: question.QuestionNumberInPart,
+		AnswerOption:         answerOption,
+	}
+}
+
+func ToLearnerQuestionsResponse(questions []*entity.Question) []*dto.LearnerQuestionResponse {
+	responses := make([]*dto.LearnerQuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		responses = append(responses, ToLearnerQuestionResponse(question))
+	}
+	return responses
+}
@@ -3,8 +3,10 @@ package mapper
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"pirate-lang-go/modules/library/dto"
 	"pirate-lang-go/modules/library/entity"
+	"strings"
 )
 
 func ToCreateExamEntity(req *dto.CreateExamRequest) *entity.Exam {
@@ -57,6 +59,8 @@ func ToExamResponse(exam *entity.Exam) *dto.ExamResponse {
 		MaxSpeakingScore:  int32(exam.MaxSpeakingScore),
 		MaxWritingScore:   int32(exam.MaxWritingScore),
 		TotalScore:        int32(exam.TotalScore),
+		Status:            exam.Status,
+		PublishedAt:       exam.PublishedAt,
 		CreatedAt:         exam.CreatedAt,
 		UpdatedAt:         exam.UpdatedAt,
 	}
@@ -80,6 +84,8 @@ func ToPaginatedExamsResponse(exams *entity.PaginatedExams) *dto.PaginatedExamRe
 			MaxSpeakingScore:  int32(exam.MaxSpeakingScore),
 			MaxWritingScore:   int32(exam.MaxWritingScore),
 			TotalScore:        int32(exam.TotalScore),
+			Status:            exam.Status,
+			PublishedAt:       exam.PublishedAt,
 			CreatedAt:         exam.CreatedAt,
 			UpdatedAt:         exam.UpdatedAt,
 		})
@@ -127,12 +133,18 @@ func ToExamPartResponse(entity *entity.ExamPart) *dto.ExamPartResponse {
 		return nil
 	}
 	return &dto.ExamPartResponse{
+		PartID:              entity.PartID,
+		ExamID:              entity.ExamID,
 		PartTitle:           entity.PartTitle,
 		PartOrder:           entity.PartOrder,
 		Description:         entity.Description,
 		IsPracticeComponent: entity.IsPracticeComponent,
 		PlanType:            entity.PlanType,
 		ToeicPartNumber:     entity.ToeicPartNumber,
+		CreatedAt:           entity.CreatedAt,
+		UpdatedAt:           entity.UpdatedAt,
+		Status:              entity.Status,
+		PublishedAt:         entity.PublishedAt,
 	}
 }
 func ToPaginatedExamPartsResponse(parts *entity.PaginatedExamPart) *dto.PaginatedExamPartResponse {
@@ -143,6 +155,8 @@ func ToPaginatedExamPartsResponse(parts *entity.PaginatedExamPart) *dto.Paginate
 	examDTOs := make([]*dto.ExamPartResponse, 0, len(parts.Items))
 	for _, exam := range parts.Items {
 		examDTOs = append(examDTOs, &dto.ExamPartResponse{
+			PartID:              exam.PartID,
+			ExamID:              exam.ExamID,
 			PartTitle:           exam.PartTitle,
 			PartOrder:           exam.PartOrder,
 			Description:         exam.Description,
@@ -151,6 +165,8 @@ func ToPaginatedExamPartsResponse(parts *entity.PaginatedExamPart) *dto.Paginate
 			ToeicPartNumber:     exam.ToeicPartNumber,
 			CreatedAt:           exam.CreatedAt,
 			UpdatedAt:           exam.UpdatedAt,
+			Status:              exam.Status,
+			PublishedAt:         exam.PublishedAt,
 		})
 	}
 
@@ -229,6 +245,103 @@ func UnmarshalAnswerOption(jsonString string) (dto.AnswerOption, error) {
 	return opt, nil
 }
 
+// AnswerOptionKeys lists the keys of an answer option: its letters and the keys of its Matching and
+// Ordering entries.
+func AnswerOptionKeys(opt dto.AnswerOption) map[string]bool {
+	keys := make(map[string]bool)
+	for key, value := range map[string]*string{"A": opt.A, "B": opt.B, "C": opt.C, "D": opt.D} {
+		if value != nil {
+			keys[key] = true
+		}
+	}
+	for _, items := range [][]dto.OptionItem{opt.Prompts, opt.Choices, opt.Items} {
+		for _, item := range items {
+			keys[item.Key] = true
+		}
+	}
+	return keys
+}
+
+// UnmarshalAnswerKey reads the correct_answer of a question for its type:
+//   - Matching: a JSON object of prompt keys to choice keys, {"1": "b", "2": "a"}
+//   - Ordering: a JSON array of the item keys in order, ["C", "A", "B"]
+//   - FillInTheBlank: a JSON array with the accepted variants of every blank, each a string or an array
+//     of strings, ["went", ["has gone", "had gone"]]; plain text is the answer of a single blank
+//   - ShortAnswer: a JSON array of the accepted variants; plain text is the only accepted answer
+//   - any other type: the plain text answer, such as the letter of a choice question
+//
+// An empty correct_answer gives an empty key.
+func UnmarshalAnswerKey(questionType, correctAnswer string) (*entity.AnswerKey, error) {
+	key := &entity.AnswerKey{}
+	correctAnswer = strings.TrimSpace(correctAnswer)
+	if correctAnswer == "" {
+		return key, nil
+	}
+	switch questionType {
+	case entity.QuestionTypeMatching:
+		if err := json.Unmarshal([]byte(correctAnswer), &key.Pairs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Matching correct answer: %w", err)
+		}
+	case entity.QuestionTypeOrdering:
+		if err := json.Unmarshal([]byte(correctAnswer), &key.Order); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal Ordering correct answer: %w", err)
+		}
+	case entity.QuestionTypeFillInTheBlank:
+		if !strings.HasPrefix(correctAnswer, "[") {
+			key.Blanks = [][]string{{correctAnswer}}
+			break
+		}
+		var blanks []json.RawMessage
+		if err := json.Unmarshal([]byte(correctAnswer), &blanks); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal FillInTheBlank correct answer: %w", err)
+		}
+		for _, blank := range blanks {
+			variants, err := unmarshalVariants(blank)
+			if err != nil {
+				return nil, fmt.Errorf("failed to unmarshal FillInTheBlank correct answer: %w", err)
+			}
+			key.Blanks = append(key.Blanks, variants)
+		}
+	case entity.QuestionTypeShortAnswer:
+		if !strings.HasPrefix(correctAnswer, "[") {
+			key.Accepted = []string{correctAnswer}
+			break
+		}
+		if err := json.Unmarshal([]byte(correctAnswer), &key.Accepted); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal ShortAnswer correct answer: %w", err)
+		}
+	case entity.QuestionTypeTrueFalse:
+		key.Choice = NormalizeTrueFalse(correctAnswer)
+	default:
+		key.Choice = correctAnswer
+	}
+	return key, nil
+}
+
+// unmarshalVariants reads the accepted variants of a blank, given as a string or an array of strings.
+func unmarshalVariants(raw json.RawMessage) ([]string, error) {
+	var variant string
+	if err := json.Unmarshal(raw, &variant); err == nil {
+		return []string{variant}, nil
+	}
+	var variants []string
+	if err := json.Unmarshal(raw, &variants); err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+// NormalizeTrueFalse reads the usual spellings of true and false; other values are only lower-cased.
+func NormalizeTrueFalse(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "true", "t", "1", "yes":
+		return "true"
+	case "false", "f", "0", "no":
+		return "false"
+	}
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
 func ToCreateQuestionEntity(dto *dto.CreateQuestionRequest) *entity.Question {
 	if dto == nil {
 		return nil
@@ -242,6 +355,9 @@ func ToCreateQuestionEntity(dto *dto.CreateQuestionRequest) *entity.Question {
 		ToeicQuestionSection: dto.ToeicQuestionSection,
 		QuestionNumberInPart: dto.QuestionNumberInPart,
 		AnswerOption:         dto.AnswerOption,
+		CorrectAnswer:        dto.CorrectAnswer,
+		PreparationSeconds:   dto.PreparationSeconds,
+		ResponseSeconds:      dto.ResponseSeconds,
 	}
 }
 func ToUpdateQuestionEntity(dto *dto.UpdateQuestionRequest) *entity.Question {
@@ -257,6 +373,9 @@ func ToUpdateQuestionEntity(dto *dto.UpdateQuestionRequest) *entity.Question {
 		ToeicQuestionSection: dto.ToeicQuestionSection,
 		QuestionNumberInPart: dto.QuestionNumberInPart,
 		AnswerOption:         dto.AnswerOption,
+		CorrectAnswer:        dto.CorrectAnswer,
+		PreparationSeconds:   dto.PreparationSeconds,
+		ResponseSeconds:      dto.ResponseSeconds,
 	}
 }
 
@@ -273,9 +392,18 @@ func ToQuestionResponse(entity *entity.Question) *dto.QuestionResponse {
 	return &dto.QuestionResponse{
 		QuestionID:           entity.QuestionID,
 		QuestionContent:      entity.QuestionContent,
+		QuestionType:         entity.QuestionType,
+		PartID:               entity.PartID,
 		ParagraphID:          entity.ParagraphID,
+		QuestionOrder:        entity.QuestionOrder,
+		AudioUrl:             entity.AudioUrl,
+		ImageUrl:             entity.ImageUrl,
+		ToeicQuestionSection: entity.ToeicQuestionSection,
 		QuestionNumberInPart: entity.QuestionNumberInPart,
 		AnswerOption:         answerOption,
+		CorrectAnswer:        entity.CorrectAnswer,
+		PreparationSeconds:   entity.PreparationSeconds,
+		ResponseSeconds:      entity.ResponseSeconds,
 		CreatedAt:            entity.CreatedAt,
 		UpdatedAt:            entity.UpdatedAt,
 	}
@@ -298,3 +426,162 @@ func ToPaginatedQuestionResponse(parts *entity.PaginatedQuestion) *dto.Paginated
 		PageSize:    parts.PageSize,
 	}
 }
+
+func ToExamTreeResponse(tree *entity.ExamTree) *dto.ExamTreeResponse {
+	if tree == nil {
+		return nil
+	}
+	response := &dto.ExamTreeResponse{
+		ExamResponse: *ToExamResponse(tree.Exam),
+		Parts:        make([]*dto.ExamTreePartResponse, 0, len(tree.Parts)),
+	}
+	for _, part := range tree.Parts {
+		partResponse := &dto.ExamTreePartResponse{
+			ExamPartResponse: *ToExamPartResponse(part.Part),
+			Paragraphs:       make([]*dto.ExamTreeParagraphResponse, 0, len(part.Paragraphs)),
+			Questions:        toQuestionsResponse(part.Questions),
+		}
+		for _, paragraph := range part.Paragraphs {
+			partResponse.Paragraphs = append(partResponse.Paragraphs, &dto.ExamTreeParagraphResponse{
+				ParagraphResponse: *ToParagraphResponse(paragraph.Paragraph),
+				Questions:         toQuestionsResponse(paragraph.Questions),
+			})
+		}
+		response.Parts = append(response.Parts, partResponse)
+	}
+	return response
+}
+
+func toQuestionsResponse(questions []*entity.Question) []*dto.QuestionResponse {
+	responses := make([]*dto.QuestionResponse, 0, len(questions))
+	for _, question := range questions {
+		responses = append(responses, ToQuestionResponse(question))
+	}
+	return responses
+}
+
+func ToStatusChangesResponse(changes []*entity.StatusChange) []*dto.StatusChangeResponse {
+	responses := make([]*dto.StatusChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		responses = append(responses, &dto.StatusChangeResponse{
+			ChangeID:   change.ChangeID,
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ChangedBy:  change.ChangedBy,
+			Note:       change.Note,
+			ChangedAt:  change.ChangedAt,
+		})
+	}
+	return responses
+}
+
+func toFieldChangesResponse(diff map[string]entity.FieldChange) map[string]dto.FieldChange {
+	response := make(map[string]dto.FieldChange, len(diff))
+	for field, change := range diff {
+		response[field] = dto.FieldChange{From: change.From, To: change.To}
+	}
+	return response
+}
+
+func ToQuestionRevisionResponse(revision *entity.QuestionRevision) *dto.QuestionRevisionResponse {
+	if revision == nil {
+		return nil
+	}
+	return &dto.QuestionRevisionResponse{
+		RevisionID:     revision.RevisionID,
+		RevisionNumber: revision.RevisionNumber,
+		Question:       ToQuestionResponse(revision.Question),
+		Diff:           toFieldChangesResponse(revision.Diff),
+		RestoredFrom:   revision.RestoredFrom,
+		CreatedBy:      revision.CreatedBy,
+		CreatedAt:      revision.CreatedAt,
+	}
+}
+
+func ToQuestionRevisionsResponse(revisions []*entity.QuestionRevision) []*dto.QuestionRevisionResponse {
+	responses := make([]*dto.QuestionRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		responses = append(responses, ToQuestionRevisionResponse(revision))
+	}
+	return responses
+}
+
+func ToParagraphRevisionResponse(revision *entity.ParagraphRevision) *dto.ParagraphRevisionResponse {
+	if revision == nil {
+		return nil
+	}
+	return &dto.ParagraphRevisionResponse{
+		RevisionID:     revision.RevisionID,
+		RevisionNumber: revision.RevisionNumber,
+		Paragraph:      ToParagraphResponse(revision.Paragraph),
+		Diff:           toFieldChangesResponse(revision.Diff),
+		RestoredFrom:   revision.RestoredFrom,
+		CreatedBy:      revision.CreatedBy,
+		CreatedAt:      revision.CreatedAt,
+	}
+}
+
+func ToParagraphRevisionsResponse(revisions []*entity.ParagraphRevision) []*dto.ParagraphRevisionResponse {
+	responses := make([]*dto.ParagraphRevisionResponse, 0, len(revisions))
+	for _, revision := range revisions {
+		responses = append(responses, ToParagraphRevisionResponse(revision))
+	}
+	return responses
+}
+
+func ToQuestionExplanationResponse(explanation *entity.QuestionExplanation) *dto.QuestionExplanationResponse {
+	if explanation == nil {
+		return nil
+	}
+	return &dto.QuestionExplanationResponse{
+		QuestionID:         explanation.QuestionID,
+		Lang:               explanation.Lang,
+		Explanation:        explanation.Explanation,
+		OptionExplanations: explanation.OptionExplanations,
+		UpdatedBy:          explanation.UpdatedBy,
+		UpdatedAt:          explanation.UpdatedAt,
+	}
+}
+
+func ToQuestionExplanationsResponse(explanations []*entity.QuestionExplanation) []*dto.QuestionExplanationResponse {
+	responses := make([]*dto.QuestionExplanationResponse, 0, len(explanations))
+	for _, explanation := range explanations {
+		responses = append(responses, ToQuestionExplanationResponse(explanation))
+	}
+	return responses
+}
+
+func ToTrashItemResponse(item *entity.TrashItem) *dto.TrashItemResponse {
+	response := &dto.TrashItemResponse{
+		ContentType: item.ContentType,
+		ContentID:   item.ContentID,
+		Title:       item.Title,
+		ParentType:  item.ParentType,
+		DeletedAt:   item.DeletedAt,
+		DeletedBy:   item.DeletedBy,
+	}
+	if item.ParentID != uuid.Nil {
+		parentId := item.ParentID
+		response.ParentID = &parentId
+	}
+	return response
+}
+
+func ToPaginatedTrashItemResponse(items *entity.PaginatedTrashItem) *dto.PaginatedTrashItemResponse {
+	if items == nil {
+		return nil
+	}
+
+	dtOs := make([]*dto.TrashItemResponse, 0, len(items.Items))
+	for _, item := range items.Items {
+		dtOs = append(dtOs, ToTrashItemResponse(item))
+	}
+
+	return &dto.PaginatedTrashItemResponse{
+		Items:       dtOs,
+		TotalItems:  items.TotalItems,
+		TotalPages:  items.TotalPages,
+		CurrentPage: items.CurrentPage,
+		PageSize:    items.PageSize,
+	}
+}
@@ -0,0 +1,81 @@
+package mapper
+
+import (
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+)
+
+// ToExamManifest maps an exam tree to the manifest of an exam package; mediaPath gives the package
+// path of each stored audio or image URL.
+func ToExamManifest(tree *entity.ExamTree, mediaPath func(url string) string) *dto.ExamManifest {
+	if tree == nil {
+		return nil
+	}
+	manifest := &dto.ExamManifest{
+		ExamTitle:         tree.Exam.ExamTitle,
+		Description:       tree.Exam.Description,
+		DurationMinutes:   tree.Exam.DurationMinutes,
+		ExamType:          tree.Exam.ExamType,
+		MaxListeningScore: tree.Exam.MaxListeningScore,
+		MaxReadingScore:   tree.Exam.MaxReadingScore,
+		MaxSpeakingScore:  tree.Exam.MaxSpeakingScore,
+		MaxWritingScore:   tree.Exam.MaxWritingScore,
+		Parts:             make([]*dto.PartManifest, 0, len(tree.Parts)),
+	}
+	for _, part := range tree.Parts {
+		manifest.Parts = append(manifest.Parts, ToPartManifest(part, mediaPath))
+	}
+	return manifest
+}
+
+func ToPartManifest(part *entity.PartTree, mediaPath func(url string) string) *dto.PartManifest {
+	if part == nil {
+		return nil
+	}
+	manifest := &dto.PartManifest{
+		PartTitle:       part.Part.PartTitle,
+		PartOrder:       part.Part.PartOrder,
+		Description:     part.Part.Description,
+		PlanType:        part.Part.PlanType,
+		ToeicPartNumber: part.Part.ToeicPartNumber,
+		Paragraphs:      make([]*dto.ParagraphManifest, 0, len(part.Paragraphs)),
+		Questions:       toQuestionManifests(part.Questions, mediaPath),
+	}
+	for _, paragraph := range part.Paragraphs {
+		manifest.Paragraphs = append(manifest.Paragraphs, &dto.ParagraphManifest{
+			ParagraphContent: paragraph.Paragraph.ParagraphContent,
+			Title:            paragraph.Paragraph.Title,
+			ParagraphOrder:   paragraph.Paragraph.ParagraphOrder,
+			ParagraphType:    paragraph.Paragraph.ParagraphType,
+			Audio:            mediaPath(paragraph.Paragraph.AudioUrl),
+			Image:            mediaPath(paragraph.Paragraph.ImageUrl),
+			Questions:        toQuestionManifests(paragraph.Questions, mediaPath),
+		})
+	}
+	return manifest
+}
+
+func toQuestionManifests(questions []*entity.Question, mediaPath func(url string) string) []*dto.QuestionManifest {
+	manifests := make([]*dto.QuestionManifest, 0, len(questions))
+	for _, question := range questions {
+		manifest := &dto.QuestionManifest{
+			QuestionContent:      question.QuestionContent,
+			QuestionType:         question.QuestionType,
+			QuestionOrder:        question.QuestionOrder,
+			ToeicQuestionSection: question.ToeicQuestionSection,
+			QuestionNumberInPart: question.QuestionNumberInPart,
+			CorrectAnswer:        question.CorrectAnswer,
+			PreparationSeconds:   question.PreparationSeconds,
+			ResponseSeconds:      question.ResponseSeconds,
+			Audio:                mediaPath(question.AudioUrl),
+			Image:                mediaPath(question.ImageUrl),
+		}
+		if question.AnswerOption != "" {
+			if answerOption, err := UnmarshalAnswerOption(question.AnswerOption); err == nil {
+				manifest.AnswerOption = &answerOption
+			}
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests
+}
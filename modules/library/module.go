@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"pirate-lang-go/core/cache"
 	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/mailer"
 	"pirate-lang-go/core/middleware"
 	"pirate-lang-go/core/storage"
 	accountrepo "pirate-lang-go/modules/account/repository"
@@ -12,16 +13,28 @@ import (
 	"pirate-lang-go/modules/library/repository"
 	"pirate-lang-go/modules/library/router"
 	"pirate-lang-go/modules/library/service"
+	subscriptionrepo "pirate-lang-go/modules/subscription/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
 )
 
-func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage) {
-	accountService := accountservice.NewAccountService(accountrepo.NewAccountRepository(db.DB()), cache, storage)
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, mailer mailer.IMailer) {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	accountService := accountservice.NewAccountService(accountRepository, cache, storage, mailer)
 	middleware := middleware.NewMiddleware(accountService)
-	repository := repository.NewLibraryRepository(db.DB())
-
-	libraryService := service.NewLibraryService(repository, cache, storage)
 	// Update: pass only the controller
 	router.NewLibraryRouter(
-		controller.NewLibraryController(libraryService),
+		controller.NewLibraryController(NewService(db, cache, storage)),
 	).Setup(e, middleware)
 }
+
+// NewService builds the library service, for the HTTP routes and the command line tools.
+func NewService(db database.Database, cache *cache.Cache, storage *storage.Storage) service.ILibraryService {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	subscriptionService := subscriptionservice.NewSubscriptionService(subscriptionrepo.NewSubscriptionRepository(db.DB()), accountRepository, cache)
+	return service.NewLibraryService(repository.NewLibraryRepository(db.DB()), cache, storage, accountRepository, subscriptionService)
+}
+
+// NewTrashPurger builds the background purge of the library trash and of the media files left unused.
+func NewTrashPurger(db database.Database, cache *cache.Cache, storage *storage.Storage) *service.TrashPurger {
+	return service.NewTrashPurger(NewService(db, cache, storage), service.DefaultPurgeConfig())
+}
@@ -7,9 +7,20 @@ import (
 	"pirate-lang-go/core/logger"
 	"pirate-lang-go/internal/database"
 	"pirate-lang-go/modules/library/entity"
+	"time"
 )
 
 func (r *LibraryRepository) CreateExam(ctx context.Context, exam *entity.Exam) error {
+	_, err := r.Queries.CreateExam(ctx, createExamParams(exam))
+	if err != nil {
+		logger.Error("LibraryRepository.CreateExam: failed to create exam",
+			"error", err)
+		return err
+	}
+	return err
+}
+
+func createExamParams(exam *entity.Exam) database.CreateExamParams {
 	var (
 		ExamTitle         string
 		Description       sql.NullString
@@ -47,7 +58,7 @@ func (r *LibraryRepository) CreateExam(ctx context.Context, exam *entity.Exam) e
 		TotalScore = sql.NullInt32{Int32: exam.TotalScore, Valid: true}
 	}
 
-	_, err := r.Queries.CreateExam(ctx, database.CreateExamParams{
+	return database.CreateExamParams{
 		ExamTitle:         ExamTitle,
 		Description:       Description,
 		DurationMinutes:   DurationMinutes,
@@ -57,13 +68,7 @@ func (r *LibraryRepository) CreateExam(ctx context.Context, exam *entity.Exam) e
 		MaxSpeakingScore:  MaxSpeakingScore,
 		MaxWritingScore:   MaxWritingScore,
 		TotalScore:        TotalScore,
-	})
-	if err != nil {
-		logger.Error("LibraryRepository.CreateExam: failed to create exam",
-			"error", err)
-		return err
 	}
-	return err
 }
 
 func (r *LibraryRepository) UpdateExam(ctx context.Context, exam *entity.Exam, examId uuid.UUID) error {
@@ -152,15 +157,20 @@ func (r *LibraryRepository) GetExam(ctx context.Context, examId uuid.UUID) (*ent
 		MaxSpeakingScore:  getInt32(dbExam.MaxSpeakingScore),
 		MaxWritingScore:   getInt32(dbExam.MaxWritingScore),
 		TotalScore:        getInt32(dbExam.TotalScore),
+		Status:            dbExam.Status,
+		PublishedAt:       nullTimeToPtr(dbExam.PublishedAt),
 		CreatedAt:         dbExam.CreatedAt.Time,
 		UpdatedAt:         dbExam.UpdatedAt.Time,
 	}, err
 }
 
-func (r *LibraryRepository) GetExams(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedExams, error) {
-	totalItems, err := r.Queries.GetExamsCount(ctx)
+// GetExams lists exams, optionally only those in the given status; an empty status lists all of them.
+func (r *LibraryRepository) GetExams(ctx context.Context, status string, pageNumber, pageSize int) (*entity.PaginatedExams, error) {
+	statusFilter := sql.NullString{String: status, Valid: status != ""}
+	totalItems, err := r.Queries.GetExamsCount(ctx, statusFilter)
 	if err != nil {
 		logger.Error("LibraryRepository.GetExams: failed to get total count of exams",
+			"status", status,
 			"page_number", pageNumber,
 			"page_size", pageSize,
 			"error", err)
@@ -171,6 +181,7 @@ func (r *LibraryRepository) GetExams(ctx context.Context, pageNumber, pageSize i
 	listParams := database.GetPaginatedExamsParams{
 		Limit:  int32(pageSize),
 		Offset: int32(offset),
+		Status: statusFilter,
 	}
 
 	dbExams, err := r.Queries.GetPaginatedExams(ctx, listParams)
@@ -201,6 +212,8 @@ func (r *LibraryRepository) GetExams(ctx context.Context, pageNumber, pageSize i
 			MaxSpeakingScore:  getInt32(dbExam.MaxSpeakingScore),
 			MaxWritingScore:   getInt32(dbExam.MaxWritingScore),
 			TotalScore:        getInt32(dbExam.TotalScore),
+			Status:            dbExam.Status,
+			PublishedAt:       nullTimeToPtr(dbExam.PublishedAt),
 			CreatedAt:         dbExam.CreatedAt.Time,
 			UpdatedAt:         dbExam.UpdatedAt.Time,
 		}
@@ -216,3 +229,25 @@ func (r *LibraryRepository) GetExams(ctx context.Context, pageNumber, pageSize i
 		PageSize:    pageSize,
 	}, nil
 }
+
+func nullTimeToPtr(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
+
+func int32PtrToNull(value *int32) sql.NullInt32 {
+	if value == nil {
+		return sql.NullInt32{Valid: false}
+	}
+	return sql.NullInt32{Int32: *value, Valid: true}
+}
+
+func nullInt32ToPtr(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	v := value.Int32
+	return &v
+}
@@ -10,6 +10,16 @@ import (
 )
 
 func (r *LibraryRepository) CreateExamPart(ctx context.Context, examPart *entity.ExamPart) error {
+	_, err := r.Queries.CreateExamPart(ctx, createExamPartParams(examPart))
+	if err != nil {
+		logger.Error("LibraryRepository.CreateExamPart: failed to create exam part",
+			"error", err)
+		return err
+	}
+	return nil
+}
+
+func createExamPartParams(examPart *entity.ExamPart) database.CreateExamPartParams {
 	var (
 		ExamID              uuid.NullUUID
 		PartTitle           string
@@ -35,7 +45,7 @@ func (r *LibraryRepository) CreateExamPart(ctx context.Context, examPart *entity
 		ToeicPartNumber = sql.NullInt32{Int32: examPart.ToeicPartNumber, Valid: true}
 	}
 
-	_, err := r.Queries.CreateExamPart(ctx, database.CreateExamPartParams{
+	return database.CreateExamPartParams{
 		ExamID:              ExamID,
 		PartTitle:           PartTitle,
 		PartOrder:           PartOrder,
@@ -43,13 +53,7 @@ func (r *LibraryRepository) CreateExamPart(ctx context.Context, examPart *entity
 		IsPracticeComponent: IsPracticeComponent,
 		PlanType:            PlanType,
 		ToeicPartNumber:     ToeicPartNumber,
-	})
-	if err != nil {
-		logger.Error("LibraryRepository.CreateExamPart: failed to create exam part",
-			"error", err)
-		return err
 	}
-	return nil
 }
 
 func (r *LibraryRepository) UpdateExamPart(ctx context.Context, examPart *entity.ExamPart, examPartId uuid.UUID) error {
@@ -108,6 +112,7 @@ func (r *LibraryRepository) GetExamPart(ctx context.Context, examPartId uuid.UUI
 
 	return &entity.ExamPart{
 		PartID:              dbExamPart.PartID,
+		ExamID:              dbExamPart.ExamID.UUID,
 		PartTitle:           dbExamPart.PartTitle,
 		PartOrder:           dbExamPart.PartOrder.Int32,
 		Description:         dbExamPart.Description.String,
@@ -116,13 +121,18 @@ func (r *LibraryRepository) GetExamPart(ctx context.Context, examPartId uuid.UUI
 		CreatedAt:           dbExamPart.CreatedAt.Time,
 		UpdatedAt:           dbExamPart.UpdatedAt.Time,
 		ToeicPartNumber:     dbExamPart.ToeicPartNumber.Int32,
+		Status:              dbExamPart.Status,
+		PublishedAt:         nullTimeToPtr(dbExamPart.PublishedAt),
 	}, nil
 }
 
-func (r *LibraryRepository) GetPracticeExamParts(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedExamPart, error) {
-	totalItems, err := r.Queries.GetPracticeExamPartCount(ctx)
+// GetPracticeExamParts lists practice parts, optionally only those in the given status; an empty status lists all of them.
+func (r *LibraryRepository) GetPracticeExamParts(ctx context.Context, status string, pageNumber, pageSize int) (*entity.PaginatedExamPart, error) {
+	statusFilter := sql.NullString{String: status, Valid: status != ""}
+	totalItems, err := r.Queries.GetPracticeExamPartCount(ctx, statusFilter)
 	if err != nil {
 		logger.Error("LibraryRepository.GetExamParts: failed to get total count of exam parts",
+			"status", status,
 			"page_number", pageNumber,
 			"page_size", pageSize,
 			"error", err)
@@ -133,6 +143,7 @@ func (r *LibraryRepository) GetPracticeExamParts(ctx context.Context, pageNumber
 	listParams := database.GetPaginatedPracticeExamPartsParams{
 		Limit:  int32(pageSize),
 		Offset: int32(offset),
+		Status: statusFilter,
 	}
 
 	dbExamParts, err := r.Queries.GetPaginatedPracticeExamParts(ctx, listParams)
@@ -157,6 +168,8 @@ func (r *LibraryRepository) GetPracticeExamParts(ctx context.Context, pageNumber
 			CreatedAt:           dbExamPart.CreatedAt.Time,
 			UpdatedAt:           dbExamPart.UpdatedAt.Time,
 			ToeicPartNumber:     dbExamPart.ToeicPartNumber.Int32,
+			Status:              dbExamPart.Status,
+			PublishedAt:         nullTimeToPtr(dbExamPart.PublishedAt),
 		}
 		examParts = append(examParts, examPart)
 	}
@@ -189,6 +202,8 @@ func (r *LibraryRepository) GetExamPartsByExamId(ctx context.Context, examPartId
 			CreatedAt:           dbExamPart.CreatedAt.Time,
 			UpdatedAt:           dbExamPart.UpdatedAt.Time,
 			ToeicPartNumber:     dbExamPart.ToeicPartNumber.Int32,
+			Status:              dbExamPart.Status,
+			PublishedAt:         nullTimeToPtr(dbExamPart.PublishedAt),
 		}
 		examParts = append(examParts, examPart)
 	}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/library/entity"
+)
+
+func (r *LibraryRepository) GetQuestionExplanations(ctx context.Context, questionId uuid.UUID) ([]*entity.QuestionExplanation, error) {
+	explanationsDB, err := r.Queries.ListQuestionExplanations(ctx, questionId)
+	if err != nil {
+		logger.Error("LibraryRepository.GetQuestionExplanations: failed to get explanations", "question_id", questionId, "error", err)
+		return nil, err
+	}
+	explanations := make([]*entity.QuestionExplanation, 0, len(explanationsDB))
+	for _, explanationDB := range explanationsDB {
+		explanations = append(explanations, toQuestionExplanationEntity(explanationDB))
+	}
+	return explanations, nil
+}
+
+// SaveQuestionExplanation creates or replaces the explanations of a question in one language.
+func (r *LibraryRepository) SaveQuestionExplanation(ctx context.Context, explanation *entity.QuestionExplanation, editorId uuid.UUID) (*entity.QuestionExplanation, error) {
+	optionExplanations := explanation.OptionExplanations
+	if optionExplanations == nil {
+		optionExplanations = map[string]string{}
+	}
+	optionsJSON, err := json.Marshal(optionExplanations)
+	if err != nil {
+		return nil, err
+	}
+	explanationDB, err := r.Queries.UpsertQuestionExplanation(ctx, database.UpsertQuestionExplanationParams{
+		QuestionID:         explanation.QuestionID,
+		Lang:               explanation.Lang,
+		Explanation:        explanation.Explanation,
+		OptionExplanations: optionsJSON,
+		UpdatedBy:          uuid.NullUUID{UUID: editorId, Valid: editorId != uuid.Nil},
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.SaveQuestionExplanation: failed to save explanation", "question_id", explanation.QuestionID, "lang", explanation.Lang, "error", err)
+		return nil, err
+	}
+	return toQuestionExplanationEntity(explanationDB), nil
+}
+
+// DeleteQuestionExplanation reports whether the question had explanations in that language.
+func (r *LibraryRepository) DeleteQuestionExplanation(ctx context.Context, questionId uuid.UUID, lang string) (bool, error) {
+	rows, err := r.Queries.DeleteQuestionExplanation(ctx, database.DeleteQuestionExplanationParams{
+		QuestionID: questionId,
+		Lang:       lang,
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.DeleteQuestionExplanation: failed to delete explanation", "question_id", questionId, "lang", lang, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toQuestionExplanationEntity(explanationDB database.QuestionExplanation) *entity.QuestionExplanation {
+	optionExplanations := map[string]string{}
+	if len(explanationDB.OptionExplanations) > 0 {
+		if err := json.Unmarshal(explanationDB.OptionExplanations, &optionExplanations); err != nil {
+			logger.Warn("LibraryRepository: malformed option explanations", "question_id", explanationDB.QuestionID, "lang", explanationDB.Lang, "error", err)
+		}
+	}
+	return &entity.QuestionExplanation{
+		QuestionID:         explanationDB.QuestionID,
+		Lang:               explanationDB.Lang,
+		Explanation:        explanationDB.Explanation,
+		OptionExplanations: optionExplanations,
+		UpdatedBy:          explanationDB.UpdatedBy.UUID,
+		UpdatedAt:          explanationDB.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/library/entity"
+)
+
+// ImportExamTree inserts an exam with all of its content in one transaction and records the first
+// revision of every paragraph and question. The ids of the new rows are set on the tree. An error about
+// a single item is an *entity.ImportError naming it.
+func (r *LibraryRepository) ImportExamTree(ctx context.Context, tree *entity.ExamTree, editorId uuid.UUID) error {
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		examId, err := qtx.CreateExam(ctx, createExamParams(tree.Exam))
+		if err != nil {
+			return &entity.ImportError{Item: "exam", Err: err}
+		}
+		tree.Exam.ExamID = examId
+
+		for i, partTree := range tree.Parts {
+			item := fmt.Sprintf("parts[%d]", i)
+			partTree.Part.ExamID = examId
+			partId, err := qtx.CreateExamPart(ctx, createExamPartParams(partTree.Part))
+			if err != nil {
+				return &entity.ImportError{Item: item, Err: err}
+			}
+			partTree.Part.PartID = partId
+
+			for j, paragraphTree := range partTree.Paragraphs {
+				paragraphItem := fmt.Sprintf("%s.paragraphs[%d]", item, j)
+				paragraphTree.Paragraph.PartID = partId
+				paragraphId, err := qtx.CreateParagraph(ctx, createParagraphParams(paragraphTree.Paragraph))
+				if err != nil {
+					return &entity.ImportError{Item: paragraphItem, Err: err}
+				}
+				if _, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, 0); err != nil {
+					return &entity.ImportError{Item: paragraphItem, Err: err}
+				}
+				paragraphTree.Paragraph.ParagraphID = paragraphId
+
+				for k, question := range paragraphTree.Questions {
+					question.PartID = partId
+					question.ParagraphID = paragraphId
+					if err := importQuestion(ctx, qtx, question, editorId); err != nil {
+						return &entity.ImportError{Item: fmt.Sprintf("%s.questions[%d]", paragraphItem, k), Err: err}
+					}
+				}
+			}
+			for k, question := range partTree.Questions {
+				question.PartID = partId
+				if err := importQuestion(ctx, qtx, question, editorId); err != nil {
+					return &entity.ImportError{Item: fmt.Sprintf("%s.questions[%d]", item, k), Err: err}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.ImportExamTree: failed to import exam", "exam_title", tree.Exam.ExamTitle, "error", err)
+		return err
+	}
+	return nil
+}
+
+func importQuestion(ctx context.Context, qtx *database.Queries, question *entity.Question, editorId uuid.UUID) error {
+	questionDB, err := qtx.CreateQuestion(ctx, createQuestionParams(question))
+	if err != nil {
+		return err
+	}
+	question.QuestionID = questionDB.QuestionID
+	_, err = recordQuestionRevision(ctx, qtx, questionDB.QuestionID, editorId, 0)
+	return err
+}
+
+// ImportQuestions inserts questions into existing parts and paragraphs in one transaction and records
+// the first revision of each. An error about a single question is an *entity.ImportError whose Item is
+// "questions[i]", i being its index in questions.
+func (r *LibraryRepository) ImportQuestions(ctx context.Context, questions []*entity.Question, editorId uuid.UUID) error {
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		for i, question := range questions {
+			if err := importQuestion(ctx, qtx, question, editorId); err != nil {
+				return &entity.ImportError{Item: fmt.Sprintf("questions[%d]", i), Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.ImportQuestions: failed to import questions", "count", len(questions), "error", err)
+		return err
+	}
+	return nil
+}
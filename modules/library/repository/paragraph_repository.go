@@ -9,7 +9,23 @@ import (
 	"pirate-lang-go/modules/library/entity"
 )
 
-func (r *LibraryRepository) CreateParagraph(ctx context.Context, paragraph *entity.Paragraph) error {
+func (r *LibraryRepository) CreateParagraph(ctx context.Context, paragraph *entity.Paragraph, editorId uuid.UUID) error {
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		paragraphId, err := qtx.CreateParagraph(ctx, createParagraphParams(paragraph))
+		if err != nil {
+			return err
+		}
+		_, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, 0)
+		return err
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.CreateParagraph: failed to create paragraph", "error", err)
+		return err
+	}
+	return nil
+}
+
+func createParagraphParams(paragraph *entity.Paragraph) database.CreateParagraphParams {
 	var (
 		Title         sql.NullString
 		ParagraphType sql.NullString
@@ -30,7 +46,7 @@ func (r *LibraryRepository) CreateParagraph(ctx context.Context, paragraph *enti
 		ImageUrl = sql.NullString{String: paragraph.ImageUrl, Valid: true}
 	}
 
-	_, err := r.Queries.CreateParagraph(ctx, database.CreateParagraphParams{
+	return database.CreateParagraphParams{
 		ParagraphContent: paragraph.ParagraphContent,
 		Title:            Title,
 		PartID:           paragraph.PartID,
@@ -38,15 +54,10 @@ func (r *LibraryRepository) CreateParagraph(ctx context.Context, paragraph *enti
 		ParagraphType:    ParagraphType,
 		AudioUrl:         AudioUrl,
 		ImageUrl:         ImageUrl,
-	})
-	if err != nil {
-		logger.Error("LibraryRepository.CreateParagraph: failed to create paragraph", "error", err)
-		return err
 	}
-	return nil
 }
 
-func (r *LibraryRepository) UpdateParagraph(ctx context.Context, paragraph *entity.Paragraph, paragraphId uuid.UUID) error {
+func (r *LibraryRepository) UpdateParagraph(ctx context.Context, paragraph *entity.Paragraph, paragraphId, editorId uuid.UUID) error {
 	var (
 		Title         sql.NullString
 		ParagraphType sql.NullString
@@ -59,13 +70,20 @@ func (r *LibraryRepository) UpdateParagraph(ctx context.Context, paragraph *enti
 		ParagraphType = sql.NullString{String: paragraph.ParagraphType, Valid: true}
 	}
 
-	err := r.Queries.UpdateParagraph(ctx, database.UpdateParagraphParams{
-		ParagraphContent: paragraph.ParagraphContent,
-		Title:            Title,
-		PartID:           paragraph.PartID,
-		ParagraphOrder:   paragraph.ParagraphOrder,
-		ParagraphType:    ParagraphType,
-		ParagraphID:      paragraphId,
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		err := qtx.UpdateParagraph(ctx, database.UpdateParagraphParams{
+			ParagraphContent: paragraph.ParagraphContent,
+			Title:            Title,
+			PartID:           paragraph.PartID,
+			ParagraphOrder:   paragraph.ParagraphOrder,
+			ParagraphType:    ParagraphType,
+			ParagraphID:      paragraphId,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, 0)
+		return err
 	})
 	if err != nil {
 		logger.Error("LibraryRepository.UpdateParagraph: failed to update paragraph",
@@ -133,16 +151,23 @@ func (r *LibraryRepository) GetParagraphsByPartId(ctx context.Context, partId uu
 
 	return paragraphs, nil
 }
-func (r *LibraryRepository) UpdateAudioParagraph(ctx context.Context, audioUrl *string, paragraphId uuid.UUID) error {
+func (r *LibraryRepository) UpdateAudioParagraph(ctx context.Context, audioUrl *string, paragraphId, editorId uuid.UUID) error {
 	var (
 		Url sql.NullString
 	)
 	if audioUrl != nil {
 		Url = sql.NullString{String: *audioUrl, Valid: true}
 	}
-	err := r.Queries.UpdateParagraphAudioURL(ctx, database.UpdateParagraphAudioURLParams{
-		AudioUrl:    Url,
-		ParagraphID: paragraphId,
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		err := qtx.UpdateParagraphAudioURL(ctx, database.UpdateParagraphAudioURLParams{
+			AudioUrl:    Url,
+			ParagraphID: paragraphId,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, 0)
+		return err
 	})
 	if err != nil {
 		logger.Error("LibraryRepository:UpdateAudioParagraph: failed to update audio content for group",
@@ -154,16 +179,23 @@ func (r *LibraryRepository) UpdateAudioParagraph(ctx context.Context, audioUrl *
 	return nil
 }
 
-func (r *LibraryRepository) UpdateImageParagraph(ctx context.Context, imageUrl *string, paragraphId uuid.UUID) error {
+func (r *LibraryRepository) UpdateImageParagraph(ctx context.Context, imageUrl *string, paragraphId, editorId uuid.UUID) error {
 	var (
 		Url sql.NullString
 	)
 	if imageUrl != nil {
 		Url = sql.NullString{String: *imageUrl, Valid: true}
 	}
-	err := r.Queries.UpdateParagraphImageURL(ctx, database.UpdateParagraphImageURLParams{
-		ImageUrl:    Url,
-		ParagraphID: paragraphId,
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		err := qtx.UpdateParagraphImageURL(ctx, database.UpdateParagraphImageURLParams{
+			ImageUrl:    Url,
+			ParagraphID: paragraphId,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, 0)
+		return err
 	})
 	if err != nil {
 		logger.Error("LibraryRepository.UpdateImageGroup: failed to update image content for group",
@@ -11,7 +11,7 @@ import (
 )
 
 func (r *LibraryRepository) GetQuestionsByParagraph(ctx context.Context, paragraphId uuid.UUID) ([]*entity.Question, error) {
-	questionDBs, err := r.Queries.ListQuestionsByParagraphID(ctx, uuid.NullUUID{UUID: paragraphId})
+	questionDBs, err := r.Queries.ListQuestionsByParagraphID(ctx, uuid.NullUUID{UUID: paragraphId, Valid: true})
 	if err != nil {
 		logger.Error("LibraryRepository:UpdateQuestionGroup: failed to get questions from group",
 			"group_id", paragraphId,
@@ -31,6 +31,10 @@ func (r *LibraryRepository) GetQuestionsByParagraph(ctx context.Context, paragra
 			ToeicQuestionSection: questionDB.ToeicQuestionSection,
 			QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
 			QuestionType:         questionDB.QuestionType,
+			AnswerOption:         string(questionDB.AnswerOption.RawMessage),
+			CorrectAnswer:        questionDB.CorrectAnswer.String,
+			PreparationSeconds:   nullInt32ToPtr(questionDB.PreparationSeconds),
+			ResponseSeconds:      nullInt32ToPtr(questionDB.ResponseSeconds),
 		}
 		questions = append(questions, question)
 	}
@@ -64,13 +68,17 @@ func (r *LibraryRepository) GetSeparateQuestionsByPart(ctx context.Context, part
 			QuestionID:           questionDB.QuestionID,
 			QuestionContent:      questionDB.QuestionContent,
 			PartID:               questionDB.PartID,
-			ParagraphID:          partId,
+			ParagraphID:          questionDB.ParagraphID.UUID,
 			QuestionOrder:        questionDB.QuestionOrder,
 			AudioUrl:             questionDB.AudioUrl.String,
 			ImageUrl:             questionDB.ImageUrl.String,
 			ToeicQuestionSection: questionDB.ToeicQuestionSection,
 			QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
 			QuestionType:         questionDB.QuestionType,
+			AnswerOption:         string(questionDB.AnswerOption.RawMessage),
+			CorrectAnswer:        questionDB.CorrectAnswer.String,
+			PreparationSeconds:   nullInt32ToPtr(questionDB.PreparationSeconds),
+			ResponseSeconds:      nullInt32ToPtr(questionDB.ResponseSeconds),
 		}
 		questions = append(questions, question)
 	}
@@ -83,8 +91,32 @@ func (r *LibraryRepository) GetSeparateQuestionsByPart(ctx context.Context, part
 		PageSize:    pageSize,
 	}, nil
 }
-func (r *LibraryRepository) CreateQuestion(ctx context.Context, questionRequest *entity.Question) (*entity.Question, error) {
+func (r *LibraryRepository) CreateQuestion(ctx context.Context, questionRequest *entity.Question, editorId uuid.UUID) (*entity.Question, error) {
+	params := createQuestionParams(questionRequest)
+	var question *entity.Question
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		questionDB, err := qtx.CreateQuestion(ctx, params)
+		if err != nil {
+			return err
+		}
+		if _, err = recordQuestionRevision(ctx, qtx, questionDB.QuestionID, editorId, 0); err != nil {
+			return err
+		}
+		createdDB, err := qtx.GetQuestionByID(ctx, questionDB.QuestionID)
+		if err != nil {
+			return err
+		}
+		question = toQuestionEntity(createdDB)
+		return nil
+	})
+	if err != nil {
+		logger.Error("LibraryRepository:CreateQuestion: failed to create question", "part_id", questionRequest.PartID, "error", err)
+		return nil, err
+	}
+	return question, nil
+}
 
+func createQuestionParams(questionRequest *entity.Question) database.CreateQuestionParams {
 	var (
 		questionContent      string
 		questionType         string
@@ -95,6 +127,8 @@ func (r *LibraryRepository) CreateQuestion(ctx context.Context, questionRequest
 		questionNumberInPart sql.NullInt32
 		answerOption         pqtype.NullRawMessage
 		correctAnswer        sql.NullString
+		audioUrl             sql.NullString
+		imageUrl             sql.NullString
 	)
 	questionContent = questionRequest.QuestionContent
 	questionType = questionRequest.QuestionType
@@ -105,33 +139,30 @@ func (r *LibraryRepository) CreateQuestion(ctx context.Context, questionRequest
 	answerOption = pqtype.NullRawMessage{RawMessage: []byte(questionRequest.AnswerOption), Valid: questionRequest.AnswerOption != ""}
 	correctAnswer = sql.NullString{String: questionRequest.CorrectAnswer, Valid: true}
 	questionNumberInPart = sql.NullInt32{Int32: questionRequest.QuestionNumberInPart, Valid: true}
-	params := database.CreateQuestionParams{
+	if questionRequest.AudioUrl != "" {
+		audioUrl = sql.NullString{String: questionRequest.AudioUrl, Valid: true}
+	}
+	if questionRequest.ImageUrl != "" {
+		imageUrl = sql.NullString{String: questionRequest.ImageUrl, Valid: true}
+	}
+	return database.CreateQuestionParams{
 		QuestionContent:      questionContent,
 		QuestionType:         questionType,
 		PartID:               partID,
 		ParagraphID:          paragraphID,
 		QuestionOrder:        questionOrder,
+		AudioUrl:             audioUrl,
+		ImageUrl:             imageUrl,
 		ToeicQuestionSection: toeicQuestionSection,
 		QuestionNumberInPart: questionNumberInPart,
 		AnswerOption:         answerOption,
 		CorrectAnswer:        correctAnswer,
+		PreparationSeconds:   int32PtrToNull(questionRequest.PreparationSeconds),
+		ResponseSeconds:      int32PtrToNull(questionRequest.ResponseSeconds),
 	}
-	questionDB, err := r.Queries.CreateQuestion(ctx, params)
-	if err != nil {
-		logger.Error("LibraryRepository:CreateQuestion: failed to create question")
-	}
-	return &entity.Question{
-		QuestionID:           questionDB.QuestionID,
-		QuestionOrder:        questionDB.QuestionOrder,
-		QuestionContent:      questionDB.QuestionContent,
-		PartID:               questionDB.PartID,
-		ParagraphID:          questionDB.PartID,
-		QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
-		QuestionType:         questionDB.QuestionType,
-	}, nil
 }
 
-func (r *LibraryRepository) UpdateQuestion(ctx context.Context, questionRequest *entity.Question, questionId uuid.UUID) error {
+func (r *LibraryRepository) UpdateQuestion(ctx context.Context, questionRequest *entity.Question, questionId, editorId uuid.UUID) error {
 
 	var (
 		questionContent      string
@@ -164,10 +195,18 @@ func (r *LibraryRepository) UpdateQuestion(ctx context.Context, questionRequest
 		QuestionNumberInPart: questionNumberInPart,
 		AnswerOption:         answerOption,
 		CorrectAnswer:        correctAnswer,
+		PreparationSeconds:   int32PtrToNull(questionRequest.PreparationSeconds),
+		ResponseSeconds:      int32PtrToNull(questionRequest.ResponseSeconds),
 	}
-	err := r.Queries.UpdateQuestion(ctx, params)
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		if err := qtx.UpdateQuestion(ctx, params); err != nil {
+			return err
+		}
+		_, err := recordQuestionRevision(ctx, qtx, questionId, editorId, 0)
+		return err
+	})
 	if err != nil {
-		logger.Error("LibraryRepository:CreateQuestion: failed to create question")
+		logger.Error("LibraryRepository:UpdateQuestion: failed to update question", "question_id", questionId, "error", err)
 		return err
 	}
 	return nil
@@ -176,20 +215,12 @@ func (r *LibraryRepository) GetQuestion(ctx context.Context, questionId uuid.UUI
 
 	questionDB, err := r.Queries.GetQuestionByID(ctx, questionId)
 	if err != nil {
-		logger.Error("LibraryRepository:CreateQuestion: failed to create question")
+		logger.Error("LibraryRepository:GetQuestion: failed to get question", "question_id", questionId, "error", err)
 		return nil, err
 	}
-	return &entity.Question{
-		QuestionID:           questionDB.QuestionID,
-		QuestionOrder:        questionDB.QuestionOrder,
-		QuestionContent:      questionDB.QuestionContent,
-		PartID:               questionDB.PartID,
-		ParagraphID:          questionDB.PartID,
-		QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
-		QuestionType:         questionDB.QuestionType,
-	}, nil
+	return toQuestionEntity(questionDB), nil
 }
-func (r *LibraryRepository) UpdateQuestionAudioUrl(ctx context.Context, url *string, questionId uuid.UUID) error {
+func (r *LibraryRepository) UpdateQuestionAudioUrl(ctx context.Context, url *string, questionId, editorId uuid.UUID) error {
 	var audioUrl sql.NullString
 
 	if url != nil {
@@ -201,13 +232,20 @@ func (r *LibraryRepository) UpdateQuestionAudioUrl(ctx context.Context, url *str
 		QuestionID: questionId,
 		AudioUrl:   audioUrl,
 	}
-	err := r.Queries.UpdateQuestionAudioURL(ctx, params)
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		if err := qtx.UpdateQuestionAudioURL(ctx, params); err != nil {
+			return err
+		}
+		_, err := recordQuestionRevision(ctx, qtx, questionId, editorId, 0)
+		return err
+	})
 	if err != nil {
-		logger.Error("LibraryRepository:CreateQuestion: failed to create question")
+		logger.Error("LibraryRepository:UpdateQuestionAudioUrl: failed to update audio url", "question_id", questionId, "error", err)
+		return err
 	}
 	return nil
 }
-func (r *LibraryRepository) UpdateQuestionImageUrl(ctx context.Context, url *string, questionId uuid.UUID) error {
+func (r *LibraryRepository) UpdateQuestionImageUrl(ctx context.Context, url *string, questionId, editorId uuid.UUID) error {
 	var imageUrl sql.NullString
 
 	if url != nil {
@@ -219,9 +257,16 @@ func (r *LibraryRepository) UpdateQuestionImageUrl(ctx context.Context, url *str
 		QuestionID: questionId,
 		ImageUrl:   imageUrl,
 	}
-	err := r.Queries.UpdateQuestionImageURL(ctx, params)
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		if err := qtx.UpdateQuestionImageURL(ctx, params); err != nil {
+			return err
+		}
+		_, err := recordQuestionRevision(ctx, qtx, questionId, editorId, 0)
+		return err
+	})
 	if err != nil {
-		logger.Error("LibraryRepository:CreateQuestion: failed to create question")
+		logger.Error("LibraryRepository:UpdateQuestionImageUrl: failed to update image url", "question_id", questionId, "error", err)
+		return err
 	}
 	return nil
 }
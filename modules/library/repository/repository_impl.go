@@ -6,15 +6,18 @@ import (
 	"github.com/google/uuid"
 	"pirate-lang-go/internal/database"
 	"pirate-lang-go/modules/library/entity"
+	"time"
 )
 
 type LibraryRepository struct {
 	Queries *database.Queries
+	db      *sql.DB
 }
 
 func NewLibraryRepository(sqlDB *sql.DB) ILibraryRepository {
 	return &LibraryRepository{
 		Queries: database.New(sqlDB),
+		db:      sqlDB,
 	}
 }
 
@@ -22,7 +25,7 @@ type ILibraryRepository interface {
 	CreateExam(ctx context.Context, exam *entity.Exam) error
 	UpdateExam(ctx context.Context, exam *entity.Exam, examId uuid.UUID) error
 	GetExam(ctx context.Context, examId uuid.UUID) (*entity.Exam, error)
-	GetExams(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedExams, error)
+	GetExams(ctx context.Context, status string, pageNumber, pageSize int) (*entity.PaginatedExams, error)
 	//CreateGroupGroup(ctx context.Context, group *entity.QuestionGroup) (*uuid.UUID, error)
 	//GetQuestionGroups(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedQuestionGroup, error)
 	//GetAudioGroup(ctx context.Context, groupId uuid.UUID) (string, error)
@@ -35,19 +38,42 @@ type ILibraryRepository interface {
 	CreateExamPart(ctx context.Context, examPart *entity.ExamPart) error
 	UpdateExamPart(ctx context.Context, examPart *entity.ExamPart, examPartId uuid.UUID) error
 	GetExamPart(ctx context.Context, examPartId uuid.UUID) (*entity.ExamPart, error)
-	GetPracticeExamParts(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedExamPart, error)
+	GetPracticeExamParts(ctx context.Context, status string, pageNumber, pageSize int) (*entity.PaginatedExamPart, error)
 	GetExamPartsByExamId(ctx context.Context, examId uuid.UUID) ([]*entity.ExamPart, error)
-	CreateParagraph(ctx context.Context, paragraph *entity.Paragraph) error
-	UpdateParagraph(ctx context.Context, paragraph *entity.Paragraph, paragraphId uuid.UUID) error
+	CreateParagraph(ctx context.Context, paragraph *entity.Paragraph, editorId uuid.UUID) error
+	UpdateParagraph(ctx context.Context, paragraph *entity.Paragraph, paragraphId, editorId uuid.UUID) error
 	GetParagraph(ctx context.Context, paragraphId uuid.UUID) (*entity.Paragraph, error)
 	GetParagraphsByPartId(ctx context.Context, partId uuid.UUID) ([]*entity.Paragraph, error)
-	UpdateAudioParagraph(ctx context.Context, audioUrl *string, paragraphId uuid.UUID) error
-	UpdateImageParagraph(ctx context.Context, imageUrl *string, paragraphId uuid.UUID) error
+	UpdateAudioParagraph(ctx context.Context, audioUrl *string, paragraphId, editorId uuid.UUID) error
+	UpdateImageParagraph(ctx context.Context, imageUrl *string, paragraphId, editorId uuid.UUID) error
 	GetQuestionsByParagraph(ctx context.Context, paragraphId uuid.UUID) ([]*entity.Question, error)
 	GetSeparateQuestionsByPart(ctx context.Context, partId uuid.UUID, pageNumber, pageSize int) (*entity.PaginatedQuestion, error)
-	CreateQuestion(ctx context.Context, questionRequest *entity.Question) (*entity.Question, error)
-	UpdateQuestion(ctx context.Context, questionRequest *entity.Question, questionId uuid.UUID) error
-	UpdateQuestionAudioUrl(ctx context.Context, url *string, questionId uuid.UUID) error
-	UpdateQuestionImageUrl(ctx context.Context, url *string, questionId uuid.UUID) error
+	CreateQuestion(ctx context.Context, questionRequest *entity.Question, editorId uuid.UUID) (*entity.Question, error)
+	UpdateQuestion(ctx context.Context, questionRequest *entity.Question, questionId, editorId uuid.UUID) error
+	UpdateQuestionAudioUrl(ctx context.Context, url *string, questionId, editorId uuid.UUID) error
+	UpdateQuestionImageUrl(ctx context.Context, url *string, questionId, editorId uuid.UUID) error
 	GetQuestion(ctx context.Context, questionId uuid.UUID) (*entity.Question, error)
+	GetExamTree(ctx context.Context, examId uuid.UUID) (*entity.ExamTree, error)
+	GetPartTrees(ctx context.Context, parts []*entity.ExamPart) ([]*entity.PartTree, error)
+	ChangeStatus(ctx context.Context, change *entity.StatusChange) (bool, error)
+	GetStatusChanges(ctx context.Context, contentType string, contentId uuid.UUID) ([]*entity.StatusChange, error)
+	GetQuestionRevisions(ctx context.Context, questionId uuid.UUID) ([]*entity.QuestionRevision, error)
+	RestoreQuestionRevision(ctx context.Context, questionId, revisionId, editorId uuid.UUID) (*entity.QuestionRevision, error)
+	GetQuestionExplanations(ctx context.Context, questionId uuid.UUID) ([]*entity.QuestionExplanation, error)
+	SaveQuestionExplanation(ctx context.Context, explanation *entity.QuestionExplanation, editorId uuid.UUID) (*entity.QuestionExplanation, error)
+	DeleteQuestionExplanation(ctx context.Context, questionId uuid.UUID, lang string) (bool, error)
+	GetParagraphRevisions(ctx context.Context, paragraphId uuid.UUID) ([]*entity.ParagraphRevision, error)
+	RestoreParagraphRevision(ctx context.Context, paragraphId, revisionId, editorId uuid.UUID) (*entity.ParagraphRevision, error)
+	ImportExamTree(ctx context.Context, tree *entity.ExamTree, editorId uuid.UUID) error
+	ImportQuestions(ctx context.Context, questions []*entity.Question, editorId uuid.UUID) error
+	TrashExam(ctx context.Context, examId, editorId uuid.UUID) (bool, error)
+	TrashExamPart(ctx context.Context, partId, editorId uuid.UUID) (bool, error)
+	TrashParagraph(ctx context.Context, paragraphId, editorId uuid.UUID) (bool, error)
+	TrashQuestion(ctx context.Context, questionId, editorId uuid.UUID) (bool, error)
+	GetTrashItems(ctx context.Context, contentType string, pageNumber, pageSize int) (*entity.PaginatedTrashItem, error)
+	GetTrashItem(ctx context.Context, contentType string, contentId uuid.UUID) (*entity.TrashItem, error)
+	RestoreTrashItem(ctx context.Context, item *entity.TrashItem) (bool, error)
+	PurgeTrash(ctx context.Context, deletedBefore time.Time) (*entity.PurgeResult, error)
+	GetMediaReferences(ctx context.Context) ([]string, error)
+	GetContentIds(ctx context.Context) ([]uuid.UUID, error)
 }
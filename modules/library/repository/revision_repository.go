@@ -0,0 +1,349 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/library/entity"
+	"strconv"
+)
+
+func (r *LibraryRepository) GetQuestionRevisions(ctx context.Context, questionId uuid.UUID) ([]*entity.QuestionRevision, error) {
+	revisionsDB, err := r.Queries.GetQuestionRevisions(ctx, questionId)
+	if err != nil {
+		logger.Error("LibraryRepository.GetQuestionRevisions: failed to get revisions", "question_id", questionId, "error", err)
+		return nil, err
+	}
+	revisions := make([]*entity.QuestionRevision, 0, len(revisionsDB))
+	for _, revisionDB := range revisionsDB {
+		revisions = append(revisions, toQuestionRevisionEntity(revisionDB))
+	}
+	return revisions, nil
+}
+
+// RestoreQuestionRevision puts a question back to the state of one of its revisions and records that
+// as a new revision, so the history is never rewritten.
+func (r *LibraryRepository) RestoreQuestionRevision(ctx context.Context, questionId, revisionId, editorId uuid.UUID) (*entity.QuestionRevision, error) {
+	var restored *entity.QuestionRevision
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		revisionDB, err := qtx.GetQuestionRevision(ctx, database.GetQuestionRevisionParams{
+			RevisionID: revisionId,
+			QuestionID: questionId,
+		})
+		if err != nil {
+			return err
+		}
+		err = qtx.RestoreQuestion(ctx, database.RestoreQuestionParams{
+			QuestionID:           questionId,
+			QuestionContent:      revisionDB.QuestionContent,
+			QuestionType:         revisionDB.QuestionType,
+			PartID:               revisionDB.PartID,
+			ParagraphID:          revisionDB.ParagraphID,
+			QuestionOrder:        revisionDB.QuestionOrder,
+			AudioUrl:             revisionDB.AudioUrl,
+			ImageUrl:             revisionDB.ImageUrl,
+			ToeicQuestionSection: revisionDB.ToeicQuestionSection,
+			QuestionNumberInPart: revisionDB.QuestionNumberInPart,
+			AnswerOption:         revisionDB.AnswerOption,
+			CorrectAnswer:        revisionDB.CorrectAnswer,
+			PreparationSeconds:   revisionDB.PreparationSeconds,
+			ResponseSeconds:      revisionDB.ResponseSeconds,
+		})
+		if err != nil {
+			return err
+		}
+		restored, err = recordQuestionRevision(ctx, qtx, questionId, editorId, revisionDB.RevisionNumber)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("LibraryRepository.RestoreQuestionRevision: failed to restore revision", "question_id", questionId, "revision_id", revisionId, "error", err)
+		}
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (r *LibraryRepository) GetParagraphRevisions(ctx context.Context, paragraphId uuid.UUID) ([]*entity.ParagraphRevision, error) {
+	revisionsDB, err := r.Queries.GetParagraphRevisions(ctx, paragraphId)
+	if err != nil {
+		logger.Error("LibraryRepository.GetParagraphRevisions: failed to get revisions", "paragraph_id", paragraphId, "error", err)
+		return nil, err
+	}
+	revisions := make([]*entity.ParagraphRevision, 0, len(revisionsDB))
+	for _, revisionDB := range revisionsDB {
+		revisions = append(revisions, toParagraphRevisionEntity(revisionDB))
+	}
+	return revisions, nil
+}
+
+// RestoreParagraphRevision puts a paragraph back to the state of one of its revisions and records that
+// as a new revision.
+func (r *LibraryRepository) RestoreParagraphRevision(ctx context.Context, paragraphId, revisionId, editorId uuid.UUID) (*entity.ParagraphRevision, error) {
+	var restored *entity.ParagraphRevision
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		revisionDB, err := qtx.GetParagraphRevision(ctx, database.GetParagraphRevisionParams{
+			RevisionID:  revisionId,
+			ParagraphID: paragraphId,
+		})
+		if err != nil {
+			return err
+		}
+		err = qtx.RestoreParagraph(ctx, database.RestoreParagraphParams{
+			ParagraphID:      paragraphId,
+			ParagraphContent: revisionDB.ParagraphContent,
+			Title:            revisionDB.Title,
+			PartID:           revisionDB.PartID,
+			ParagraphOrder:   revisionDB.ParagraphOrder,
+			ParagraphType:    revisionDB.ParagraphType,
+			AudioUrl:         revisionDB.AudioUrl,
+			ImageUrl:         revisionDB.ImageUrl,
+		})
+		if err != nil {
+			return err
+		}
+		restored, err = recordParagraphRevision(ctx, qtx, paragraphId, editorId, revisionDB.RevisionNumber)
+		return err
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("LibraryRepository.RestoreParagraphRevision: failed to restore revision", "paragraph_id", paragraphId, "revision_id", revisionId, "error", err)
+		}
+		return nil, err
+	}
+	return restored, nil
+}
+
+// withTx runs fn in a transaction committed only when fn succeeds.
+func (r *LibraryRepository) withTx(ctx context.Context, fn func(qtx *database.Queries) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if err = fn(r.Queries.WithTx(tx)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recordQuestionRevision snapshots a question after a write made in the same transaction. The row lock
+// taken by that write serializes concurrent editors, so revision numbers stay gapless. Writes that
+// change nothing record no revision, except restores.
+func recordQuestionRevision(ctx context.Context, qtx *database.Queries, questionId, editorId uuid.UUID, restoredFrom int32) (*entity.QuestionRevision, error) {
+	current, err := qtx.GetQuestionByID(ctx, questionId)
+	if err != nil {
+		return nil, err
+	}
+	var previous map[string]string
+	latest, err := qtx.GetLatestQuestionRevision(ctx, questionId)
+	switch {
+	case err == nil:
+		previous = questionFields(toQuestionRevisionEntity(latest).Question)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+	diff := diffFields(previous, questionFields(toQuestionEntity(current)))
+	if len(diff) == 0 && previous != nil && restoredFrom == 0 {
+		return toQuestionRevisionEntity(latest), nil
+	}
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+	revisionDB, err := qtx.CreateQuestionRevision(ctx, database.CreateQuestionRevisionParams{
+		Diff:         diffJSON,
+		RestoredFrom: sql.NullInt32{Int32: restoredFrom, Valid: restoredFrom != 0},
+		CreatedBy:    uuid.NullUUID{UUID: editorId, Valid: editorId != uuid.Nil},
+		QuestionID:   questionId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toQuestionRevisionEntity(revisionDB), nil
+}
+
+// recordParagraphRevision snapshots a paragraph after a write made in the same transaction.
+func recordParagraphRevision(ctx context.Context, qtx *database.Queries, paragraphId, editorId uuid.UUID, restoredFrom int32) (*entity.ParagraphRevision, error) {
+	current, err := qtx.GetParagraphByID(ctx, paragraphId)
+	if err != nil {
+		return nil, err
+	}
+	var previous map[string]string
+	latest, err := qtx.GetLatestParagraphRevision(ctx, paragraphId)
+	switch {
+	case err == nil:
+		previous = paragraphFields(toParagraphRevisionEntity(latest).Paragraph)
+	case !errors.Is(err, sql.ErrNoRows):
+		return nil, err
+	}
+	diff := diffFields(previous, paragraphFields(toParagraphEntity(current)))
+	if len(diff) == 0 && previous != nil && restoredFrom == 0 {
+		return toParagraphRevisionEntity(latest), nil
+	}
+	diffJSON, err := json.Marshal(diff)
+	if err != nil {
+		return nil, err
+	}
+	revisionDB, err := qtx.CreateParagraphRevision(ctx, database.CreateParagraphRevisionParams{
+		Diff:         diffJSON,
+		RestoredFrom: sql.NullInt32{Int32: restoredFrom, Valid: restoredFrom != 0},
+		CreatedBy:    uuid.NullUUID{UUID: editorId, Valid: editorId != uuid.Nil},
+		ParagraphID:  paragraphId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toParagraphRevisionEntity(revisionDB), nil
+}
+
+func questionFields(question *entity.Question) map[string]string {
+	return map[string]string{
+		"question_content":        question.QuestionContent,
+		"question_type":           question.QuestionType,
+		"part_id":                 uuidField(question.PartID),
+		"paragraph_id":            uuidField(question.ParagraphID),
+		"question_order":          strconv.Itoa(int(question.QuestionOrder)),
+		"audio_url":               question.AudioUrl,
+		"image_url":               question.ImageUrl,
+		"toeic_question_section":  question.ToeicQuestionSection,
+		"question_number_in_part": strconv.Itoa(int(question.QuestionNumberInPart)),
+		"answer_option":           question.AnswerOption,
+		"correct_answer":          question.CorrectAnswer,
+		"preparation_seconds":     int32PtrField(question.PreparationSeconds),
+		"response_seconds":        int32PtrField(question.ResponseSeconds),
+	}
+}
+
+func paragraphFields(paragraph *entity.Paragraph) map[string]string {
+	return map[string]string{
+		"paragraph_content": paragraph.ParagraphContent,
+		"title":             paragraph.Title,
+		"part_id":           uuidField(paragraph.PartID),
+		"paragraph_order":   strconv.Itoa(int(paragraph.ParagraphOrder)),
+		"paragraph_type":    paragraph.ParagraphType,
+		"audio_url":         paragraph.AudioUrl,
+		"image_url":         paragraph.ImageUrl,
+	}
+}
+
+// diffFields lists the fields whose value differs; every non-empty field is listed for a first revision.
+func diffFields(previous, current map[string]string) map[string]entity.FieldChange {
+	diff := make(map[string]entity.FieldChange)
+	for field, value := range current {
+		if previous[field] != value {
+			diff[field] = entity.FieldChange{From: previous[field], To: value}
+		}
+	}
+	return diff
+}
+
+func uuidField(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+func int32PtrField(value *int32) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(int(*value))
+}
+
+func toQuestionEntity(questionDB database.GetQuestionByIDRow) *entity.Question {
+	return &entity.Question{
+		QuestionID:           questionDB.QuestionID,
+		QuestionContent:      questionDB.QuestionContent,
+		QuestionType:         questionDB.QuestionType,
+		PartID:               questionDB.PartID,
+		ParagraphID:          questionDB.ParagraphID.UUID,
+		QuestionOrder:        questionDB.QuestionOrder,
+		AudioUrl:             questionDB.AudioUrl.String,
+		ImageUrl:             questionDB.ImageUrl.String,
+		ToeicQuestionSection: questionDB.ToeicQuestionSection,
+		QuestionNumberInPart: questionDB.QuestionNumberInPart.Int32,
+		AnswerOption:         string(questionDB.AnswerOption.RawMessage),
+		CorrectAnswer:        questionDB.CorrectAnswer.String,
+		PreparationSeconds:   nullInt32ToPtr(questionDB.PreparationSeconds),
+		ResponseSeconds:      nullInt32ToPtr(questionDB.ResponseSeconds),
+		CreatedAt:            questionDB.CreatedAt.Time,
+		UpdatedAt:            questionDB.UpdatedAt.Time,
+	}
+}
+
+func toParagraphEntity(paragraphDB database.GetParagraphByIDRow) *entity.Paragraph {
+	return &entity.Paragraph{
+		ParagraphID:      paragraphDB.ParagraphID,
+		ParagraphContent: paragraphDB.ParagraphContent,
+		Title:            paragraphDB.Title.String,
+		PartID:           paragraphDB.PartID,
+		ParagraphOrder:   paragraphDB.ParagraphOrder,
+		ParagraphType:    paragraphDB.ParagraphType.String,
+		AudioUrl:         paragraphDB.AudioUrl.String,
+		ImageUrl:         paragraphDB.ImageUrl.String,
+		CreatedAt:        paragraphDB.CreatedAt.Time,
+		UpdatedAt:        paragraphDB.UpdatedAt.Time,
+	}
+}
+
+func toQuestionRevisionEntity(revisionDB database.QuestionRevision) *entity.QuestionRevision {
+	diff := map[string]entity.FieldChange{}
+	if err := json.Unmarshal(revisionDB.Diff, &diff); err != nil {
+		logger.Warn("LibraryRepository: malformed question revision diff", "revision_id", revisionDB.RevisionID, "error", err)
+	}
+	return &entity.QuestionRevision{
+		RevisionID:     revisionDB.RevisionID,
+		RevisionNumber: revisionDB.RevisionNumber,
+		Question: &entity.Question{
+			QuestionID:           revisionDB.QuestionID,
+			QuestionContent:      revisionDB.QuestionContent,
+			QuestionType:         revisionDB.QuestionType,
+			PartID:               revisionDB.PartID,
+			ParagraphID:          revisionDB.ParagraphID.UUID,
+			QuestionOrder:        revisionDB.QuestionOrder,
+			AudioUrl:             revisionDB.AudioUrl.String,
+			ImageUrl:             revisionDB.ImageUrl.String,
+			ToeicQuestionSection: revisionDB.ToeicQuestionSection,
+			QuestionNumberInPart: revisionDB.QuestionNumberInPart.Int32,
+			AnswerOption:         string(revisionDB.AnswerOption.RawMessage),
+			CorrectAnswer:        revisionDB.CorrectAnswer.String,
+			PreparationSeconds:   nullInt32ToPtr(revisionDB.PreparationSeconds),
+			ResponseSeconds:      nullInt32ToPtr(revisionDB.ResponseSeconds),
+		},
+		Diff:         diff,
+		RestoredFrom: revisionDB.RestoredFrom.Int32,
+		CreatedBy:    revisionDB.CreatedBy.UUID,
+		CreatedAt:    revisionDB.CreatedAt,
+	}
+}
+
+func toParagraphRevisionEntity(revisionDB database.ParagraphRevision) *entity.ParagraphRevision {
+	diff := map[string]entity.FieldChange{}
+	if err := json.Unmarshal(revisionDB.Diff, &diff); err != nil {
+		logger.Warn("LibraryRepository: malformed paragraph revision diff", "revision_id", revisionDB.RevisionID, "error", err)
+	}
+	return &entity.ParagraphRevision{
+		RevisionID:     revisionDB.RevisionID,
+		RevisionNumber: revisionDB.RevisionNumber,
+		Paragraph: &entity.Paragraph{
+			ParagraphID:      revisionDB.ParagraphID,
+			ParagraphContent: revisionDB.ParagraphContent,
+			Title:            revisionDB.Title.String,
+			PartID:           revisionDB.PartID,
+			ParagraphOrder:   revisionDB.ParagraphOrder,
+			ParagraphType:    revisionDB.ParagraphType.String,
+			AudioUrl:         revisionDB.AudioUrl.String,
+			ImageUrl:         revisionDB.ImageUrl.String,
+		},
+		Diff:         diff,
+		RestoredFrom: revisionDB.RestoredFrom.Int32,
+		CreatedBy:    revisionDB.CreatedBy.UUID,
+		CreatedAt:    revisionDB.CreatedAt,
+	}
+}
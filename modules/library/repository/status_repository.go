@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/library/entity"
+)
+
+// ChangeStatus moves an exam or practice part from change.FromStatus to change.ToStatus and records the
+// transition in the same transaction. It returns false when the content is no longer in FromStatus.
+func (r *LibraryRepository) ChangeStatus(ctx context.Context, change *entity.StatusChange) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("LibraryRepository.ChangeStatus: failed to begin transaction", "content_id", change.ContentID, "error", err)
+		return false, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	var rows int64
+	switch change.ContentType {
+	case entity.ContentTypeExam:
+		rows, err = qtx.UpdateExamStatus(ctx, database.UpdateExamStatusParams{
+			ToStatus:   change.ToStatus,
+			ExamID:     change.ContentID,
+			FromStatus: change.FromStatus,
+		})
+	default:
+		rows, err = qtx.UpdateExamPartStatus(ctx, database.UpdateExamPartStatusParams{
+			ToStatus:   change.ToStatus,
+			PartID:     change.ContentID,
+			FromStatus: change.FromStatus,
+		})
+	}
+	if err != nil {
+		logger.Error("LibraryRepository.ChangeStatus: failed to update status",
+			"content_type", change.ContentType,
+			"content_id", change.ContentID,
+			"to_status", change.ToStatus,
+			"error", err)
+		return false, err
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	err = qtx.CreateContentStatusChange(ctx, database.CreateContentStatusChangeParams{
+		ContentType: change.ContentType,
+		ContentID:   change.ContentID,
+		FromStatus:  change.FromStatus,
+		ToStatus:    change.ToStatus,
+		ChangedBy:   uuid.NullUUID{UUID: change.ChangedBy, Valid: change.ChangedBy != uuid.Nil},
+		Note:        change.Note,
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.ChangeStatus: failed to record status change", "content_id", change.ContentID, "error", err)
+		return false, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error("LibraryRepository.ChangeStatus: failed to commit transaction", "content_id", change.ContentID, "error", err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *LibraryRepository) GetStatusChanges(ctx context.Context, contentType string, contentId uuid.UUID) ([]*entity.StatusChange, error) {
+	changesDB, err := r.Queries.GetContentStatusChanges(ctx, database.GetContentStatusChangesParams{
+		ContentType: contentType,
+		ContentID:   contentId,
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.GetStatusChanges: failed to get status changes", "content_id", contentId, "error", err)
+		return nil, err
+	}
+	changes := make([]*entity.StatusChange, 0, len(changesDB))
+	for _, changeDB := range changesDB {
+		changes = append(changes, &entity.StatusChange{
+			ChangeID:    changeDB.ChangeID,
+			ContentType: changeDB.ContentType,
+			ContentID:   changeDB.ContentID,
+			FromStatus:  changeDB.FromStatus,
+			ToStatus:    changeDB.ToStatus,
+			ChangedBy:   changeDB.ChangedBy.UUID,
+			Note:        changeDB.Note,
+			ChangedAt:   changeDB.ChangedAt,
+		})
+	}
+	return changes, nil
+}
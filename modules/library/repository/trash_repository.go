@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/library/entity"
+	"time"
+)
+
+// trashStamp marks rows deleted in one operation. Children share the deletion time of the item deleted
+// on its own, which is how a restore finds them again.
+type trashStamp struct {
+	deletedAt sql.NullTime
+	deletedBy uuid.NullUUID
+}
+
+func newTrashStamp(editorId uuid.UUID) trashStamp {
+	return trashStamp{
+		deletedAt: sql.NullTime{Time: time.Now().Truncate(time.Microsecond), Valid: true},
+		deletedBy: uuid.NullUUID{UUID: editorId, Valid: editorId != uuid.Nil},
+	}
+}
+
+// TrashExam moves an exam to the trash with its parts and their content. It returns false when the exam
+// is missing or already in the trash.
+func (r *LibraryRepository) TrashExam(ctx context.Context, examId, editorId uuid.UUID) (bool, error) {
+	stamp := newTrashStamp(editorId)
+	trashed := false
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		rows, err := qtx.TrashExam(ctx, database.TrashExamParams{
+			DeletedAt: stamp.deletedAt,
+			DeletedBy: stamp.deletedBy,
+			ExamID:    examId,
+		})
+		if err != nil || rows == 0 {
+			return err
+		}
+		partIds, err := qtx.TrashPartsOfExam(ctx, database.TrashPartsOfExamParams{
+			DeletedAt: stamp.deletedAt,
+			DeletedBy: stamp.deletedBy,
+			ExamID:    uuid.NullUUID{UUID: examId, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		trashed = true
+		return trashPartContent(ctx, qtx, partIds, stamp)
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.TrashExam: failed to move exam to the trash", "exam_id", examId, "error", err)
+		return false, err
+	}
+	return trashed, nil
+}
+
+// TrashExamPart moves a part to the trash with its paragraphs and questions. It returns false when the
+// part is missing or already in the trash.
+func (r *LibraryRepository) TrashExamPart(ctx context.Context, partId, editorId uuid.UUID) (bool, error) {
+	stamp := newTrashStamp(editorId)
+	trashed := false
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		rows, err := qtx.TrashExamPart(ctx, database.TrashExamPartParams{
+			DeletedAt: stamp.deletedAt,
+			DeletedBy: stamp.deletedBy,
+			PartID:    partId,
+		})
+		if err != nil || rows == 0 {
+			return err
+		}
+		trashed = true
+		return trashPartContent(ctx, qtx, []uuid.UUID{partId}, stamp)
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.TrashExamPart: failed to move part to the trash", "part_id", partId, "error", err)
+		return false, err
+	}
+	return trashed, nil
+}
+
+// TrashParagraph moves a paragraph to the trash with its questions. It returns false when the paragraph
+// is missing or already in the trash.
+func (r *LibraryRepository) TrashParagraph(ctx context.Context, paragraphId, editorId uuid.UUID) (bool, error) {
+	stamp := newTrashStamp(editorId)
+	trashed := false
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		rows, err := qtx.TrashParagraph(ctx, database.TrashParagraphParams{
+			DeletedAt:   stamp.deletedAt,
+			DeletedBy:   stamp.deletedBy,
+			ParagraphID: paragraphId,
+		})
+		if err != nil || rows == 0 {
+			return err
+		}
+		trashed = true
+		return qtx.TrashQuestionsOfParagraph(ctx, database.TrashQuestionsOfParagraphParams{
+			DeletedAt:   stamp.deletedAt,
+			DeletedBy:   stamp.deletedBy,
+			ParagraphID: uuid.NullUUID{UUID: paragraphId, Valid: true},
+		})
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.TrashParagraph: failed to move paragraph to the trash", "paragraph_id", paragraphId, "error", err)
+		return false, err
+	}
+	return trashed, nil
+}
+
+// TrashQuestion moves a question to the trash. It returns false when the question is missing or already
+// in the trash.
+func (r *LibraryRepository) TrashQuestion(ctx context.Context, questionId, editorId uuid.UUID) (bool, error) {
+	stamp := newTrashStamp(editorId)
+	rows, err := r.Queries.TrashQuestion(ctx, database.TrashQuestionParams{
+		DeletedAt:  stamp.deletedAt,
+		DeletedBy:  stamp.deletedBy,
+		QuestionID: questionId,
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.TrashQuestion: failed to move question to the trash", "question_id", questionId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func trashPartContent(ctx context.Context, qtx *database.Queries, partIds []uuid.UUID, stamp trashStamp) error {
+	if len(partIds) == 0 {
+		return nil
+	}
+	err := qtx.TrashParagraphsOfParts(ctx, database.TrashParagraphsOfPartsParams{
+		DeletedAt: stamp.deletedAt,
+		DeletedBy: stamp.deletedBy,
+		PartIds:   partIds,
+	})
+	if err != nil {
+		return err
+	}
+	return qtx.TrashQuestionsOfParts(ctx, database.TrashQuestionsOfPartsParams{
+		DeletedAt: stamp.deletedAt,
+		DeletedBy: stamp.deletedBy,
+		PartIds:   partIds,
+	})
+}
+
+// GetTrashItems lists the trash, most recently deleted first, optionally only one content type; an
+// empty content type lists all of them.
+func (r *LibraryRepository) GetTrashItems(ctx context.Context, contentType string, pageNumber, pageSize int) (*entity.PaginatedTrashItem, error) {
+	typeFilter := sql.NullString{String: contentType, Valid: contentType != ""}
+	totalItems, err := r.Queries.GetTrashItemsCount(ctx, typeFilter)
+	if err != nil {
+		logger.Error("LibraryRepository.GetTrashItems: failed to get total count of trash items",
+			"content_type", contentType,
+			"error", err)
+		return nil, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+	itemsDB, err := r.Queries.GetTrashItems(ctx, database.GetTrashItemsParams{
+		Limit:       int32(pageSize),
+		Offset:      int32(offset),
+		ContentType: typeFilter,
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.GetTrashItems: failed to retrieve trash items",
+			"content_type", contentType,
+			"page_number", pageNumber,
+			"page_size", pageSize,
+			"offset", offset,
+			"error", err)
+		return nil, err
+	}
+	items := make([]*entity.TrashItem, 0, len(itemsDB))
+	for _, itemDB := range itemsDB {
+		items = append(items, toTrashItemEntity(itemDB))
+	}
+	totalPages := (totalItems + int64(pageSize) - 1) / int64(pageSize)
+
+	return &entity.PaginatedTrashItem{
+		Items:       items,
+		TotalItems:  totalItems,
+		TotalPages:  totalPages,
+		CurrentPage: pageNumber,
+		PageSize:    pageSize,
+	}, nil
+}
+
+// GetTrashItem returns sql.ErrNoRows when the content is not in the trash, or was deleted along with
+// its parent rather than on its own.
+func (r *LibraryRepository) GetTrashItem(ctx context.Context, contentType string, contentId uuid.UUID) (*entity.TrashItem, error) {
+	itemDB, err := r.Queries.GetTrashItem(ctx, database.GetTrashItemParams{
+		ContentType: contentType,
+		ContentID:   contentId,
+	})
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("LibraryRepository.GetTrashItem: failed to get trash item", "content_type", contentType, "content_id", contentId, "error", err)
+		}
+		return nil, err
+	}
+	return toTrashItemEntity(itemDB), nil
+}
+
+// RestoreTrashItem takes an item out of the trash along with the children deleted with it. It returns
+// false when the item is no longer in the trash.
+func (r *LibraryRepository) RestoreTrashItem(ctx context.Context, item *entity.TrashItem) (bool, error) {
+	restored := false
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		var err error
+		restored, err = restoreTrashItem(ctx, qtx, item)
+		return err
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.RestoreTrashItem: failed to restore trash item", "content_type", item.ContentType, "content_id", item.ContentID, "error", err)
+		return false, err
+	}
+	return restored, nil
+}
+
+// PurgeTrash deletes for good the content that went to the trash before deletedBefore, children first.
+// Content that attempts still refer to stays in the trash.
+func (r *LibraryRepository) PurgeTrash(ctx context.Context, deletedBefore time.Time) (*entity.PurgeResult, error) {
+	before := sql.NullTime{Time: deletedBefore, Valid: true}
+	result := &entity.PurgeResult{}
+	err := r.withTx(ctx, func(qtx *database.Queries) error {
+		var err error
+		if result.Questions, err = qtx.PurgeTrashedQuestions(ctx, before); err != nil {
+			return err
+		}
+		if result.Paragraphs, err = qtx.PurgeTrashedParagraphs(ctx, before); err != nil {
+			return err
+		}
+		if result.Parts, err = qtx.PurgeTrashedExamParts(ctx, before); err != nil {
+			return err
+		}
+		result.Exams, err = qtx.PurgeTrashedExams(ctx, before)
+		return err
+	})
+	if err != nil {
+		logger.Error("LibraryRepository.PurgeTrash: failed to purge trash", "deleted_before", deletedBefore, "error", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetMediaReferences returns every audio and image reference held by questions, paragraphs and their
+// revisions, trashed content included.
+func (r *LibraryRepository) GetMediaReferences(ctx context.Context) ([]string, error) {
+	references, err := r.Queries.GetContentMediaReferences(ctx)
+	if err != nil {
+		logger.Error("LibraryRepository.GetMediaReferences: failed to get media references", "error", err)
+		return nil, err
+	}
+	return references, nil
+}
+
+// GetContentIds returns the ids of every question and paragraph, trashed content included.
+func (r *LibraryRepository) GetContentIds(ctx context.Context) ([]uuid.UUID, error) {
+	ids, err := r.Queries.GetContentIds(ctx)
+	if err != nil {
+		logger.Error("LibraryRepository.GetContentIds: failed to get content ids", "error", err)
+		return nil, err
+	}
+	return ids, nil
+}
+
+func restoreTrashItem(ctx context.Context, qtx *database.Queries, item *entity.TrashItem) (bool, error) {
+	deletedAt := sql.NullTime{Time: item.DeletedAt, Valid: true}
+	switch item.ContentType {
+	case entity.ContentTypeExam:
+		rows, err := qtx.RestoreTrashedExam(ctx, database.RestoreTrashedExamParams{ExamID: item.ContentID, DeletedAt: deletedAt})
+		if err != nil || rows == 0 {
+			return false, err
+		}
+		partIds, err := qtx.RestoreTrashedPartsOfExam(ctx, database.RestoreTrashedPartsOfExamParams{
+			ExamID:    uuid.NullUUID{UUID: item.ContentID, Valid: true},
+			DeletedAt: deletedAt,
+		})
+		if err != nil {
+			return false, err
+		}
+		return true, restorePartContent(ctx, qtx, partIds, deletedAt)
+	case entity.ContentTypePart:
+		rows, err := qtx.RestoreTrashedExamPart(ctx, database.RestoreTrashedExamPartParams{PartID: item.ContentID, DeletedAt: deletedAt})
+		if err != nil || rows == 0 {
+			return false, err
+		}
+		return true, restorePartContent(ctx, qtx, []uuid.UUID{item.ContentID}, deletedAt)
+	case entity.ContentTypeParagraph:
+		rows, err := qtx.RestoreTrashedParagraph(ctx, database.RestoreTrashedParagraphParams{ParagraphID: item.ContentID, DeletedAt: deletedAt})
+		if err != nil || rows == 0 {
+			return false, err
+		}
+		return true, qtx.RestoreTrashedQuestionsOfParagraph(ctx, database.RestoreTrashedQuestionsOfParagraphParams{
+			ParagraphID: uuid.NullUUID{UUID: item.ContentID, Valid: true},
+			DeletedAt:   deletedAt,
+		})
+	default:
+		rows, err := qtx.RestoreTrashedQuestion(ctx, database.RestoreTrashedQuestionParams{QuestionID: item.ContentID, DeletedAt: deletedAt})
+		return rows > 0, err
+	}
+}
+
+func restorePartContent(ctx context.Context, qtx *database.Queries, partIds []uuid.UUID, deletedAt sql.NullTime) error {
+	if len(partIds) == 0 {
+		return nil
+	}
+	err := qtx.RestoreTrashedParagraphsOfParts(ctx, database.RestoreTrashedParagraphsOfPartsParams{PartIds: partIds, DeletedAt: deletedAt})
+	if err != nil {
+		return err
+	}
+	return qtx.RestoreTrashedQuestionsOfParts(ctx, database.RestoreTrashedQuestionsOfPartsParams{PartIds: partIds, DeletedAt: deletedAt})
+}
+
+func toTrashItemEntity(itemDB database.TrashItem) *entity.TrashItem {
+	return &entity.TrashItem{
+		ContentType: itemDB.ContentType,
+		ContentID:   itemDB.ContentID,
+		Title:       itemDB.Title,
+		ParentType:  itemDB.ParentType.String,
+		ParentID:    itemDB.ParentID.UUID,
+		DeletedAt:   itemDB.DeletedAt.Time,
+		DeletedBy:   itemDB.DeletedBy.UUID,
+	}
+}
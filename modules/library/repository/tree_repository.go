@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/modules/library/entity"
+)
+
+// GetExamTree loads an exam with its parts, paragraphs and questions in four queries, whatever the
+// size of the exam. It returns sql.ErrNoRows when the exam does not exist, like GetExam.
+func (r *LibraryRepository) GetExamTree(ctx context.Context, examId uuid.UUID) (*entity.ExamTree, error) {
+	exam, err := r.GetExam(ctx, examId)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := r.GetExamPartsByExamId(ctx, examId)
+	if err != nil {
+		return nil, err
+	}
+	partTrees, err := r.GetPartTrees(ctx, parts)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ExamTree{Exam: exam, Parts: partTrees}, nil
+}
+
+// GetPartTrees loads the paragraphs and questions of the given parts with one query each and
+// returns the parts in the order given.
+func (r *LibraryRepository) GetPartTrees(ctx context.Context, parts []*entity.ExamPart) ([]*entity.PartTree, error) {
+	partTrees := make([]*entity.PartTree, 0, len(parts))
+	if len(parts) == 0 {
+		return partTrees, nil
+	}
+	partIds := make([]uuid.UUID, 0, len(parts))
+	partsById := make(map[uuid.UUID]*entity.PartTree, len(parts))
+	for _, part := range parts {
+		partTree := &entity.PartTree{
+			Part:       part,
+			Paragraphs: []*entity.ParagraphTree{},
+			Questions:  []*entity.Question{},
+		}
+		partIds = append(partIds, part.PartID)
+		partsById[part.PartID] = partTree
+		partTrees = append(partTrees, partTree)
+	}
+
+	dbParagraphs, err := r.Queries.GetParagraphsByPartIds(ctx, partIds)
+	if err != nil {
+		logger.Error("LibraryRepository.GetPartTrees: failed to retrieve paragraphs", "part_ids", partIds, "error", err)
+		return nil, err
+	}
+	paragraphsById := make(map[uuid.UUID]*entity.ParagraphTree, len(dbParagraphs))
+	for _, dbParagraph := range dbParagraphs {
+		paragraphTree := &entity.ParagraphTree{
+			Paragraph: &entity.Paragraph{
+				ParagraphID:      dbParagraph.ParagraphID,
+				ParagraphContent: dbParagraph.ParagraphContent,
+				Title:            dbParagraph.Title.String,
+				PartID:           dbParagraph.PartID,
+				ParagraphOrder:   dbParagraph.ParagraphOrder,
+				ParagraphType:    dbParagraph.ParagraphType.String,
+				AudioUrl:         dbParagraph.AudioUrl.String,
+				ImageUrl:         dbParagraph.ImageUrl.String,
+				CreatedAt:        dbParagraph.CreatedAt.Time,
+				UpdatedAt:        dbParagraph.UpdatedAt.Time,
+			},
+			Questions: []*entity.Question{},
+		}
+		paragraphsById[dbParagraph.ParagraphID] = paragraphTree
+		partTree := partsById[dbParagraph.PartID]
+		partTree.Paragraphs = append(partTree.Paragraphs, paragraphTree)
+	}
+
+	dbQuestions, err := r.Queries.GetQuestionsByPartIds(ctx, partIds)
+	if err != nil {
+		logger.Error("LibraryRepository.GetPartTrees: failed to retrieve questions", "part_ids", partIds, "error", err)
+		return nil, err
+	}
+	for _, dbQuestion := range dbQuestions {
+		question := &entity.Question{
+			QuestionID:           dbQuestion.QuestionID,
+			QuestionContent:      dbQuestion.QuestionContent,
+			QuestionType:         dbQuestion.QuestionType,
+			PartID:               dbQuestion.PartID,
+			ParagraphID:          dbQuestion.ParagraphID.UUID,
+			QuestionOrder:        dbQuestion.QuestionOrder,
+			AudioUrl:             dbQuestion.AudioUrl.String,
+			ImageUrl:             dbQuestion.ImageUrl.String,
+			ToeicQuestionSection: dbQuestion.ToeicQuestionSection,
+			QuestionNumberInPart: dbQuestion.QuestionNumberInPart.Int32,
+			AnswerOption:         string(dbQuestion.AnswerOption.RawMessage),
+			CorrectAnswer:        dbQuestion.CorrectAnswer.String,
+			PreparationSeconds:   nullInt32ToPtr(dbQuestion.PreparationSeconds),
+			ResponseSeconds:      nullInt32ToPtr(dbQuestion.ResponseSeconds),
+			CreatedAt:            dbQuestion.CreatedAt.Time,
+			UpdatedAt:            dbQuestion.UpdatedAt.Time,
+		}
+		if paragraphTree, ok := paragraphsById[question.ParagraphID]; ok && dbQuestion.ParagraphID.Valid {
+			paragraphTree.Questions = append(paragraphTree.Questions, question)
+			continue
+		}
+		partTree := partsById[question.PartID]
+		partTree.Questions = append(partTree.Questions, question)
+	}
+	return partTrees, nil
+}
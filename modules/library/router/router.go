@@ -2,8 +2,10 @@ package router
 
 import (
 	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/constants"
 	"pirate-lang-go/core/middleware"
 	"pirate-lang-go/modules/library/controller"
+	"pirate-lang-go/modules/library/entity"
 )
 
 type LibraryRouter struct {
@@ -21,47 +23,98 @@ func (r *LibraryRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
 	//public group - no middleware needed
 	public := v1.Group("/public")
 	publicExams := public.Group("/exams")
-	publicExams.GET("", r.controller.GetExams)
+	publicExams.GET("", r.controller.GetLearnerExams)
+	publicExams.GET("/:examId", r.controller.GetLearnerExam)
+	publicPracticeParts := public.Group("/practice-parts")
+	publicPracticeParts.GET("", r.controller.GetLearnerPracticeParts)
+	publicPracticeParts.GET("/:partId", r.controller.GetLearnerPracticePart)
+	// Learner routes - same read model, with SUBSCRIPTION parts unlocked for subscribers
+	auth := middleware.AuthMiddleware()
+	v1.GET("/exams/:examId", r.controller.GetLearnerExam, auth)
+	v1.GET("/practice-parts/:partId", r.controller.GetLearnerPracticePart, auth)
 	// Admin routes
 	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware())
+	canRead := middleware.PermissionMiddleware(constants.PermissionLibraryRead)
+	canWrite := middleware.PermissionMiddleware(constants.PermissionLibraryWrite)
+	canPublish := middleware.PermissionMiddleware(constants.PermissionLibraryPublish)
 	// Exam routes
 	examsAdmin := admin.Group("/exams")
-	examsAdmin.GET("", r.controller.GetExams)
-	examsAdmin.POST("", r.controller.CreateExam)
-	examsAdmin.GET("/:examId", r.controller.GetExam)
-	examsAdmin.PUT("/:examId", r.controller.UpdateExam)
-	examsAdmin.GET("/:examId/parts", r.controller.GetExamPartsByExam)
+	examsAdmin.GET("", r.controller.GetExams, canRead)
+	examsAdmin.POST("", r.controller.CreateExam, canWrite)
+	examsAdmin.POST("/import", r.controller.ImportExamPackage, canWrite)
+	examsAdmin.GET("/:examId", r.controller.GetExam, canRead)
+	examsAdmin.PUT("/:examId", r.controller.UpdateExam, canWrite)
+	examsAdmin.DELETE("/:examId", r.controller.DeleteExam, canWrite)
+	examsAdmin.GET("/:examId/parts", r.controller.GetExamPartsByExam, canRead)
+	examsAdmin.GET("/:examId/tree", r.controller.GetExamTree, canRead)
+	examsAdmin.GET("/:examId/export", r.controller.ExportExamPackage, canRead)
+	examsAdmin.GET("/:examId/export/qti", r.controller.ExportExamQTI, canRead)
+	// Publishing workflow: editors submit, publishers review, publish and archive
+	examsAdmin.GET("/:examId/publish-check", r.controller.CheckExamPublishable, canRead)
+	examsAdmin.GET("/:examId/status-history", r.controller.GetExamStatusHistory, canRead)
+	examsAdmin.POST("/:examId/submit", r.controller.TransitionExam(entity.StatusActionSubmit), canWrite)
+	examsAdmin.POST("/:examId/reject", r.controller.TransitionExam(entity.StatusActionReject), canPublish)
+	examsAdmin.POST("/:examId/publish", r.controller.TransitionExam(entity.StatusActionPublish), canPublish)
+	examsAdmin.POST("/:examId/archive", r.controller.TransitionExam(entity.StatusActionArchive), canPublish)
+	examsAdmin.POST("/:examId/restore", r.controller.TransitionExam(entity.StatusActionRestore), canPublish)
 
 	examPartsAdmin := admin.Group("/parts")
 
-	examPartsAdmin.POST("", r.controller.CreateExamPart)
-	examPartsAdmin.GET("/:partId", r.controller.GetExamPart)
-	examPartsAdmin.PUT("/:partId", r.controller.UpdateExamPart)
-	examPartsAdmin.GET("/:partId/paragraphs", r.controller.GetParagraphsByPart)
-	examPartsAdmin.GET("/:partId/questions", r.controller.GetQuestionsPart)
+	examPartsAdmin.POST("", r.controller.CreateExamPart, canWrite)
+	examPartsAdmin.GET("/:partId", r.controller.GetExamPart, canRead)
+	examPartsAdmin.PUT("/:partId", r.controller.UpdateExamPart, canWrite)
+	examPartsAdmin.DELETE("/:partId", r.controller.DeleteExamPart, canWrite)
+	examPartsAdmin.GET("/:partId/paragraphs", r.controller.GetParagraphsByPart, canRead)
+	examPartsAdmin.GET("/:partId/questions", r.controller.GetQuestionsPart, canRead)
+	examPartsAdmin.POST("/:partId/questions/import", r.controller.ImportQuestionSheet, canWrite)
 	paragraphsAdmin := admin.Group("/paragraphs")
-	paragraphsAdmin.POST("", r.controller.CreateParagraph)
-	paragraphsAdmin.GET("/:paragraphId", r.controller.GetParagraph)
-	paragraphsAdmin.PUT("/:paragraphId", r.controller.UpdateParagraph)
+	paragraphsAdmin.POST("", r.controller.CreateParagraph, canWrite)
+	paragraphsAdmin.GET("/:paragraphId", r.controller.GetParagraph, canRead)
+	paragraphsAdmin.PUT("/:paragraphId", r.controller.UpdateParagraph, canWrite)
+	paragraphsAdmin.DELETE("/:paragraphId", r.controller.DeleteParagraph, canWrite)
 
-	paragraphsAdmin.POST("/:paragraphId/audio", r.controller.UploadAudioParagraph)
-	paragraphsAdmin.POST("/:paragraphId/image", r.controller.UploadImageParagraph)
-	paragraphsAdmin.POST("/:paragraphId/transcript", r.controller.UploadTranscriptAudioParagraph)
-	paragraphsAdmin.GET("/:paragraphId/questions", r.controller.GetQuestionsParagraph)
+	paragraphsAdmin.POST("/:paragraphId/audio", r.controller.UploadAudioParagraph, canWrite)
+	paragraphsAdmin.POST("/:paragraphId/image", r.controller.UploadImageParagraph, canWrite)
+	paragraphsAdmin.POST("/:paragraphId/transcript", r.controller.UploadTranscriptAudioParagraph, canWrite)
+	paragraphsAdmin.GET("/:paragraphId/questions", r.controller.GetQuestionsParagraph, canRead)
+	paragraphsAdmin.GET("/:paragraphId/revisions", r.controller.GetParagraphRevisions, canRead)
+	paragraphsAdmin.POST("/:paragraphId/revisions/:revisionId/restore", r.controller.RestoreParagraphRevision, canWrite)
 	// Paragraph Routes
 	practicePartsAdmin := admin.Group("/practice-parts")
-	practicePartsAdmin.GET("", r.controller.GetPracticeParts)
-	practicePartsAdmin.POST("", r.controller.CreateExamPart)
-	practicePartsAdmin.GET("/:partId", r.controller.GetExamPart)
-	practicePartsAdmin.PUT("/:partId", r.controller.UpdateExamPart)
-	practicePartsAdmin.GET("/:partId/paragraphs", r.controller.GetParagraphsByPart)
-	practicePartsAdmin.GET("/:partId/questions", r.controller.GetQuestionsPart)
+	practicePartsAdmin.GET("", r.controller.GetPracticeParts, canRead)
+	practicePartsAdmin.POST("", r.controller.CreateExamPart, canWrite)
+	practicePartsAdmin.GET("/:partId", r.controller.GetExamPart, canRead)
+	practicePartsAdmin.PUT("/:partId", r.controller.UpdateExamPart, canWrite)
+	practicePartsAdmin.DELETE("/:partId", r.controller.DeleteExamPart, canWrite)
+	practicePartsAdmin.GET("/:partId/paragraphs", r.controller.GetParagraphsByPart, canRead)
+	practicePartsAdmin.GET("/:partId/questions", r.controller.GetQuestionsPart, canRead)
+	practicePartsAdmin.POST("/:partId/questions/import", r.controller.ImportQuestionSheet, canWrite)
+	practicePartsAdmin.GET("/:partId/export", r.controller.ExportPracticePartPackage, canRead)
+	practicePartsAdmin.GET("/:partId/export/qti", r.controller.ExportPracticePartQTI, canRead)
+	practicePartsAdmin.GET("/:partId/publish-check", r.controller.CheckPracticePartPublishable, canRead)
+	practicePartsAdmin.GET("/:partId/status-history", r.controller.GetPracticePartStatusHistory, canRead)
+	practicePartsAdmin.POST("/:partId/submit", r.controller.TransitionPracticePart(entity.StatusActionSubmit), canWrite)
+	practicePartsAdmin.POST("/:partId/reject", r.controller.TransitionPracticePart(entity.StatusActionReject), canPublish)
+	practicePartsAdmin.POST("/:partId/publish", r.controller.TransitionPracticePart(entity.StatusActionPublish), canPublish)
+	practicePartsAdmin.POST("/:partId/archive", r.controller.TransitionPracticePart(entity.StatusActionArchive), canPublish)
+	practicePartsAdmin.POST("/:partId/restore", r.controller.TransitionPracticePart(entity.StatusActionRestore), canPublish)
 	questions := admin.Group("/questions")
-	questions.PUT("", r.controller.CreateQuestion)
-	questions.PUT("/:questionId", r.controller.UpdateQuestion)
-	questions.POST("/:questionId/audio", r.controller.UploadAudioGroup)
-	questions.POST("/:questionId/image", r.controller.UploadImageGroup)
-	questions.POST("/:questionId/transcript", r.controller.UploadTranscriptAudioGroup)
+	questions.PUT("", r.controller.CreateQuestion, canWrite)
+	questions.PUT("/:questionId", r.controller.UpdateQuestion, canWrite)
+	questions.DELETE("/:questionId", r.controller.DeleteQuestion, canWrite)
+	questions.POST("/:questionId/audio", r.controller.UploadAudioGroup, canWrite)
+	questions.POST("/:questionId/image", r.controller.UploadImageGroup, canWrite)
+	questions.POST("/:questionId/transcript", r.controller.UploadTranscriptAudioGroup, canWrite)
+	questions.GET("/:questionId/revisions", r.controller.GetQuestionRevisions, canRead)
+	questions.POST("/:questionId/revisions/:revisionId/restore", r.controller.RestoreQuestionRevision, canWrite)
+	questions.GET("/:questionId/explanations", r.controller.GetQuestionExplanations, canRead)
+	questions.PUT("/:questionId/explanations/:lang", r.controller.SaveQuestionExplanation, canWrite)
+	questions.DELETE("/:questionId/explanations/:lang", r.controller.DeleteQuestionExplanation, canWrite)
+	// Trash: deleted content stays restorable until the background purge removes it
+	trash := admin.Group("/trash")
+	trash.GET("", r.controller.GetTrash, canRead)
+	trash.POST("/:contentType/:contentId/restore", r.controller.RestoreFromTrash, canWrite)
 	test := v1.Group("/test2")
 	test.GET("/hello", r.controller.HelloWorld)
 
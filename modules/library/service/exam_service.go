@@ -11,12 +11,12 @@ import (
 	"time"
 )
 
-func (s *LibraryService) GetExams(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedExamResponse, *errors.AppError) {
+func (s *LibraryService) GetExams(ctx context.Context, status string, pageNumber, pageSize int) (*dto.PaginatedExamResponse, *errors.AppError) {
 
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	resultGetExams, err := s.repo.GetExams(ctx, pageNumber, pageSize)
+	resultGetExams, err := s.repo.GetExams(ctx, status, pageNumber, pageSize)
 	if err != nil {
 		logger.Error("LibraryService:GetExams:Failed to get exams", "error", err)
 		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetExams:Failed to get exams", err)
@@ -36,6 +36,7 @@ func (s *LibraryService) CreateExam(ctx context.Context, dataRequest *dto.Create
 		logger.Error("LibraryService:CreateExam:Failed to create exam", "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:CreateExam:Failed to create exam", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 
@@ -44,11 +45,15 @@ func (s *LibraryService) UpdateExam(ctx context.Context, dataRequest *dto.Update
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if appErr := s.ensureExamEditable(ctx, examId); appErr != nil {
+		return appErr
+	}
 	err := s.repo.UpdateExam(ctx, mapper.ToUpdateExamEntity(dataRequest), examId)
 	if err != nil {
 		logger.Error("LibraryService:UpdateExam:Failed to update exam", "exam_id", examId, "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:UpdateExam:Failed to update exam", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 
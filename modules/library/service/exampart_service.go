@@ -15,24 +15,39 @@ func (s *LibraryService) CreateExamPart(ctx context.Context, dataRequest *dto.Cr
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if dataRequest.ExamID.Valid {
+		if appErr := s.ensureExamEditable(ctx, dataRequest.ExamID.UUID); appErr != nil {
+			return appErr
+		}
+	}
 	examPartEntity := mapper.ToCreateExamPartEntity(dataRequest)
 	err := s.repo.CreateExamPart(ctx, examPartEntity)
 	if err != nil {
 		logger.Error("LibraryService:CreateExamPart:Failed to create exam part", "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:CreateExamPart:Failed to create exam part", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 func (s *LibraryService) UpdateExamPart(ctx context.Context, dataRequest *dto.UpdateExamPartRequest, examPartId uuid.UUID) *errors.AppError {
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	if appErr := s.ensurePartEditable(ctx, examPartId); appErr != nil {
+		return appErr
+	}
+	if dataRequest.ExamID.Valid {
+		if appErr := s.ensureExamEditable(ctx, dataRequest.ExamID.UUID); appErr != nil {
+			return appErr
+		}
+	}
 	examPartEntity := mapper.ToUpdateExamPartEntity(dataRequest)
 	err := s.repo.UpdateExamPart(ctx, examPartEntity, examPartId)
 	if err != nil {
 		logger.Error("LibraryService:UpdateExamPart:Failed to update exam part", "exam_part_id", examPartId, "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:UpdateExamPart:Failed to update exam part", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 func (s *LibraryService) GetExamPart(ctx context.Context, examPartId uuid.UUID) (*dto.ExamPartResponse, *errors.AppError) {
@@ -48,11 +63,11 @@ func (s *LibraryService) GetExamPart(ctx context.Context, examPartId uuid.UUID)
 	examPartDTO := mapper.ToExamPartResponse(examPart)
 	return examPartDTO, nil
 }
-func (s *LibraryService) GetPracticeExamParts(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedExamPartResponse, *errors.AppError) {
+func (s *LibraryService) GetPracticeExamParts(ctx context.Context, status string, pageNumber, pageSize int) (*dto.PaginatedExamPartResponse, *errors.AppError) {
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	resultGetExamParts, err := s.repo.GetPracticeExamParts(ctx, pageNumber, pageSize)
+	resultGetExamParts, err := s.repo.GetPracticeExamParts(ctx, status, pageNumber, pageSize)
 	if err != nil {
 		logger.Error("LibraryService:GetExamParts:Failed to get exam parts", "error", err)
 		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetExamParts:Failed to get exam parts", err)
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"time"
+)
+
+// GetQuestionExplanations lists the explanations of a question in every language they were written in.
+func (s *LibraryService) GetQuestionExplanations(ctx context.Context, questionId uuid.UUID) ([]*dto.QuestionExplanationResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, err := s.repo.GetQuestion(ctx, questionId); err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:GetQuestionExplanations:Question not found", err)
+		}
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetQuestionExplanations:Failed to get question", err)
+	}
+	explanations, err := s.repo.GetQuestionExplanations(ctx, questionId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetQuestionExplanations:Failed to get explanations", err)
+	}
+	return mapper.ToQuestionExplanationsResponse(explanations), nil
+}
+
+// SaveQuestionExplanation writes the explanations of a question in one language, replacing the previous
+// ones. Option explanations must name options the question has.
+func (s *LibraryService) SaveQuestionExplanation(ctx context.Context, token string, questionId uuid.UUID, lang string, request *dto.QuestionExplanationRequest) (*dto.QuestionExplanationResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	question, err := s.repo.GetQuestion(ctx, questionId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:SaveQuestionExplanation:Question not found", err)
+		}
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:SaveQuestionExplanation:Failed to get question", err)
+	}
+	if appErr := s.ensurePartEditable(ctx, question.PartID); appErr != nil {
+		return nil, appErr
+	}
+	if len(request.OptionExplanations) > 0 {
+		answerOption, err := mapper.UnmarshalAnswerOption(question.AnswerOption)
+		if err != nil {
+			return nil, errors.NewAppError(errors.ErrInvalidState, "LibraryService:SaveQuestionExplanation:Question has malformed answer options", err)
+		}
+		options := mapper.AnswerOptionKeys(answerOption)
+		for option := range request.OptionExplanations {
+			if !options[option] {
+				return nil, errors.NewAppError(errors.ErrInvalidInput, "LibraryService:SaveQuestionExplanation:Question has no option "+option, nil)
+			}
+		}
+	}
+
+	explanation, err := s.repo.SaveQuestionExplanation(ctx, &entity.QuestionExplanation{
+		QuestionID:         questionId,
+		Lang:               lang,
+		Explanation:        utils.TrimSpace(request.Explanation),
+		OptionExplanations: request.OptionExplanations,
+	}, editorId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:SaveQuestionExplanation:Failed to save explanation", err)
+	}
+	return mapper.ToQuestionExplanationResponse(explanation), nil
+}
+
+func (s *LibraryService) DeleteQuestionExplanation(ctx context.Context, token string, questionId uuid.UUID, lang string) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	if _, appErr := editorID(token); appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensureQuestionEditable(ctx, questionId); appErr != nil {
+		return appErr
+	}
+	deleted, err := s.repo.DeleteQuestionExplanation(ctx, questionId, lang)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteQuestionExplanation:Failed to delete explanation", err)
+	}
+	if !deleted {
+		return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteQuestionExplanation:Explanation not found", nil)
+	}
+	return nil
+}
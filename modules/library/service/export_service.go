@@ -0,0 +1,207 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"path"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/storage"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"strings"
+	"time"
+)
+
+// ExportExamPackage writes an exam as a zip package in the import format, media included. Nothing is
+// written when the exam cannot be loaded.
+func (s *LibraryService) ExportExamPackage(ctx context.Context, examId uuid.UUID, w io.Writer) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	tree, appErr := s.getExamTree(ctx, examId)
+	if appErr != nil {
+		return appErr
+	}
+	export := newPackageExport(w, s.storage)
+	manifest := mapper.ToExamManifest(tree, export.mediaPath)
+	if err := export.write(ctx, func() error { return export.writeJSON(manifestNames[0], manifest) }); err != nil {
+		logger.Error("LibraryService:ExportExamPackage:Failed to write package", "exam_id", examId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ExportExamPackage:Failed to write package", err)
+	}
+	return nil
+}
+
+// ExportPracticePartPackage writes a practice part as a zip package whose manifest is the part element
+// of an exam manifest.
+func (s *LibraryService) ExportPracticePartPackage(ctx context.Context, partId uuid.UUID, w io.Writer) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	partTree, appErr := s.getPracticePartTree(ctx, partId)
+	if appErr != nil {
+		return appErr
+	}
+	export := newPackageExport(w, s.storage)
+	manifest := mapper.ToPartManifest(partTree, export.mediaPath)
+	if err := export.write(ctx, func() error { return export.writeJSON(manifestNames[0], manifest) }); err != nil {
+		logger.Error("LibraryService:ExportPracticePartPackage:Failed to write package", "part_id", partId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ExportPracticePartPackage:Failed to write package", err)
+	}
+	return nil
+}
+
+// ExportExamQTI writes the MultipleChoice and TrueFalse questions of an exam as an IMS QTI 2.1
+// content package, with one section per part.
+func (s *LibraryService) ExportExamQTI(ctx context.Context, examId uuid.UUID, w io.Writer) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	tree, appErr := s.getExamTree(ctx, examId)
+	if appErr != nil {
+		return appErr
+	}
+	return s.writeQTIPackage(ctx, w, "EXAM-"+examId.String(), tree.Exam.ExamTitle, tree.Parts)
+}
+
+func (s *LibraryService) ExportPracticePartQTI(ctx context.Context, partId uuid.UUID, w io.Writer) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	partTree, appErr := s.getPracticePartTree(ctx, partId)
+	if appErr != nil {
+		return appErr
+	}
+	return s.writeQTIPackage(ctx, w, "PART-"+partId.String(), partTree.Part.PartTitle, []*entity.PartTree{partTree})
+}
+
+func (s *LibraryService) writeQTIPackage(ctx context.Context, w io.Writer, identifier, title string, parts []*entity.PartTree) *errors.AppError {
+	export := newPackageExport(w, s.storage)
+	qtiPackage := buildQTIPackage(identifier, title, parts, export.mediaPath)
+	if len(qtiPackage.items) == 0 {
+		return errors.NewAppError(errors.ErrBusinessRule, "LibraryService:writeQTIPackage:No MultipleChoice or TrueFalse question to export", nil)
+	}
+	err := export.write(ctx, func() error {
+		for _, item := range qtiPackage.items {
+			if err := export.writeXML(item.href, item.item); err != nil {
+				return err
+			}
+		}
+		if err := export.writeXML(qtiPackage.testHref, qtiPackage.test); err != nil {
+			return err
+		}
+		return export.writeXML("imsmanifest.xml", qtiPackage.manifest())
+	})
+	if err != nil {
+		logger.Error("LibraryService:writeQTIPackage:Failed to write package", "identifier", identifier, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:writeQTIPackage:Failed to write package", err)
+	}
+	return nil
+}
+
+func (s *LibraryService) getPracticePartTree(ctx context.Context, partId uuid.UUID) (*entity.PartTree, *errors.AppError) {
+	part, appErr := s.getPracticePart(ctx, partId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	partTrees, err := s.repo.GetPartTrees(ctx, []*entity.ExamPart{part})
+	if err != nil {
+		logger.Error("LibraryService:getPracticePartTree:Failed to get part content", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:getPracticePartTree:Failed to get part content", err)
+	}
+	return partTrees[0], nil
+}
+
+// packageExport writes a zip package, pulling each referenced media file from storage once.
+type packageExport struct {
+	zip     *zip.Writer
+	storage storage.IStorage
+	paths   map[string]string // stored URL to package path
+	names   map[string]bool
+	urls    []string
+}
+
+func newPackageExport(w io.Writer, storage storage.IStorage) *packageExport {
+	return &packageExport{
+		zip:     zip.NewWriter(w),
+		storage: storage,
+		paths:   make(map[string]string),
+		names:   make(map[string]bool),
+	}
+}
+
+// mediaPath returns the package path of a stored media file, under media/ and unique in the package.
+func (e *packageExport) mediaPath(url string) string {
+	if url == "" {
+		return ""
+	}
+	if mediaPath, ok := e.paths[url]; ok {
+		return mediaPath
+	}
+	name := path.Base(url)
+	extension := path.Ext(name)
+	for i := 2; e.names[name]; i++ {
+		name = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path.Base(url), extension), i, extension)
+	}
+	e.names[name] = true
+	e.paths[url] = "media/" + name
+	e.urls = append(e.urls, url)
+	return e.paths[url]
+}
+
+// write copies the media files then runs writeDocuments. The manifest comes last, so that a package
+// cut short by a failure is never mistaken for a complete one.
+func (e *packageExport) write(ctx context.Context, writeDocuments func() error) error {
+	for _, url := range e.urls {
+		if err := e.writeMedia(ctx, url); err != nil {
+			return fmt.Errorf("%s: %w", url, err)
+		}
+	}
+	if err := writeDocuments(); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+func (e *packageExport) writeMedia(ctx context.Context, url string) error {
+	object, err := e.storage.OpenObject(ctx, url)
+	if err != nil {
+		return err
+	}
+	defer object.Close()
+	file, err := e.zip.Create(e.paths[url])
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, object)
+	return err
+}
+
+func (e *packageExport) writeJSON(name string, v any) error {
+	file, err := e.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func (e *packageExport) writeXML(name string, v any) error {
+	file, err := e.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(file, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(file)
+	encoder.Indent("", "  ")
+	return encoder.Encode(v)
+}
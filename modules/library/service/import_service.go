@@ -0,0 +1,372 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+	"io"
+	"mime/multipart"
+	"path"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/core/validation"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	validator "pirate-lang-go/modules/library/validation"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// maxImportPackageSize bounds both the uploaded zip and its extracted content.
+	maxImportPackageSize = 512 << 20
+	maxManifestSize      = 10 << 20
+)
+
+var manifestNames = []string{"manifest.json", "manifest.yaml", "manifest.yml"}
+
+var importAudioExtensions = map[string]bool{".mp3": true}
+var importImageExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true, ".gif": true}
+
+func (s *LibraryService) ImportExamPackage(ctx context.Context, token string, file *multipart.FileHeader) (*dto.ImportReport, *errors.AppError) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if file.Size > maxImportPackageSize {
+		return nil, errors.NewAppError(errors.ErrLimitExceeded, fmt.Sprintf("LibraryService:ImportExamPackage:Package is larger than %d MB", maxImportPackageSize>>20), nil)
+	}
+	src, err := file.Open()
+	if err != nil {
+		logger.Error("LibraryService:ImportExamPackage:Failed to open uploaded package", "error", err)
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "LibraryService:ImportExamPackage:Failed to read package", err)
+	}
+	defer src.Close()
+	archive, err := zip.NewReader(src, file.Size)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInvalidFormat, "LibraryService:ImportExamPackage:Package is not a zip file", err)
+	}
+	return s.ImportPackage(ctx, editorId, archive)
+}
+
+// ImportPackage imports the exam described by the manifest of a zip package, uploading the media files
+// it references. Problems with the package contents are listed in the report, which is then returned
+// without an error and with nothing imported.
+func (s *LibraryService) ImportPackage(ctx context.Context, editorId uuid.UUID, archive *zip.Reader) (*dto.ImportReport, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	p := newPackageImport(archive)
+	if p.extractedSize > maxImportPackageSize {
+		p.addError("package", "", fmt.Sprintf("Package content is larger than %d MB once extracted", maxImportPackageSize>>20))
+		return p.report, nil
+	}
+	manifest, err := p.readManifest()
+	if err != nil {
+		p.addError("manifest", "", err.Error())
+		return p.report, nil
+	}
+	tree := p.buildTree(manifest)
+	if len(p.report.Errors) > 0 {
+		return p.report, nil
+	}
+
+	if err := s.uploadPackageMedia(ctx, p); err != nil {
+		return p.report, nil
+	}
+	if err := s.repo.ImportExamTree(ctx, tree, editorId); err != nil {
+		var importErr *entity.ImportError
+		if stderrors.As(err, &importErr) {
+			p.addError(importErr.Item, "", importErr.Err.Error())
+			return p.report, nil
+		}
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:ImportPackage:Failed to import exam", err)
+	}
+	s.invalidateContent(ctx)
+
+	p.report.Imported = true
+	p.report.ExamID = tree.Exam.ExamID
+	p.report.Parts = len(tree.Parts)
+	for _, part := range tree.Parts {
+		p.report.Questions += len(part.Questions)
+		p.report.Paragraphs += len(part.Paragraphs)
+		for _, paragraph := range part.Paragraphs {
+			p.report.Questions += len(paragraph.Questions)
+		}
+	}
+	p.report.MediaFiles = len(p.media)
+	return p.report, nil
+}
+
+// uploadPackageMedia uploads every media file the manifest references, once each, and sets the object
+// URLs on the tree. Objects uploaded before a failure are left to the storage cleanup.
+func (s *LibraryService) uploadPackageMedia(ctx context.Context, p *packageImport) error {
+	paths := make([]string, 0, len(p.media))
+	for filePath := range p.media {
+		paths = append(paths, filePath)
+	}
+	slices.Sort(paths)
+
+	for _, filePath := range paths {
+		media := p.media[filePath]
+		objectURL, err := s.uploadPackageFile(ctx, p.files[filePath], media.audio)
+		if err != nil {
+			logger.Error("LibraryService:uploadPackageMedia:Failed to upload media file", "path", filePath, "error", err)
+			p.addError(filePath, "", "Failed to upload media file")
+			return err
+		}
+		for _, target := range media.targets {
+			*target = objectURL
+		}
+	}
+	return nil
+}
+
+func (s *LibraryService) uploadPackageFile(ctx context.Context, file *zip.File, audio bool) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	filename := path.Base(file.Name)
+	size := int64(file.UncompressedSize64)
+	var objectURL string
+	if audio {
+		_, objectURL, err = s.storage.UploadAudio(ctx, uuid.New(), src, size, filename, GroupFolder)
+	} else {
+		_, objectURL, err = s.storage.UploadImage(ctx, uuid.New(), src, size, filename, ImageGroupFolder)
+	}
+	return objectURL, err
+}
+
+// packageImport validates the content of a package and turns its manifest into an exam tree.
+type packageImport struct {
+	files         map[string]*zip.File
+	extractedSize uint64
+	media         map[string]*packageMedia
+	report        *dto.ImportReport
+}
+
+// packageMedia is a media file of a package and the URL fields to set once it is uploaded.
+type packageMedia struct {
+	audio   bool
+	targets []*string
+}
+
+func newPackageImport(archive *zip.Reader) *packageImport {
+	p := &packageImport{
+		files:  make(map[string]*zip.File),
+		media:  make(map[string]*packageMedia),
+		report: &dto.ImportReport{Errors: []dto.ImportItemError{}},
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		p.files[path.Clean(file.Name)] = file
+		p.extractedSize += file.UncompressedSize64
+	}
+	return p
+}
+
+func (p *packageImport) addError(item, field, message string) {
+	p.report.Errors = append(p.report.Errors, dto.ImportItemError{Item: item, Field: field, Message: message})
+}
+
+func (p *packageImport) addValidation(item string, result *validation.ValidationResult) {
+	for _, validationErr := range result.Errors {
+		p.addError(item, validationErr.Field, validationErr.Message)
+	}
+}
+
+// readManifest decodes the JSON or YAML manifest at the root of the package. YAML goes through JSON so
+// that both formats share the dto field names, and unknown fields are rejected to catch typos.
+func (p *packageImport) readManifest() (*dto.ExamManifest, error) {
+	var file *zip.File
+	for _, name := range manifestNames {
+		if file = p.files[name]; file != nil {
+			break
+		}
+	}
+	if file == nil {
+		return nil, fmt.Errorf("package has no manifest, expected one of %s", strings.Join(manifestNames, ", "))
+	}
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	data, err := io.ReadAll(io.LimitReader(src, maxManifestSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxManifestSize {
+		return nil, fmt.Errorf("manifest is larger than %d MB", maxManifestSize>>20)
+	}
+
+	if path.Ext(file.Name) != ".json" {
+		var document any
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+		if data, err = json.Marshal(document); err != nil {
+			return nil, fmt.Errorf("invalid YAML: %w", err)
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	manifest := new(dto.ExamManifest)
+	if err := decoder.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// buildTree validates every item of the manifest with the rules of the create endpoints and maps it to
+// the entities to insert. The parent ids of parts, paragraphs and questions are only known once their
+// parents are inserted, so uuid.Max stands in for them during validation.
+func (p *packageImport) buildTree(manifest *dto.ExamManifest) *entity.ExamTree {
+	examRequest := &dto.CreateExamRequest{
+		ExamTitle:         manifest.ExamTitle,
+		Description:       manifest.Description,
+		DurationMinutes:   manifest.DurationMinutes,
+		ExamType:          manifest.ExamType,
+		MaxListeningScore: manifest.MaxListeningScore,
+		MaxReadingScore:   manifest.MaxReadingScore,
+		MaxSpeakingScore:  manifest.MaxSpeakingScore,
+		MaxWritingScore:   manifest.MaxWritingScore,
+	}
+	p.addValidation("exam", validator.ValidateCreateExam(examRequest))
+	if len(manifest.Parts) == 0 {
+		p.addError("exam", "parts", "An exam package needs at least one part")
+	}
+	tree := &entity.ExamTree{Exam: mapper.ToCreateExamEntity(examRequest)}
+
+	partOrders := make(map[int32]bool)
+	for i, partManifest := range manifest.Parts {
+		item := fmt.Sprintf("parts[%d]", i)
+		if partManifest == nil {
+			p.addError(item, "", "Part is empty")
+			continue
+		}
+		partRequest := &dto.CreateExamPartRequest{
+			ExamID:          uuid.NullUUID{UUID: uuid.Max, Valid: true},
+			PartTitle:       partManifest.PartTitle,
+			PartOrder:       partManifest.PartOrder,
+			Description:     partManifest.Description,
+			PlanType:        partManifest.PlanType,
+			ToeicPartNumber: partManifest.ToeicPartNumber,
+		}
+		p.addValidation(item, validator.ValidateCreateExamPart(partRequest))
+		if !validator.ValidPlan[partManifest.PlanType] {
+			p.addError(item, "plan_type", "Plan type must be one of 'SUBSCRIPTION' or 'FREE'")
+		}
+		if partOrders[partManifest.PartOrder] {
+			p.addError(item, "part_order", fmt.Sprintf("Part order %d is used by another part", partManifest.PartOrder))
+		}
+		partOrders[partManifest.PartOrder] = true
+
+		partTree := &entity.PartTree{Part: mapper.ToCreateExamPartEntity(partRequest)}
+		for j, paragraphManifest := range partManifest.Paragraphs {
+			paragraphItem := fmt.Sprintf("%s.paragraphs[%d]", item, j)
+			if paragraphManifest == nil {
+				p.addError(paragraphItem, "", "Paragraph is empty")
+				continue
+			}
+			paragraphRequest := &dto.CreateParagraphRequest{
+				ParagraphContent: paragraphManifest.ParagraphContent,
+				Title:            paragraphManifest.Title,
+				PartID:           uuid.Max,
+				ParagraphOrder:   paragraphManifest.ParagraphOrder,
+				ParagraphType:    paragraphManifest.ParagraphType,
+			}
+			p.addValidation(paragraphItem, validator.ValidateCreateParagraph(paragraphRequest))
+			paragraph := mapper.ToCreateParagraphEntity(paragraphRequest)
+			p.useMedia(paragraphItem, "audio", paragraphManifest.Audio, &paragraph.AudioUrl)
+			p.useMedia(paragraphItem, "image", paragraphManifest.Image, &paragraph.ImageUrl)
+
+			paragraphTree := &entity.ParagraphTree{Paragraph: paragraph}
+			for k, questionManifest := range paragraphManifest.Questions {
+				question := p.buildQuestion(fmt.Sprintf("%s.questions[%d]", paragraphItem, k), questionManifest)
+				if question != nil {
+					paragraphTree.Questions = append(paragraphTree.Questions, question)
+				}
+			}
+			partTree.Paragraphs = append(partTree.Paragraphs, paragraphTree)
+		}
+		for k, questionManifest := range partManifest.Questions {
+			question := p.buildQuestion(fmt.Sprintf("%s.questions[%d]", item, k), questionManifest)
+			if question != nil {
+				partTree.Questions = append(partTree.Questions, question)
+			}
+		}
+		tree.Parts = append(tree.Parts, partTree)
+	}
+	return tree
+}
+
+func (p *packageImport) buildQuestion(item string, questionManifest *dto.QuestionManifest) *entity.Question {
+	if questionManifest == nil {
+		p.addError(item, "", "Question is empty")
+		return nil
+	}
+	questionRequest := &dto.CreateQuestionRequest{
+		QuestionContent:      questionManifest.QuestionContent,
+		QuestionType:         questionManifest.QuestionType,
+		PartID:               uuid.Max,
+		QuestionOrder:        questionManifest.QuestionOrder,
+		ToeicQuestionSection: questionManifest.ToeicQuestionSection,
+		QuestionNumberInPart: questionManifest.QuestionNumberInPart,
+		CorrectAnswer:        questionManifest.CorrectAnswer,
+		PreparationSeconds:   questionManifest.PreparationSeconds,
+		ResponseSeconds:      questionManifest.ResponseSeconds,
+	}
+	if option := questionManifest.AnswerOption; option != nil {
+		answerOption, err := mapper.MarshalAnswerOption(option)
+		if err != nil {
+			p.addError(item, "answer_option", err.Error())
+		}
+		questionRequest.AnswerOption = answerOption
+	}
+	p.addValidation(item, validator.ValidateCreateQuestion(questionRequest))
+
+	question := mapper.ToCreateQuestionEntity(questionRequest)
+	p.useMedia(item, "audio", questionManifest.Audio, &question.AudioUrl)
+	p.useMedia(item, "image", questionManifest.Image, &question.ImageUrl)
+	return question
+}
+
+// useMedia checks a media file referenced by an item and registers target to receive its URL.
+func (p *packageImport) useMedia(item, field, filePath string, target *string) {
+	if filePath == "" {
+		return
+	}
+	filePath = path.Clean(strings.TrimPrefix(filePath, "/"))
+	extension := strings.ToLower(path.Ext(filePath))
+	audio := field == "audio"
+	switch {
+	case audio && !importAudioExtensions[extension]:
+		p.addError(item, field, "Audio must be an MP3 file")
+		return
+	case !audio && !importImageExtensions[extension]:
+		p.addError(item, field, "Image must be a JPEG, PNG or GIF file")
+		return
+	case p.files[filePath] == nil:
+		p.addError(item, field, fmt.Sprintf("File %s is not in the package", filePath))
+		return
+	}
+	media := p.media[filePath]
+	if media == nil {
+		media = &packageMedia{audio: audio}
+		p.media[filePath] = media
+	}
+	media.targets = append(media.targets, target)
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"time"
+)
+
+func (s *LibraryService) GetLearnerExams(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedLearnerExamResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	exams, err := s.repo.GetExams(ctx, entity.ContentStatusPublished, pageNumber, pageSize)
+	if err != nil {
+		logger.Error("LibraryService:GetLearnerExams:Failed to get exams", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetLearnerExams:Failed to get exams", err)
+	}
+	return mapper.ToPaginatedLearnerExamsResponse(exams), nil
+}
+
+// GetLearnerExam returns an exam with its parts tree. SUBSCRIPTION parts are listed for every caller
+// but only carry their content when the caller may read subscription content.
+func (s *LibraryService) GetLearnerExam(ctx context.Context, token string, examId uuid.UUID) (*dto.LearnerExamDetailResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	tree, appErr := s.getExamTree(ctx, examId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if tree.Exam.Status != entity.ContentStatusPublished {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:GetLearnerExam:Exam not found", nil)
+	}
+	canReadSubscription, appErr := s.canReadSubscription(ctx, token)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	response := &dto.LearnerExamDetailResponse{
+		LearnerExamResponse: *mapper.ToLearnerExamResponse(tree.Exam),
+		Parts:               make([]*dto.LearnerPartResponse, 0, len(tree.Parts)),
+	}
+	for _, part := range tree.Parts {
+		response.Parts = append(response.Parts, mapper.ToLearnerPartResponse(part, isLocked(part.Part, canReadSubscription)))
+	}
+	return response, nil
+}
+
+func (s *LibraryService) GetLearnerPracticeParts(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedLearnerPartResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	parts, err := s.repo.GetPracticeExamParts(ctx, entity.ContentStatusPublished, pageNumber, pageSize)
+	if err != nil {
+		logger.Error("LibraryService:GetLearnerPracticeParts:Failed to get practice parts", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetLearnerPracticeParts:Failed to get practice parts", err)
+	}
+	// The list is a catalogue: content is only loaded for a single part
+	items := make([]*dto.LearnerPartResponse, 0, len(parts.Items))
+	for _, part := range parts.Items {
+		items = append(items, mapper.ToLearnerPartSummaryResponse(part, part.PlanType == entity.PlanTypeSubscription))
+	}
+	return &dto.PaginatedLearnerPartResponse{
+		Items:       items,
+		TotalItems:  parts.TotalItems,
+		TotalPages:  parts.TotalPages,
+		CurrentPage: parts.CurrentPage,
+		PageSize:    parts.PageSize,
+	}, nil
+}
+
+func (s *LibraryService) GetLearnerPracticePart(ctx context.Context, token string, partId uuid.UUID) (*dto.LearnerPartResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	part, err := s.repo.GetExamPart(ctx, partId)
+	if err != nil && !stderrors.Is(err, sql.ErrNoRows) {
+		logger.Error("LibraryService:GetLearnerPracticePart:Failed to get part", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetLearnerPracticePart:Failed to get part", err)
+	}
+	if part == nil || !part.IsPracticeComponent || part.Status != entity.ContentStatusPublished {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:GetLearnerPracticePart:Practice part not found", err)
+	}
+	canReadSubscription, appErr := s.canReadSubscription(ctx, token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	locked := isLocked(part, canReadSubscription)
+	if locked {
+		return mapper.ToLearnerPartSummaryResponse(part, true), nil
+	}
+	partTrees, err := s.repo.GetPartTrees(ctx, []*entity.ExamPart{part})
+	if err != nil {
+		logger.Error("LibraryService:GetLearnerPracticePart:Failed to get part content", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetLearnerPracticePart:Failed to get part content", err)
+	}
+	return mapper.ToLearnerPartResponse(partTrees[0], false), nil
+}
+
+// canReadSubscription reports whether the caller has a running subscription and a verified email.
+// Anonymous callers and free users get false rather than an error, so they still see FREE content.
+func (s *LibraryService) canReadSubscription(ctx context.Context, token string) (bool, *errors.AppError) {
+	if token == "" {
+		return false, nil
+	}
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("LibraryService:canReadSubscription:Failed to validate token", "error", err)
+		return false, errors.NewAppError(errors.ErrUnauthorized, "LibraryService:canReadSubscription:Failed to get user", err)
+	}
+	entitlement, appErr := s.subscriptionService.GetEntitlement(ctx, claims.UserID)
+	if appErr != nil {
+		return false, appErr
+	}
+	if !entitlement.CanAccess(entity.PlanTypeSubscription) {
+		return false, nil
+	}
+	user, err := s.accountRepo.GetUserByEmailOrUserNameOrId(ctx, "", "", claims.UserID)
+	if err != nil {
+		logger.Error("LibraryService:canReadSubscription:Failed to get user", "user_id", claims.UserID, "error", err)
+		return false, errors.NewAppError(errors.ErrInternal, "LibraryService:canReadSubscription:Failed to get user", err)
+	}
+	return user != nil && user.EmailVerifiedAt != nil, nil
+}
+
+// isLocked reports whether a part's content is hidden from the caller.
+func isLocked(part *entity.ExamPart, canReadSubscription bool) bool {
+	return part.PlanType == entity.PlanTypeSubscription && !canReadSubscription
+}
@@ -19,29 +19,45 @@ const (
 	ImageGroupFolder = "TranscriptFolder"
 )
 
-func (s *LibraryService) CreateParagraph(ctx context.Context, dataRequest *dto.CreateParagraphRequest) *errors.AppError {
+func (s *LibraryService) CreateParagraph(ctx context.Context, token string, dataRequest *dto.CreateParagraphRequest) *errors.AppError {
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensurePartEditable(ctx, dataRequest.PartID); appErr != nil {
+		return appErr
+	}
 	paragraphEntity := mapper.ToCreateParagraphEntity(dataRequest)
-	err := s.repo.CreateParagraph(ctx, paragraphEntity)
+	err := s.repo.CreateParagraph(ctx, paragraphEntity, editorId)
 	if err != nil {
 		logger.Error("LibraryService:CreateParagraph:Failed to create paragraph", "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:CreateParagraph:Failed to create paragraph", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 
-func (s *LibraryService) UpdateParagraph(ctx context.Context, dataRequest *dto.UpdateParagraphRequest, paragraphId uuid.UUID) *errors.AppError {
+func (s *LibraryService) UpdateParagraph(ctx context.Context, token string, dataRequest *dto.UpdateParagraphRequest, paragraphId uuid.UUID) *errors.AppError {
 	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensureParagraphMovable(ctx, paragraphId, dataRequest.PartID); appErr != nil {
+		return appErr
+	}
 	paragraphEntity := mapper.ToUpdateParagraphEntity(dataRequest)
-	err := s.repo.UpdateParagraph(ctx, paragraphEntity, paragraphId)
+	err := s.repo.UpdateParagraph(ctx, paragraphEntity, paragraphId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UpdateParagraph:Failed to update paragraph", "paragraph_id", paragraphId, "error", err)
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:UpdateParagraph:Failed to update paragraph", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 
@@ -75,7 +91,14 @@ func (s *LibraryService) GetParagraphsByPartId(ctx context.Context, partId uuid.
 	}
 	return paragraphDTOs, nil
 }
-func (s *LibraryService) UploadAudioParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+func (s *LibraryService) UploadAudioParagraph(ctx context.Context, token string, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureParagraphEditable(ctx, paragraphId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to open uploaded audio file", "error", err, "paragraphId", paragraphId.String())
@@ -87,11 +110,12 @@ func (s *LibraryService) UploadAudioParagraph(ctx context.Context, file *multipa
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to open uploaded audio file", "error", err, "paragraphId", paragraphId.String())
 		return nil, errors.NewAppError(errors.ErrInvalidInput, "Service:UploadAudioGroup:Failed to upload audio file", err)
 	}
-	err = s.repo.UpdateAudioParagraph(ctx, &objectURL, paragraphId)
+	err = s.repo.UpdateAudioParagraph(ctx, &objectURL, paragraphId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to update audio URL in database", "error", err, "paragraphId", paragraphId.String())
 		return nil, errors.NewAppError(errors.ErrInternal, "Service:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	response := &dto.UpdateContentFileResponse{
 		Filename:  objectName,
 		ObjectURL: objectURL,
@@ -99,6 +123,9 @@ func (s *LibraryService) UploadAudioParagraph(ctx context.Context, file *multipa
 	return response, nil
 }
 func (s *LibraryService) UploadTranscriptAudioParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID, language string) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	if appErr := s.ensureParagraphEditable(ctx, paragraphId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to open uploaded audio file", "error", err, "paragraphId", paragraphId.String())
@@ -116,7 +143,14 @@ func (s *LibraryService) UploadTranscriptAudioParagraph(ctx context.Context, fil
 	}
 	return response, nil
 }
-func (s *LibraryService) UploadImageParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+func (s *LibraryService) UploadImageParagraph(ctx context.Context, token string, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureParagraphEditable(ctx, paragraphId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to open uploaded audio file", "error", err, "paragraphId", paragraphId.String())
@@ -128,23 +162,32 @@ func (s *LibraryService) UploadImageParagraph(ctx context.Context, file *multipa
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to open uploaded audio file", "error", err, "paragraphId", paragraphId.String())
 		return nil, errors.NewAppError(errors.ErrInvalidInput, "Service:UploadAudioGroup:Failed to upload audio file", err)
 	}
-	err = s.repo.UpdateAudioParagraph(ctx, &objectName, paragraphId)
+	err = s.repo.UpdateImageParagraph(ctx, &objectName, paragraphId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioParagraph:Failed to update audio URL in database", "error", err, "paragraphId", paragraphId.String())
 		return nil, errors.NewAppError(errors.ErrInternal, "Service:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	response := &dto.UpdateContentFileResponse{
 		Filename:  objectName,
 		ObjectURL: objectURL,
 	}
 	return response, nil
 }
-func (s *LibraryService) DeleteAudioParagraph(ctx context.Context, groupId uuid.UUID) *errors.AppError {
+func (s *LibraryService) DeleteAudioParagraph(ctx context.Context, token string, groupId uuid.UUID) *errors.AppError {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensureParagraphEditable(ctx, groupId); appErr != nil {
+		return appErr
+	}
 	objectName := ""
-	err := s.repo.UpdateAudioParagraph(ctx, &objectName, groupId)
+	err := s.repo.UpdateAudioParagraph(ctx, &objectName, groupId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to update audio URL in database", "error", err, "groupId", groupId.String())
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"encoding/xml"
+	"mime"
+	"path"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"strings"
+)
+
+const (
+	qtiNamespace      = "http://www.imsglobal.org/xsd/imsqti_v2p1"
+	qtiSchemaLocation = "http://www.imsglobal.org/xsd/imsqti_v2p1 http://www.imsglobal.org/xsd/qti/qtiv2p1/imsqti_v2p1.xsd"
+	xsiNamespace      = "http://www.w3.org/2001/XMLSchema-instance"
+	imscpNamespace    = "http://www.imsglobal.org/xsd/imscp_v1p1"
+	qtiMatchCorrect   = "http://www.imsglobal.org/question/qti_v2p1/rptemplates/match_correct"
+)
+
+type qtiItem struct {
+	XMLName        xml.Name               `xml:"assessmentItem"`
+	Xmlns          string                 `xml:"xmlns,attr"`
+	Xsi            string                 `xml:"xmlns:xsi,attr"`
+	SchemaLocation string                 `xml:"xsi:schemaLocation,attr"`
+	Identifier     string                 `xml:"identifier,attr"`
+	Title          string                 `xml:"title,attr"`
+	Adaptive       bool                   `xml:"adaptive,attr"`
+	TimeDependent  bool                   `xml:"timeDependent,attr"`
+	Response       qtiResponseDeclaration `xml:"responseDeclaration"`
+	Outcome        qtiOutcomeDeclaration  `xml:"outcomeDeclaration"`
+	Body           qtiItemBody            `xml:"itemBody"`
+	Processing     qtiResponseProcessing  `xml:"responseProcessing"`
+}
+type qtiResponseDeclaration struct {
+	Identifier      string      `xml:"identifier,attr"`
+	Cardinality     string      `xml:"cardinality,attr"`
+	BaseType        string      `xml:"baseType,attr"`
+	CorrectResponse qtiValueSet `xml:"correctResponse"`
+}
+type qtiOutcomeDeclaration struct {
+	Identifier   string      `xml:"identifier,attr"`
+	Cardinality  string      `xml:"cardinality,attr"`
+	BaseType     string      `xml:"baseType,attr"`
+	DefaultValue qtiValueSet `xml:"defaultValue"`
+}
+type qtiValueSet struct {
+	Values []string `xml:"value"`
+}
+type qtiItemBody struct {
+	Stimulus    *qtiStimulus         `xml:"div,omitempty"`
+	Media       []qtiBlock           `xml:"p,omitempty"`
+	Interaction qtiChoiceInteraction `xml:"choiceInteraction"`
+}
+
+// qtiStimulus is the paragraph a question belongs to.
+type qtiStimulus struct {
+	Class  string     `xml:"class,attr"`
+	Blocks []qtiBlock `xml:"p"`
+}
+type qtiBlock struct {
+	Text   string     `xml:",chardata"`
+	Image  *qtiImage  `xml:"img,omitempty"`
+	Object *qtiObject `xml:"object,omitempty"`
+}
+type qtiImage struct {
+	Src string `xml:"src,attr"`
+	Alt string `xml:"alt,attr"`
+}
+type qtiObject struct {
+	Data string `xml:"data,attr"`
+	Type string `xml:"type,attr"`
+}
+type qtiChoiceInteraction struct {
+	ResponseIdentifier string      `xml:"responseIdentifier,attr"`
+	Shuffle            bool        `xml:"shuffle,attr"`
+	MaxChoices         int         `xml:"maxChoices,attr"`
+	Prompt             string      `xml:"prompt,omitempty"`
+	Choices            []qtiChoice `xml:"simpleChoice"`
+}
+type qtiChoice struct {
+	Identifier string `xml:"identifier,attr"`
+	Text       string `xml:",chardata"`
+}
+type qtiResponseProcessing struct {
+	Template string `xml:"template,attr"`
+}
+
+type qtiTest struct {
+	XMLName        xml.Name    `xml:"assessmentTest"`
+	Xmlns          string      `xml:"xmlns,attr"`
+	Xsi            string      `xml:"xmlns:xsi,attr"`
+	SchemaLocation string      `xml:"xsi:schemaLocation,attr"`
+	Identifier     string      `xml:"identifier,attr"`
+	Title          string      `xml:"title,attr"`
+	TestPart       qtiTestPart `xml:"testPart"`
+}
+type qtiTestPart struct {
+	Identifier     string       `xml:"identifier,attr"`
+	NavigationMode string       `xml:"navigationMode,attr"`
+	SubmissionMode string       `xml:"submissionMode,attr"`
+	Sections       []qtiSection `xml:"assessmentSection"`
+}
+type qtiSection struct {
+	Identifier string       `xml:"identifier,attr"`
+	Title      string       `xml:"title,attr"`
+	Visible    bool         `xml:"visible,attr"`
+	Items      []qtiItemRef `xml:"assessmentItemRef"`
+}
+type qtiItemRef struct {
+	Identifier string `xml:"identifier,attr"`
+	Href       string `xml:"href,attr"`
+}
+
+type imsManifest struct {
+	XMLName       xml.Name      `xml:"manifest"`
+	Xmlns         string        `xml:"xmlns,attr"`
+	Identifier    string        `xml:"identifier,attr"`
+	Metadata      imsMetadata   `xml:"metadata"`
+	Organizations struct{}      `xml:"organizations"`
+	Resources     []imsResource `xml:"resources>resource"`
+}
+type imsMetadata struct {
+	Schema        string `xml:"schema"`
+	SchemaVersion string `xml:"schemaversion"`
+}
+type imsResource struct {
+	Identifier   string          `xml:"identifier,attr"`
+	Type         string          `xml:"type,attr"`
+	Href         string          `xml:"href,attr,omitempty"`
+	Files        []imsFile       `xml:"file"`
+	Dependencies []imsDependency `xml:"dependency"`
+}
+type imsFile struct {
+	Href string `xml:"href,attr"`
+}
+type imsDependency struct {
+	IdentifierRef string `xml:"identifierref,attr"`
+}
+
+type qtiPackageItem struct {
+	href  string
+	item  *qtiItem
+	media []string
+}
+
+// qtiPackage is an assessment test over the MultipleChoice and TrueFalse questions of some parts.
+// Other question types have no choiceInteraction equivalent and are left out.
+type qtiPackage struct {
+	identifier string
+	testHref   string
+	test       *qtiTest
+	items      []*qtiPackageItem
+}
+
+func buildQTIPackage(identifier, title string, parts []*entity.PartTree, mediaPath func(url string) string) *qtiPackage {
+	qtiPackage := &qtiPackage{
+		identifier: identifier,
+		testHref:   "assessment.xml",
+		test: &qtiTest{
+			Xmlns:          qtiNamespace,
+			Xsi:            xsiNamespace,
+			SchemaLocation: qtiSchemaLocation,
+			Identifier:     identifier,
+			Title:          title,
+			TestPart: qtiTestPart{
+				Identifier:     "TP-1",
+				NavigationMode: "nonlinear",
+				SubmissionMode: "simultaneous",
+			},
+		},
+	}
+	for _, part := range parts {
+		section := qtiSection{
+			Identifier: "S-" + part.Part.PartID.String(),
+			Title:      part.Part.PartTitle,
+			Visible:    true,
+		}
+		addItem := func(question *entity.Question, paragraph *entity.Paragraph) {
+			item := buildQTIItem(question, paragraph, mediaPath)
+			if item == nil {
+				return
+			}
+			qtiPackage.items = append(qtiPackage.items, item)
+			section.Items = append(section.Items, qtiItemRef{Identifier: item.item.Identifier, Href: item.href})
+		}
+		for _, paragraph := range part.Paragraphs {
+			for _, question := range paragraph.Questions {
+				addItem(question, paragraph.Paragraph)
+			}
+		}
+		for _, question := range part.Questions {
+			addItem(question, nil)
+		}
+		if len(section.Items) > 0 {
+			qtiPackage.test.TestPart.Sections = append(qtiPackage.test.TestPart.Sections, section)
+		}
+	}
+	return qtiPackage
+}
+
+// manifest lists the test and each item as resources; media files belong to the items that use them.
+func (p *qtiPackage) manifest() *imsManifest {
+	manifest := &imsManifest{
+		Xmlns:      imscpNamespace,
+		Identifier: "MANIFEST-" + p.identifier,
+		Metadata:   imsMetadata{Schema: "QTIv2.1 Package", SchemaVersion: "1.0.0"},
+	}
+	test := imsResource{
+		Identifier: p.identifier,
+		Type:       "imsqti_test_xmlv2p1",
+		Href:       p.testHref,
+		Files:      []imsFile{{Href: p.testHref}},
+	}
+	for _, item := range p.items {
+		resource := imsResource{
+			Identifier: item.item.Identifier,
+			Type:       "imsqti_item_xmlv2p1",
+			Href:       item.href,
+			Files:      []imsFile{{Href: item.href}},
+		}
+		for _, media := range item.media {
+			resource.Files = append(resource.Files, imsFile{Href: media})
+		}
+		manifest.Resources = append(manifest.Resources, resource)
+		test.Dependencies = append(test.Dependencies, imsDependency{IdentifierRef: item.item.Identifier})
+	}
+	manifest.Resources = append([]imsResource{test}, manifest.Resources...)
+	return manifest
+}
+
+func buildQTIItem(question *entity.Question, paragraph *entity.Paragraph, mediaPath func(url string) string) *qtiPackageItem {
+	if question.QuestionType != "MultipleChoice" && question.QuestionType != "TrueFalse" {
+		return nil
+	}
+	choices, correct := qtiChoices(question)
+	if len(choices) == 0 || correct == "" {
+		return nil
+	}
+
+	identifier := "Q-" + question.QuestionID.String()
+	packageItem := &qtiPackageItem{href: "items/" + identifier + ".xml"}
+	media := func(url string) []qtiBlock {
+		if url == "" {
+			return nil
+		}
+		// Items live in items/, media in media/.
+		mediaFile := mediaPath(url)
+		packageItem.media = append(packageItem.media, mediaFile)
+		src := "../" + mediaFile
+		contentType := mime.TypeByExtension(path.Ext(mediaFile))
+		if strings.HasPrefix(contentType, "image/") {
+			return []qtiBlock{{Image: &qtiImage{Src: src, Alt: path.Base(mediaFile)}}}
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		return []qtiBlock{{Object: &qtiObject{Data: src, Type: contentType}}}
+	}
+
+	body := qtiItemBody{
+		Interaction: qtiChoiceInteraction{
+			ResponseIdentifier: "RESPONSE",
+			MaxChoices:         1,
+			Prompt:             question.QuestionContent,
+			Choices:            choices,
+		},
+	}
+	if paragraph != nil {
+		stimulus := &qtiStimulus{Class: "stimulus"}
+		for _, text := range []string{paragraph.Title, paragraph.ParagraphContent} {
+			if text != "" {
+				stimulus.Blocks = append(stimulus.Blocks, qtiBlock{Text: text})
+			}
+		}
+		stimulus.Blocks = append(stimulus.Blocks, media(paragraph.ImageUrl)...)
+		stimulus.Blocks = append(stimulus.Blocks, media(paragraph.AudioUrl)...)
+		body.Stimulus = stimulus
+	}
+	body.Media = append(media(question.ImageUrl), media(question.AudioUrl)...)
+
+	title := question.QuestionContent
+	if title == "" {
+		title = identifier
+	}
+	packageItem.item = &qtiItem{
+		Xmlns:          qtiNamespace,
+		Xsi:            xsiNamespace,
+		SchemaLocation: qtiSchemaLocation,
+		Identifier:     identifier,
+		Title:          title,
+		Response: qtiResponseDeclaration{
+			Identifier:      "RESPONSE",
+			Cardinality:     "single",
+			BaseType:        "identifier",
+			CorrectResponse: qtiValueSet{Values: []string{correct}},
+		},
+		Outcome: qtiOutcomeDeclaration{
+			Identifier:   "SCORE",
+			Cardinality:  "single",
+			BaseType:     "float",
+			DefaultValue: qtiValueSet{Values: []string{"0"}},
+		},
+		Body:       body,
+		Processing: qtiResponseProcessing{Template: qtiMatchCorrect},
+	}
+	return packageItem
+}
+
+// qtiChoices returns the choices of a question and the identifier of the correct one. A TrueFalse
+// question without options gets "true" and "false" choices, keyed like the scoring does.
+func qtiChoices(question *entity.Question) ([]qtiChoice, string) {
+	correctAnswer := strings.TrimSpace(question.CorrectAnswer)
+	var choices []qtiChoice
+	correct := ""
+	if question.AnswerOption != "" {
+		if answerOption, err := mapper.UnmarshalAnswerOption(question.AnswerOption); err == nil {
+			for _, option := range []struct {
+				key   string
+				value *string
+			}{{"A", answerOption.A}, {"B", answerOption.B}, {"C", answerOption.C}, {"D", answerOption.D}} {
+				if option.value == nil {
+					continue
+				}
+				choices = append(choices, qtiChoice{Identifier: option.key, Text: *option.value})
+				if strings.EqualFold(correctAnswer, option.key) {
+					correct = option.key
+				}
+			}
+		}
+	}
+	if len(choices) > 0 || question.QuestionType != "TrueFalse" {
+		return choices, correct
+	}
+
+	if normalized := mapper.NormalizeTrueFalse(correctAnswer); normalized == "true" || normalized == "false" {
+		correct = normalized
+	}
+	return []qtiChoice{{Identifier: "true", Text: "True"}, {Identifier: "false", Text: "False"}}, correct
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	stderrors "errors"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"mime/multipart"
+	"path"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	validator "pirate-lang-go/modules/library/validation"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	maxQuestionSheetSize = 10 << 20
+	maxQuestionSheetRows = 2000
+)
+
+// Columns of a question sheet, found by their header. Headers ignore case, and spaces or dashes count
+// as underscores.
+const (
+	sheetContent   = "content"
+	sheetType      = "type"
+	sheetSection   = "section"
+	sheetOptionA   = "a"
+	sheetOptionB   = "b"
+	sheetOptionC   = "c"
+	sheetOptionD   = "d"
+	sheetOptions   = "answer_option"
+	sheetCorrect   = "correct_answer"
+	sheetOrder     = "order"
+	sheetNumber    = "number"
+	sheetParagraph = "paragraph"
+)
+
+var questionSheetHeaders = map[string]string{
+	"content": sheetContent, "question_content": sheetContent,
+	"type": sheetType, "question_type": sheetType,
+	"section": sheetSection, "toeic_question_section": sheetSection,
+	"a": sheetOptionA, "option_a": sheetOptionA,
+	"b": sheetOptionB, "option_b": sheetOptionB,
+	"c": sheetOptionC, "option_c": sheetOptionC,
+	"d": sheetOptionD, "option_d": sheetOptionD,
+	"answer_option": sheetOptions, "options": sheetOptions,
+	"correct_answer": sheetCorrect, "answer": sheetCorrect,
+	"order": sheetOrder, "question_order": sheetOrder,
+	"number": sheetNumber, "question_number_in_part": sheetNumber,
+	"paragraph": sheetParagraph, "paragraph_id": sheetParagraph, "paragraph_order": sheetParagraph,
+}
+
+var requiredSheetColumns = []string{sheetContent, sheetType, sheetSection, sheetOrder}
+
+// ImportQuestionSheet adds the questions of a CSV or XLSX sheet to a part. Every row is checked with the
+// rules of the create endpoint; a dry run stops there, otherwise the questions are inserted in one
+// transaction, and only when no row has an error.
+func (s *LibraryService) ImportQuestionSheet(ctx context.Context, token string, partId uuid.UUID, file *multipart.FileHeader, dryRun bool) (*dto.QuestionSheetReport, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensurePartEditable(ctx, partId); appErr != nil {
+		return nil, appErr
+	}
+	rows, appErr := readQuestionSheet(file)
+	if appErr != nil {
+		return nil, appErr
+	}
+	part, err := s.repo.GetExamPart(ctx, partId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:ImportQuestionSheet:Failed to get part", err)
+	}
+	partTrees, err := s.repo.GetPartTrees(ctx, []*entity.ExamPart{part})
+	if err != nil {
+		logger.Error("LibraryService:ImportQuestionSheet:Failed to get part content", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:ImportQuestionSheet:Failed to get part content", err)
+	}
+
+	sheet := newQuestionSheet(partTrees[0], dryRun)
+	sheet.read(rows)
+	if len(sheet.report.Errors) > 0 || dryRun {
+		return sheet.report, nil
+	}
+
+	if err := s.repo.ImportQuestions(ctx, sheet.questions, editorId); err != nil {
+		var importErr *entity.ImportError
+		if stderrors.As(err, &importErr) {
+			sheet.addError(sheet.items[importErr.Item], "", importErr.Err.Error())
+			return sheet.report, nil
+		}
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:ImportQuestionSheet:Failed to import questions", err)
+	}
+	s.invalidateContent(ctx)
+	sheet.report.Imported = true
+	return sheet.report, nil
+}
+
+// readQuestionSheet returns the rows of an uploaded sheet, telling CSV from XLSX by the file extension.
+func readQuestionSheet(file *multipart.FileHeader) ([][]string, *errors.AppError) {
+	if file.Size > maxQuestionSheetSize {
+		return nil, errors.NewAppError(errors.ErrLimitExceeded, fmt.Sprintf("LibraryService:readQuestionSheet:Sheet is larger than %d MB", maxQuestionSheetSize>>20), nil)
+	}
+	extension := strings.ToLower(path.Ext(file.Filename))
+	if extension != ".csv" && extension != ".xlsx" {
+		return nil, errors.NewAppError(errors.ErrInvalidFormat, "LibraryService:readQuestionSheet:Sheet must be a .csv or .xlsx file", nil)
+	}
+	src, err := file.Open()
+	if err != nil {
+		logger.Error("LibraryService:readQuestionSheet:Failed to open uploaded sheet", "error", err)
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "LibraryService:readQuestionSheet:Failed to read sheet", err)
+	}
+	defer src.Close()
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "LibraryService:readQuestionSheet:Failed to read sheet", err)
+	}
+
+	var rows [][]string
+	if extension == ".csv" {
+		rows, err = readCSVSheet(bytes.NewReader(data))
+	} else {
+		// One row more for the header
+		rows, err = readXLSXSheet(bytes.NewReader(data), int64(len(data)), maxQuestionSheetRows+1)
+	}
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInvalidFormat, "LibraryService:readQuestionSheet:"+err.Error(), err)
+	}
+	return rows, nil
+}
+
+// questionSheet turns sheet rows into questions of a part, collecting row errors in the report.
+type questionSheet struct {
+	part       *entity.PartTree
+	paragraphs map[uuid.UUID]bool
+	orders     map[string]string // parent and question order to the item holding it
+	columns    map[string]int
+	questions  []*entity.Question
+	items      map[string]string // repository item of a question to its row
+	report     *dto.QuestionSheetReport
+}
+
+func newQuestionSheet(part *entity.PartTree, dryRun bool) *questionSheet {
+	sheet := &questionSheet{
+		part:       part,
+		paragraphs: make(map[uuid.UUID]bool),
+		orders:     make(map[string]string),
+		columns:    make(map[string]int),
+		items:      make(map[string]string),
+		report:     &dto.QuestionSheetReport{PartID: part.Part.PartID, DryRun: dryRun, Errors: []dto.ImportItemError{}},
+	}
+	for _, question := range part.Questions {
+		sheet.orders[orderKey(uuid.Nil, question.QuestionOrder)] = "an existing question"
+	}
+	for _, paragraph := range part.Paragraphs {
+		sheet.paragraphs[paragraph.Paragraph.ParagraphID] = true
+		for _, question := range paragraph.Questions {
+			sheet.orders[orderKey(paragraph.Paragraph.ParagraphID, question.QuestionOrder)] = "an existing question"
+		}
+	}
+	return sheet
+}
+
+func orderKey(paragraphId uuid.UUID, questionOrder int32) string {
+	return fmt.Sprintf("%s/%d", paragraphId, questionOrder)
+}
+
+func (sheet *questionSheet) addError(item, field, message string) {
+	sheet.report.Errors = append(sheet.report.Errors, dto.ImportItemError{Item: item, Field: field, Message: message})
+}
+
+func (sheet *questionSheet) read(rows [][]string) {
+	if len(rows) == 0 {
+		sheet.addError("sheet", "", "Sheet is empty")
+		return
+	}
+	if !sheet.readHeader(rows[0]) {
+		return
+	}
+	for i, row := range rows[1:] {
+		if isBlankRow(row) {
+			continue
+		}
+		sheet.report.Rows++
+		if sheet.report.Rows > maxQuestionSheetRows {
+			sheet.addError("sheet", "", fmt.Sprintf("Sheet has more than %d question rows", maxQuestionSheetRows))
+			return
+		}
+		// Sheet rows are numbered from 1, the header being row 1.
+		sheet.readRow(fmt.Sprintf("row %d", i+2), row)
+	}
+	if sheet.report.Rows == 0 {
+		sheet.addError("sheet", "", "Sheet has no question rows")
+	}
+	sheet.report.Questions = len(sheet.questions)
+}
+
+func (sheet *questionSheet) readHeader(header []string) bool {
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		name = strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+		if name == "" {
+			continue
+		}
+		column, ok := questionSheetHeaders[name]
+		if !ok {
+			sheet.addError("row 1", name, "Unknown column")
+			continue
+		}
+		if _, ok := sheet.columns[column]; ok {
+			sheet.addError("row 1", name, "Column appears more than once")
+			continue
+		}
+		sheet.columns[column] = i
+	}
+	for _, column := range requiredSheetColumns {
+		if _, ok := sheet.columns[column]; !ok {
+			sheet.addError("row 1", column, "Column is required")
+		}
+	}
+	return len(sheet.report.Errors) == 0
+}
+
+func (sheet *questionSheet) cell(row []string, column string) string {
+	i, ok := sheet.columns[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func (sheet *questionSheet) readRow(item string, row []string) {
+	errorCount := len(sheet.report.Errors)
+	request := &dto.CreateQuestionRequest{
+		QuestionContent:      sheet.cell(row, sheetContent),
+		QuestionType:         sheet.cell(row, sheetType),
+		PartID:               sheet.part.Part.PartID,
+		ToeicQuestionSection: sheet.cell(row, sheetSection),
+		CorrectAnswer:        sheet.cell(row, sheetCorrect),
+	}
+	request.QuestionOrder = sheet.number(item, "question_order", sheet.cell(row, sheetOrder))
+	request.QuestionNumberInPart = sheet.number(item, "question_number_in_part", sheet.cell(row, sheetNumber))
+	request.ParagraphID = sheet.paragraph(item, sheet.cell(row, sheetParagraph))
+
+	option := &dto.AnswerOption{}
+	for key, target := range map[string]**string{sheetOptionA: &option.A, sheetOptionB: &option.B, sheetOptionC: &option.C, sheetOptionD: &option.D} {
+		if value := sheet.cell(row, key); value != "" {
+			*target = &value
+		}
+	}
+	hasLetters := option.A != nil || option.B != nil || option.C != nil || option.D != nil
+	// Questions other than choices describe their options in JSON, as the create endpoint takes them
+	if answerOption := sheet.cell(row, sheetOptions); answerOption != "" {
+		if hasLetters {
+			sheet.addError(item, "answer_option", "Fill either answer_option or the A to D columns, not both")
+		}
+		request.AnswerOption = answerOption
+	} else if hasLetters {
+		answerOption, err := mapper.MarshalAnswerOption(option)
+		if err != nil {
+			sheet.addError(item, "answer_option", err.Error())
+		}
+		request.AnswerOption = answerOption
+		request.CorrectAnswer = strings.ToUpper(request.CorrectAnswer)
+	}
+	result := validator.ValidateCreateQuestion(request)
+	for _, validationErr := range result.Errors {
+		sheet.addError(item, validationErr.Field, validationErr.Message)
+	}
+
+	if request.QuestionOrder > 0 {
+		key := orderKey(request.ParagraphID, request.QuestionOrder)
+		if holder, ok := sheet.orders[key]; ok {
+			sheet.addError(item, "question_order", fmt.Sprintf("Question order %d is already used by %s", request.QuestionOrder, holder))
+		}
+		sheet.orders[key] = item
+	}
+	if len(sheet.report.Errors) > errorCount {
+		return
+	}
+	sheet.items[fmt.Sprintf("questions[%d]", len(sheet.questions))] = item
+	sheet.questions = append(sheet.questions, mapper.ToCreateQuestionEntity(request))
+}
+
+func (sheet *questionSheet) number(item, field, value string) int32 {
+	if value == "" {
+		return 0
+	}
+	// Spreadsheets store every number as a float.
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number != float64(int32(number)) {
+		sheet.addError(item, field, fmt.Sprintf("%q is not a whole number", value))
+		return 0
+	}
+	return int32(number)
+}
+
+// paragraph resolves a paragraph reference, either the id of a paragraph of the part or its order.
+func (sheet *questionSheet) paragraph(item, reference string) uuid.UUID {
+	if reference == "" {
+		return uuid.Nil
+	}
+	if paragraphId, err := uuid.Parse(reference); err == nil {
+		if !sheet.paragraphs[paragraphId] {
+			sheet.addError(item, "paragraph", "Paragraph "+reference+" is not in this part")
+			return uuid.Nil
+		}
+		return paragraphId
+	}
+	paragraphOrder := sheet.number(item, "paragraph", reference)
+	if paragraphOrder == 0 {
+		return uuid.Nil
+	}
+	for _, paragraph := range sheet.part.Paragraphs {
+		if paragraph.Paragraph.ParagraphOrder == paragraphOrder {
+			return paragraph.Paragraph.ParagraphID
+		}
+	}
+	sheet.addError(item, "paragraph", fmt.Sprintf("Part has no paragraph with order %d", paragraphOrder))
+	return uuid.Nil
+}
+
+func isBlankRow(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -12,7 +12,14 @@ import (
 	"time"
 )
 
-func (s *LibraryService) UploadAudioQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+func (s *LibraryService) UploadAudioQuestion(ctx context.Context, token string, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureQuestionEditable(ctx, groupId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to open uploaded audio file", "error", err, "groupId", groupId.String())
@@ -24,11 +31,12 @@ func (s *LibraryService) UploadAudioQuestion(ctx context.Context, file *multipar
 		logger.Error("LibraryService:UploadAudioGroup:Failed to open uploaded audio file", "error", err, "groupId", groupId.String())
 		return nil, errors.NewAppError(errors.ErrInvalidInput, "Service:UploadAudioGroup:Failed to upload audio file", err)
 	}
-	err = s.repo.UpdateQuestionAudioUrl(ctx, &objectURL, groupId)
+	err = s.repo.UpdateQuestionAudioUrl(ctx, &objectURL, groupId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to update audio URL in database", "error", err, "groupId", groupId.String())
 		return nil, errors.NewAppError(errors.ErrInternal, "Service:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	response := &dto.UpdateContentFileResponse{
 		Filename:  objectName,
 		ObjectURL: objectURL,
@@ -36,6 +44,9 @@ func (s *LibraryService) UploadAudioQuestion(ctx context.Context, file *multipar
 	return response, nil
 }
 func (s *LibraryService) UploadTranscriptQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID, language string) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	if appErr := s.ensureQuestionEditable(ctx, groupId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to open uploaded audio file", "error", err, "groupId", groupId.String())
@@ -53,7 +64,14 @@ func (s *LibraryService) UploadTranscriptQuestion(ctx context.Context, file *mul
 	}
 	return response, nil
 }
-func (s *LibraryService) UploadImageQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+func (s *LibraryService) UploadImageQuestion(ctx context.Context, token string, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureQuestionEditable(ctx, groupId); appErr != nil {
+		return nil, appErr
+	}
 	src, err := file.Open()
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to open uploaded audio file", "error", err, "groupId", groupId.String())
@@ -65,24 +83,33 @@ func (s *LibraryService) UploadImageQuestion(ctx context.Context, file *multipar
 		logger.Error("LibraryService:UploadAudioGroup:Failed to open uploaded audio file", "error", err, "groupId", groupId.String())
 		return nil, errors.NewAppError(errors.ErrInvalidInput, "Service:UploadAudioGroup:Failed to upload audio file", err)
 	}
-	err = s.repo.UpdateQuestionImageUrl(ctx, &objectName, groupId)
+	err = s.repo.UpdateQuestionImageUrl(ctx, &objectName, groupId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to update audio URL in database", "error", err, "groupId", groupId.String())
 		return nil, errors.NewAppError(errors.ErrInternal, "Service:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	response := &dto.UpdateContentFileResponse{
 		Filename:  objectName,
 		ObjectURL: objectURL,
 	}
 	return response, nil
 }
-func (s *LibraryService) DeleteAudioGroup(ctx context.Context, groupId uuid.UUID) *errors.AppError {
+func (s *LibraryService) DeleteAudioGroup(ctx context.Context, token string, groupId uuid.UUID) *errors.AppError {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensureQuestionEditable(ctx, groupId); appErr != nil {
+		return appErr
+	}
 	objectName := ""
-	err := s.repo.UpdateQuestionAudioUrl(ctx, &objectName, groupId)
+	err := s.repo.UpdateQuestionAudioUrl(ctx, &objectName, groupId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:UploadAudioGroup:Failed to update audio URL in database", "error", err, "groupId", groupId.String())
 		return errors.NewAppError(errors.ErrInternal, "LibraryService:UploadAudioGroup:Failed to persist audio information in database", err)
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 func (s *LibraryService) GetQuestionByParts(ctx context.Context, pageNumber, pageSize int, partId uuid.UUID) (*dto.PaginatedQuestionResponse, *errors.AppError) {
@@ -111,23 +138,39 @@ func (s *LibraryService) GetQuestionsByParagraph(ctx context.Context, paragraphI
 	}
 	return questions, nil
 }
-func (s *LibraryService) CreateQuestion(ctx context.Context, request *dto.CreateQuestionRequest) (*dto.QuestionResponse, error) {
+func (s *LibraryService) CreateQuestion(ctx context.Context, token string, request *dto.CreateQuestionRequest) (*dto.QuestionResponse, error) {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensurePartEditable(ctx, request.PartID); appErr != nil {
+		return nil, appErr
+	}
 	questionEntity := mapper.ToCreateQuestionEntity(request)
-	question, err := s.repo.CreateQuestion(ctx, questionEntity)
+	question, err := s.repo.CreateQuestion(ctx, questionEntity, editorId)
 	if err != nil {
 		logger.Error("LibraryService:CreateQuestion: failed to create question", err)
 		return nil, err
 	}
+	s.invalidateContent(ctx)
 	response := mapper.ToQuestionResponse(question)
 	return response, nil
 }
-func (s *LibraryService) UpdateQuestion(ctx context.Context, request *dto.UpdateQuestionRequest, questionId uuid.UUID) error {
+func (s *LibraryService) UpdateQuestion(ctx context.Context, token string, request *dto.UpdateQuestionRequest, questionId uuid.UUID) error {
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr := s.ensureQuestionMovable(ctx, questionId, request.PartID); appErr != nil {
+		return appErr
+	}
 	questionEntity := mapper.ToUpdateQuestionEntity(request)
-	err := s.repo.UpdateQuestion(ctx, questionEntity, questionId)
+	err := s.repo.UpdateQuestion(ctx, questionEntity, questionId, editorId)
 	if err != nil {
 		logger.Error("LibraryService:CreateQuestion: failed to create question", err)
 		return err
 	}
+	s.invalidateContent(ctx)
 	return nil
 }
 func (s *LibraryService) GetQuestion(ctx context.Context, questionId uuid.UUID) (*dto.QuestionResponse, error) {
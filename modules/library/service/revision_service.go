@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/mapper"
+	"time"
+)
+
+func (s *LibraryService) GetQuestionRevisions(ctx context.Context, questionId uuid.UUID) ([]*dto.QuestionRevisionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	revisions, err := s.repo.GetQuestionRevisions(ctx, questionId)
+	if err != nil {
+		logger.Error("LibraryService:GetQuestionRevisions:Failed to get revisions", "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetQuestionRevisions:Failed to get revisions", err)
+	}
+	return mapper.ToQuestionRevisionsResponse(revisions), nil
+}
+
+// RestoreQuestionRevision brings a question back to an earlier revision. Both the part the question is
+// in now and the part it was in at that revision have to be editable.
+func (s *LibraryService) RestoreQuestionRevision(ctx context.Context, token string, questionId, revisionId uuid.UUID) (*dto.QuestionRevisionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureQuestionEditable(ctx, questionId); appErr != nil {
+		return nil, appErr
+	}
+	revisions, err := s.repo.GetQuestionRevisions(ctx, questionId)
+	if err != nil {
+		logger.Error("LibraryService:RestoreQuestionRevision:Failed to get revisions", "question_id", questionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreQuestionRevision:Failed to get revisions", err)
+	}
+	found := false
+	for _, revision := range revisions {
+		if revision.RevisionID == revisionId {
+			found = true
+			if appErr := s.ensurePartEditable(ctx, revision.Question.PartID); appErr != nil {
+				return nil, appErr
+			}
+		}
+	}
+	if !found {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreQuestionRevision:Revision not found", nil)
+	}
+
+	restored, err := s.repo.RestoreQuestionRevision(ctx, questionId, revisionId, editorId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreQuestionRevision:Revision not found", err)
+		}
+		logger.Error("LibraryService:RestoreQuestionRevision:Failed to restore revision", "question_id", questionId, "revision_id", revisionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreQuestionRevision:Failed to restore revision", err)
+	}
+	s.invalidateContent(ctx)
+	return mapper.ToQuestionRevisionResponse(restored), nil
+}
+
+func (s *LibraryService) GetParagraphRevisions(ctx context.Context, paragraphId uuid.UUID) ([]*dto.ParagraphRevisionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	revisions, err := s.repo.GetParagraphRevisions(ctx, paragraphId)
+	if err != nil {
+		logger.Error("LibraryService:GetParagraphRevisions:Failed to get revisions", "paragraph_id", paragraphId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetParagraphRevisions:Failed to get revisions", err)
+	}
+	return mapper.ToParagraphRevisionsResponse(revisions), nil
+}
+
+func (s *LibraryService) RestoreParagraphRevision(ctx context.Context, token string, paragraphId, revisionId uuid.UUID) (*dto.ParagraphRevisionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if appErr := s.ensureParagraphEditable(ctx, paragraphId); appErr != nil {
+		return nil, appErr
+	}
+	revisions, err := s.repo.GetParagraphRevisions(ctx, paragraphId)
+	if err != nil {
+		logger.Error("LibraryService:RestoreParagraphRevision:Failed to get revisions", "paragraph_id", paragraphId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreParagraphRevision:Failed to get revisions", err)
+	}
+	found := false
+	for _, revision := range revisions {
+		if revision.RevisionID == revisionId {
+			found = true
+			if appErr := s.ensurePartEditable(ctx, revision.Paragraph.PartID); appErr != nil {
+				return nil, appErr
+			}
+		}
+	}
+	if !found {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreParagraphRevision:Revision not found", nil)
+	}
+
+	restored, err := s.repo.RestoreParagraphRevision(ctx, paragraphId, revisionId, editorId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreParagraphRevision:Revision not found", err)
+		}
+		logger.Error("LibraryService:RestoreParagraphRevision:Failed to restore revision", "paragraph_id", paragraphId, "revision_id", revisionId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreParagraphRevision:Failed to restore revision", err)
+	}
+	s.invalidateContent(ctx)
+	return mapper.ToParagraphRevisionResponse(restored), nil
+}
+
+// editorID returns the user recorded as the author of a revision.
+func editorID(token string) (uuid.UUID, *errors.AppError) {
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("LibraryService:editorID:Failed to validate token", "error", err)
+		return uuid.Nil, errors.NewAppError(errors.ErrUnauthorized, "LibraryService:editorID:Failed to get user", err)
+	}
+	return claims.UserID, nil
+}
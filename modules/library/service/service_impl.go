@@ -1,55 +1,104 @@
 package service
 
 import (
+	"archive/zip"
 	"context"
 	"github.com/google/uuid"
+	"io"
 	"mime/multipart"
 	"pirate-lang-go/core/cache"
 	"pirate-lang-go/core/errors"
 	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
 	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
 	"pirate-lang-go/modules/library/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+	"time"
 )
 
 type LibraryService struct {
-	repo    repository.ILibraryRepository
-	cache   cache.ICache
-	storage storage.IStorage
+	repo                repository.ILibraryRepository
+	cache               cache.ICache
+	storage             storage.IStorage
+	accountRepo         accountrepo.IAccountRepository
+	subscriptionService subscriptionservice.ISubscriptionService
 }
 
-func NewLibraryService(repo repository.ILibraryRepository, cache cache.ICache, storage storage.IStorage) ILibraryService {
+func NewLibraryService(repo repository.ILibraryRepository, cache cache.ICache, storage storage.IStorage, accountRepo accountrepo.IAccountRepository, subscriptionService subscriptionservice.ISubscriptionService) ILibraryService {
 
 	return &LibraryService{
-		repo:    repo,
-		cache:   cache,
-		storage: storage,
+		repo:                repo,
+		cache:               cache,
+		storage:             storage,
+		accountRepo:         accountRepo,
+		subscriptionService: subscriptionService,
 	}
 }
 
 type ILibraryService interface {
-	GetExams(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedExamResponse, *errors.AppError)
+	GetExams(ctx context.Context, status string, pageNumber, pageSize int) (*dto.PaginatedExamResponse, *errors.AppError)
 	CreateExam(ctx context.Context, dataRequest *dto.CreateExamRequest) *errors.AppError
 	UpdateExam(ctx context.Context, dataRequest *dto.UpdateExamRequest, examId uuid.UUID) *errors.AppError
 	GetExam(ctx context.Context, examId uuid.UUID) (*dto.ExamResponse, *errors.AppError)
 	CreateExamPart(ctx context.Context, dataRequest *dto.CreateExamPartRequest) *errors.AppError
 	UpdateExamPart(ctx context.Context, dataRequest *dto.UpdateExamPartRequest, examPartId uuid.UUID) *errors.AppError
 	GetExamPart(ctx context.Context, examPartId uuid.UUID) (*dto.ExamPartResponse, *errors.AppError)
-	GetPracticeExamParts(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedExamPartResponse, *errors.AppError)
+	GetPracticeExamParts(ctx context.Context, status string, pageNumber, pageSize int) (*dto.PaginatedExamPartResponse, *errors.AppError)
 	GetExamPartsByExamId(ctx context.Context, examId uuid.UUID) ([]*dto.ExamPartResponse, *errors.AppError)
-	CreateParagraph(ctx context.Context, dataRequest *dto.CreateParagraphRequest) *errors.AppError
-	UpdateParagraph(ctx context.Context, dataRequest *dto.UpdateParagraphRequest, paragraphId uuid.UUID) *errors.AppError
+	CreateParagraph(ctx context.Context, token string, dataRequest *dto.CreateParagraphRequest) *errors.AppError
+	UpdateParagraph(ctx context.Context, token string, dataRequest *dto.UpdateParagraphRequest, paragraphId uuid.UUID) *errors.AppError
 	GetParagraph(ctx context.Context, paragraphId uuid.UUID) (*dto.ParagraphResponse, *errors.AppError)
 	GetParagraphsByPartId(ctx context.Context, partId uuid.UUID) ([]*dto.ParagraphResponse, *errors.AppError)
-	UploadAudioParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
+	UploadAudioParagraph(ctx context.Context, token string, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
 	UploadTranscriptAudioParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID, language string) (*dto.UpdateContentFileResponse, *errors.AppError)
-	UploadImageParagraph(ctx context.Context, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
-	UploadAudioQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
+	UploadImageParagraph(ctx context.Context, token string, file *multipart.FileHeader, paragraphId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
+	UploadAudioQuestion(ctx context.Context, token string, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
 	UploadTranscriptQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID, language string) (*dto.UpdateContentFileResponse, *errors.AppError)
-	UploadImageQuestion(ctx context.Context, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
-	DeleteAudioGroup(ctx context.Context, groupId uuid.UUID) *errors.AppError
+	UploadImageQuestion(ctx context.Context, token string, file *multipart.FileHeader, groupId uuid.UUID) (*dto.UpdateContentFileResponse, *errors.AppError)
+	DeleteAudioGroup(ctx context.Context, token string, groupId uuid.UUID) *errors.AppError
 	GetQuestionByParts(ctx context.Context, pageNumber, pageSize int, partId uuid.UUID) (*dto.PaginatedQuestionResponse, *errors.AppError)
 	GetQuestionsByParagraph(ctx context.Context, paragraphId uuid.UUID) ([]*dto.QuestionResponse, error)
-	CreateQuestion(ctx context.Context, request *dto.CreateQuestionRequest) (*dto.QuestionResponse, error)
-	UpdateQuestion(ctx context.Context, request *dto.UpdateQuestionRequest, questionId uuid.UUID) error
+	CreateQuestion(ctx context.Context, token string, request *dto.CreateQuestionRequest) (*dto.QuestionResponse, error)
+	UpdateQuestion(ctx context.Context, token string, request *dto.UpdateQuestionRequest, questionId uuid.UUID) error
 	GetQuestion(ctx context.Context, questionId uuid.UUID) (*dto.QuestionResponse, error)
+	// GetExamTree returns an exam with all of its content for editors; it is cached until the next library write.
+	GetExamTree(ctx context.Context, examId uuid.UUID) (*dto.ExamTreeResponse, *errors.AppError)
+	// Publishing workflow; only PUBLISHED content is visible to learners and only DRAFT content can be edited
+	TransitionExam(ctx context.Context, token string, examId uuid.UUID, action string, request *dto.ChangeStatusRequest) (*dto.ContentStatusResponse, *errors.AppError)
+	TransitionPracticePart(ctx context.Context, token string, partId uuid.UUID, action string, request *dto.ChangeStatusRequest) (*dto.ContentStatusResponse, *errors.AppError)
+	CheckExamPublishable(ctx context.Context, examId uuid.UUID) (*dto.PublishCheckResponse, *errors.AppError)
+	CheckPracticePartPublishable(ctx context.Context, partId uuid.UUID) (*dto.PublishCheckResponse, *errors.AppError)
+	GetExamStatusHistory(ctx context.Context, examId uuid.UUID) ([]*dto.StatusChangeResponse, *errors.AppError)
+	GetPracticePartStatusHistory(ctx context.Context, partId uuid.UUID) ([]*dto.StatusChangeResponse, *errors.AppError)
+	// Revisions; every question and paragraph write records one, and restoring records another
+	GetQuestionRevisions(ctx context.Context, questionId uuid.UUID) ([]*dto.QuestionRevisionResponse, *errors.AppError)
+	RestoreQuestionRevision(ctx context.Context, token string, questionId, revisionId uuid.UUID) (*dto.QuestionRevisionResponse, *errors.AppError)
+	GetQuestionExplanations(ctx context.Context, questionId uuid.UUID) ([]*dto.QuestionExplanationResponse, *errors.AppError)
+	SaveQuestionExplanation(ctx context.Context, token string, questionId uuid.UUID, lang string, request *dto.QuestionExplanationRequest) (*dto.QuestionExplanationResponse, *errors.AppError)
+	DeleteQuestionExplanation(ctx context.Context, token string, questionId uuid.UUID, lang string) *errors.AppError
+	GetParagraphRevisions(ctx context.Context, paragraphId uuid.UUID) ([]*dto.ParagraphRevisionResponse, *errors.AppError)
+	RestoreParagraphRevision(ctx context.Context, token string, paragraphId, revisionId uuid.UUID) (*dto.ParagraphRevisionResponse, *errors.AppError)
+	// Package import; ImportPackage also serves the import command, which has no token
+	ImportExamPackage(ctx context.Context, token string, file *multipart.FileHeader) (*dto.ImportReport, *errors.AppError)
+	ImportPackage(ctx context.Context, editorId uuid.UUID, archive *zip.Reader) (*dto.ImportReport, *errors.AppError)
+	ImportQuestionSheet(ctx context.Context, token string, partId uuid.UUID, file *multipart.FileHeader, dryRun bool) (*dto.QuestionSheetReport, *errors.AppError)
+	// Package export, in the import format or as IMS QTI 2.1
+	ExportExamPackage(ctx context.Context, examId uuid.UUID, w io.Writer) *errors.AppError
+	ExportPracticePartPackage(ctx context.Context, partId uuid.UUID, w io.Writer) *errors.AppError
+	ExportExamQTI(ctx context.Context, examId uuid.UUID, w io.Writer) *errors.AppError
+	ExportPracticePartQTI(ctx context.Context, partId uuid.UUID, w io.Writer) *errors.AppError
+	// Trash; PurgeTrash also serves the background purger
+	DeleteExam(ctx context.Context, token string, examId uuid.UUID) *errors.AppError
+	DeleteExamPart(ctx context.Context, token string, partId uuid.UUID) *errors.AppError
+	DeleteParagraph(ctx context.Context, token string, paragraphId uuid.UUID) *errors.AppError
+	DeleteQuestion(ctx context.Context, token string, questionId uuid.UUID) *errors.AppError
+	GetTrash(ctx context.Context, contentType string, pageNumber, pageSize int) (*dto.PaginatedTrashItemResponse, *errors.AppError)
+	RestoreFromTrash(ctx context.Context, token string, contentType string, contentId uuid.UUID) (*dto.TrashItemResponse, *errors.AppError)
+	PurgeTrash(ctx context.Context, deletedBefore, uploadedBefore time.Time) (*entity.PurgeResult, *errors.AppError)
+	// Learner read model; token is empty for anonymous callers, who only see FREE content
+	GetLearnerExams(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedLearnerExamResponse, *errors.AppError)
+	GetLearnerExam(ctx context.Context, token string, examId uuid.UUID) (*dto.LearnerExamDetailResponse, *errors.AppError)
+	GetLearnerPracticeParts(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedLearnerPartResponse, *errors.AppError)
+	GetLearnerPracticePart(ctx context.Context, token string, partId uuid.UUID) (*dto.LearnerPartResponse, *errors.AppError)
 }
@@ -0,0 +1,221 @@
+package service
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maxXLSXColumns is the column count of Excel sheets, up to column XFD.
+	maxXLSXColumns = 16384
+	// maxXLSXCells bounds the cells of a sheet, counting the empty ones in front of a filled cell.
+	maxXLSXCells = 1 << 20
+	// maxXLSXPartSize bounds each XML part read from a workbook once decompressed.
+	maxXLSXPartSize = 100 << 20
+)
+
+// readCSVSheet reads every record of a CSV file. Rows may have different lengths, and the byte order
+// mark spreadsheet programs put in front of UTF-8 exports is dropped.
+func readCSVSheet(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelationshipID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+type xlsxSharedStrings struct {
+	Items []xlsxText `xml:"si"`
+}
+
+// xlsxText is a shared or inline string, either plain or split into formatted runs.
+type xlsxText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		Index int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline xlsxText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXSheet reads the cell values of the first worksheet of an XLSX workbook, which must have at
+// most maxRows rows. Cells keep their stored value: formulas give their last computed result and dates
+// their serial number.
+func readXLSXSheet(r io.ReaderAt, size int64, maxRows int) ([][]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("not an XLSX file: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, err
+	}
+	var sharedStrings xlsxSharedStrings
+	if file := files["xl/sharedStrings.xml"]; file != nil {
+		if err := decodeXMLFile(file, &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	var worksheet xlsxWorksheet
+	if err := decodeXMLFile(files[sheetPath], &worksheet); err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	cells := 0
+	for _, sheetRow := range worksheet.Rows {
+		if sheetRow.Index > maxRows || len(rows) >= maxRows {
+			return nil, fmt.Errorf("sheet has more than %d rows", maxRows)
+		}
+		// Empty rows are left out of the sheet data, so they are put back to keep row numbers right.
+		for sheetRow.Index > len(rows)+1 {
+			rows = append(rows, nil)
+		}
+		var row []string
+		for i, cell := range sheetRow.Cells {
+			column := i
+			if cell.Ref != "" {
+				if column, err = xlsxColumn(cell.Ref); err != nil {
+					return nil, err
+				}
+			} else if column >= maxXLSXColumns {
+				return nil, fmt.Errorf("row %d has more than %d columns", sheetRow.Index, maxXLSXColumns)
+			}
+			if column >= len(row) {
+				if cells += column + 1 - len(row); cells > maxXLSXCells {
+					return nil, fmt.Errorf("sheet has more than %d cells", maxXLSXCells)
+				}
+			}
+			for len(row) <= column {
+				row = append(row, "")
+			}
+			switch cell.Type {
+			case "s":
+				index, err := strconv.Atoi(cell.Value)
+				if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+					return nil, fmt.Errorf("cell %s refers to a missing shared string", cell.Ref)
+				}
+				row[column] = sharedStrings.Items[index].String()
+			case "inlineStr":
+				row[column] = cell.Inline.String()
+			default:
+				row[column] = cell.Value
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	var workbook xlsxWorkbook
+	var relationships xlsxRelationships
+	if files["xl/workbook.xml"] == nil || files["xl/_rels/workbook.xml.rels"] == nil {
+		return "", fmt.Errorf("not an XLSX file: workbook is missing")
+	}
+	if err := decodeXMLFile(files["xl/workbook.xml"], &workbook); err != nil {
+		return "", err
+	}
+	if err := decodeXMLFile(files["xl/_rels/workbook.xml.rels"], &relationships); err != nil {
+		return "", err
+	}
+	if len(workbook.Sheets) == 0 {
+		return "", fmt.Errorf("workbook has no sheet")
+	}
+	for _, relationship := range relationships.Relationships {
+		if relationship.ID != workbook.Sheets[0].RelationshipID {
+			continue
+		}
+		sheetPath := path.Join("xl", relationship.Target)
+		if strings.HasPrefix(relationship.Target, "/") {
+			sheetPath = strings.TrimPrefix(relationship.Target, "/")
+		}
+		if files[sheetPath] == nil {
+			return "", fmt.Errorf("worksheet %s is missing", sheetPath)
+		}
+		return sheetPath, nil
+	}
+	return "", fmt.Errorf("first sheet of the workbook has no worksheet")
+}
+
+func decodeXMLFile(file *zip.File, v any) error {
+	src, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	// The declared size may lie, so the reader is limited too; a truncated part fails to decode.
+	if file.UncompressedSize64 > maxXLSXPartSize {
+		return fmt.Errorf("%s is larger than %d MB", file.Name, maxXLSXPartSize>>20)
+	}
+	if err := xml.NewDecoder(io.LimitReader(src, maxXLSXPartSize)).Decode(v); err != nil {
+		return fmt.Errorf("invalid %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// xlsxColumn returns the zero-based column of a cell reference such as "AB12".
+func xlsxColumn(ref string) (int, error) {
+	column := 0
+	for _, letter := range ref {
+		if letter < 'A' || letter > 'Z' {
+			break
+		}
+		column = column*26 + int(letter-'A'+1)
+		if column > maxXLSXColumns {
+			return 0, fmt.Errorf("cell reference %q is past the last column XFD", ref)
+		}
+	}
+	if column == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return column - 1, nil
+}
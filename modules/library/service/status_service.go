@@ -0,0 +1,400 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"github.com/google/uuid"
+	"maps"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"slices"
+	"strings"
+	"time"
+)
+
+type statusTransition struct {
+	From string
+	To   string
+}
+
+// statusTransitions maps every workflow action to the status it leaves and the status it enters.
+var statusTransitions = map[string]statusTransition{
+	entity.StatusActionSubmit:  {From: entity.ContentStatusDraft, To: entity.ContentStatusInReview},
+	entity.StatusActionReject:  {From: entity.ContentStatusInReview, To: entity.ContentStatusDraft},
+	entity.StatusActionPublish: {From: entity.ContentStatusInReview, To: entity.ContentStatusPublished},
+	entity.StatusActionArchive: {From: entity.ContentStatusPublished, To: entity.ContentStatusArchived},
+	entity.StatusActionRestore: {From: entity.ContentStatusArchived, To: entity.ContentStatusDraft},
+}
+
+// toeicPartQuestionCounts lists, for the TOEIC formats, every part an exam must have and its question count.
+var toeicPartQuestionCounts = map[string]map[int32]int{
+	"TOEIC L&R": {1: 6, 2: 25, 3: 39, 4: 30, 5: 30, 6: 16, 7: 54},
+	"TOEIC S&W": {1: 2, 2: 2, 3: 3, 4: 3, 5: 1, 6: 5, 7: 2, 8: 1},
+}
+
+// answerKeyQuestionTypes lists the question types that cannot be published without a correct_answer.
+var answerKeyQuestionTypes = map[string]bool{
+	"MultipleChoice": true,
+	"Matching":       true,
+	"Ordering":       true,
+	"FillInTheBlank": true,
+	"ShortAnswer":    true,
+}
+
+// TransitionExam applies a workflow action to an exam. Submitting and publishing run the publish checks,
+// so an exam never reaches review or learners while it is incomplete.
+func (s *LibraryService) TransitionExam(ctx context.Context, token string, examId uuid.UUID, action string, request *dto.ChangeStatusRequest) (*dto.ContentStatusResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	exam, err := s.repo.GetExam(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:TransitionExam:Exam not found", err)
+		}
+		logger.Error("LibraryService:TransitionExam:Failed to get exam", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:TransitionExam:Failed to get exam", err)
+	}
+	transition, appErr := checkTransition(action, exam.Status)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if needsPublishCheck(transition) {
+		check, appErr := s.CheckExamPublishable(ctx, examId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !check.Publishable {
+			return nil, errors.NewAppError(errors.ErrBusinessRule, "LibraryService:TransitionExam:Exam is not ready to publish: "+strings.Join(check.Issues, "; "), nil)
+		}
+	}
+	if appErr = s.changeStatus(ctx, token, entity.ContentTypeExam, examId, transition, request.Note); appErr != nil {
+		return nil, appErr
+	}
+
+	exam, err = s.repo.GetExam(ctx, examId)
+	if err != nil {
+		logger.Error("LibraryService:TransitionExam:Failed to get exam", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:TransitionExam:Failed to get exam", err)
+	}
+	return &dto.ContentStatusResponse{
+		ContentType: entity.ContentTypeExam,
+		ContentID:   examId,
+		Status:      exam.Status,
+		PublishedAt: exam.PublishedAt,
+	}, nil
+}
+
+// TransitionPracticePart applies a workflow action to a practice part.
+func (s *LibraryService) TransitionPracticePart(ctx context.Context, token string, partId uuid.UUID, action string, request *dto.ChangeStatusRequest) (*dto.ContentStatusResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	part, appErr := s.getPracticePart(ctx, partId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	transition, appErr := checkTransition(action, part.Status)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if needsPublishCheck(transition) {
+		check, appErr := s.CheckPracticePartPublishable(ctx, partId)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if !check.Publishable {
+			return nil, errors.NewAppError(errors.ErrBusinessRule, "LibraryService:TransitionPracticePart:Practice part is not ready to publish: "+strings.Join(check.Issues, "; "), nil)
+		}
+	}
+	if appErr = s.changeStatus(ctx, token, entity.ContentTypePart, partId, transition, request.Note); appErr != nil {
+		return nil, appErr
+	}
+
+	part, appErr = s.getPracticePart(ctx, partId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return &dto.ContentStatusResponse{
+		ContentType: entity.ContentTypePart,
+		ContentID:   partId,
+		Status:      part.Status,
+		PublishedAt: part.PublishedAt,
+	}, nil
+}
+
+// CheckExamPublishable runs the publish checks on an exam without changing it.
+func (s *LibraryService) CheckExamPublishable(ctx context.Context, examId uuid.UUID) (*dto.PublishCheckResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	tree, err := s.repo.GetExamTree(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:CheckExamPublishable:Exam not found", err)
+		}
+		logger.Error("LibraryService:CheckExamPublishable:Failed to get exam tree", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:CheckExamPublishable:Failed to get exam tree", err)
+	}
+	return toPublishCheckResponse(examPublishIssues(tree)), nil
+}
+
+// CheckPracticePartPublishable runs the publish checks on a practice part without changing it.
+func (s *LibraryService) CheckPracticePartPublishable(ctx context.Context, partId uuid.UUID) (*dto.PublishCheckResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	part, appErr := s.getPracticePart(ctx, partId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	partTrees, err := s.repo.GetPartTrees(ctx, []*entity.ExamPart{part})
+	if err != nil {
+		logger.Error("LibraryService:CheckPracticePartPublishable:Failed to get part content", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:CheckPracticePartPublishable:Failed to get part content", err)
+	}
+	return toPublishCheckResponse(partPublishIssues(partTrees[0])), nil
+}
+
+func (s *LibraryService) GetExamStatusHistory(ctx context.Context, examId uuid.UUID) ([]*dto.StatusChangeResponse, *errors.AppError) {
+	return s.getStatusHistory(ctx, entity.ContentTypeExam, examId)
+}
+
+func (s *LibraryService) GetPracticePartStatusHistory(ctx context.Context, partId uuid.UUID) ([]*dto.StatusChangeResponse, *errors.AppError) {
+	return s.getStatusHistory(ctx, entity.ContentTypePart, partId)
+}
+
+func (s *LibraryService) getStatusHistory(ctx context.Context, contentType string, contentId uuid.UUID) ([]*dto.StatusChangeResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	changes, err := s.repo.GetStatusChanges(ctx, contentType, contentId)
+	if err != nil {
+		logger.Error("LibraryService:getStatusHistory:Failed to get status changes", "content_id", contentId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:getStatusHistory:Failed to get status changes", err)
+	}
+	return mapper.ToStatusChangesResponse(changes), nil
+}
+
+func (s *LibraryService) changeStatus(ctx context.Context, token string, contentType string, contentId uuid.UUID, transition statusTransition, note string) *errors.AppError {
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("LibraryService:changeStatus:Failed to validate token", "error", err)
+		return errors.NewAppError(errors.ErrUnauthorized, "LibraryService:changeStatus:Failed to get user", err)
+	}
+	changed, err := s.repo.ChangeStatus(ctx, &entity.StatusChange{
+		ContentType: contentType,
+		ContentID:   contentId,
+		FromStatus:  transition.From,
+		ToStatus:    transition.To,
+		ChangedBy:   claims.UserID,
+		Note:        strings.TrimSpace(note),
+	})
+	if err != nil {
+		logger.Error("LibraryService:changeStatus:Failed to change status", "content_id", contentId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:changeStatus:Failed to change status", err)
+	}
+	if !changed {
+		return errors.NewAppError(errors.ErrInvalidState, "LibraryService:changeStatus:Status was changed by someone else, reload and retry", nil)
+	}
+	s.invalidateContent(ctx)
+	return nil
+}
+
+func (s *LibraryService) getPracticePart(ctx context.Context, partId uuid.UUID) (*entity.ExamPart, *errors.AppError) {
+	part, err := s.repo.GetExamPart(ctx, partId)
+	if err != nil && !stderrors.Is(err, sql.ErrNoRows) {
+		logger.Error("LibraryService:getPracticePart:Failed to get part", "part_id", partId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:getPracticePart:Failed to get part", err)
+	}
+	if part == nil || !part.IsPracticeComponent {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:getPracticePart:Practice part not found", err)
+	}
+	return part, nil
+}
+
+// ensureExamEditable rejects edits to an exam under review or visible to learners; it has to be
+// rejected, or archived and restored, back to DRAFT first.
+func (s *LibraryService) ensureExamEditable(ctx context.Context, examId uuid.UUID) *errors.AppError {
+	exam, err := s.repo.GetExam(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:ensureExamEditable:Exam not found", err)
+		}
+		logger.Error("LibraryService:ensureExamEditable:Failed to get exam", "exam_id", examId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ensureExamEditable:Failed to get exam", err)
+	}
+	if exam.Status != entity.ContentStatusDraft {
+		return errors.NewAppError(errors.ErrInvalidState, "LibraryService:ensureExamEditable:Exam is "+exam.Status+", move it back to DRAFT before editing", nil)
+	}
+	return nil
+}
+
+// ensurePartEditable applies ensureExamEditable to the exam owning a part, and the same rule to the
+// part itself when it is published on its own as a practice part.
+func (s *LibraryService) ensurePartEditable(ctx context.Context, partId uuid.UUID) *errors.AppError {
+	part, err := s.repo.GetExamPart(ctx, partId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:ensurePartEditable:Part not found", err)
+		}
+		logger.Error("LibraryService:ensurePartEditable:Failed to get part", "part_id", partId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ensurePartEditable:Failed to get part", err)
+	}
+	if part.IsPracticeComponent && part.Status != entity.ContentStatusDraft {
+		return errors.NewAppError(errors.ErrInvalidState, "LibraryService:ensurePartEditable:Practice part is "+part.Status+", move it back to DRAFT before editing", nil)
+	}
+	if part.ExamID != uuid.Nil {
+		return s.ensureExamEditable(ctx, part.ExamID)
+	}
+	return nil
+}
+
+func (s *LibraryService) ensureParagraphEditable(ctx context.Context, paragraphId uuid.UUID) *errors.AppError {
+	return s.ensureParagraphMovable(ctx, paragraphId, uuid.Nil)
+}
+
+// ensureParagraphMovable applies ensureParagraphEditable, and the same rule to the part the paragraph
+// is moved to: moving it into published content would change that content too.
+func (s *LibraryService) ensureParagraphMovable(ctx context.Context, paragraphId, partId uuid.UUID) *errors.AppError {
+	paragraph, err := s.repo.GetParagraph(ctx, paragraphId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:ensureParagraphMovable:Paragraph not found", err)
+		}
+		logger.Error("LibraryService:ensureParagraphMovable:Failed to get paragraph", "paragraph_id", paragraphId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ensureParagraphMovable:Failed to get paragraph", err)
+	}
+	if appErr := s.ensurePartEditable(ctx, paragraph.PartID); appErr != nil {
+		return appErr
+	}
+	if partId != uuid.Nil && partId != paragraph.PartID {
+		return s.ensurePartEditable(ctx, partId)
+	}
+	return nil
+}
+
+func (s *LibraryService) ensureQuestionEditable(ctx context.Context, questionId uuid.UUID) *errors.AppError {
+	return s.ensureQuestionMovable(ctx, questionId, uuid.Nil)
+}
+
+// ensureQuestionMovable is ensureParagraphMovable for questions.
+func (s *LibraryService) ensureQuestionMovable(ctx context.Context, questionId, partId uuid.UUID) *errors.AppError {
+	question, err := s.repo.GetQuestion(ctx, questionId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:ensureQuestionMovable:Question not found", err)
+		}
+		logger.Error("LibraryService:ensureQuestionMovable:Failed to get question", "question_id", questionId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:ensureQuestionMovable:Failed to get question", err)
+	}
+	if appErr := s.ensurePartEditable(ctx, question.PartID); appErr != nil {
+		return appErr
+	}
+	if partId != uuid.Nil && partId != question.PartID {
+		return s.ensurePartEditable(ctx, partId)
+	}
+	return nil
+}
+
+func checkTransition(action, status string) (statusTransition, *errors.AppError) {
+	transition, ok := statusTransitions[action]
+	if !ok {
+		return statusTransition{}, errors.NewAppError(errors.ErrInvalidInput, "LibraryService:checkTransition:Unknown action "+action, nil)
+	}
+	if status != transition.From {
+		return statusTransition{}, errors.NewAppError(errors.ErrInvalidState, fmt.Sprintf("LibraryService:checkTransition:Cannot %s content that is %s", action, status), nil)
+	}
+	return transition, nil
+}
+
+// needsPublishCheck reports whether a transition sends content towards learners.
+func needsPublishCheck(transition statusTransition) bool {
+	return transition.To == entity.ContentStatusInReview || transition.To == entity.ContentStatusPublished
+}
+
+func toPublishCheckResponse(issues []string) *dto.PublishCheckResponse {
+	if issues == nil {
+		issues = []string{}
+	}
+	return &dto.PublishCheckResponse{
+		Publishable: len(issues) == 0,
+		Issues:      issues,
+	}
+}
+
+// examPublishIssues checks that an exam has content in every part and, for the TOEIC formats,
+// exactly the official parts with their official question counts.
+func examPublishIssues(tree *entity.ExamTree) []string {
+	var issues []string
+	if len(tree.Parts) == 0 {
+		return append(issues, "exam has no parts")
+	}
+	for _, part := range tree.Parts {
+		issues = append(issues, partPublishIssues(part)...)
+	}
+
+	counts, ok := toeicPartQuestionCounts[tree.Exam.ExamType]
+	if !ok {
+		return issues
+	}
+	seen := make(map[int32]bool, len(counts))
+	for _, part := range tree.Parts {
+		number := part.Part.ToeicPartNumber
+		expected, ok := counts[number]
+		switch {
+		case !ok:
+			issues = append(issues, fmt.Sprintf("part %q has TOEIC part number %d, which is not part of %s", part.Part.PartTitle, number, tree.Exam.ExamType))
+		case seen[number]:
+			issues = append(issues, fmt.Sprintf("TOEIC part %d appears more than once", number))
+		default:
+			if got := countQuestions(part); got != expected {
+				issues = append(issues, fmt.Sprintf("TOEIC part %d has %d questions, expected %d", number, got, expected))
+			}
+		}
+		seen[number] = true
+	}
+	for _, number := range slices.Sorted(maps.Keys(counts)) {
+		if !seen[number] {
+			issues = append(issues, fmt.Sprintf("TOEIC part %d is missing", number))
+		}
+	}
+	return issues
+}
+
+// partPublishIssues checks that a part has questions and that every question needing an answer key has a
+// well-formed one.
+func partPublishIssues(part *entity.PartTree) []string {
+	var issues []string
+	if countQuestions(part) == 0 {
+		issues = append(issues, fmt.Sprintf("part %q has no questions", part.Part.PartTitle))
+	}
+	check := func(questions []*entity.Question) {
+		for _, question := range questions {
+			if answerKeyQuestionTypes[question.QuestionType] && strings.TrimSpace(question.CorrectAnswer) == "" {
+				issues = append(issues, fmt.Sprintf("question %d of part %q (%s) has no correct_answer", question.QuestionNumberInPart, part.Part.PartTitle, question.QuestionID))
+			} else if _, err := mapper.UnmarshalAnswerKey(question.QuestionType, question.CorrectAnswer); err != nil {
+				issues = append(issues, fmt.Sprintf("question %d of part %q (%s) has a malformed correct_answer", question.QuestionNumberInPart, part.Part.PartTitle, question.QuestionID))
+			}
+		}
+	}
+	for _, paragraph := range part.Paragraphs {
+		check(paragraph.Questions)
+	}
+	check(part.Questions)
+	return issues
+}
+
+func countQuestions(part *entity.PartTree) int {
+	count := len(part.Questions)
+	for _, paragraph := range part.Paragraphs {
+		count += len(paragraph.Questions)
+	}
+	return count
+}
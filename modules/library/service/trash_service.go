@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"path"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/storage"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DeleteExam moves an exam to the trash with all of its content. Only drafts and archived exams can be
+// deleted, so learners never lose an exam they are being offered.
+func (s *LibraryService) DeleteExam(ctx context.Context, token string, examId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	exam, err := s.repo.GetExam(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteExam:Exam not found", err)
+		}
+		logger.Error("LibraryService:DeleteExam:Failed to get exam", "exam_id", examId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteExam:Failed to get exam", err)
+	}
+	if appErr = checkDeletable("Exam", exam.Status); appErr != nil {
+		return appErr
+	}
+	trashed, err := s.repo.TrashExam(ctx, examId, editorId)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteExam:Failed to delete exam", err)
+	}
+	if !trashed {
+		return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteExam:Exam not found", nil)
+	}
+	s.invalidateContent(ctx)
+	return nil
+}
+
+// DeleteExamPart moves a part to the trash with its paragraphs and questions. A part of an exam follows
+// the editing rule of its exam; a practice part must be a draft or archived itself.
+func (s *LibraryService) DeleteExamPart(ctx context.Context, token string, partId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	part, err := s.repo.GetExamPart(ctx, partId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteExamPart:Part not found", err)
+		}
+		logger.Error("LibraryService:DeleteExamPart:Failed to get part", "part_id", partId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteExamPart:Failed to get part", err)
+	}
+	if part.IsPracticeComponent {
+		if appErr = checkDeletable("Practice part", part.Status); appErr != nil {
+			return appErr
+		}
+	}
+	if part.ExamID != uuid.Nil {
+		if appErr = s.ensureExamEditable(ctx, part.ExamID); appErr != nil {
+			return appErr
+		}
+	}
+	trashed, err := s.repo.TrashExamPart(ctx, partId, editorId)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteExamPart:Failed to delete part", err)
+	}
+	if !trashed {
+		return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteExamPart:Part not found", nil)
+	}
+	s.invalidateContent(ctx)
+	return nil
+}
+
+// DeleteParagraph moves a paragraph to the trash with its questions.
+func (s *LibraryService) DeleteParagraph(ctx context.Context, token string, paragraphId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = s.ensureParagraphEditable(ctx, paragraphId); appErr != nil {
+		return appErr
+	}
+	trashed, err := s.repo.TrashParagraph(ctx, paragraphId, editorId)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteParagraph:Failed to delete paragraph", err)
+	}
+	if !trashed {
+		return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteParagraph:Paragraph not found", nil)
+	}
+	s.invalidateContent(ctx)
+	return nil
+}
+
+func (s *LibraryService) DeleteQuestion(ctx context.Context, token string, questionId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	editorId, appErr := editorID(token)
+	if appErr != nil {
+		return appErr
+	}
+	if appErr = s.ensureQuestionEditable(ctx, questionId); appErr != nil {
+		return appErr
+	}
+	trashed, err := s.repo.TrashQuestion(ctx, questionId, editorId)
+	if err != nil {
+		return errors.NewAppError(errors.ErrInternal, "LibraryService:DeleteQuestion:Failed to delete question", err)
+	}
+	if !trashed {
+		return errors.NewAppError(errors.ErrNotFound, "LibraryService:DeleteQuestion:Question not found", nil)
+	}
+	s.invalidateContent(ctx)
+	return nil
+}
+
+// GetTrash lists the content deleted on its own; children deleted along with it come back with it and
+// are not listed.
+func (s *LibraryService) GetTrash(ctx context.Context, contentType string, pageNumber, pageSize int) (*dto.PaginatedTrashItemResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	items, err := s.repo.GetTrashItems(ctx, contentType, pageNumber, pageSize)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:GetTrash:Failed to get trash", err)
+	}
+	return mapper.ToPaginatedTrashItemResponse(items), nil
+}
+
+// RestoreFromTrash takes an item out of the trash with the children deleted along with it. The item
+// goes back under its parent, which has to be out of the trash and editable.
+func (s *LibraryService) RestoreFromTrash(ctx context.Context, token string, contentType string, contentId uuid.UUID) (*dto.TrashItemResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	if _, appErr := editorID(token); appErr != nil {
+		return nil, appErr
+	}
+	item, err := s.repo.GetTrashItem(ctx, contentType, contentId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreFromTrash:Item not found in the trash", err)
+		}
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreFromTrash:Failed to get trash item", err)
+	}
+	if appErr := s.ensureParentRestorable(ctx, item); appErr != nil {
+		return nil, appErr
+	}
+	restored, err := s.repo.RestoreTrashItem(ctx, item)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:RestoreFromTrash:Failed to restore item", err)
+	}
+	if !restored {
+		return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:RestoreFromTrash:Item not found in the trash", nil)
+	}
+	s.invalidateContent(ctx)
+	return mapper.ToTrashItemResponse(item), nil
+}
+
+func (s *LibraryService) ensureParentRestorable(ctx context.Context, item *entity.TrashItem) *errors.AppError {
+	var appErr *errors.AppError
+	switch item.ParentType {
+	case entity.ContentTypeExam:
+		appErr = s.ensureExamEditable(ctx, item.ParentID)
+	case entity.ContentTypePart:
+		appErr = s.ensurePartEditable(ctx, item.ParentID)
+	case entity.ContentTypeParagraph:
+		appErr = s.ensureParagraphEditable(ctx, item.ParentID)
+	}
+	if appErr != nil && appErr.Code == errors.ErrNotFound {
+		return errors.NewAppError(errors.ErrInvalidState, "LibraryService:ensureParentRestorable:"+strings.ToLower(item.ParentType)+" "+item.ParentID.String()+" is in the trash, restore it first", appErr)
+	}
+	return appErr
+}
+
+// PurgeTrash deletes for good the content deleted before deletedBefore, then the uploaded files no
+// content refers to anymore. Files uploaded after uploadedBefore are kept, since the content they were
+// uploaded for may not be saved yet.
+func (s *LibraryService) PurgeTrash(ctx context.Context, deletedBefore, uploadedBefore time.Time) (*entity.PurgeResult, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 30*time.Minute)
+	defer cancel()
+
+	result, err := s.repo.PurgeTrash(ctx, deletedBefore)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:PurgeTrash:Failed to purge trash", err)
+	}
+	result.MediaFiles, err = s.purgeOrphanMedia(ctx, uploadedBefore)
+	if err != nil {
+		return result, errors.NewAppError(errors.ErrInternal, "LibraryService:PurgeTrash:Failed to purge media files", err)
+	}
+	if result.Exams+result.Parts+result.Paragraphs+result.Questions > 0 {
+		s.invalidateContent(ctx)
+	}
+	return result, nil
+}
+
+// purgeOrphanMedia deletes the content files that neither content nor a revision refers to. Transcript
+// audio is not stored on the content but named after it, so it is kept while its content exists.
+func (s *LibraryService) purgeOrphanMedia(ctx context.Context, uploadedBefore time.Time) (int, error) {
+	// Files are listed first: a file uploaded and saved while the references are read is then either
+	// too recent to be deleted or already referenced.
+	objects, err := s.storage.ListContentObjects(ctx)
+	if err != nil {
+		logger.Error("LibraryService:purgeOrphanMedia:Failed to list media files", "error", err)
+		return 0, err
+	}
+	references, err := s.repo.GetMediaReferences(ctx)
+	if err != nil {
+		return 0, err
+	}
+	contentIds, err := s.repo.GetContentIds(ctx)
+	if err != nil {
+		return 0, err
+	}
+	referenced := make(map[string]bool, len(references))
+	for _, reference := range references {
+		referenced[reference] = true
+	}
+	existing := make(map[uuid.UUID]bool, len(contentIds))
+	for _, id := range contentIds {
+		existing[id] = true
+	}
+
+	deleted := 0
+	for _, object := range objects {
+		if object.LastModified.After(uploadedBefore) ||
+			slices.ContainsFunc(object.References, func(reference string) bool { return referenced[reference] }) ||
+			existing[transcriptContentID(object)] {
+			continue
+		}
+		if err := s.storage.DeleteObject(ctx, object.Bucket, object.Name); err != nil {
+			logger.Error("LibraryService:purgeOrphanMedia:Failed to delete media file", "bucket", object.Bucket, "object", object.Name, "error", err)
+			continue
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// transcriptContentID returns the question or paragraph a transcript audio file is named after, or
+// uuid.Nil when the file is not a transcript.
+func transcriptContentID(object storage.StoredObject) uuid.UUID {
+	name, ok := strings.CutPrefix(object.Name, TranscriptFolder+"/")
+	if object.Bucket != storage.AudioBucket || !ok {
+		return uuid.Nil
+	}
+	id, _, _ := strings.Cut(strings.TrimSuffix(name, path.Ext(name)), "_")
+	contentId, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil
+	}
+	return contentId
+}
+
+func checkDeletable(content, status string) *errors.AppError {
+	if status != entity.ContentStatusDraft && status != entity.ContentStatusArchived {
+		return errors.NewAppError(errors.ErrInvalidState, "LibraryService:checkDeletable:"+content+" is "+status+", only DRAFT or ARCHIVED content can be deleted", nil)
+	}
+	return nil
+}
+
+type PurgeConfig struct {
+	Interval    time.Duration // how often the trash is purged
+	Retention   time.Duration // how long deleted content can be restored
+	UploadGrace time.Duration // how long an unreferenced upload is kept for the content being edited
+}
+
+func DefaultPurgeConfig() PurgeConfig {
+	return PurgeConfig{
+		Interval:    6 * time.Hour,
+		Retention:   30 * 24 * time.Hour,
+		UploadGrace: 24 * time.Hour,
+	}
+}
+
+// TrashPurger purges the trash in the background. Every instance runs its own purger; purges are
+// idempotent, so overlapping runs only repeat work.
+type TrashPurger struct {
+	service ILibraryService
+	config  PurgeConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTrashPurger(service ILibraryService, config PurgeConfig) *TrashPurger {
+	defaults := DefaultPurgeConfig()
+	if config.Interval <= 0 {
+		config.Interval = defaults.Interval
+	}
+	if config.Retention <= 0 {
+		config.Retention = defaults.Retention
+	}
+	if config.UploadGrace <= 0 {
+		config.UploadGrace = defaults.UploadGrace
+	}
+	return &TrashPurger{
+		service: service,
+		config:  config,
+	}
+}
+
+// Start purges the trash right away, then every Interval until Stop is called.
+func (p *TrashPurger) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	p.wg.Add(1)
+	go p.run(ctx)
+	logger.Info("Trash purger started", "interval", p.config.Interval, "retention", p.config.Retention)
+}
+
+// Stop waits for a running purge to finish.
+func (p *TrashPurger) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	p.wg.Wait()
+	logger.Info("Trash purger stopped")
+}
+
+func (p *TrashPurger) run(ctx context.Context) {
+	defer p.wg.Done()
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		p.purge(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *TrashPurger) purge(ctx context.Context) {
+	now := time.Now()
+	result, appErr := p.service.PurgeTrash(ctx, now.Add(-p.config.Retention), now.Add(-p.config.UploadGrace))
+	if appErr != nil {
+		if ctx.Err() == nil {
+			logger.Error("TrashPurger:purge:Failed to purge trash", "error", appErr)
+		}
+		return
+	}
+	logger.Info("Trash purged",
+		"exams", result.Exams,
+		"parts", result.Parts,
+		"paragraphs", result.Paragraphs,
+		"questions", result.Questions,
+		"media_files", result.MediaFiles)
+}
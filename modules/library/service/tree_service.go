@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"time"
+)
+
+func (s *LibraryService) GetExamTree(ctx context.Context, examId uuid.UUID) (*dto.ExamTreeResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	tree, appErr := s.getExamTree(ctx, examId)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return mapper.ToExamTreeResponse(tree), nil
+}
+
+// getExamTree returns the exam tree from the cache, loading and caching it on a miss.
+// The cache is an optimisation only: when Redis fails the tree is read from the database.
+func (s *LibraryService) getExamTree(ctx context.Context, examId uuid.UUID) (*entity.ExamTree, *errors.AppError) {
+	version, err := s.cache.Get(ctx, constants.ContentVersionKey).Int64()
+	cacheUsable := err == nil || err == redis.Nil
+	if !cacheUsable {
+		logger.Error("LibraryService:getExamTree:Failed to read content version", "error", err)
+	}
+	key := fmt.Sprintf(constants.ExamTreeCacheKey, examId, version)
+
+	tree := new(entity.ExamTree)
+	if cacheUsable {
+		cached, err := s.cache.Get(ctx, key).Result()
+		if err == nil && json.Unmarshal([]byte(cached), tree) == nil {
+			return tree, nil
+		}
+		if err != nil && err != redis.Nil {
+			logger.Error("LibraryService:getExamTree:Failed to read exam tree cache", "exam_id", examId, "error", err)
+		}
+	}
+
+	tree, err = s.repo.GetExamTree(ctx, examId)
+	if err != nil {
+		if stderrors.Is(err, sql.ErrNoRows) {
+			return nil, errors.NewAppError(errors.ErrNotFound, "LibraryService:getExamTree:Exam not found", err)
+		}
+		logger.Error("LibraryService:getExamTree:Failed to load exam tree", "exam_id", examId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "LibraryService:getExamTree:Failed to load exam tree", err)
+	}
+	if cacheUsable {
+		encoded, _ := json.Marshal(tree)
+		if err = s.cache.Set(ctx, key, encoded, constants.ExamTreeCacheExpiry); err != nil {
+			logger.Error("LibraryService:getExamTree:Failed to write exam tree cache", "exam_id", examId, "error", err)
+		}
+	}
+	return tree, nil
+}
+
+// invalidateContent bumps the content version after a library write, which retires every cached exam tree.
+func (s *LibraryService) invalidateContent(ctx context.Context) {
+	if _, err := s.cache.Incr(ctx, constants.ContentVersionKey); err != nil {
+		logger.Error("LibraryService:invalidateContent:Failed to bump content version", "error", err)
+	}
+}
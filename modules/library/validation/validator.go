@@ -1,10 +1,14 @@
 package validation
 
 import (
+	"fmt"
 	"github.com/google/uuid"
 	"pirate-lang-go/core/utils"
 	"pirate-lang-go/core/validation"
 	"pirate-lang-go/modules/library/dto"
+	"pirate-lang-go/modules/library/entity"
+	"pirate-lang-go/modules/library/mapper"
+	"strings"
 )
 
 var ValidParagraphTypes = map[string]bool{
@@ -46,6 +50,35 @@ var ValidExamTypes = map[string]bool{
 	"TOEIC Bridge": true,
 	"General":      true,
 }
+var ValidContentStatuses = map[string]bool{
+	"DRAFT":     true,
+	"IN_REVIEW": true,
+	"PUBLISHED": true,
+	"ARCHIVED":  true,
+}
+var ValidTrashContentTypes = map[string]bool{
+	"EXAM":      true,
+	"PART":      true,
+	"PARAGRAPH": true,
+	"QUESTION":  true,
+}
+
+// Length limits of a question explanation and of the explanation of one answer option.
+const (
+	MaxExplanationLength       = 10000
+	MaxOptionExplanationLength = 2000
+)
+
+var ValidChoiceLetters = map[string]bool{
+	"A": true,
+	"B": true,
+	"C": true,
+	"D": true,
+}
+
+// MaxResponseWindowSeconds bounds the preparation and response windows of a Speaking or Writing question.
+const MaxResponseWindowSeconds = 3600
+
 var ValidToeicQuestionSections = map[string]bool{
 	"Listening": true,
 	"Reading":   true,
@@ -308,6 +341,8 @@ func ValidateCreateQuestion(dataRequest *dto.CreateQuestionRequest) *validation.
 			result.AddError("toeic_question_section", "Invalid TOEIC question section. Must be 'Listening', 'Reading', 'Speaking', or 'Writing'.")
 		}
 	}
+	validateResponseWindows(result, dataRequest.PreparationSeconds, dataRequest.ResponseSeconds)
+	validateAnswerSchema(result, dataRequest.QuestionType, dataRequest.AnswerOption, dataRequest.CorrectAnswer)
 
 	return result
 }
@@ -344,6 +379,163 @@ func ValidateUpdateQuestion(dataRequest *dto.UpdateQuestionRequest) *validation.
 			result.AddError("toeic_question_section", "Invalid TOEIC question section. Must be 'Listening', 'Reading', 'Speaking', or 'Writing'.")
 		}
 	}
+	validateResponseWindows(result, dataRequest.PreparationSeconds, dataRequest.ResponseSeconds)
+	validateAnswerSchema(result, dataRequest.QuestionType, dataRequest.AnswerOption, dataRequest.CorrectAnswer)
+
+	return result
+}
+
+// validateAnswerSchema checks answer_option and correct_answer against the schema of the question type.
+// correct_answer may stay empty while a question is drafted; publishing requires it.
+func validateAnswerSchema(result *validation.ValidationResult, questionType, answerOption, correctAnswer string) {
+	option, err := mapper.UnmarshalAnswerOption(answerOption)
+	if err != nil {
+		result.AddError("answer_option", "Answer option must be a JSON object")
+		return
+	}
+	key, err := mapper.UnmarshalAnswerKey(questionType, correctAnswer)
+	if err != nil {
+		result.AddError("correct_answer", "Correct answer does not follow the schema of "+questionType+" questions")
+		return
+	}
+	hasLetters := option.A != nil || option.B != nil || option.C != nil || option.D != nil
+	hasEntries := len(option.Prompts) > 0 || len(option.Choices) > 0 || len(option.Items) > 0 || option.Blanks != 0
+	empty := utils.IsEmpty(correctAnswer)
+
+	switch {
+	case entity.ChoiceQuestionTypes[questionType]:
+		if hasEntries {
+			result.AddError("answer_option", "Choice questions take options A to D only")
+		}
+		letter := strings.ToUpper(key.Choice)
+		if !empty && !ValidChoiceLetters[letter] {
+			result.AddError("correct_answer", "Correct answer must be one of A, B, C or D")
+		} else if !empty && hasLetters && !mapper.AnswerOptionKeys(option)[letter] {
+			result.AddError("correct_answer", "Correct answer must be one of the answer options")
+		}
+	case questionType == entity.QuestionTypeTrueFalse:
+		if hasLetters || hasEntries {
+			result.AddError("answer_option", "True/false questions take no answer options")
+		}
+		if !empty && key.Choice != "true" && key.Choice != "false" {
+			result.AddError("correct_answer", "Correct answer must be true or false")
+		}
+	case questionType == entity.QuestionTypeMatching:
+		prompts := validateOptionItems(result, "prompts", option.Prompts, 1)
+		choices := validateOptionItems(result, "choices", option.Choices, 1)
+		if empty {
+			break
+		}
+		for prompt := range prompts {
+			if _, ok := key.Pairs[prompt]; !ok {
+				result.AddError("correct_answer", "Correct answer must match prompt "+prompt)
+			}
+		}
+		for prompt, choice := range key.Pairs {
+			if !prompts[prompt] {
+				result.AddError("correct_answer", "Correct answer matches unknown prompt "+prompt)
+			} else if !choices[choice] {
+				result.AddError("correct_answer", "Prompt "+prompt+" is matched to unknown choice "+choice)
+			}
+		}
+	case questionType == entity.QuestionTypeOrdering:
+		items := validateOptionItems(result, "items", option.Items, 2)
+		if empty {
+			break
+		}
+		complete := len(key.Order) == len(items)
+		seen := make(map[string]bool, len(key.Order))
+		for _, item := range key.Order {
+			complete = complete && items[item] && !seen[item]
+			seen[item] = true
+		}
+		if !complete {
+			result.AddError("correct_answer", "Correct answer must list every item key once")
+		}
+	case questionType == entity.QuestionTypeFillInTheBlank:
+		if option.Blanks <= 0 {
+			result.AddError("answer_option", "Fill-in-the-blank questions need the number of blanks")
+		}
+		if empty {
+			break
+		}
+		if int32(len(key.Blanks)) != option.Blanks {
+			result.AddError("correct_answer", "Correct answer must give the accepted answers of every blank")
+		}
+		for _, variants := range key.Blanks {
+			if !validVariants(variants) {
+				result.AddError("correct_answer", "Every blank needs at least one accepted answer, none of them empty")
+				break
+			}
+		}
+	case questionType == entity.QuestionTypeShortAnswer:
+		if !empty && !validVariants(key.Accepted) {
+			result.AddError("correct_answer", "Correct answer needs at least one accepted answer, none of them empty")
+		}
+	}
+}
+
+// validateOptionItems requires at least minItems entries with distinct, non-empty keys and returns the keys.
+func validateOptionItems(result *validation.ValidationResult, field string, items []dto.OptionItem, minItems int) map[string]bool {
+	if len(items) < minItems {
+		result.AddError("answer_option", fmt.Sprintf("Answer option needs at least %d %s", minItems, field))
+	}
+	keys := make(map[string]bool, len(items))
+	for _, item := range items {
+		switch {
+		case utils.IsEmpty(item.Key):
+			result.AddError("answer_option", "Every entry of "+field+" needs a key")
+		case keys[item.Key]:
+			result.AddError("answer_option", "Key "+item.Key+" is used twice in "+field)
+		case utils.IsEmpty(item.Text):
+			result.AddError("answer_option", "Entry "+item.Key+" of "+field+" needs a text")
+		}
+		keys[item.Key] = true
+	}
+	return keys
+}
+
+func validVariants(variants []string) bool {
+	for _, variant := range variants {
+		if utils.IsEmpty(variant) {
+			return false
+		}
+	}
+	return len(variants) > 0
+}
+
+func validateResponseWindows(result *validation.ValidationResult, preparationSeconds, responseSeconds *int32) {
+	if preparationSeconds != nil && (*preparationSeconds < 0 || *preparationSeconds > MaxResponseWindowSeconds) {
+		result.AddError("preparation_seconds", "Preparation seconds must be between 0 and 3600")
+	}
+	if responseSeconds != nil && (*responseSeconds <= 0 || *responseSeconds > MaxResponseWindowSeconds) {
+		result.AddError("response_seconds", "Response seconds must be between 1 and 3600")
+	}
+}
+
+func ValidateQuestionExplanation(dataRequest *dto.QuestionExplanationRequest) *validation.ValidationResult {
+	if dataRequest == nil {
+		return nil
+	}
+	result := validation.NewValidationResult()
+
+	if utils.IsEmpty(dataRequest.Explanation) && len(dataRequest.OptionExplanations) == 0 {
+		result.AddError("explanation", "An explanation or at least one option explanation is required")
+	}
+	if len([]rune(dataRequest.Explanation)) > MaxExplanationLength {
+		result.AddError("explanation", "Explanation must be at most 10000 characters")
+	}
+	for option, explanation := range dataRequest.OptionExplanations {
+		if utils.IsEmpty(option) {
+			result.AddError("option_explanations", "Option key is required")
+		}
+		if utils.IsEmpty(explanation) {
+			result.AddError("option_explanations", "Explanation of option "+option+" is required")
+		}
+		if len([]rune(explanation)) > MaxOptionExplanationLength {
+			result.AddError("option_explanations", "Explanation of option "+option+" must be at most 2000 characters")
+		}
+	}
 
 	return result
 }
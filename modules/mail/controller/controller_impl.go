@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"pirate-lang-go/core/controller"
+	"pirate-lang-go/modules/mail/service"
+)
+
+type MailController struct {
+	controller.BaseController
+	mailService service.IMailService
+}
+
+func NewMailController(service service.IMailService) *MailController {
+
+	return &MailController{
+		BaseController: controller.NewBaseController(),
+		mailService:    service,
+	}
+}
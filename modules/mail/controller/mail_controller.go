@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+)
+
+func (controller *MailController) GetFailedMails(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	pageNumber := utils.ToNumberWithDefault(c.QueryParam("pageNumber"), 1)
+	pageSize := utils.ToNumberWithDefault(c.QueryParam("pageSize"), 20)
+
+	response, appErr := controller.mailService.GetFailedMails(ctx, pageNumber, pageSize)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting failed mails", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get failed mails successfully")
+}
+
+func (controller *MailController) GetFailedMail(c echo.Context) error {
+	ctx := c.Request().Context()
+	jobId, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		return controller.BadRequest("Invalid job ID format", err.Error())
+	}
+
+	response, appErr := controller.mailService.GetFailedMail(ctx, jobId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Failed mail not found", appErr.Error())
+		}
+		return controller.InternalServerError("Error getting failed mail", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get failed mail successfully")
+}
+
+func (controller *MailController) RetryFailedMail(c echo.Context) error {
+	ctx := c.Request().Context()
+	jobId, err := uuid.Parse(c.Param("jobId"))
+	if err != nil {
+		return controller.BadRequest("Invalid job ID format", err.Error())
+	}
+
+	if appErr := controller.mailService.RetryFailedMail(ctx, jobId); appErr != nil {
+		switch appErr.Code {
+		case errors.ErrNotFound:
+			return controller.NotFound("Failed mail not found", appErr.Error())
+		case errors.ErrInvalidState:
+			return controller.BadRequest("Error retrying failed mail", appErr.Error())
+		}
+		return controller.InternalServerError("Error retrying failed mail", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Retry failed mail successfully")
+}
@@ -0,0 +1,20 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/core/entity"
+	"time"
+)
+
+type FailedMailResponse struct {
+	JobID      uuid.UUID  `json:"job_id"`
+	To         []string   `json:"to"`
+	Cc         []string   `json:"cc,omitempty"`
+	Bcc        []string   `json:"bcc,omitempty"`
+	Subject    string     `json:"subject"`
+	Attempts   int32      `json:"attempts"`
+	LastError  string     `json:"last_error"`
+	FailedAt   time.Time  `json:"failed_at"`
+	RequeuedAt *time.Time `json:"requeued_at"`
+}
+type PaginatedFailedMailResponse = entity.Pagination[*FailedMailResponse]
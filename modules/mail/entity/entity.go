@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/core/entity"
+	"pirate-lang-go/core/mailer"
+	"time"
+)
+
+// FailedMail is a mail the queue gave up on after exhausting its retries.
+type FailedMail struct {
+	JobID      uuid.UUID        `json:"job_id"`
+	Recipients string           `json:"recipients"`
+	Subject    string           `json:"subject"`
+	Email      mailer.EmailData `json:"email"`
+	Attempts   int32            `json:"attempts"`
+	LastError  string           `json:"last_error"`
+	FailedAt   time.Time        `json:"failed_at"`
+	RequeuedAt *time.Time       `json:"requeued_at"`
+}
+type PaginatedFailedMails = entity.Pagination[*FailedMail]
@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"pirate-lang-go/modules/mail/dto"
+	"pirate-lang-go/modules/mail/entity"
+)
+
+// ToFailedMailResponse leaves out the body, which may hold single-use links such as password resets.
+func ToFailedMailResponse(mail *entity.FailedMail) *dto.FailedMailResponse {
+	if mail == nil {
+		return nil
+	}
+	return &dto.FailedMailResponse{
+		JobID:      mail.JobID,
+		To:         mail.Email.To,
+		Cc:         mail.Email.Cc,
+		Bcc:        mail.Email.Bcc,
+		Subject:    mail.Subject,
+		Attempts:   mail.Attempts,
+		LastError:  mail.LastError,
+		FailedAt:   mail.FailedAt,
+		RequeuedAt: mail.RequeuedAt,
+	}
+}
+
+func ToPaginatedFailedMailsResponse(mails *entity.PaginatedFailedMails) *dto.PaginatedFailedMailResponse {
+	if mails == nil {
+		return nil
+	}
+	items := make([]*dto.FailedMailResponse, 0, len(mails.Items))
+	for _, mail := range mails.Items {
+		items = append(items, ToFailedMailResponse(mail))
+	}
+	return &dto.PaginatedFailedMailResponse{
+		Items:       items,
+		TotalItems:  mails.TotalItems,
+		TotalPages:  mails.TotalPages,
+		CurrentPage: mails.CurrentPage,
+		PageSize:    mails.PageSize,
+	}
+}
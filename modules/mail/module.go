@@ -0,0 +1,26 @@
+package mail
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	accountservice "pirate-lang-go/modules/account/service"
+	"pirate-lang-go/modules/mail/controller"
+	"pirate-lang-go/modules/mail/repository"
+	"pirate-lang-go/modules/mail/router"
+	"pirate-lang-go/modules/mail/service"
+)
+
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, queue mailer.IQueue) {
+	accountService := accountservice.NewAccountService(accountrepo.NewAccountRepository(db.DB()), cache, storage, queue)
+	middleware := middleware.NewMiddleware(accountService)
+
+	mailService := service.NewMailService(repository.NewMailRepository(db.DB()), queue)
+	router.NewMailRouter(
+		controller.NewMailController(mailService),
+	).Setup(e, middleware)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/mail/entity"
+	"strings"
+)
+
+func (r *MailRepository) SaveDeadLetter(ctx context.Context, job *mailer.Job) error {
+	payload, err := json.Marshal(job.Email)
+	if err != nil {
+		logger.Error("MailRepository.SaveDeadLetter: failed to encode mail", "job_id", job.ID, "error", err)
+		return err
+	}
+	recipients := append(append(append([]string{}, job.Email.To...), job.Email.Cc...), job.Email.Bcc...)
+	err = r.Queries.SaveMailDeadLetter(ctx, database.SaveMailDeadLetterParams{
+		JobID:      job.ID,
+		Recipients: strings.Join(recipients, ", "),
+		Subject:    job.Email.Subject,
+		Payload:    payload,
+		Attempts:   int32(job.Attempts),
+		LastError:  job.LastError,
+	})
+	if err != nil {
+		logger.Error("MailRepository.SaveDeadLetter: failed to save dead letter", "job_id", job.ID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *MailRepository) GetFailedMails(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedFailedMails, error) {
+	totalItems, err := r.Queries.GetMailDeadLettersCount(ctx)
+	if err != nil {
+		logger.Error("MailRepository.GetFailedMails: failed to count failed mails", "error", err)
+		return nil, err
+	}
+
+	offset := (pageNumber - 1) * pageSize
+	dbMails, err := r.Queries.GetPaginatedMailDeadLetters(ctx, database.GetPaginatedMailDeadLettersParams{
+		Limit:  int32(pageSize),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		logger.Error("MailRepository.GetFailedMails: failed to list failed mails",
+			"page_number", pageNumber,
+			"page_size", pageSize,
+			"error", err)
+		return nil, err
+	}
+	mails := make([]*entity.FailedMail, 0, len(dbMails))
+	for _, dbMail := range dbMails {
+		mails = append(mails, toFailedMailEntity(dbMail))
+	}
+	totalPages := (totalItems + int64(pageSize) - 1) / int64(pageSize)
+
+	return &entity.PaginatedFailedMails{
+		Items:       mails,
+		TotalItems:  totalItems,
+		TotalPages:  totalPages,
+		CurrentPage: pageNumber,
+		PageSize:    pageSize,
+	}, nil
+}
+
+func (r *MailRepository) GetFailedMail(ctx context.Context, jobId uuid.UUID) (*entity.FailedMail, error) {
+	dbMail, err := r.Queries.GetMailDeadLetter(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("MailRepository.GetFailedMail: failed to get failed mail", "job_id", jobId, "error", err)
+		return nil, err
+	}
+	return toFailedMailEntity(dbMail), nil
+}
+
+func (r *MailRepository) MarkFailedMailRequeued(ctx context.Context, jobId uuid.UUID) (bool, error) {
+	rows, err := r.Queries.MarkMailDeadLetterRequeued(ctx, jobId)
+	if err != nil {
+		logger.Error("MailRepository.MarkFailedMailRequeued: failed to mark failed mail", "job_id", jobId, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toFailedMailEntity(dbMail database.MailDeadLetter) *entity.FailedMail {
+	mail := &entity.FailedMail{
+		JobID:      dbMail.JobID,
+		Recipients: dbMail.Recipients,
+		Subject:    dbMail.Subject,
+		Attempts:   dbMail.Attempts,
+		LastError:  dbMail.LastError,
+		FailedAt:   dbMail.FailedAt,
+	}
+	if err := json.Unmarshal(dbMail.Payload, &mail.Email); err != nil {
+		logger.Error("MailRepository: failed to decode mail payload", "job_id", dbMail.JobID, "error", err)
+	}
+	if dbMail.RequeuedAt.Valid {
+		mail.RequeuedAt = &dbMail.RequeuedAt.Time
+	}
+	return mail
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/mail/entity"
+)
+
+type MailRepository struct {
+	Queries *database.Queries
+	db      *sql.DB
+}
+
+func NewMailRepository(sqlDB *sql.DB) IMailRepository {
+	return &MailRepository{
+		Queries: database.New(sqlDB),
+		db:      sqlDB,
+	}
+}
+
+// IMailRepository is also the dead-letter store of the mail queue.
+type IMailRepository interface {
+	mailer.DeadLetterStore
+	GetFailedMails(ctx context.Context, pageNumber, pageSize int) (*entity.PaginatedFailedMails, error)
+	GetFailedMail(ctx context.Context, jobId uuid.UUID) (*entity.FailedMail, error)
+	MarkFailedMailRequeued(ctx context.Context, jobId uuid.UUID) (bool, error)
+}
@@ -0,0 +1,30 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/modules/mail/controller"
+)
+
+type MailRouter struct {
+	controller *controller.MailController
+}
+
+func NewMailRouter(controller *controller.MailController) *MailRouter {
+	return &MailRouter{
+		controller: controller,
+	}
+}
+func (r *MailRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
+	// API v1 group
+	v1 := e.Group("/v1")
+	// Admin routes
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(), middleware.PermissionMiddleware(constants.PermissionMailManage))
+	// Mails the queue gave up on
+	failedMails := admin.Group("/mails/failed")
+	failedMails.GET("", r.controller.GetFailedMails)
+	failedMails.GET("/:jobId", r.controller.GetFailedMail)
+	failedMails.POST("/:jobId/retry", r.controller.RetryFailedMail)
+}
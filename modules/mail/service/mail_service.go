@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/mail/dto"
+	"pirate-lang-go/modules/mail/mapper"
+	"time"
+)
+
+func (s *MailService) GetFailedMails(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedFailedMailResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	mails, err := s.repo.GetFailedMails(ctx, pageNumber, pageSize)
+	if err != nil {
+		logger.Error("MailService:GetFailedMails:Failed to get failed mails", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "MailService:GetFailedMails:Failed to get failed mails", err)
+	}
+	return mapper.ToPaginatedFailedMailsResponse(mails), nil
+}
+
+func (s *MailService) GetFailedMail(ctx context.Context, jobId uuid.UUID) (*dto.FailedMailResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	mail, err := s.repo.GetFailedMail(ctx, jobId)
+	if err != nil {
+		logger.Error("MailService:GetFailedMail:Failed to get failed mail", "job_id", jobId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "MailService:GetFailedMail:Failed to get failed mail", err)
+	}
+	if mail == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "MailService:GetFailedMail:Mail not found", nil)
+	}
+	return mapper.ToFailedMailResponse(mail), nil
+}
+
+// RetryFailedMail puts a failed mail back on the queue with a fresh set of attempts.
+// If it fails again it returns to the dead letters under the same job id.
+func (s *MailService) RetryFailedMail(ctx context.Context, jobId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	mail, err := s.repo.GetFailedMail(ctx, jobId)
+	if err != nil {
+		logger.Error("MailService:RetryFailedMail:Failed to get failed mail", "job_id", jobId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "MailService:RetryFailedMail:Failed to get failed mail", err)
+	}
+	if mail == nil {
+		return errors.NewAppError(errors.ErrNotFound, "MailService:RetryFailedMail:Mail not found", nil)
+	}
+	// Claim the mail first so that two admins cannot send it twice
+	claimed, err := s.repo.MarkFailedMailRequeued(ctx, jobId)
+	if err != nil {
+		logger.Error("MailService:RetryFailedMail:Failed to mark mail as requeued", "job_id", jobId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "MailService:RetryFailedMail:Failed to mark mail as requeued", err)
+	}
+	if !claimed {
+		return errors.NewAppError(errors.ErrInvalidState, "MailService:RetryFailedMail:Mail was already requeued", nil)
+	}
+
+	job := &mailer.Job{ID: mail.JobID, Email: mail.Email, LastError: mail.LastError}
+	if err = s.queue.Enqueue(ctx, job); err != nil {
+		logger.Error("MailService:RetryFailedMail:Failed to enqueue mail", "job_id", jobId, "error", err)
+		// Put it back among the dead letters so it can be retried again
+		job.Attempts = int(mail.Attempts)
+		if saveErr := s.repo.SaveDeadLetter(ctx, job); saveErr != nil {
+			logger.Error("MailService:RetryFailedMail:Failed to restore dead letter", "job_id", jobId, "error", saveErr)
+		}
+		return errors.NewAppError(errors.ErrInternal, "MailService:RetryFailedMail:Failed to enqueue mail", err)
+	}
+	return nil
+}
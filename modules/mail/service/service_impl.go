@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/modules/mail/dto"
+	"pirate-lang-go/modules/mail/repository"
+)
+
+type MailService struct {
+	repo  repository.IMailRepository
+	queue mailer.IQueue
+}
+
+func NewMailService(repo repository.IMailRepository, queue mailer.IQueue) IMailService {
+
+	return &MailService{
+		repo:  repo,
+		queue: queue,
+	}
+}
+
+type IMailService interface {
+	GetFailedMails(ctx context.Context, pageNumber, pageSize int) (*dto.PaginatedFailedMailResponse, *errors.AppError)
+	GetFailedMail(ctx context.Context, jobId uuid.UUID) (*dto.FailedMailResponse, *errors.AppError)
+	RetryFailedMail(ctx context.Context, jobId uuid.UUID) *errors.AppError
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"pirate-lang-go/core/controller"
+	"pirate-lang-go/modules/payment/service"
+)
+
+type PaymentController struct {
+	controller.BaseController
+	paymentService service.IPaymentService
+}
+
+func NewPaymentController(service service.IPaymentService) *PaymentController {
+
+	return &PaymentController{
+		BaseController: controller.NewBaseController(),
+		paymentService: service,
+	}
+}
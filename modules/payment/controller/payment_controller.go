@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"io"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/payment/dto"
+	validator "pirate-lang-go/modules/payment/validation"
+)
+
+// maxWebhookSize bounds the webhook body read into memory
+const maxWebhookSize = 1 << 20
+
+func (controller *PaymentController) Checkout(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	requestData := new(dto.CheckoutRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateCheckout(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.paymentService.Checkout(ctx, token, requestData)
+	if appErr != nil {
+		switch appErr.Code {
+		case errors.ErrNotFound:
+			return controller.NotFound("Error checkout", appErr.Error())
+		case errors.ErrThirdParty, errors.ErrInternal:
+			return controller.InternalServerError("Error checkout", appErr.Error())
+		}
+		return controller.BadRequest("Error checkout", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Checkout successfully")
+}
+
+func (controller *PaymentController) GetMyOrders(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+
+	response, appErr := controller.paymentService.GetMyOrders(ctx, token)
+	if appErr != nil {
+		return controller.BadRequest("Error getting orders", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get orders successfully")
+}
+
+func (controller *PaymentController) GetMyOrder(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	orderId, err := uuid.Parse(c.Param("orderId"))
+	if err != nil {
+		return controller.BadRequest("Invalid order ID format", err.Error())
+	}
+
+	response, appErr := controller.paymentService.GetMyOrder(ctx, token, orderId)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error getting order", appErr.Error())
+		}
+		return controller.BadRequest("Error getting order", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get order successfully")
+}
+
+// HandleWebhook answers 2xx only once the event is stored, so the provider retries anything
+// that failed on our side; rejected requests get a 4xx and are not retried.
+func (controller *PaymentController) HandleWebhook(c echo.Context) error {
+	ctx := c.Request().Context()
+	payload, err := io.ReadAll(io.LimitReader(c.Request().Body, maxWebhookSize))
+	if err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+
+	appErr := controller.paymentService.HandleWebhook(ctx, c.Param("provider"), payload, c.Request().Header)
+	if appErr != nil {
+		switch appErr.Code {
+		case errors.ErrNotFound:
+			return controller.NotFound("Error handling webhook", appErr.Error())
+		case errors.ErrUnauthorized:
+			return controller.Unauthorized("Error handling webhook", appErr.Error())
+		case errors.ErrInternal:
+			return controller.InternalServerError("Error handling webhook", appErr.Error())
+		}
+		return controller.BadRequest("Error handling webhook", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Handle webhook successfully")
+}
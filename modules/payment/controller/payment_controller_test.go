@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/payment"
+	"pirate-lang-go/core/payment/paymenttest"
+	"pirate-lang-go/modules/payment/service"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("APP_PAYMENT_STRIPE_SECRET_KEY", paymenttest.SecretKey)
+	os.Setenv("APP_PAYMENT_STRIPE_WEBHOOK_SECRET", paymenttest.WebhookSecret)
+	if err := logger.Init(logger.LogConfig{Level: logger.LogLevelError}); err != nil {
+		panic(err)
+	}
+	if err := config.Init(config.DevEnvironment); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// Webhooks that fail verification are answered before anything is read from the database,
+// so the service needs no repositories here.
+func TestHandleWebhookRejectsBadSignature(t *testing.T) {
+	provider := paymenttest.NewServer()
+	defer provider.Close()
+	session, err := payment.NewStripeProvider(provider.ProviderConfig()).CreateCheckoutSession(context.Background(), &payment.CheckoutRequest{
+		OrderID:     uuid.New(),
+		AmountCents: 999,
+		Currency:    "USD",
+		Description: "Monthly",
+		SuccessURL:  "http://localhost/checkout/success",
+		CancelURL:   "http://localhost/checkout/cancel",
+	})
+	if err != nil {
+		t.Fatalf("create checkout session: %v", err)
+	}
+	payload, header, err := provider.Pay(session.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := echo.New()
+	e.POST("/v1/payments/webhooks/:provider", NewPaymentController(service.NewPaymentService(nil, nil, nil, nil)).HandleWebhook)
+	app := httptest.NewServer(e)
+	defer app.Close()
+
+	signed := func(secret string, at time.Time) http.Header {
+		header := http.Header{}
+		header.Set(payment.StripeSignatureHeader, payment.SignPayload(secret, payload, at))
+		return header
+	}
+	tests := []struct {
+		name    string
+		payload []byte
+		header  http.Header
+	}{
+		{name: "tampered payload", payload: bytes.Replace(payload, []byte(`"paid"`), []byte(`"PAID"`), 1), header: header},
+		{name: "wrong secret", payload: payload, header: signed("whsec_other", time.Now())},
+		{name: "expired", payload: payload, header: signed(paymenttest.WebhookSecret, time.Now().Add(-payment.SignatureTolerance-time.Minute))},
+		{name: "unsigned", payload: payload, header: http.Header{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := paymenttest.Deliver(app.URL+"/v1/payments/webhooks/"+payment.ProviderStripe, tt.payload, tt.header)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+			}
+		})
+	}
+}
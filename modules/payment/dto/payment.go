@@ -0,0 +1,29 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type CheckoutRequest struct {
+	PlanID   uuid.UUID `json:"plan_id"`
+	Provider string    `json:"provider"` // defaults to stripe
+}
+type CheckoutResponse struct {
+	OrderID     uuid.UUID  `json:"order_id"`
+	CheckoutURL string     `json:"checkout_url"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+}
+type OrderResponse struct {
+	OrderID        uuid.UUID  `json:"order_id"`
+	PlanID         uuid.UUID  `json:"plan_id"`
+	PlanName       string     `json:"plan_name"`
+	Provider       string     `json:"provider"`
+	AmountCents    int64      `json:"amount_cents"`
+	Currency       string     `json:"currency"`
+	Status         string     `json:"status"`
+	FailureReason  string     `json:"failure_reason,omitempty"`
+	SubscriptionID *uuid.UUID `json:"subscription_id"`
+	PaidAt         *time.Time `json:"paid_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Order struct {
+	OrderID           uuid.UUID  `json:"order_id"`
+	UserID            uuid.UUID  `json:"user_id"`
+	PlanID            uuid.UUID  `json:"plan_id"`
+	PlanName          string     `json:"plan_name"`
+	Provider          string     `json:"provider"`
+	ProviderSessionID string     `json:"provider_session_id"`
+	AmountCents       int64      `json:"amount_cents"`
+	Currency          string     `json:"currency"`
+	Status            string     `json:"status"`
+	FailureReason     string     `json:"failure_reason"`
+	SubscriptionID    *uuid.UUID `json:"subscription_id"`
+	PaidAt            *time.Time `json:"paid_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// Status of an order
+const (
+	OrderStatusPending = "PENDING"
+	OrderStatusPaid    = "PAID"
+	OrderStatusFailed  = "FAILED"
+	OrderStatusExpired = "EXPIRED"
+)
+
+// Reasons recorded on a failed order
+const (
+	FailureCheckoutFailed = "checkout_failed"
+	FailurePaymentFailed  = "payment_failed"
+	FailureAmountMismatch = "amount_mismatch"
+)
+
+// EventOutcome tells what applying a webhook event did.
+type EventOutcome struct {
+	Duplicate      bool       // the event had been processed before
+	OrderFound     bool       // the event refers to one of our orders
+	ActivatedForID *uuid.UUID // user whose subscription was activated or renewed
+	SubscriptionID *uuid.UUID
+}
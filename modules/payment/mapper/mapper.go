@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"pirate-lang-go/modules/payment/dto"
+	"pirate-lang-go/modules/payment/entity"
+)
+
+func ToOrderResponse(order *entity.Order) *dto.OrderResponse {
+	if order == nil {
+		return nil
+	}
+	return &dto.OrderResponse{
+		OrderID:        order.OrderID,
+		PlanID:         order.PlanID,
+		PlanName:       order.PlanName,
+		Provider:       order.Provider,
+		AmountCents:    order.AmountCents,
+		Currency:       order.Currency,
+		Status:         order.Status,
+		FailureReason:  order.FailureReason,
+		SubscriptionID: order.SubscriptionID,
+		PaidAt:         order.PaidAt,
+		CreatedAt:      order.CreatedAt,
+	}
+}
+
+func ToOrdersResponse(orders []*entity.Order) []*dto.OrderResponse {
+	responses := make([]*dto.OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		responses = append(responses, ToOrderResponse(order))
+	}
+	return responses
+}
@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	accountservice "pirate-lang-go/modules/account/service"
+	"pirate-lang-go/modules/payment/controller"
+	"pirate-lang-go/modules/payment/repository"
+	"pirate-lang-go/modules/payment/router"
+	"pirate-lang-go/modules/payment/service"
+	subscriptionrepo "pirate-lang-go/modules/subscription/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+)
+
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, mailer mailer.IMailer) {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	accountService := accountservice.NewAccountService(accountRepository, cache, storage, mailer)
+	middleware := middleware.NewMiddleware(accountService)
+
+	subscriptionRepository := subscriptionrepo.NewSubscriptionRepository(db.DB())
+	subscriptionService := subscriptionservice.NewSubscriptionService(subscriptionRepository, accountRepository, cache)
+	paymentService := service.NewPaymentService(repository.NewPaymentRepository(db.DB()), subscriptionRepository, accountRepository, subscriptionService)
+	router.NewPaymentRouter(
+		controller.NewPaymentController(paymentService),
+	).Setup(e, middleware)
+}
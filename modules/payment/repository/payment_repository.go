@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/payment"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/payment/entity"
+	subscriptionentity "pirate-lang-go/modules/subscription/entity"
+	"strings"
+	"time"
+)
+
+func (r *PaymentRepository) CreateOrder(ctx context.Context, order *entity.Order) (*entity.Order, error) {
+	orderDB, err := r.Queries.CreatePaymentOrder(ctx, database.CreatePaymentOrderParams{
+		UserID:      order.UserID,
+		PlanID:      order.PlanID,
+		Provider:    order.Provider,
+		AmountCents: order.AmountCents,
+		Currency:    order.Currency,
+	})
+	if err != nil {
+		logger.Error("PaymentRepository.CreateOrder: failed to create order", "user_id", order.UserID, "plan_id", order.PlanID, "error", err)
+		return nil, err
+	}
+	created := toOrderEntity(database.GetPaymentOrderRow{
+		OrderID:     orderDB.OrderID,
+		UserID:      orderDB.UserID,
+		PlanID:      orderDB.PlanID,
+		Provider:    orderDB.Provider,
+		AmountCents: orderDB.AmountCents,
+		Currency:    orderDB.Currency,
+		Status:      orderDB.Status,
+		CreatedAt:   orderDB.CreatedAt,
+		UpdatedAt:   orderDB.UpdatedAt,
+	})
+	created.PlanName = order.PlanName
+	return created, nil
+}
+
+func (r *PaymentRepository) SetOrderSession(ctx context.Context, orderId uuid.UUID, sessionId string) error {
+	err := r.Queries.SetPaymentOrderSession(ctx, database.SetPaymentOrderSessionParams{
+		OrderID:           orderId,
+		ProviderSessionID: sql.NullString{String: sessionId, Valid: sessionId != ""},
+	})
+	if err != nil {
+		logger.Error("PaymentRepository.SetOrderSession: failed to set checkout session", "order_id", orderId, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (r *PaymentRepository) CloseOrder(ctx context.Context, orderId uuid.UUID, status, reason string) (bool, error) {
+	rows, err := r.Queries.ClosePaymentOrder(ctx, database.ClosePaymentOrderParams{
+		OrderID:       orderId,
+		Status:        status,
+		FailureReason: reason,
+	})
+	if err != nil {
+		logger.Error("PaymentRepository.CloseOrder: failed to close order", "order_id", orderId, "status", status, "error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func (r *PaymentRepository) GetOrder(ctx context.Context, orderId uuid.UUID) (*entity.Order, error) {
+	orderDB, err := r.Queries.GetPaymentOrder(ctx, orderId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("PaymentRepository.GetOrder: failed to get order", "order_id", orderId, "error", err)
+		return nil, err
+	}
+	return toOrderEntity(orderDB), nil
+}
+
+func (r *PaymentRepository) GetUserOrders(ctx context.Context, userId uuid.UUID) ([]*entity.Order, error) {
+	ordersDB, err := r.Queries.GetUserPaymentOrders(ctx, userId)
+	if err != nil {
+		logger.Error("PaymentRepository.GetUserOrders: failed to get orders", "user_id", userId, "error", err)
+		return nil, err
+	}
+	orders := make([]*entity.Order, 0, len(ordersDB))
+	for _, orderDB := range ordersDB {
+		orders = append(orders, toOrderEntity(database.GetPaymentOrderRow(orderDB)))
+	}
+	return orders, nil
+}
+
+// ApplyEvent stores the event first, so a redelivered event is detected by its id and changes nothing.
+// A successful payment activates the plan for the buyer, starting after their current subscription
+// so that a renewal bought early adds to the remaining time.
+func (r *PaymentRepository) ApplyEvent(ctx context.Context, provider string, event *payment.Event, payload []byte) (*entity.EventOutcome, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		logger.Error("PaymentRepository.ApplyEvent: failed to begin transaction", "event_id", event.ID, "error", err)
+		return nil, err
+	}
+	defer tx.Rollback()
+	qtx := r.Queries.WithTx(tx)
+
+	outcome := &entity.EventOutcome{}
+	var order *database.PaymentOrder
+	if event.OrderID != uuid.Nil {
+		orderDB, err := qtx.GetPaymentOrderForUpdate(ctx, event.OrderID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Error("PaymentRepository.ApplyEvent: failed to lock order", "order_id", event.OrderID, "error", err)
+			return nil, err
+		}
+		if err == nil {
+			order = &orderDB
+			outcome.OrderFound = true
+		}
+	}
+
+	eventParams := database.RecordPaymentEventParams{
+		Provider:  provider,
+		EventID:   event.ID,
+		EventType: event.RawType,
+		Payload:   payload,
+	}
+	if order != nil {
+		eventParams.OrderID = uuid.NullUUID{UUID: order.OrderID, Valid: true}
+	}
+	recorded, err := qtx.RecordPaymentEvent(ctx, eventParams)
+	if err != nil {
+		logger.Error("PaymentRepository.ApplyEvent: failed to record event", "event_id", event.ID, "error", err)
+		return nil, err
+	}
+	if recorded == 0 {
+		outcome.Duplicate = true
+		return outcome, nil
+	}
+
+	if order != nil {
+		switch event.Type {
+		case payment.EventPaymentSucceeded:
+			err = r.activateOrder(ctx, qtx, order, event, outcome)
+		case payment.EventPaymentFailed:
+			_, err = qtx.ClosePaymentOrder(ctx, database.ClosePaymentOrderParams{
+				OrderID:       order.OrderID,
+				Status:        entity.OrderStatusFailed,
+				FailureReason: entity.FailurePaymentFailed,
+			})
+		case payment.EventCheckoutExpired:
+			_, err = qtx.ClosePaymentOrder(ctx, database.ClosePaymentOrderParams{
+				OrderID: order.OrderID,
+				Status:  entity.OrderStatusExpired,
+			})
+		}
+		if err != nil {
+			logger.Error("PaymentRepository.ApplyEvent: failed to apply event", "event_id", event.ID, "order_id", order.OrderID, "error", err)
+			return nil, err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		logger.Error("PaymentRepository.ApplyEvent: failed to commit transaction", "event_id", event.ID, "error", err)
+		return nil, err
+	}
+	return outcome, nil
+}
+
+func (r *PaymentRepository) activateOrder(ctx context.Context, qtx *database.Queries, order *database.PaymentOrder, event *payment.Event, outcome *entity.EventOutcome) error {
+	if order.Status == entity.OrderStatusPaid {
+		return nil
+	}
+	// Money arriving after the session expired still pays for the order, but only the agreed price does.
+	if event.AmountCents != order.AmountCents || !strings.EqualFold(event.Currency, order.Currency) {
+		logger.Error("PaymentRepository.activateOrder: paid amount does not match order",
+			"order_id", order.OrderID,
+			"expected", order.AmountCents, "expected_currency", order.Currency,
+			"paid", event.AmountCents, "paid_currency", event.Currency)
+		_, err := qtx.ClosePaymentOrder(ctx, database.ClosePaymentOrderParams{
+			OrderID:       order.OrderID,
+			Status:        entity.OrderStatusFailed,
+			FailureReason: entity.FailureAmountMismatch,
+		})
+		return err
+	}
+
+	planDB, err := qtx.GetSubscriptionPlan(ctx, order.PlanID)
+	if err != nil {
+		return err
+	}
+	startsAt := time.Now()
+	latestEnd, err := qtx.GetLatestUserSubscriptionEnd(ctx, order.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if err == nil && latestEnd.After(startsAt) {
+		startsAt = latestEnd
+	}
+	plan := &subscriptionentity.Plan{DurationDays: planDB.DurationDays, GracePeriodDays: planDB.GracePeriodDays}
+	endsAt, graceEndsAt := plan.Period(startsAt, 0)
+
+	subscription, err := qtx.CreateUserSubscription(ctx, database.CreateUserSubscriptionParams{
+		UserID:      order.UserID,
+		PlanID:      order.PlanID,
+		Source:      subscriptionentity.SourcePurchase,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		GraceEndsAt: graceEndsAt,
+		Note:        "order " + order.OrderID.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if err = qtx.MarkPaymentOrderPaid(ctx, database.MarkPaymentOrderPaidParams{
+		OrderID:        order.OrderID,
+		SubscriptionID: uuid.NullUUID{UUID: subscription.SubscriptionID, Valid: true},
+	}); err != nil {
+		return err
+	}
+	outcome.ActivatedForID = &order.UserID
+	outcome.SubscriptionID = &subscription.SubscriptionID
+	return nil
+}
+
+func toOrderEntity(orderDB database.GetPaymentOrderRow) *entity.Order {
+	order := &entity.Order{
+		OrderID:           orderDB.OrderID,
+		UserID:            orderDB.UserID,
+		PlanID:            orderDB.PlanID,
+		PlanName:          orderDB.PlanName,
+		Provider:          orderDB.Provider,
+		ProviderSessionID: orderDB.ProviderSessionID.String,
+		AmountCents:       orderDB.AmountCents,
+		Currency:          orderDB.Currency,
+		Status:            orderDB.Status,
+		FailureReason:     orderDB.FailureReason,
+		CreatedAt:         orderDB.CreatedAt.Time,
+		UpdatedAt:         orderDB.UpdatedAt.Time,
+	}
+	if orderDB.SubscriptionID.Valid {
+		order.SubscriptionID = &orderDB.SubscriptionID.UUID
+	}
+	if orderDB.PaidAt.Valid {
+		order.PaidAt = &orderDB.PaidAt.Time
+	}
+	return order
+}
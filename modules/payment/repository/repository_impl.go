@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/payment"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/payment/entity"
+)
+
+type PaymentRepository struct {
+	Queries *database.Queries
+	db      *sql.DB
+}
+
+func NewPaymentRepository(sqlDB *sql.DB) IPaymentRepository {
+	return &PaymentRepository{
+		Queries: database.New(sqlDB),
+		db:      sqlDB,
+	}
+}
+
+type IPaymentRepository interface {
+	CreateOrder(ctx context.Context, order *entity.Order) (*entity.Order, error)
+	SetOrderSession(ctx context.Context, orderId uuid.UUID, sessionId string) error
+	CloseOrder(ctx context.Context, orderId uuid.UUID, status, reason string) (bool, error)
+	GetOrder(ctx context.Context, orderId uuid.UUID) (*entity.Order, error)
+	GetUserOrders(ctx context.Context, userId uuid.UUID) ([]*entity.Order, error)
+	// ApplyEvent records a webhook event and applies it to its order in one transaction.
+	ApplyEvent(ctx context.Context, provider string, event *payment.Event, payload []byte) (*entity.EventOutcome, error)
+}
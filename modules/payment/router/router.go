@@ -0,0 +1,30 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/modules/payment/controller"
+)
+
+type PaymentRouter struct {
+	controller *controller.PaymentController
+}
+
+func NewPaymentRouter(controller *controller.PaymentController) *PaymentRouter {
+	return &PaymentRouter{
+		controller: controller,
+	}
+}
+func (r *PaymentRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
+	// API v1 group
+	v1 := e.Group("/v1")
+	payments := v1.Group("/payments")
+	// Webhooks are authenticated by the provider's signature
+	payments.POST("/webhooks/:provider", r.controller.HandleWebhook)
+	// Checkout and orders of the signed-in user
+	auth := payments.Group("")
+	auth.Use(middleware.AuthMiddleware())
+	auth.POST("/checkout", r.controller.Checkout)
+	auth.GET("/orders", r.controller.GetMyOrders)
+	auth.GET("/orders/:orderId", r.controller.GetMyOrder)
+}
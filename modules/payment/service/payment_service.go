@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	stderrors "errors"
+	"github.com/google/uuid"
+	"net/http"
+	"net/url"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/payment"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/payment/dto"
+	"pirate-lang-go/modules/payment/entity"
+	"pirate-lang-go/modules/payment/mapper"
+	"strings"
+	"time"
+)
+
+func (s *PaymentService) Checkout(ctx context.Context, token string, request *dto.CheckoutRequest) (*dto.CheckoutResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("PaymentService:Checkout:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "PaymentService:Checkout:Failed to get user", err)
+	}
+	providerName := request.Provider
+	if providerName == "" {
+		providerName = payment.ProviderStripe
+	}
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInvalidInput, "PaymentService:Checkout:Payment provider is not available", err)
+	}
+	plan, err := s.subscriptionRepo.GetPlan(ctx, request.PlanID)
+	if err != nil {
+		logger.Error("PaymentService:Checkout:Failed to get plan", "plan_id", request.PlanID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:Checkout:Failed to get plan", err)
+	}
+	if plan == nil || !plan.IsActive {
+		return nil, errors.NewAppError(errors.ErrNotFound, "PaymentService:Checkout:Plan not found", nil)
+	}
+	if plan.PriceCents <= 0 {
+		return nil, errors.NewAppError(errors.ErrBusinessRule, "PaymentService:Checkout:Plan is not for sale", nil)
+	}
+	user, err := s.accountRepo.GetUserByEmailOrUserNameOrId(ctx, "", "", claims.UserID)
+	if err != nil {
+		logger.Error("PaymentService:Checkout:Failed to get user", "user_id", claims.UserID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:Checkout:Failed to get user", err)
+	}
+	if user == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "PaymentService:Checkout:User not found", nil)
+	}
+
+	// The price is fixed on the order, so a later price change does not affect a checkout in progress
+	order, err := s.repo.CreateOrder(ctx, &entity.Order{
+		UserID:      user.ID,
+		PlanID:      plan.PlanID,
+		PlanName:    plan.Name,
+		Provider:    provider.Name(),
+		AmountCents: plan.PriceCents,
+		Currency:    strings.ToUpper(plan.Currency),
+	})
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:Checkout:Failed to create order", err)
+	}
+
+	successURL, cancelURL := checkoutURLs(order.OrderID)
+	session, err := provider.CreateCheckoutSession(ctx, &payment.CheckoutRequest{
+		OrderID:       order.OrderID,
+		AmountCents:   order.AmountCents,
+		Currency:      order.Currency,
+		Description:   plan.Name,
+		CustomerEmail: user.Email,
+		SuccessURL:    successURL,
+		CancelURL:     cancelURL,
+	})
+	if err != nil {
+		logger.Error("PaymentService:Checkout:Failed to create checkout session", "order_id", order.OrderID, "provider", provider.Name(), "error", err)
+		if _, closeErr := s.repo.CloseOrder(ctx, order.OrderID, entity.OrderStatusFailed, entity.FailureCheckoutFailed); closeErr != nil {
+			logger.Error("PaymentService:Checkout:Failed to close order", "order_id", order.OrderID, "error", closeErr)
+		}
+		return nil, errors.NewAppError(errors.ErrThirdParty, "PaymentService:Checkout:Failed to create checkout session", err)
+	}
+	if err = s.repo.SetOrderSession(ctx, order.OrderID, session.SessionID); err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:Checkout:Failed to save checkout session", err)
+	}
+	return &dto.CheckoutResponse{
+		OrderID:     order.OrderID,
+		CheckoutURL: session.URL,
+		ExpiresAt:   session.ExpiresAt,
+	}, nil
+}
+
+func (s *PaymentService) GetMyOrders(ctx context.Context, token string) ([]*dto.OrderResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("PaymentService:GetMyOrders:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "PaymentService:GetMyOrders:Failed to get user", err)
+	}
+	orders, err := s.repo.GetUserOrders(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:GetMyOrders:Failed to get orders", err)
+	}
+	return mapper.ToOrdersResponse(orders), nil
+}
+
+func (s *PaymentService) GetMyOrder(ctx context.Context, token string, orderId uuid.UUID) (*dto.OrderResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("PaymentService:GetMyOrder:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "PaymentService:GetMyOrder:Failed to get user", err)
+	}
+	order, err := s.repo.GetOrder(ctx, orderId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "PaymentService:GetMyOrder:Failed to get order", err)
+	}
+	if order == nil || order.UserID != claims.UserID {
+		return nil, errors.NewAppError(errors.ErrNotFound, "PaymentService:GetMyOrder:Order not found", nil)
+	}
+	return mapper.ToOrderResponse(order), nil
+}
+
+func (s *PaymentService) HandleWebhook(ctx context.Context, providerName string, payload []byte, header http.Header) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return errors.NewAppError(errors.ErrNotFound, "PaymentService:HandleWebhook:Unknown payment provider", err)
+	}
+	event, err := provider.ParseWebhook(payload, header)
+	if err != nil {
+		logger.Warn("PaymentService:HandleWebhook:Rejected webhook", "provider", providerName, "error", err)
+		if stderrors.Is(err, payment.ErrInvalidSignature) {
+			return errors.NewAppError(errors.ErrUnauthorized, "PaymentService:HandleWebhook:Invalid signature", err)
+		}
+		return errors.NewAppError(errors.ErrInvalidFormat, "PaymentService:HandleWebhook:Invalid payload", err)
+	}
+	if event.Type == payment.EventIgnored {
+		return nil
+	}
+
+	outcome, err := s.repo.ApplyEvent(ctx, provider.Name(), event, payload)
+	if err != nil {
+		// An error response makes the provider deliver the event again
+		return errors.NewAppError(errors.ErrInternal, "PaymentService:HandleWebhook:Failed to apply event", err)
+	}
+	if outcome.Duplicate {
+		logger.Info("PaymentService:HandleWebhook:Skipping duplicate event", "provider", providerName, "event_id", event.ID)
+		return nil
+	}
+	if !outcome.OrderFound {
+		logger.Warn("PaymentService:HandleWebhook:Event does not match an order", "provider", providerName, "event_id", event.ID, "order_id", event.OrderID)
+		return nil
+	}
+	if outcome.ActivatedForID != nil {
+		s.subscriptionService.InvalidateEntitlement(ctx, *outcome.ActivatedForID)
+		logger.Info("PaymentService:HandleWebhook:Subscription activated", "order_id", event.OrderID, "subscription_id", outcome.SubscriptionID)
+	}
+	return nil
+}
+
+// checkoutURLs returns where the provider sends the buyer back to, with the order id so the
+// web app can poll the order until the webhook has been processed.
+func checkoutURLs(orderId uuid.UUID) (successURL, cancelURL string) {
+	cfg := config.Get()
+	baseURL := cfg.Server.FrontendURL
+	if baseURL == "" {
+		baseURL = cfg.Server.BaseURL
+	}
+	successURL = cfg.Payment.SuccessURL
+	if successURL == "" {
+		successURL = baseURL + "/checkout/success"
+	}
+	cancelURL = cfg.Payment.CancelURL
+	if cancelURL == "" {
+		cancelURL = baseURL + "/checkout/cancel"
+	}
+	return withOrderID(successURL, orderId), withOrderID(cancelURL, orderId)
+}
+
+func withOrderID(rawURL string, orderId uuid.UUID) string {
+	separator := "?"
+	if strings.Contains(rawURL, "?") {
+		separator = "&"
+	}
+	return rawURL + separator + "order_id=" + url.QueryEscape(orderId.String())
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/database/dbtest"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/payment"
+	"pirate-lang-go/core/payment/paymenttest"
+	"pirate-lang-go/core/utils"
+	accountentity "pirate-lang-go/modules/account/entity"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	"pirate-lang-go/modules/payment/dto"
+	"pirate-lang-go/modules/payment/entity"
+	"pirate-lang-go/modules/payment/repository"
+	subscriptionentity "pirate-lang-go/modules/subscription/entity"
+	subscriptionrepo "pirate-lang-go/modules/subscription/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+)
+
+func TestMain(m *testing.M) {
+	os.Setenv("APP_JWT_SECRET", "payment-service-test")
+	if err := logger.Init(logger.LogConfig{Level: logger.LogLevelError}); err != nil {
+		panic(err)
+	}
+	if err := config.Init(config.DevEnvironment); err != nil {
+		panic(err)
+	}
+	os.Exit(m.Run())
+}
+
+// entitlementRecorder stands in for the subscription service, which the payment service only asks
+// to forget cached entitlements.
+type entitlementRecorder struct {
+	subscriptionservice.ISubscriptionService
+	invalidated []uuid.UUID
+}
+
+func (r *entitlementRecorder) InvalidateEntitlement(ctx context.Context, userId uuid.UUID) {
+	r.invalidated = append(r.invalidated, userId)
+}
+
+type fixture struct {
+	server           *paymenttest.Server
+	service          *PaymentService
+	repo             repository.IPaymentRepository
+	subscriptionRepo subscriptionrepo.ISubscriptionRepository
+	entitlements     *entitlementRecorder
+	user             *accountentity.User
+	plan             *subscriptionentity.Plan
+	token            string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db := dbtest.Open(t)
+	server := paymenttest.NewServer()
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	accountRepo := accountrepo.NewAccountRepository(db)
+	user, err := accountRepo.CreateAccount(ctx, &accountentity.User{UserName: "buyer", Email: "buyer@example.com", Password: "hash"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	subscriptionRepo := subscriptionrepo.NewSubscriptionRepository(db)
+	plan, err := subscriptionRepo.CreatePlan(ctx, &subscriptionentity.Plan{
+		Code:         "monthly",
+		Name:         "Monthly",
+		DurationDays: 30,
+		PriceCents:   999,
+		Currency:     "USD",
+		IsActive:     true,
+	})
+	if err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+	token, _, err := utils.GenerateSessionToken(user.ID, user.Email, user.UserName, constants.TokenTypeAccess, uuid.New(), time.Hour)
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	repo := repository.NewPaymentRepository(db)
+	entitlements := &entitlementRecorder{}
+	return &fixture{
+		server: server,
+		service: &PaymentService{
+			repo:                repo,
+			subscriptionRepo:    subscriptionRepo,
+			accountRepo:         accountRepo,
+			subscriptionService: entitlements,
+			providers:           payment.NewRegistry(payment.NewStripeProvider(server.ProviderConfig())),
+		},
+		repo:             repo,
+		subscriptionRepo: subscriptionRepo,
+		entitlements:     entitlements,
+		user:             user,
+		plan:             plan,
+		token:            token,
+	}
+}
+
+// checkout buys the plan and returns the order with the session the fake provider created for it.
+func (f *fixture) checkout(t *testing.T) (*entity.Order, *paymenttest.Session) {
+	t.Helper()
+	response, appErr := f.service.Checkout(context.Background(), f.token, &dto.CheckoutRequest{PlanID: f.plan.PlanID})
+	if appErr != nil {
+		t.Fatalf("checkout: %v", appErr)
+	}
+	order := f.order(t, response.OrderID)
+	session, ok := f.server.Session(order.ProviderSessionID)
+	if !ok {
+		t.Fatalf("checkout session %q was not created on the provider", order.ProviderSessionID)
+	}
+	return order, session
+}
+
+func (f *fixture) order(t *testing.T, orderId uuid.UUID) *entity.Order {
+	t.Helper()
+	order, err := f.repo.GetOrder(context.Background(), orderId)
+	if err != nil || order == nil {
+		t.Fatalf("get order %s: %v", orderId, err)
+	}
+	return order
+}
+
+func (f *fixture) subscriptions(t *testing.T) []*subscriptionentity.Subscription {
+	t.Helper()
+	subscriptions, err := f.subscriptionRepo.GetUserSubscriptions(context.Background(), f.user.ID)
+	if err != nil {
+		t.Fatalf("get subscriptions: %v", err)
+	}
+	return subscriptions
+}
+
+func (f *fixture) deliver(t *testing.T, payload []byte, header http.Header) {
+	t.Helper()
+	if appErr := f.service.HandleWebhook(context.Background(), payment.ProviderStripe, payload, header); appErr != nil {
+		t.Fatalf("handle webhook: %v", appErr)
+	}
+}
+
+func (f *fixture) pay(t *testing.T, session *paymenttest.Session) {
+	t.Helper()
+	payload, header, err := f.server.Pay(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.deliver(t, payload, header)
+}
+
+func TestCheckoutCreatesPendingOrder(t *testing.T) {
+	f := newFixture(t)
+	order, session := f.checkout(t)
+
+	if order.Status != entity.OrderStatusPending {
+		t.Errorf("order status = %s, want %s", order.Status, entity.OrderStatusPending)
+	}
+	if order.AmountCents != 999 || order.Currency != "USD" {
+		t.Errorf("order price = %d %s, want 999 USD", order.AmountCents, order.Currency)
+	}
+	if session.ClientReferenceID != order.OrderID.String() {
+		t.Errorf("session reference = %s, want order %s", session.ClientReferenceID, order.OrderID)
+	}
+	if session.AmountCents != 999 || session.Currency != "usd" {
+		t.Errorf("session price = %d %s, want 999 usd", session.AmountCents, session.Currency)
+	}
+	if session.CustomerEmail != f.user.Email {
+		t.Errorf("session email = %s, want %s", session.CustomerEmail, f.user.Email)
+	}
+}
+
+func TestPaidWebhookActivatesSubscription(t *testing.T) {
+	f := newFixture(t)
+	order, session := f.checkout(t)
+	f.pay(t, session)
+
+	order = f.order(t, order.OrderID)
+	if order.Status != entity.OrderStatusPaid || order.SubscriptionID == nil {
+		t.Fatalf("order status = %s, subscription %v; want PAID with a subscription", order.Status, order.SubscriptionID)
+	}
+	subscriptions := f.subscriptions(t)
+	if len(subscriptions) != 1 || subscriptions[0].SubscriptionID != *order.SubscriptionID {
+		t.Fatalf("subscriptions = %d, want the one of the order", len(subscriptions))
+	}
+	checkPlanPeriod(t, subscriptions[0])
+	if len(f.entitlements.invalidated) != 1 || f.entitlements.invalidated[0] != f.user.ID {
+		t.Errorf("invalidated entitlements = %v, want the buyer's", f.entitlements.invalidated)
+	}
+}
+
+func TestRedeliveredEventIsNoop(t *testing.T) {
+	f := newFixture(t)
+	order, session := f.checkout(t)
+	payload, header, err := f.server.Pay(session.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.deliver(t, payload, header)
+	paid := f.order(t, order.OrderID)
+	if paid.Status != entity.OrderStatusPaid {
+		t.Fatalf("order status = %s after the first delivery, want PAID", paid.Status)
+	}
+
+	f.deliver(t, payload, header)
+	if again := f.order(t, order.OrderID); again.Status != entity.OrderStatusPaid || *again.SubscriptionID != *paid.SubscriptionID {
+		t.Errorf("redelivery changed the order to %s, subscription %v", again.Status, again.SubscriptionID)
+	}
+	if subscriptions := f.subscriptions(t); len(subscriptions) != 1 {
+		t.Errorf("subscriptions = %d after redelivery, want 1", len(subscriptions))
+	}
+	if len(f.entitlements.invalidated) != 1 {
+		t.Errorf("entitlements invalidated %d times, want 1", len(f.entitlements.invalidated))
+	}
+}
+
+func TestMismatchedPaymentFailsOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		currency string
+	}{
+		{name: "amount", amount: 1, currency: "usd"},
+		{name: "currency", amount: 999, currency: "eur"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			order, session := f.checkout(t)
+			payload, _, err := f.server.Pay(session.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			payload, header := resign(t, payload, func(object map[string]any) {
+				object["amount_total"] = tt.amount
+				object["currency"] = tt.currency
+			})
+			f.deliver(t, payload, header)
+
+			order = f.order(t, order.OrderID)
+			if order.Status != entity.OrderStatusFailed || order.FailureReason != entity.FailureAmountMismatch {
+				t.Errorf("order = %s (%s), want %s (%s)", order.Status, order.FailureReason, entity.OrderStatusFailed, entity.FailureAmountMismatch)
+			}
+			if subscriptions := f.subscriptions(t); len(subscriptions) != 0 {
+				t.Errorf("subscriptions = %d, want none", len(subscriptions))
+			}
+		})
+	}
+}
+
+func TestRenewalStartsAfterCurrentSubscription(t *testing.T) {
+	f := newFixture(t)
+	_, session := f.checkout(t)
+	f.pay(t, session)
+	_, session = f.checkout(t)
+	f.pay(t, session)
+
+	subscriptions := f.subscriptions(t)
+	if len(subscriptions) != 2 {
+		t.Fatalf("subscriptions = %d, want 2", len(subscriptions))
+	}
+	renewal, current := subscriptions[0], subscriptions[1]
+	if !renewal.StartsAt.Equal(current.EndsAt) {
+		t.Errorf("renewal starts at %s, want the end of the current subscription %s", renewal.StartsAt, current.EndsAt)
+	}
+	checkPlanPeriod(t, renewal)
+}
+
+// checkPlanPeriod checks that a subscription lasts the 30 days of the plan, give or take a
+// daylight saving shift.
+func checkPlanPeriod(t *testing.T, subscription *subscriptionentity.Subscription) {
+	t.Helper()
+	period := subscription.EndsAt.Sub(subscription.StartsAt)
+	if want := 30 * 24 * time.Hour; period < want-time.Hour || period > want+time.Hour {
+		t.Errorf("subscription lasts %s, want 30 days", period)
+	}
+}
+
+// resign changes the checkout session in a webhook payload and signs the result again, as a
+// provider reporting a different payment would.
+func resign(t *testing.T, payload []byte, change func(object map[string]any)) ([]byte, http.Header) {
+	t.Helper()
+	var event map[string]any
+	if err := json.Unmarshal(payload, &event); err != nil {
+		t.Fatal(err)
+	}
+	change(event["data"].(map[string]any)["object"].(map[string]any))
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{}
+	header.Set(payment.StripeSignatureHeader, payment.SignPayload(paymenttest.WebhookSecret, payload, time.Now()))
+	return payload, header
+}
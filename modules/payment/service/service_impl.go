@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"net/http"
+	"pirate-lang-go/core/config"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/payment"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	"pirate-lang-go/modules/payment/dto"
+	"pirate-lang-go/modules/payment/repository"
+	subscriptionrepo "pirate-lang-go/modules/subscription/repository"
+	subscriptionservice "pirate-lang-go/modules/subscription/service"
+)
+
+type PaymentService struct {
+	repo                repository.IPaymentRepository
+	subscriptionRepo    subscriptionrepo.ISubscriptionRepository
+	accountRepo         accountrepo.IAccountRepository
+	subscriptionService subscriptionservice.ISubscriptionService
+	providers           *payment.Registry
+}
+
+func NewPaymentService(repo repository.IPaymentRepository, subscriptionRepo subscriptionrepo.ISubscriptionRepository, accountRepo accountrepo.IAccountRepository, subscriptionService subscriptionservice.ISubscriptionService) IPaymentService {
+
+	return &PaymentService{
+		repo:                repo,
+		subscriptionRepo:    subscriptionRepo,
+		accountRepo:         accountRepo,
+		subscriptionService: subscriptionService,
+		providers:           payment.NewRegistryFromConfig(config.Get().Payment),
+	}
+}
+
+type IPaymentService interface {
+	// Checkout creates an order for a plan and returns the provider's payment page for it.
+	Checkout(ctx context.Context, token string, request *dto.CheckoutRequest) (*dto.CheckoutResponse, *errors.AppError)
+	GetMyOrders(ctx context.Context, token string) ([]*dto.OrderResponse, *errors.AppError)
+	GetMyOrder(ctx context.Context, token string, orderId uuid.UUID) (*dto.OrderResponse, *errors.AppError)
+	// HandleWebhook verifies and applies a provider notification; processing the same event twice is a no-op.
+	HandleWebhook(ctx context.Context, provider string, payload []byte, header http.Header) *errors.AppError
+}
@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/core/validation"
+	"pirate-lang-go/modules/payment/dto"
+)
+
+func ValidateCheckout(dataRequest *dto.CheckoutRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || dataRequest.PlanID == uuid.Nil {
+		result.AddError("plan_id", "Plan ID is required")
+		return result
+	}
+	if len(dataRequest.Provider) > 30 {
+		result.AddError("provider", "Provider must be at most 30 characters")
+	}
+	return result
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"pirate-lang-go/core/controller"
+	"pirate-lang-go/modules/subscription/service"
+)
+
+type SubscriptionController struct {
+	controller.BaseController
+	subscriptionService service.ISubscriptionService
+}
+
+func NewSubscriptionController(service service.ISubscriptionService) *SubscriptionController {
+
+	return &SubscriptionController{
+		BaseController:      controller.NewBaseController(),
+		subscriptionService: service,
+	}
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/modules/subscription/dto"
+	validator "pirate-lang-go/modules/subscription/validation"
+)
+
+// GetPlans lists the plans offered to learners.
+func (controller *SubscriptionController) GetPlans(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	response, appErr := controller.subscriptionService.GetPlans(ctx, true)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting plans", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get plans successfully")
+}
+
+// GetAllPlans lists every plan, inactive ones included.
+func (controller *SubscriptionController) GetAllPlans(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	response, appErr := controller.subscriptionService.GetPlans(ctx, false)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting plans", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get plans successfully")
+}
+
+func (controller *SubscriptionController) CreatePlan(c echo.Context) error {
+	ctx := c.Request().Context()
+	requestData := new(dto.CreatePlanRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateCreatePlan(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.subscriptionService.CreatePlan(ctx, requestData)
+	if appErr != nil {
+		return controller.BadRequest("Error create plan", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Create plan successfully")
+}
+
+func (controller *SubscriptionController) UpdatePlan(c echo.Context) error {
+	ctx := c.Request().Context()
+	planId, err := uuid.Parse(c.Param("planId"))
+	if err != nil {
+		return controller.BadRequest("Invalid plan ID format", err.Error())
+	}
+	requestData := new(dto.UpdatePlanRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateUpdatePlan(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.subscriptionService.UpdatePlan(ctx, planId, requestData)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Plan not found", appErr.Error())
+		}
+		return controller.BadRequest("Error update plan", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Update plan successfully")
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/subscription/dto"
+	validator "pirate-lang-go/modules/subscription/validation"
+)
+
+func (controller *SubscriptionController) GetMySubscriptions(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+
+	response, appErr := controller.subscriptionService.GetMySubscriptions(ctx, token)
+	if appErr != nil {
+		return controller.BadRequest("Error getting subscriptions", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get subscriptions successfully")
+}
+
+func (controller *SubscriptionController) GetMyEntitlement(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+
+	response, appErr := controller.subscriptionService.GetMyEntitlement(ctx, token)
+	if appErr != nil {
+		return controller.BadRequest("Error getting entitlement", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get entitlement successfully")
+}
+
+func (controller *SubscriptionController) GetUserSubscriptions(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return controller.BadRequest("Invalid user ID format", err.Error())
+	}
+
+	response, appErr := controller.subscriptionService.GetUserSubscriptions(ctx, userId)
+	if appErr != nil {
+		return controller.InternalServerError("Error getting subscriptions", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Get subscriptions successfully")
+}
+
+func (controller *SubscriptionController) GrantSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, errToken := utils.GetTokenFromHeader(c)
+	if errToken != nil {
+		return controller.Unauthorized("Unauthorized", errToken)
+	}
+	userId, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return controller.BadRequest("Invalid user ID format", err.Error())
+	}
+	requestData := new(dto.GrantSubscriptionRequest)
+	if err := c.Bind(requestData); err != nil {
+		return controller.BadRequest("Invalid request data", err.Error())
+	}
+	resultValidator := validator.ValidateGrantSubscription(requestData)
+	if !resultValidator.Valid {
+		return controller.BadRequest("Validation failed", resultValidator.Errors)
+	}
+
+	response, appErr := controller.subscriptionService.GrantSubscription(ctx, token, userId, requestData)
+	if appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error grant subscription", appErr.Error())
+		}
+		return controller.BadRequest("Error grant subscription", appErr.Error())
+	}
+	return controller.SuccessResponse(c, response, "Grant subscription successfully")
+}
+
+func (controller *SubscriptionController) CancelSubscription(c echo.Context) error {
+	ctx := c.Request().Context()
+	userId, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		return controller.BadRequest("Invalid user ID format", err.Error())
+	}
+	subscriptionId, err := uuid.Parse(c.Param("subscriptionId"))
+	if err != nil {
+		return controller.BadRequest("Invalid subscription ID format", err.Error())
+	}
+
+	if appErr := controller.subscriptionService.CancelSubscription(ctx, userId, subscriptionId); appErr != nil {
+		if appErr.Code == errors.ErrNotFound {
+			return controller.NotFound("Error cancel subscription", appErr.Error())
+		}
+		return controller.BadRequest("Error cancel subscription", appErr.Error())
+	}
+	return controller.SuccessResponse(c, nil, "Cancel subscription successfully")
+}
@@ -0,0 +1,69 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type PlanResponse struct {
+	PlanID          uuid.UUID `json:"plan_id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	DurationDays    int32     `json:"duration_days"`
+	GracePeriodDays int32     `json:"grace_period_days"`
+	PriceCents      int64     `json:"price_cents"`
+	Currency        string    `json:"currency"`
+	IsActive        bool      `json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+type CreatePlanRequest struct {
+	Code            string `json:"code"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DurationDays    int32  `json:"duration_days"`
+	GracePeriodDays int32  `json:"grace_period_days"`
+	PriceCents      int64  `json:"price_cents"`
+	Currency        string `json:"currency"`
+	IsActive        bool   `json:"is_active"`
+}
+type UpdatePlanRequest struct {
+	Name            string `json:"name"`
+	Description     string `json:"description"`
+	DurationDays    int32  `json:"duration_days"`
+	GracePeriodDays int32  `json:"grace_period_days"`
+	PriceCents      int64  `json:"price_cents"`
+	Currency        string `json:"currency"`
+	IsActive        bool   `json:"is_active"`
+}
+type SubscriptionResponse struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	PlanID         uuid.UUID  `json:"plan_id"`
+	PlanCode       string     `json:"plan_code"`
+	PlanName       string     `json:"plan_name"`
+	Source         string     `json:"source"`
+	Status         string     `json:"status"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	GraceEndsAt    time.Time  `json:"grace_ends_at"`
+	GrantedBy      *uuid.UUID `json:"granted_by,omitempty"`
+	Note           string     `json:"note,omitempty"`
+	CanceledAt     *time.Time `json:"canceled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// GrantSubscriptionRequest gives a user a plan for free. The period starts after the user's
+// current subscription when starts_at is omitted, and lasts the plan's duration unless duration_days is set.
+type GrantSubscriptionRequest struct {
+	PlanID       uuid.UUID  `json:"plan_id"`
+	StartsAt     *time.Time `json:"starts_at"`
+	DurationDays int32      `json:"duration_days"`
+	Note         string     `json:"note"`
+}
+type EntitlementResponse struct {
+	Subscribed    bool                  `json:"subscribed"`
+	InGracePeriod bool                  `json:"in_grace_period"`
+	AccessEndsAt  *time.Time            `json:"access_ends_at"`
+	Subscription  *SubscriptionResponse `json:"subscription"`
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"github.com/google/uuid"
+	libraryentity "pirate-lang-go/modules/library/entity"
+	"time"
+)
+
+type Plan struct {
+	PlanID          uuid.UUID `json:"plan_id"`
+	Code            string    `json:"code"`
+	Name            string    `json:"name"`
+	Description     string    `json:"description"`
+	DurationDays    int32     `json:"duration_days"`
+	GracePeriodDays int32     `json:"grace_period_days"`
+	PriceCents      int64     `json:"price_cents"`
+	Currency        string    `json:"currency"`
+	IsActive        bool      `json:"is_active"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// Period returns when a subscription to the plan starting at startsAt ends, and when its grace period ends.
+// durationDays overrides the plan's duration when positive.
+func (p *Plan) Period(startsAt time.Time, durationDays int32) (endsAt, graceEndsAt time.Time) {
+	if durationDays <= 0 {
+		durationDays = p.DurationDays
+	}
+	endsAt = startsAt.AddDate(0, 0, int(durationDays))
+	return endsAt, endsAt.AddDate(0, 0, int(p.GracePeriodDays))
+}
+
+type Subscription struct {
+	SubscriptionID uuid.UUID  `json:"subscription_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	PlanID         uuid.UUID  `json:"plan_id"`
+	PlanCode       string     `json:"plan_code"`
+	PlanName       string     `json:"plan_name"`
+	Source         string     `json:"source"`
+	StartsAt       time.Time  `json:"starts_at"`
+	EndsAt         time.Time  `json:"ends_at"`
+	GraceEndsAt    time.Time  `json:"grace_ends_at"`
+	GrantedBy      *uuid.UUID `json:"granted_by"`
+	Note           string     `json:"note"`
+	CanceledAt     *time.Time `json:"canceled_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// Where a subscription comes from
+const (
+	SourceAdminGrant = "ADMIN_GRANT"
+	SourcePurchase   = "PURCHASE"
+)
+
+// Status of a subscription at a point in time
+const (
+	StatusScheduled = "SCHEDULED"
+	StatusActive    = "ACTIVE"
+	StatusGrace     = "GRACE"
+	StatusExpired   = "EXPIRED"
+	StatusCanceled  = "CANCELED"
+)
+
+func (s *Subscription) Status(now time.Time) string {
+	switch {
+	case s.CanceledAt != nil:
+		return StatusCanceled
+	case now.Before(s.StartsAt):
+		return StatusScheduled
+	case now.Before(s.EndsAt):
+		return StatusActive
+	case now.Before(s.GraceEndsAt):
+		return StatusGrace
+	default:
+		return StatusExpired
+	}
+}
+
+// Entitlement tells which content a user may read.
+type Entitlement struct {
+	UserID       uuid.UUID     `json:"user_id"`
+	Subscription *Subscription `json:"subscription"` // nil for free users
+}
+
+func (e *Entitlement) Subscribed() bool {
+	return e.Subscription != nil
+}
+
+// CanAccess reports whether content of the given plan type is readable; FREE content always is.
+func (e *Entitlement) CanAccess(planType string) bool {
+	return planType != libraryentity.PlanTypeSubscription || e.Subscribed()
+}
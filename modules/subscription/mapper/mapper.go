@@ -0,0 +1,113 @@
+package mapper
+
+import (
+	"pirate-lang-go/modules/subscription/dto"
+	"pirate-lang-go/modules/subscription/entity"
+	"strings"
+	"time"
+)
+
+func ToPlanResponse(plan *entity.Plan) *dto.PlanResponse {
+	if plan == nil {
+		return nil
+	}
+	return &dto.PlanResponse{
+		PlanID:          plan.PlanID,
+		Code:            plan.Code,
+		Name:            plan.Name,
+		Description:     plan.Description,
+		DurationDays:    plan.DurationDays,
+		GracePeriodDays: plan.GracePeriodDays,
+		PriceCents:      plan.PriceCents,
+		Currency:        plan.Currency,
+		IsActive:        plan.IsActive,
+		CreatedAt:       plan.CreatedAt,
+		UpdatedAt:       plan.UpdatedAt,
+	}
+}
+
+func ToPlansResponse(plans []*entity.Plan) []*dto.PlanResponse {
+	responses := make([]*dto.PlanResponse, 0, len(plans))
+	for _, plan := range plans {
+		responses = append(responses, ToPlanResponse(plan))
+	}
+	return responses
+}
+
+func ToCreatePlanEntity(request *dto.CreatePlanRequest) *entity.Plan {
+	return &entity.Plan{
+		Code:            strings.TrimSpace(request.Code),
+		Name:            strings.TrimSpace(request.Name),
+		Description:     request.Description,
+		DurationDays:    request.DurationDays,
+		GracePeriodDays: request.GracePeriodDays,
+		PriceCents:      request.PriceCents,
+		Currency:        strings.ToUpper(request.Currency),
+		IsActive:        request.IsActive,
+	}
+}
+
+func ToUpdatePlanEntity(request *dto.UpdatePlanRequest) *entity.Plan {
+	return &entity.Plan{
+		Name:            strings.TrimSpace(request.Name),
+		Description:     request.Description,
+		DurationDays:    request.DurationDays,
+		GracePeriodDays: request.GracePeriodDays,
+		PriceCents:      request.PriceCents,
+		Currency:        strings.ToUpper(request.Currency),
+		IsActive:        request.IsActive,
+	}
+}
+
+func ToSubscriptionResponse(subscription *entity.Subscription, now time.Time) *dto.SubscriptionResponse {
+	if subscription == nil {
+		return nil
+	}
+	return &dto.SubscriptionResponse{
+		SubscriptionID: subscription.SubscriptionID,
+		PlanID:         subscription.PlanID,
+		PlanCode:       subscription.PlanCode,
+		PlanName:       subscription.PlanName,
+		Source:         subscription.Source,
+		Status:         subscription.Status(now),
+		StartsAt:       subscription.StartsAt,
+		EndsAt:         subscription.EndsAt,
+		GraceEndsAt:    subscription.GraceEndsAt,
+		GrantedBy:      subscription.GrantedBy,
+		Note:           subscription.Note,
+		CanceledAt:     subscription.CanceledAt,
+		CreatedAt:      subscription.CreatedAt,
+	}
+}
+
+// ToLearnerSubscriptionResponse leaves out who granted the subscription and the admin note.
+func ToLearnerSubscriptionResponse(subscription *entity.Subscription, now time.Time) *dto.SubscriptionResponse {
+	response := ToSubscriptionResponse(subscription, now)
+	if response != nil {
+		response.GrantedBy = nil
+		response.Note = ""
+	}
+	return response
+}
+
+func ToSubscriptionsResponse(subscriptions []*entity.Subscription, now time.Time, learner bool) []*dto.SubscriptionResponse {
+	responses := make([]*dto.SubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		if learner {
+			responses = append(responses, ToLearnerSubscriptionResponse(subscription, now))
+		} else {
+			responses = append(responses, ToSubscriptionResponse(subscription, now))
+		}
+	}
+	return responses
+}
+
+func ToEntitlementResponse(entitlement *entity.Entitlement, now time.Time) *dto.EntitlementResponse {
+	response := &dto.EntitlementResponse{Subscribed: entitlement.Subscribed()}
+	if subscription := entitlement.Subscription; subscription != nil {
+		response.InGracePeriod = subscription.Status(now) == entity.StatusGrace
+		response.AccessEndsAt = &subscription.GraceEndsAt
+		response.Subscription = ToLearnerSubscriptionResponse(subscription, now)
+	}
+	return response
+}
@@ -0,0 +1,27 @@
+package subscription
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/database"
+	"pirate-lang-go/core/mailer"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/core/storage"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	accountservice "pirate-lang-go/modules/account/service"
+	"pirate-lang-go/modules/subscription/controller"
+	"pirate-lang-go/modules/subscription/repository"
+	"pirate-lang-go/modules/subscription/router"
+	"pirate-lang-go/modules/subscription/service"
+)
+
+func Init(e *echo.Echo, db database.Database, cache *cache.Cache, storage *storage.Storage, mailer mailer.IMailer) {
+	accountRepository := accountrepo.NewAccountRepository(db.DB())
+	accountService := accountservice.NewAccountService(accountRepository, cache, storage, mailer)
+	middleware := middleware.NewMiddleware(accountService)
+
+	subscriptionService := service.NewSubscriptionService(repository.NewSubscriptionRepository(db.DB()), accountRepository, cache)
+	router.NewSubscriptionRouter(
+		controller.NewSubscriptionController(subscriptionService),
+	).Setup(e, middleware)
+}
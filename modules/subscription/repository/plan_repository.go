@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/subscription/entity"
+)
+
+func (r *SubscriptionRepository) CreatePlan(ctx context.Context, plan *entity.Plan) (*entity.Plan, error) {
+	planDB, err := r.Queries.CreateSubscriptionPlan(ctx, database.CreateSubscriptionPlanParams{
+		Code:            plan.Code,
+		Name:            plan.Name,
+		Description:     plan.Description,
+		DurationDays:    plan.DurationDays,
+		GracePeriodDays: plan.GracePeriodDays,
+		PriceCents:      plan.PriceCents,
+		Currency:        plan.Currency,
+		IsActive:        plan.IsActive,
+	})
+	if err != nil {
+		logger.Error("SubscriptionRepository.CreatePlan: failed to create plan", "code", plan.Code, "error", err)
+		return nil, err
+	}
+	return toPlanEntity(planDB), nil
+}
+
+func (r *SubscriptionRepository) UpdatePlan(ctx context.Context, planId uuid.UUID, plan *entity.Plan) (*entity.Plan, error) {
+	planDB, err := r.Queries.UpdateSubscriptionPlan(ctx, database.UpdateSubscriptionPlanParams{
+		PlanID:          planId,
+		Name:            plan.Name,
+		Description:     plan.Description,
+		DurationDays:    plan.DurationDays,
+		GracePeriodDays: plan.GracePeriodDays,
+		PriceCents:      plan.PriceCents,
+		Currency:        plan.Currency,
+		IsActive:        plan.IsActive,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("SubscriptionRepository.UpdatePlan: failed to update plan", "plan_id", planId, "error", err)
+		return nil, err
+	}
+	return toPlanEntity(planDB), nil
+}
+
+func (r *SubscriptionRepository) GetPlans(ctx context.Context, activeOnly bool) ([]*entity.Plan, error) {
+	var (
+		plansDB []database.SubscriptionPlan
+		err     error
+	)
+	if activeOnly {
+		plansDB, err = r.Queries.GetActiveSubscriptionPlans(ctx)
+	} else {
+		plansDB, err = r.Queries.GetSubscriptionPlans(ctx)
+	}
+	if err != nil {
+		logger.Error("SubscriptionRepository.GetPlans: failed to get plans", "active_only", activeOnly, "error", err)
+		return nil, err
+	}
+	plans := make([]*entity.Plan, 0, len(plansDB))
+	for _, planDB := range plansDB {
+		plans = append(plans, toPlanEntity(planDB))
+	}
+	return plans, nil
+}
+
+func (r *SubscriptionRepository) GetPlan(ctx context.Context, planId uuid.UUID) (*entity.Plan, error) {
+	planDB, err := r.Queries.GetSubscriptionPlan(ctx, planId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("SubscriptionRepository.GetPlan: failed to get plan", "plan_id", planId, "error", err)
+		return nil, err
+	}
+	return toPlanEntity(planDB), nil
+}
+
+func toPlanEntity(planDB database.SubscriptionPlan) *entity.Plan {
+	return &entity.Plan{
+		PlanID:          planDB.PlanID,
+		Code:            planDB.Code,
+		Name:            planDB.Name,
+		Description:     planDB.Description,
+		DurationDays:    planDB.DurationDays,
+		GracePeriodDays: planDB.GracePeriodDays,
+		PriceCents:      planDB.PriceCents,
+		Currency:        planDB.Currency,
+		IsActive:        planDB.IsActive,
+		CreatedAt:       planDB.CreatedAt.Time,
+		UpdatedAt:       planDB.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/subscription/entity"
+	"time"
+)
+
+type SubscriptionRepository struct {
+	Queries *database.Queries
+	db      *sql.DB
+}
+
+func NewSubscriptionRepository(sqlDB *sql.DB) ISubscriptionRepository {
+	return &SubscriptionRepository{
+		Queries: database.New(sqlDB),
+		db:      sqlDB,
+	}
+}
+
+type ISubscriptionRepository interface {
+	// Plans
+	CreatePlan(ctx context.Context, plan *entity.Plan) (*entity.Plan, error)
+	UpdatePlan(ctx context.Context, planId uuid.UUID, plan *entity.Plan) (*entity.Plan, error)
+	GetPlans(ctx context.Context, activeOnly bool) ([]*entity.Plan, error)
+	GetPlan(ctx context.Context, planId uuid.UUID) (*entity.Plan, error)
+	// User subscriptions
+	CreateSubscription(ctx context.Context, subscription *entity.Subscription) (*entity.Subscription, error)
+	GetUserSubscriptions(ctx context.Context, userId uuid.UUID) ([]*entity.Subscription, error)
+	GetCurrentSubscription(ctx context.Context, userId uuid.UUID) (*entity.Subscription, error)
+	GetLatestSubscriptionEnd(ctx context.Context, userId uuid.UUID) (*time.Time, error)
+	CancelSubscription(ctx context.Context, userId, subscriptionId uuid.UUID) (bool, error)
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/internal/database"
+	"pirate-lang-go/modules/subscription/entity"
+	"time"
+)
+
+func (r *SubscriptionRepository) CreateSubscription(ctx context.Context, subscription *entity.Subscription) (*entity.Subscription, error) {
+	params := database.CreateUserSubscriptionParams{
+		UserID:      subscription.UserID,
+		PlanID:      subscription.PlanID,
+		Source:      subscription.Source,
+		StartsAt:    subscription.StartsAt,
+		EndsAt:      subscription.EndsAt,
+		GraceEndsAt: subscription.GraceEndsAt,
+		Note:        subscription.Note,
+	}
+	if subscription.GrantedBy != nil {
+		params.GrantedBy = uuid.NullUUID{UUID: *subscription.GrantedBy, Valid: true}
+	}
+	subscriptionDB, err := r.Queries.CreateUserSubscription(ctx, params)
+	if err != nil {
+		logger.Error("SubscriptionRepository.CreateSubscription: failed to create subscription",
+			"user_id", subscription.UserID,
+			"plan_id", subscription.PlanID,
+			"error", err)
+		return nil, err
+	}
+	created := toSubscriptionEntity(database.GetUserSubscriptionsRow{
+		SubscriptionID: subscriptionDB.SubscriptionID,
+		UserID:         subscriptionDB.UserID,
+		PlanID:         subscriptionDB.PlanID,
+		Source:         subscriptionDB.Source,
+		StartsAt:       subscriptionDB.StartsAt,
+		EndsAt:         subscriptionDB.EndsAt,
+		GraceEndsAt:    subscriptionDB.GraceEndsAt,
+		GrantedBy:      subscriptionDB.GrantedBy,
+		Note:           subscriptionDB.Note,
+		CanceledAt:     subscriptionDB.CanceledAt,
+		CreatedAt:      subscriptionDB.CreatedAt,
+	})
+	created.PlanCode = subscription.PlanCode
+	created.PlanName = subscription.PlanName
+	return created, nil
+}
+
+func (r *SubscriptionRepository) GetUserSubscriptions(ctx context.Context, userId uuid.UUID) ([]*entity.Subscription, error) {
+	rows, err := r.Queries.GetUserSubscriptions(ctx, userId)
+	if err != nil {
+		logger.Error("SubscriptionRepository.GetUserSubscriptions: failed to get subscriptions", "user_id", userId, "error", err)
+		return nil, err
+	}
+	subscriptions := make([]*entity.Subscription, 0, len(rows))
+	for _, row := range rows {
+		subscriptions = append(subscriptions, toSubscriptionEntity(row))
+	}
+	return subscriptions, nil
+}
+
+func (r *SubscriptionRepository) GetCurrentSubscription(ctx context.Context, userId uuid.UUID) (*entity.Subscription, error) {
+	row, err := r.Queries.GetCurrentUserSubscription(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("SubscriptionRepository.GetCurrentSubscription: failed to get subscription", "user_id", userId, "error", err)
+		return nil, err
+	}
+	return toSubscriptionEntity(database.GetUserSubscriptionsRow(row)), nil
+}
+
+func (r *SubscriptionRepository) GetLatestSubscriptionEnd(ctx context.Context, userId uuid.UUID) (*time.Time, error) {
+	endsAt, err := r.Queries.GetLatestUserSubscriptionEnd(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error("SubscriptionRepository.GetLatestSubscriptionEnd: failed to get subscription end", "user_id", userId, "error", err)
+		return nil, err
+	}
+	return &endsAt, nil
+}
+
+func (r *SubscriptionRepository) CancelSubscription(ctx context.Context, userId, subscriptionId uuid.UUID) (bool, error) {
+	rows, err := r.Queries.CancelUserSubscription(ctx, database.CancelUserSubscriptionParams{
+		SubscriptionID: subscriptionId,
+		UserID:         userId,
+	})
+	if err != nil {
+		logger.Error("SubscriptionRepository.CancelSubscription: failed to cancel subscription",
+			"user_id", userId,
+			"subscription_id", subscriptionId,
+			"error", err)
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+func toSubscriptionEntity(row database.GetUserSubscriptionsRow) *entity.Subscription {
+	subscription := &entity.Subscription{
+		SubscriptionID: row.SubscriptionID,
+		UserID:         row.UserID,
+		PlanID:         row.PlanID,
+		PlanCode:       row.PlanCode,
+		PlanName:       row.PlanName,
+		Source:         row.Source,
+		StartsAt:       row.StartsAt,
+		EndsAt:         row.EndsAt,
+		GraceEndsAt:    row.GraceEndsAt,
+		Note:           row.Note,
+		CreatedAt:      row.CreatedAt.Time,
+	}
+	if row.GrantedBy.Valid {
+		subscription.GrantedBy = &row.GrantedBy.UUID
+	}
+	if row.CanceledAt.Valid {
+		subscription.CanceledAt = &row.CanceledAt.Time
+	}
+	return subscription
+}
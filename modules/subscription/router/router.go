@@ -0,0 +1,41 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/middleware"
+	"pirate-lang-go/modules/subscription/controller"
+)
+
+type SubscriptionRouter struct {
+	controller *controller.SubscriptionController
+}
+
+func NewSubscriptionRouter(controller *controller.SubscriptionController) *SubscriptionRouter {
+	return &SubscriptionRouter{
+		controller: controller,
+	}
+}
+func (r *SubscriptionRouter) Setup(e *echo.Echo, middleware *middleware.Middleware) {
+	// API v1 group
+	v1 := e.Group("/v1")
+	//public group - no middleware needed
+	public := v1.Group("/public")
+	public.GET("/plans", r.controller.GetPlans)
+	// Subscriptions of the signed-in user
+	subscriptions := v1.Group("/subscriptions")
+	subscriptions.Use(middleware.AuthMiddleware())
+	subscriptions.GET("", r.controller.GetMySubscriptions)
+	subscriptions.GET("/entitlement", r.controller.GetMyEntitlement)
+	// Admin routes
+	admin := v1.Group("/admin")
+	admin.Use(middleware.AuthMiddleware(), middleware.PermissionMiddleware(constants.PermissionSubscriptionsManage))
+	plansAdmin := admin.Group("/plans")
+	plansAdmin.GET("", r.controller.GetAllPlans)
+	plansAdmin.POST("", r.controller.CreatePlan)
+	plansAdmin.PUT("/:planId", r.controller.UpdatePlan)
+	userSubscriptions := admin.Group("/users/:userId/subscriptions")
+	userSubscriptions.GET("", r.controller.GetUserSubscriptions)
+	userSubscriptions.POST("", r.controller.GrantSubscription)
+	userSubscriptions.DELETE("/:subscriptionId", r.controller.CancelSubscription)
+}
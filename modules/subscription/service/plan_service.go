@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/subscription/dto"
+	"pirate-lang-go/modules/subscription/mapper"
+	"time"
+)
+
+func (s *SubscriptionService) GetPlans(ctx context.Context, activeOnly bool) ([]*dto.PlanResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	plans, err := s.repo.GetPlans(ctx, activeOnly)
+	if err != nil {
+		logger.Error("SubscriptionService:GetPlans:Failed to get plans", "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GetPlans:Failed to get plans", err)
+	}
+	return mapper.ToPlansResponse(plans), nil
+}
+
+func (s *SubscriptionService) CreatePlan(ctx context.Context, request *dto.CreatePlanRequest) (*dto.PlanResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	plan, err := s.repo.CreatePlan(ctx, mapper.ToCreatePlanEntity(request))
+	if err != nil {
+		logger.Error("SubscriptionService:CreatePlan:Failed to create plan", "code", request.Code, "error", err)
+		return nil, errors.NewAppError(errors.ErrAlreadyExists, "SubscriptionService:CreatePlan:Failed to create plan", err)
+	}
+	return mapper.ToPlanResponse(plan), nil
+}
+
+func (s *SubscriptionService) UpdatePlan(ctx context.Context, planId uuid.UUID, request *dto.UpdatePlanRequest) (*dto.PlanResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	plan, err := s.repo.UpdatePlan(ctx, planId, mapper.ToUpdatePlanEntity(request))
+	if err != nil {
+		logger.Error("SubscriptionService:UpdatePlan:Failed to update plan", "plan_id", planId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:UpdatePlan:Failed to update plan", err)
+	}
+	if plan == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "SubscriptionService:UpdatePlan:Plan not found", nil)
+	}
+	return mapper.ToPlanResponse(plan), nil
+}
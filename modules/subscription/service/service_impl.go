@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"pirate-lang-go/core/cache"
+	"pirate-lang-go/core/errors"
+	accountrepo "pirate-lang-go/modules/account/repository"
+	"pirate-lang-go/modules/subscription/dto"
+	"pirate-lang-go/modules/subscription/entity"
+	"pirate-lang-go/modules/subscription/repository"
+)
+
+type SubscriptionService struct {
+	repo        repository.ISubscriptionRepository
+	accountRepo accountrepo.IAccountRepository
+	cache       cache.ICache
+}
+
+func NewSubscriptionService(repo repository.ISubscriptionRepository, accountRepo accountrepo.IAccountRepository, cache cache.ICache) ISubscriptionService {
+
+	return &SubscriptionService{
+		repo:        repo,
+		accountRepo: accountRepo,
+		cache:       cache,
+	}
+}
+
+type ISubscriptionService interface {
+	// Plans
+	GetPlans(ctx context.Context, activeOnly bool) ([]*dto.PlanResponse, *errors.AppError)
+	CreatePlan(ctx context.Context, request *dto.CreatePlanRequest) (*dto.PlanResponse, *errors.AppError)
+	UpdatePlan(ctx context.Context, planId uuid.UUID, request *dto.UpdatePlanRequest) (*dto.PlanResponse, *errors.AppError)
+	// Subscriptions of the caller
+	GetMySubscriptions(ctx context.Context, token string) ([]*dto.SubscriptionResponse, *errors.AppError)
+	GetMyEntitlement(ctx context.Context, token string) (*dto.EntitlementResponse, *errors.AppError)
+	// Subscriptions managed by admins
+	GetUserSubscriptions(ctx context.Context, userId uuid.UUID) ([]*dto.SubscriptionResponse, *errors.AppError)
+	GrantSubscription(ctx context.Context, token string, userId uuid.UUID, request *dto.GrantSubscriptionRequest) (*dto.SubscriptionResponse, *errors.AppError)
+	CancelSubscription(ctx context.Context, userId, subscriptionId uuid.UUID) *errors.AppError
+	// GetEntitlement is the check learner-facing content reads go through.
+	GetEntitlement(ctx context.Context, userId uuid.UUID) (*entity.Entitlement, *errors.AppError)
+	InvalidateEntitlement(ctx context.Context, userId uuid.UUID)
+}
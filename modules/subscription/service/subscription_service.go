@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"pirate-lang-go/core/constants"
+	"pirate-lang-go/core/errors"
+	"pirate-lang-go/core/logger"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/modules/subscription/dto"
+	"pirate-lang-go/modules/subscription/entity"
+	"pirate-lang-go/modules/subscription/mapper"
+	"time"
+)
+
+func (s *SubscriptionService) GetMySubscriptions(ctx context.Context, token string) ([]*dto.SubscriptionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("SubscriptionService:GetMySubscriptions:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "SubscriptionService:GetMySubscriptions:Failed to get user", err)
+	}
+	subscriptions, err := s.repo.GetUserSubscriptions(ctx, claims.UserID)
+	if err != nil {
+		logger.Error("SubscriptionService:GetMySubscriptions:Failed to get subscriptions", "user_id", claims.UserID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GetMySubscriptions:Failed to get subscriptions", err)
+	}
+	return mapper.ToSubscriptionsResponse(subscriptions, time.Now(), true), nil
+}
+
+func (s *SubscriptionService) GetMyEntitlement(ctx context.Context, token string) (*dto.EntitlementResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("SubscriptionService:GetMyEntitlement:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "SubscriptionService:GetMyEntitlement:Failed to get user", err)
+	}
+	entitlement, appErr := s.GetEntitlement(ctx, claims.UserID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	return mapper.ToEntitlementResponse(entitlement, time.Now()), nil
+}
+
+func (s *SubscriptionService) GetUserSubscriptions(ctx context.Context, userId uuid.UUID) ([]*dto.SubscriptionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	subscriptions, err := s.repo.GetUserSubscriptions(ctx, userId)
+	if err != nil {
+		logger.Error("SubscriptionService:GetUserSubscriptions:Failed to get subscriptions", "user_id", userId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GetUserSubscriptions:Failed to get subscriptions", err)
+	}
+	return mapper.ToSubscriptionsResponse(subscriptions, time.Now(), false), nil
+}
+
+// GrantSubscription gives a user a plan without payment. Unless a start is given, the new period
+// follows the user's running or scheduled subscription so that granted days are never lost.
+func (s *SubscriptionService) GrantSubscription(ctx context.Context, token string, userId uuid.UUID, request *dto.GrantSubscriptionRequest) (*dto.SubscriptionResponse, *errors.AppError) {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	claims, err := utils.ValidateAndParseToken(token)
+	if err != nil {
+		logger.Error("SubscriptionService:GrantSubscription:Failed to validate token", "error", err)
+		return nil, errors.NewAppError(errors.ErrUnauthorized, "SubscriptionService:GrantSubscription:Failed to get user", err)
+	}
+	user, err := s.accountRepo.GetUserByEmailOrUserNameOrId(ctx, "", "", userId)
+	if err != nil {
+		logger.Error("SubscriptionService:GrantSubscription:Failed to get user", "user_id", userId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GrantSubscription:Failed to get user", err)
+	}
+	if user == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "SubscriptionService:GrantSubscription:User not found", nil)
+	}
+	plan, err := s.repo.GetPlan(ctx, request.PlanID)
+	if err != nil {
+		logger.Error("SubscriptionService:GrantSubscription:Failed to get plan", "plan_id", request.PlanID, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GrantSubscription:Failed to get plan", err)
+	}
+	if plan == nil {
+		return nil, errors.NewAppError(errors.ErrNotFound, "SubscriptionService:GrantSubscription:Plan not found", nil)
+	}
+
+	startsAt := time.Now()
+	if request.StartsAt != nil {
+		startsAt = *request.StartsAt
+	} else {
+		latestEnd, err := s.repo.GetLatestSubscriptionEnd(ctx, userId)
+		if err != nil {
+			logger.Error("SubscriptionService:GrantSubscription:Failed to get current subscription", "user_id", userId, "error", err)
+			return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GrantSubscription:Failed to get current subscription", err)
+		}
+		if latestEnd != nil && latestEnd.After(startsAt) {
+			startsAt = *latestEnd
+		}
+	}
+	endsAt, graceEndsAt := plan.Period(startsAt, request.DurationDays)
+
+	subscription, err := s.repo.CreateSubscription(ctx, &entity.Subscription{
+		UserID:      userId,
+		PlanID:      plan.PlanID,
+		PlanCode:    plan.Code,
+		PlanName:    plan.Name,
+		Source:      entity.SourceAdminGrant,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		GraceEndsAt: graceEndsAt,
+		GrantedBy:   &claims.UserID,
+		Note:        request.Note,
+	})
+	if err != nil {
+		logger.Error("SubscriptionService:GrantSubscription:Failed to create subscription", "user_id", userId, "error", err)
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GrantSubscription:Failed to create subscription", err)
+	}
+	s.invalidateEntitlementCache(ctx, userId)
+	return mapper.ToSubscriptionResponse(subscription, time.Now()), nil
+}
+
+// CancelSubscription ends a subscription immediately, grace period included.
+func (s *SubscriptionService) CancelSubscription(ctx context.Context, userId, subscriptionId uuid.UUID) *errors.AppError {
+	ctx, cancel := utils.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	canceled, err := s.repo.CancelSubscription(ctx, userId, subscriptionId)
+	if err != nil {
+		logger.Error("SubscriptionService:CancelSubscription:Failed to cancel subscription", "subscription_id", subscriptionId, "error", err)
+		return errors.NewAppError(errors.ErrInternal, "SubscriptionService:CancelSubscription:Failed to cancel subscription", err)
+	}
+	if !canceled {
+		return errors.NewAppError(errors.ErrNotFound, "SubscriptionService:CancelSubscription:Subscription not found or already canceled", nil)
+	}
+	s.invalidateEntitlementCache(ctx, userId)
+	return nil
+}
+
+// GetEntitlement resolves the subscription giving the user access right now, cached in Redis
+// for at most EntitlementCacheExpiry and never past the end of that access.
+func (s *SubscriptionService) GetEntitlement(ctx context.Context, userId uuid.UUID) (*entity.Entitlement, *errors.AppError) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	key := fmt.Sprintf(constants.EntitlementCacheKey, userId)
+	entitlement := new(entity.Entitlement)
+	cached, err := s.cache.Get(ctx, key).Result()
+	if err == nil && json.Unmarshal([]byte(cached), entitlement) == nil {
+		return entitlement, nil
+	}
+	if err != nil && err != redis.Nil {
+		// Redis being down must not lock subscribers out; fall back to the database.
+		logger.Error("SubscriptionService:GetEntitlement:Failed to read entitlement cache", "user_id", userId, "error", err)
+	}
+
+	subscription, err := s.repo.GetCurrentSubscription(ctx, userId)
+	if err != nil {
+		return nil, errors.NewAppError(errors.ErrInternal, "SubscriptionService:GetEntitlement:Failed to get subscription", err)
+	}
+	entitlement = &entity.Entitlement{UserID: userId, Subscription: subscription}
+
+	expiry := constants.EntitlementCacheExpiry
+	if subscription != nil {
+		expiry = min(expiry, time.Until(subscription.GraceEndsAt))
+	}
+	if expiry > 0 {
+		encoded, _ := json.Marshal(entitlement)
+		if err = s.cache.Set(ctx, key, encoded, expiry); err != nil {
+			logger.Error("SubscriptionService:GetEntitlement:Failed to write entitlement cache", "user_id", userId, "error", err)
+		}
+	}
+	return entitlement, nil
+}
+
+// InvalidateEntitlement drops the cached entitlement of a user whose subscriptions changed elsewhere, such as on payment.
+func (s *SubscriptionService) InvalidateEntitlement(ctx context.Context, userId uuid.UUID) {
+	s.invalidateEntitlementCache(ctx, userId)
+}
+
+func (s *SubscriptionService) invalidateEntitlementCache(ctx context.Context, userId uuid.UUID) {
+	if err := s.cache.Del(ctx, fmt.Sprintf(constants.EntitlementCacheKey, userId)); err != nil {
+		logger.Error("SubscriptionService:invalidateEntitlementCache:Failed to clear entitlement cache", "user_id", userId, "error", err)
+	}
+}
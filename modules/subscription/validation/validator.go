@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+	"pirate-lang-go/core/utils"
+	"pirate-lang-go/core/validation"
+	"pirate-lang-go/modules/subscription/dto"
+	"regexp"
+)
+
+var (
+	planCodeRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{1,49}$`)
+	currencyRegex = regexp.MustCompile(`^[A-Za-z]{3}$`)
+)
+
+// MaxGrantDays bounds a single admin grant to ten years.
+const MaxGrantDays = 3650
+
+func ValidateCreatePlan(dataRequest *dto.CreatePlanRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil {
+		result.AddError("request", "Request body is required")
+		return result
+	}
+	if !planCodeRegex.MatchString(dataRequest.Code) {
+		result.AddError("code", "Code must be 2-50 lowercase letters, digits, '-' or '_'")
+	}
+	validatePlanFields(result, dataRequest.Name, dataRequest.Currency, dataRequest.DurationDays, dataRequest.GracePeriodDays, dataRequest.PriceCents)
+	return result
+}
+
+func ValidateUpdatePlan(dataRequest *dto.UpdatePlanRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil {
+		result.AddError("request", "Request body is required")
+		return result
+	}
+	validatePlanFields(result, dataRequest.Name, dataRequest.Currency, dataRequest.DurationDays, dataRequest.GracePeriodDays, dataRequest.PriceCents)
+	return result
+}
+
+func validatePlanFields(result *validation.ValidationResult, name, currency string, durationDays, gracePeriodDays int32, priceCents int64) {
+	if utils.IsEmpty(name) {
+		result.AddError("name", "Name is required")
+	} else if len(name) > 100 {
+		result.AddError("name", "Name must be at most 100 characters")
+	}
+	if !currencyRegex.MatchString(currency) {
+		result.AddError("currency", "Currency must be a 3-letter ISO 4217 code")
+	}
+	if durationDays <= 0 {
+		result.AddError("duration_days", "Duration must be at least one day")
+	}
+	if gracePeriodDays < 0 {
+		result.AddError("grace_period_days", "Grace period cannot be negative")
+	}
+	if priceCents < 0 {
+		result.AddError("price_cents", "Price cannot be negative")
+	}
+}
+
+func ValidateGrantSubscription(dataRequest *dto.GrantSubscriptionRequest) *validation.ValidationResult {
+	result := validation.NewValidationResult()
+	if dataRequest == nil || dataRequest.PlanID == uuid.Nil {
+		result.AddError("plan_id", "Plan ID is required")
+		return result
+	}
+	if dataRequest.DurationDays < 0 || dataRequest.DurationDays > MaxGrantDays {
+		result.AddError("duration_days", "Duration must be between 1 and 3650 days, or omitted to use the plan's")
+	}
+	if len(dataRequest.Note) > 500 {
+		result.AddError("note", "Note must be at most 500 characters")
+	}
+	return result
+}
@@ -1,6 +1,6 @@
 -- name: GetUserByEmailOrUserNameOrId :one
 -- GetUserByEmailOrUserNameOrId retrieves a user by email, user_name, or id.
-SELECT id, user_name, email, password, created_at, updated_at
+SELECT id, user_name, email, password, is_locked, email_verified_at, created_at, updated_at
 FROM users
 WHERE
     (sqlc.narg(email)::text IS NULL OR email = sqlc.narg(email)::text) AND
@@ -83,6 +83,21 @@ SELECT EXISTS(
                       JOIN permissions p ON rp.permission_id = p.id
     WHERE ur.user_id = $1 AND p.id = $2
 );
+-- name: GetUserPermissionNames :many
+-- GetUserPermissionNames retrieves the names of every permission granted to a user through their roles.
+SELECT DISTINCT p.name
+FROM user_roles ur
+         JOIN role_permissions rp ON ur.role_id = rp.role_id
+         JOIN permissions p ON rp.permission_id = p.id
+WHERE ur.user_id = $1
+ORDER BY p.name;
+
+-- name: GetUserIdsByRole :many
+-- GetUserIdsByRole retrieves the ids of the users holding a role.
+SELECT user_id
+FROM user_roles
+WHERE role_id = $1;
+
 -- name: GetRole :one
 SELECT
     r.id AS role_id,
@@ -166,11 +181,13 @@ SELECT
     max_writing_score,
     total_score,
     created_at,
-    updated_at
+    updated_at,
+    status,
+    published_at
 FROM
     Exams
 WHERE
-    exam_id = $1;
+    exam_id = $1 AND deleted_at IS NULL;
 
 -- name: GetPaginatedExams :many
 SELECT
@@ -185,9 +202,14 @@ SELECT
     max_writing_score,
     total_score,
     created_at,
-    updated_at
+    updated_at,
+    status,
+    published_at
 FROM
     Exams
+WHERE
+    deleted_at IS NULL
+    AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
 LIMIT $1 OFFSET $2;
 -- name: UpdateExam :exec
 UPDATE Exams
@@ -209,7 +231,9 @@ DELETE FROM Exams
 WHERE
     exam_id = $1;
 -- name: GetExamsCount :one
-SELECT COUNT(*) FROM exams;
+SELECT COUNT(*) FROM exams
+WHERE deleted_at IS NULL
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'));
 
 -- name: CreateExamPart :one
 INSERT INTO exam_parts (
@@ -235,11 +259,13 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    part_id = $1;
+    part_id = $1 AND deleted_at IS NULL;
 
 -- name: GetPaginatedPracticeExamParts :many
 SELECT
@@ -252,14 +278,21 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    is_practice_component == 'TRUE'
+    is_practice_component = TRUE
+    AND deleted_at IS NULL
+    AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'))
 LIMIT $1 OFFSET $2;
 -- name: GetPracticeExamPartCount :one
-SELECT COUNT(*) FROM exam_parts where is_practice_component == 'TRUE';
+SELECT COUNT(*) FROM exam_parts
+WHERE is_practice_component = TRUE
+  AND deleted_at IS NULL
+  AND (sqlc.narg('status')::varchar IS NULL OR status = sqlc.narg('status'));
 -- name: GetExamPartsByExamId :many
 SELECT
     part_id,
@@ -271,11 +304,13 @@ SELECT
     plan_type,
     created_at,
     updated_at,
-    toeic_part_number
+    toeic_part_number,
+    status,
+    published_at
 FROM
     exam_parts
 WHERE
-    exam_id = $1
+    exam_id = $1 AND deleted_at IS NULL
 ORDER BY
     part_order;
 
@@ -329,7 +364,7 @@ SELECT
 FROM
     Paragraphs
 WHERE
-    paragraph_id = $1;
+    paragraph_id = $1 AND deleted_at IS NULL;
 -- name: GetParagraphByPartId :many
 SELECT
     paragraph_id,
@@ -345,7 +380,7 @@ SELECT
 FROM
     Paragraphs
 WHERE
-    part_id = $1;
+    part_id = $1 AND deleted_at IS NULL;
 -- name: ListParagraphs :many
 SELECT
     paragraph_id,
@@ -359,7 +394,9 @@ SELECT
     created_at,
     updated_at
 FROM
-    Paragraphs;
+    Paragraphs
+WHERE
+    deleted_at IS NULL;
 
 -- name: ListParagraphsByPartID :many
 SELECT
@@ -376,7 +413,7 @@ SELECT
 FROM
     Paragraphs
 WHERE
-    part_id = $1
+    part_id = $1 AND deleted_at IS NULL
 ORDER BY
     paragraph_order;
 
@@ -426,9 +463,11 @@ INSERT INTO Questions (
     toeic_question_section,
     question_number_in_part,
     answer_option,
-    correct_answer
+    correct_answer,
+    preparation_seconds,
+    response_seconds
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
          ) RETURNING question_id,question_content,question_type,part_id,paragraph_id,question_order,audio_url,image_url,toeic_question_section,question_number_in_part;
 
 -- name: GetQuestionByID :one
@@ -445,12 +484,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    question_id = $1;
+    question_id = $1 AND deleted_at IS NULL;
 
 -- name: ListQuestions :many
 SELECT
@@ -466,10 +507,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
-    Questions;
+    Questions
+WHERE
+    deleted_at IS NULL;
 
 -- name: ListQuestionsByPartID :many
 SELECT
@@ -485,12 +530,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    part_id = $1
+    part_id = $1 AND deleted_at IS NULL
 ORDER BY
     question_order;
 -- name: ListQuestionsByParagraphID :many
@@ -507,12 +554,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    paragraph_id = $1
+    paragraph_id = $1 AND deleted_at IS NULL
 Order By
     question_order ASC,
     question_number_in_part ASC,
@@ -523,7 +572,7 @@ SELECT
 FROM
     Questions
 WHERE
-    part_id = $1 and paragraph_id ISNULL;
+    part_id = $1 and paragraph_id ISNULL and deleted_at IS NULL;
 -- name: GetPaginatedSeparateQuestionsByPartID :many
 SELECT
     question_id,
@@ -538,12 +587,14 @@ SELECT
     question_number_in_part,
     answer_option,
     correct_answer,
+    preparation_seconds,
+    response_seconds,
     created_at,
     updated_at
 FROM
     Questions
 WHERE
-    part_id = $1 and paragraph_id ISNULL
+    part_id = $1 and paragraph_id ISNULL and deleted_at IS NULL
 Order By
     question_order ASC,
     question_number_in_part ASC,
@@ -561,7 +612,9 @@ SET
     toeic_question_section = $7,
     question_number_in_part = $8,
     answer_option = $9,
-    correct_answer = $10
+    correct_answer = $10,
+    preparation_seconds = $11,
+    response_seconds = $12
 WHERE
     question_id = $1;
 
@@ -582,4 +635,1210 @@ WHERE
 -- name: DeleteQuestion :exec
 DELETE FROM Questions
 WHERE
-    question_id = $1;
\ No newline at end of file
+    question_id = $1;
+
+-- name: CreateExamAttempt :one
+-- CreateExamAttempt starts a new attempt; deadline_at is derived from the exam duration when one is set.
+INSERT INTO exam_attempts (
+    exam_id,
+    user_id,
+    deadline_at
+) VALUES (
+    $1,
+    $2,
+    CURRENT_TIMESTAMP + (sqlc.narg(duration_minutes)::int * INTERVAL '1 minute')
+) RETURNING attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at;
+
+-- name: CreateAttemptQuestion :exec
+-- CreateAttemptQuestion records a question served in an attempt.
+-- The question and its paragraph are pinned to their current revisions.
+INSERT INTO attempt_questions (
+    attempt_id,
+    question_id,
+    part_id,
+    paragraph_id,
+    sequence_number,
+    question_revision_id,
+    paragraph_revision_id
+) VALUES (
+    $1, $2, $3, $4, $5,
+    (SELECT qr.revision_id FROM question_revisions qr WHERE qr.question_id = $2 ORDER BY qr.revision_number DESC LIMIT 1),
+    (SELECT pr.revision_id FROM paragraph_revisions pr WHERE pr.paragraph_id = $4 ORDER BY pr.revision_number DESC LIMIT 1)
+);
+
+-- name: GetExamAttempt :one
+-- GetExamAttempt retrieves an attempt owned by the given user.
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE attempt_id = $1 AND user_id = $2;
+
+-- name: GetInProgressExamAttempt :one
+-- GetInProgressExamAttempt retrieves the latest unfinished attempt of a user for an exam.
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE user_id = $1 AND exam_id = $2 AND status = 'IN_PROGRESS'
+ORDER BY started_at DESC
+LIMIT 1;
+
+-- name: ListExamAttemptsByUser :many
+-- ListExamAttemptsByUser retrieves all attempts of a user for an exam, newest first.
+SELECT attempt_id, exam_id, user_id, status, started_at, deadline_at, submitted_at, created_at, updated_at
+FROM exam_attempts
+WHERE user_id = $1 AND exam_id = $2
+ORDER BY started_at DESC;
+
+-- name: ListAttemptQuestions :many
+-- ListAttemptQuestions retrieves the questions of an attempt, as pinned when it started, with the learner's answers.
+SELECT
+    aq.attempt_id,
+    aq.question_id,
+    aq.part_id,
+    aq.paragraph_id,
+    aq.sequence_number,
+    aq.answer,
+    aq.answered_at,
+    q.question_content,
+    q.question_type,
+    q.audio_url,
+    q.image_url,
+    q.toeic_question_section,
+    q.question_number_in_part,
+    q.answer_option,
+    q.preparation_seconds,
+    q.response_seconds
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE aq.attempt_id = $1
+ORDER BY aq.sequence_number;
+
+-- name: ListAttemptParagraphs :many
+-- ListAttemptParagraphs retrieves the paragraphs referenced by the questions of an attempt.
+SELECT
+    p.paragraph_id,
+    p.paragraph_content,
+    p.title,
+    p.part_id,
+    p.paragraph_order,
+    p.paragraph_type,
+    p.audio_url,
+    p.image_url
+FROM paragraph_revisions p
+WHERE p.revision_id IN (
+    SELECT aq.paragraph_revision_id FROM attempt_questions aq WHERE aq.attempt_id = $1
+)
+ORDER BY p.paragraph_order;
+
+-- name: SaveAttemptAnswers :execrows
+-- SaveAttemptAnswers stores a batch of answers only while the attempt is in progress and before its deadline.
+-- Being one statement, the batch is stored or refused as a whole; an empty answer clears the question.
+UPDATE attempt_questions aq
+SET
+    answer = NULLIF(a.answer, ''),
+    answered_at = CURRENT_TIMESTAMP
+FROM exam_attempts ea,
+     (SELECT unnest(@question_ids::uuid[]) AS question_id, unnest(@answers::text[]) AS answer) a
+WHERE
+    aq.attempt_id = ea.attempt_id AND
+    aq.attempt_id = @attempt_id AND
+    aq.question_id = a.question_id AND
+    ea.status = 'IN_PROGRESS' AND
+    (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP);
+
+-- name: FinalizeExamAttempt :execrows
+-- FinalizeExamAttempt closes an in-progress attempt with the given final status.
+UPDATE exam_attempts
+SET
+    status = $2,
+    submitted_at = CURRENT_TIMESTAMP
+WHERE attempt_id = $1 AND status = 'IN_PROGRESS';
+
+-- name: ListAttemptQuestionsForScoring :many
+-- ListAttemptQuestionsForScoring retrieves the answers of an attempt along with the answer keys of the pinned revisions.
+SELECT
+    aq.question_id,
+    aq.answer,
+    q.question_type,
+    q.toeic_question_section,
+    q.correct_answer
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE aq.attempt_id = $1
+ORDER BY aq.sequence_number;
+
+-- name: ListAttemptReviewQuestions :many
+-- ListAttemptReviewQuestions retrieves the questions of an attempt as pinned when it started, with the
+-- learner's answers, the answer keys and the explanation in the requested language, else in the fallback one;
+-- explanation_lang is empty when the question has neither. Explanations are written for the current answer key,
+-- so they are left out for an attempt pinned to a revision whose key or options have changed since.
+SELECT
+    aq.question_id,
+    aq.part_id,
+    aq.paragraph_id,
+    aq.sequence_number,
+    aq.answer,
+    aq.is_correct,
+    q.question_content,
+    q.question_type,
+    q.audio_url,
+    q.image_url,
+    q.toeic_question_section,
+    q.question_number_in_part,
+    q.answer_option,
+    q.correct_answer,
+    COALESCE(qe.lang, '')::varchar AS explanation_lang,
+    COALESCE(qe.explanation, '')::text AS explanation,
+    COALESCE(qe.option_explanations, '{}')::jsonb AS option_explanations
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+LEFT JOIN questions cur ON cur.question_id = aq.question_id
+LEFT JOIN LATERAL (
+    SELECT e.lang, e.explanation, e.option_explanations
+    FROM question_explanations e
+    WHERE e.question_id = aq.question_id
+      AND e.lang IN (@lang::varchar, @fallback_lang::varchar)
+      AND cur.correct_answer IS NOT DISTINCT FROM q.correct_answer
+      AND cur.answer_option::jsonb IS NOT DISTINCT FROM q.answer_option::jsonb
+    ORDER BY e.lang = @lang::varchar DESC
+    LIMIT 1
+) qe ON TRUE
+WHERE aq.attempt_id = @attempt_id
+ORDER BY aq.sequence_number;
+
+-- name: UpdateAttemptQuestionCorrectness :exec
+-- UpdateAttemptQuestionCorrectness stores the grading outcome of one answered question.
+UPDATE attempt_questions
+SET is_correct = $3
+WHERE attempt_id = $1 AND question_id = $2;
+
+-- name: UpsertAttemptResult :one
+-- UpsertAttemptResult stores the scores of an attempt, replacing a previous result.
+INSERT INTO attempt_results (
+    attempt_id,
+    conversion_table_id,
+    listening_correct,
+    listening_total,
+    listening_scaled,
+    reading_correct,
+    reading_total,
+    reading_scaled,
+    total_scaled
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (attempt_id) DO UPDATE SET
+    conversion_table_id = EXCLUDED.conversion_table_id,
+    listening_correct = EXCLUDED.listening_correct,
+    listening_total = EXCLUDED.listening_total,
+    listening_scaled = EXCLUDED.listening_scaled,
+    reading_correct = EXCLUDED.reading_correct,
+    reading_total = EXCLUDED.reading_total,
+    reading_scaled = EXCLUDED.reading_scaled,
+    total_scaled = EXCLUDED.total_scaled,
+    scored_at = CURRENT_TIMESTAMP
+RETURNING attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+          speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled;
+
+-- name: GetAttemptResult :one
+-- GetAttemptResult retrieves the scores of an attempt.
+SELECT attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+       speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled
+FROM attempt_results
+WHERE attempt_id = $1;
+
+-- name: GetExamScoringConfig :one
+-- GetExamScoringConfig retrieves the score caps of an exam and the conversion table it uses, falling back to the default table.
+SELECT
+    e.exam_id,
+    e.max_listening_score,
+    e.max_reading_score,
+    e.max_speaking_score,
+    e.max_writing_score,
+    t.table_id AS conversion_table_id
+FROM exams e
+LEFT JOIN score_conversion_tables t ON t.table_id = COALESCE(e.score_conversion_table_id, (
+    SELECT d.table_id FROM score_conversion_tables d WHERE d.is_default LIMIT 1
+))
+WHERE e.exam_id = $1;
+
+-- name: CreateScoreConversionTable :one
+-- CreateScoreConversionTable creates a new, empty conversion table.
+INSERT INTO score_conversion_tables (name, description)
+VALUES ($1, $2)
+RETURNING table_id, name, description, is_default, created_at, updated_at;
+
+-- name: ListScoreConversionTables :many
+-- ListScoreConversionTables retrieves all conversion tables.
+SELECT table_id, name, description, is_default, created_at, updated_at
+FROM score_conversion_tables
+ORDER BY is_default DESC, name;
+
+-- name: GetScoreConversionTable :one
+-- GetScoreConversionTable retrieves a conversion table by id.
+SELECT table_id, name, description, is_default, created_at, updated_at
+FROM score_conversion_tables
+WHERE table_id = $1;
+
+-- name: ListScoreConversionEntries :many
+-- ListScoreConversionEntries retrieves the raw-to-scaled rows of a conversion table.
+SELECT table_id, section, raw_score, scaled_score
+FROM score_conversion_entries
+WHERE table_id = $1
+ORDER BY section, raw_score;
+
+-- name: DeleteScoreConversionEntries :exec
+-- DeleteScoreConversionEntries removes every row of a conversion table.
+DELETE FROM score_conversion_entries
+WHERE table_id = $1;
+
+-- name: CreateScoreConversionEntry :exec
+-- CreateScoreConversionEntry adds one raw-to-scaled row to a conversion table.
+INSERT INTO score_conversion_entries (table_id, section, raw_score, scaled_score)
+VALUES ($1, $2, $3, $4);
+
+-- name: ClearDefaultScoreConversionTable :exec
+-- ClearDefaultScoreConversionTable unsets the current default conversion table.
+UPDATE score_conversion_tables
+SET is_default = FALSE
+WHERE is_default;
+
+-- name: SetDefaultScoreConversionTable :execrows
+-- SetDefaultScoreConversionTable marks a conversion table as the default one.
+UPDATE score_conversion_tables
+SET is_default = TRUE
+WHERE table_id = $1;
+
+-- name: SetExamScoreConversionTable :execrows
+-- SetExamScoreConversionTable pins a conversion table to an exam; NULL reverts to the default table.
+UPDATE exams
+SET score_conversion_table_id = $2
+WHERE exam_id = $1;
+
+-- name: CreateUserSession :one
+-- CreateUserSession opens a session for a successful login.
+INSERT INTO user_sessions (session_id, user_id, expires_at, user_agent, ip_address)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreateRefreshToken :exec
+-- CreateRefreshToken records a refresh token issued for a session.
+INSERT INTO refresh_tokens (token_id, session_id, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: GetRefreshToken :one
+-- GetRefreshToken returns a refresh token together with the state of its session.
+SELECT rt.token_id,
+       rt.session_id,
+       rt.expires_at,
+       rt.used_at,
+       s.user_id,
+       s.revoked_at
+FROM refresh_tokens rt
+         JOIN user_sessions s ON s.session_id = rt.session_id
+WHERE rt.token_id = $1;
+
+-- name: UseRefreshToken :execrows
+-- UseRefreshToken marks a refresh token as exchanged; zero rows means it was already used.
+UPDATE refresh_tokens
+SET used_at = CURRENT_TIMESTAMP
+WHERE token_id = $1
+  AND used_at IS NULL;
+
+-- name: TouchUserSession :exec
+-- TouchUserSession extends a session after its refresh token was rotated.
+UPDATE user_sessions
+SET last_used_at = CURRENT_TIMESTAMP,
+    expires_at   = $2
+WHERE session_id = $1;
+
+-- name: RevokeUserSession :execrows
+-- RevokeUserSession closes a session so none of its refresh tokens can be exchanged again.
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $2
+WHERE session_id = $1
+  AND revoked_at IS NULL;
+
+-- name: ListActiveUserSessions :many
+-- ListActiveUserSessions returns the sessions of a user that are neither revoked nor expired, most recently used first.
+SELECT *
+FROM user_sessions
+WHERE user_id = $1
+  AND revoked_at IS NULL
+  AND expires_at > CURRENT_TIMESTAMP
+ORDER BY last_used_at DESC;
+
+-- name: RevokeUserSessionOfUser :execrows
+-- RevokeUserSessionOfUser closes one session, provided it belongs to the given user.
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $3
+WHERE session_id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL;
+
+-- name: RevokeAllUserSessions :many
+-- RevokeAllUserSessions closes every open session of a user and returns their ids.
+UPDATE user_sessions
+SET revoked_at    = CURRENT_TIMESTAMP,
+    revoke_reason = $2
+WHERE user_id = $1
+  AND revoked_at IS NULL
+RETURNING session_id;
+
+-- name: GetUserProvider :one
+-- GetUserProvider returns the link between a provider identity and a local user.
+SELECT *
+FROM user_providers
+WHERE provider = $1
+  AND provider_user_id = $2;
+
+-- name: CreateUserProvider :exec
+-- CreateUserProvider links a provider identity to a local user.
+INSERT INTO user_providers (user_id, provider, provider_user_id, email, access_token, refresh_token, avatar_url, full_name)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: UpdateUserProvider :exec
+-- UpdateUserProvider refreshes the tokens and profile data of a linked provider identity.
+UPDATE user_providers
+SET email         = $3,
+    access_token  = $4,
+    refresh_token = $5,
+    avatar_url    = $6,
+    full_name     = $7
+WHERE provider = $1
+  AND provider_user_id = $2;
+
+-- name: CreateSocialAccount :one
+-- CreateSocialAccount creates a user that signs in through an OAuth provider only.
+INSERT INTO users (user_name, email, is_social_login, email_verified_at)
+VALUES ($1, $2, TRUE, CURRENT_TIMESTAMP)
+RETURNING id, user_name, email;
+
+-- name: CreateSocialUserProfile :exec
+-- CreateSocialUserProfile creates the profile of a social account from the provider's profile data.
+INSERT INTO user_profiles (user_id, full_name)
+VALUES ($1, $2);
+
+-- name: MarkEmailVerified :execrows
+-- MarkEmailVerified records that the user confirmed their email; zero rows means it was already verified.
+UPDATE users
+SET email_verified_at = CURRENT_TIMESTAMP
+WHERE id = $1
+  AND email_verified_at IS NULL;
+
+-- name: SaveMailDeadLetter :exec
+-- SaveMailDeadLetter stores a mail the queue gave up on; a retried mail that fails again replaces its row.
+INSERT INTO mail_dead_letters (job_id, recipients, subject, payload, attempts, last_error)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (job_id) DO UPDATE
+    SET recipients  = EXCLUDED.recipients,
+        subject     = EXCLUDED.subject,
+        payload     = EXCLUDED.payload,
+        attempts    = EXCLUDED.attempts,
+        last_error  = EXCLUDED.last_error,
+        failed_at   = CURRENT_TIMESTAMP,
+        requeued_at = NULL;
+
+-- name: GetPaginatedMailDeadLetters :many
+-- GetPaginatedMailDeadLetters lists failed mails, most recent first.
+SELECT *
+FROM mail_dead_letters
+ORDER BY failed_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: GetMailDeadLettersCount :one
+-- GetMailDeadLettersCount counts the failed mails.
+SELECT COUNT(*)
+FROM mail_dead_letters;
+
+-- name: GetMailDeadLetter :one
+-- GetMailDeadLetter returns a failed mail by its job id.
+SELECT *
+FROM mail_dead_letters
+WHERE job_id = $1;
+
+-- name: MarkMailDeadLetterRequeued :execrows
+-- MarkMailDeadLetterRequeued records that a failed mail was put back on the queue; zero rows means it already was.
+UPDATE mail_dead_letters
+SET requeued_at = CURRENT_TIMESTAMP
+WHERE job_id = $1
+  AND requeued_at IS NULL;
+
+-- name: CreateSubscriptionPlan :one
+-- CreateSubscriptionPlan adds a plan users can subscribe to or be granted.
+INSERT INTO subscription_plans (code, name, description, duration_days, grace_period_days, price_cents, currency, is_active)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: UpdateSubscriptionPlan :one
+-- UpdateSubscriptionPlan changes a plan; existing subscriptions keep the period they were created with.
+UPDATE subscription_plans
+SET name              = $2,
+    description       = $3,
+    duration_days     = $4,
+    grace_period_days = $5,
+    price_cents       = $6,
+    currency          = $7,
+    is_active         = $8,
+    updated_at        = CURRENT_TIMESTAMP
+WHERE plan_id = $1
+RETURNING *;
+
+-- name: GetSubscriptionPlans :many
+-- GetSubscriptionPlans lists every plan, cheapest first.
+SELECT *
+FROM subscription_plans
+ORDER BY price_cents, name;
+
+-- name: GetActiveSubscriptionPlans :many
+-- GetActiveSubscriptionPlans lists the plans offered to learners, cheapest first.
+SELECT *
+FROM subscription_plans
+WHERE is_active = TRUE
+ORDER BY price_cents, name;
+
+-- name: GetSubscriptionPlan :one
+-- GetSubscriptionPlan returns a plan by id.
+SELECT *
+FROM subscription_plans
+WHERE plan_id = $1;
+
+-- name: CreateUserSubscription :one
+-- CreateUserSubscription records a subscription period for a user.
+INSERT INTO user_subscriptions (user_id, plan_id, source, starts_at, ends_at, grace_ends_at, granted_by, note)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetUserSubscriptions :many
+-- GetUserSubscriptions lists the subscriptions of a user with their plan, most recent first.
+SELECT us.*, sp.code AS plan_code, sp.name AS plan_name
+FROM user_subscriptions us
+         JOIN subscription_plans sp ON sp.plan_id = us.plan_id
+WHERE us.user_id = $1
+ORDER BY us.starts_at DESC;
+
+-- name: GetCurrentUserSubscription :one
+-- GetCurrentUserSubscription returns the subscription giving the user access right now, the one lasting longest first.
+SELECT us.*, sp.code AS plan_code, sp.name AS plan_name
+FROM user_subscriptions us
+         JOIN subscription_plans sp ON sp.plan_id = us.plan_id
+WHERE us.user_id = $1
+  AND us.canceled_at IS NULL
+  AND us.starts_at <= CURRENT_TIMESTAMP
+  AND us.grace_ends_at > CURRENT_TIMESTAMP
+ORDER BY us.grace_ends_at DESC
+LIMIT 1;
+
+-- name: GetLatestUserSubscriptionEnd :one
+-- GetLatestUserSubscriptionEnd returns when the last running or scheduled subscription of the user ends, so a new one can start after it.
+SELECT ends_at
+FROM user_subscriptions
+WHERE user_id = $1
+  AND canceled_at IS NULL
+  AND ends_at > CURRENT_TIMESTAMP
+ORDER BY ends_at DESC
+LIMIT 1;
+
+-- name: CancelUserSubscription :execrows
+-- CancelUserSubscription ends a subscription immediately; zero rows means it does not exist or was already canceled.
+UPDATE user_subscriptions
+SET canceled_at = CURRENT_TIMESTAMP,
+    updated_at  = CURRENT_TIMESTAMP
+WHERE subscription_id = $1
+  AND user_id = $2
+  AND canceled_at IS NULL;
+
+-- name: CreatePaymentOrder :one
+-- CreatePaymentOrder opens a pending order for a plan, priced at the plan's current price.
+INSERT INTO payment_orders (user_id, plan_id, provider, amount_cents, currency)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: SetPaymentOrderSession :exec
+-- SetPaymentOrderSession stores the provider's checkout session of an order.
+UPDATE payment_orders
+SET provider_session_id = $2,
+    updated_at          = CURRENT_TIMESTAMP
+WHERE order_id = $1;
+
+-- name: GetPaymentOrder :one
+-- GetPaymentOrder returns an order with the name of its plan.
+SELECT po.*, sp.name AS plan_name
+FROM payment_orders po
+         JOIN subscription_plans sp ON sp.plan_id = po.plan_id
+WHERE po.order_id = $1;
+
+-- name: GetPaymentOrderForUpdate :one
+-- GetPaymentOrderForUpdate locks an order while a webhook event is applied to it.
+SELECT *
+FROM payment_orders
+WHERE order_id = $1
+    FOR UPDATE;
+
+-- name: GetUserPaymentOrders :many
+-- GetUserPaymentOrders lists the orders of a user with the name of their plan, most recent first.
+SELECT po.*, sp.name AS plan_name
+FROM payment_orders po
+         JOIN subscription_plans sp ON sp.plan_id = po.plan_id
+WHERE po.user_id = $1
+ORDER BY po.created_at DESC;
+
+-- name: MarkPaymentOrderPaid :exec
+-- MarkPaymentOrderPaid records the payment of an order and the subscription it activated.
+UPDATE payment_orders
+SET status          = 'PAID',
+    failure_reason  = '',
+    subscription_id = $2,
+    paid_at         = CURRENT_TIMESTAMP,
+    updated_at      = CURRENT_TIMESTAMP
+WHERE order_id = $1;
+
+-- name: ClosePaymentOrder :execrows
+-- ClosePaymentOrder marks a pending order as failed or expired; zero rows means it was no longer pending.
+UPDATE payment_orders
+SET status         = $2,
+    failure_reason = $3,
+    updated_at     = CURRENT_TIMESTAMP
+WHERE order_id = $1
+  AND status = 'PENDING';
+
+-- name: RecordPaymentEvent :execrows
+-- RecordPaymentEvent stores a webhook event; zero rows means it was received before.
+INSERT INTO payment_events (provider, event_id, event_type, order_id, payload)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (provider, event_id) DO NOTHING;
+
+-- name: GetParagraphsByPartIds :many
+-- GetParagraphsByPartIds loads the paragraphs of several parts in one query, in display order.
+SELECT paragraph_id,
+       paragraph_content,
+       title,
+       part_id,
+       paragraph_order,
+       paragraph_type,
+       audio_url,
+       image_url,
+       created_at,
+       updated_at
+FROM paragraphs
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at IS NULL
+ORDER BY part_id, paragraph_order, paragraph_id;
+
+-- name: GetQuestionsByPartIds :many
+-- GetQuestionsByPartIds loads the questions of several parts in one query, both those under a paragraph
+-- and the standalone ones, in display order.
+SELECT question_id,
+       question_content,
+       question_type,
+       part_id,
+       paragraph_id,
+       question_order,
+       audio_url,
+       image_url,
+       toeic_question_section,
+       question_number_in_part,
+       answer_option,
+       correct_answer,
+       preparation_seconds,
+       response_seconds,
+       created_at,
+       updated_at
+FROM questions
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at IS NULL
+ORDER BY question_order, question_number_in_part, question_id;
+
+-- name: UpdateExamStatus :execrows
+-- UpdateExamStatus moves an exam to a new status only if it is still in the expected one; zero rows means
+-- the exam changed in the meantime.
+UPDATE exams
+SET status       = @to_status,
+    published_at = CASE WHEN @to_status::varchar = 'PUBLISHED' THEN CURRENT_TIMESTAMP ELSE published_at END,
+    updated_at   = CURRENT_TIMESTAMP
+WHERE exam_id = @exam_id
+  AND status = @from_status;
+
+-- name: UpdateExamPartStatus :execrows
+-- UpdateExamPartStatus moves a part to a new status only if it is still in the expected one.
+UPDATE exam_parts
+SET status       = @to_status,
+    published_at = CASE WHEN @to_status::varchar = 'PUBLISHED' THEN CURRENT_TIMESTAMP ELSE published_at END,
+    updated_at   = CURRENT_TIMESTAMP
+WHERE part_id = @part_id
+  AND status = @from_status;
+
+-- name: CreateContentStatusChange :exec
+-- CreateContentStatusChange records a status transition of an exam or practice part.
+INSERT INTO content_status_changes (content_type, content_id, from_status, to_status, changed_by, note)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: GetContentStatusChanges :many
+-- GetContentStatusChanges lists the transitions of an exam or practice part, most recent first.
+SELECT *
+FROM content_status_changes
+WHERE content_type = $1
+  AND content_id = $2
+ORDER BY changed_at DESC;
+
+-- name: CreateQuestionRevision :one
+-- CreateQuestionRevision snapshots the current state of a question as its next revision.
+INSERT INTO question_revisions (question_id, revision_number, question_content, question_type, part_id, paragraph_id,
+                                question_order, audio_url, image_url, toeic_question_section, question_number_in_part,
+                                answer_option, correct_answer, preparation_seconds, response_seconds, diff, restored_from,
+                                created_by)
+SELECT q.question_id,
+       COALESCE((SELECT MAX(r.revision_number) FROM question_revisions r WHERE r.question_id = q.question_id), 0) + 1,
+       q.question_content, q.question_type, q.part_id, q.paragraph_id,
+       q.question_order, q.audio_url, q.image_url, q.toeic_question_section, q.question_number_in_part,
+       q.answer_option, q.correct_answer, q.preparation_seconds, q.response_seconds, @diff, sqlc.narg('restored_from'), sqlc.narg('created_by')
+FROM questions q
+WHERE q.question_id = @question_id
+RETURNING *;
+
+-- name: GetLatestQuestionRevision :one
+SELECT *
+FROM question_revisions
+WHERE question_id = $1
+ORDER BY revision_number DESC
+LIMIT 1;
+
+-- name: GetQuestionRevision :one
+SELECT *
+FROM question_revisions
+WHERE revision_id = $1
+  AND question_id = $2;
+
+-- name: GetQuestionRevisions :many
+-- GetQuestionRevisions lists the revisions of a question, most recent first.
+SELECT *
+FROM question_revisions
+WHERE question_id = $1
+ORDER BY revision_number DESC;
+
+-- name: RestoreQuestion :exec
+-- RestoreQuestion puts every field of a question back to the given values, media included.
+UPDATE questions
+SET question_content        = $2,
+    question_type           = $3,
+    part_id                 = $4,
+    paragraph_id            = $5,
+    question_order          = $6,
+    audio_url               = $7,
+    image_url               = $8,
+    toeic_question_section  = $9,
+    question_number_in_part = $10,
+    answer_option           = $11,
+    correct_answer          = $12,
+    preparation_seconds     = $13,
+    response_seconds        = $14,
+    updated_at              = CURRENT_TIMESTAMP
+WHERE question_id = $1;
+
+-- name: CreateParagraphRevision :one
+-- CreateParagraphRevision snapshots the current state of a paragraph as its next revision.
+INSERT INTO paragraph_revisions (paragraph_id, revision_number, paragraph_content, title, part_id, paragraph_order,
+                                 paragraph_type, audio_url, image_url, diff, restored_from, created_by)
+SELECT p.paragraph_id,
+       COALESCE((SELECT MAX(r.revision_number) FROM paragraph_revisions r WHERE r.paragraph_id = p.paragraph_id), 0) + 1,
+       p.paragraph_content, p.title, p.part_id, p.paragraph_order,
+       p.paragraph_type, p.audio_url, p.image_url, @diff, sqlc.narg('restored_from'), sqlc.narg('created_by')
+FROM paragraphs p
+WHERE p.paragraph_id = @paragraph_id
+RETURNING *;
+
+-- name: GetLatestParagraphRevision :one
+SELECT *
+FROM paragraph_revisions
+WHERE paragraph_id = $1
+ORDER BY revision_number DESC
+LIMIT 1;
+
+-- name: GetParagraphRevision :one
+SELECT *
+FROM paragraph_revisions
+WHERE revision_id = $1
+  AND paragraph_id = $2;
+
+-- name: GetParagraphRevisions :many
+-- GetParagraphRevisions lists the revisions of a paragraph, most recent first.
+SELECT *
+FROM paragraph_revisions
+WHERE paragraph_id = $1
+ORDER BY revision_number DESC;
+
+-- name: RestoreParagraph :exec
+-- RestoreParagraph puts every field of a paragraph back to the given values, media included.
+UPDATE paragraphs
+SET paragraph_content = $2,
+    title             = $3,
+    part_id           = $4,
+    paragraph_order   = $5,
+    paragraph_type    = $6,
+    audio_url         = $7,
+    image_url         = $8,
+    updated_at        = CURRENT_TIMESTAMP
+WHERE paragraph_id = $1;
+
+-- name: TrashExam :execrows
+-- TrashExam moves an exam to the trash; zero rows means it is missing or already there.
+UPDATE exams
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE exam_id = @exam_id
+  AND deleted_at IS NULL;
+
+-- name: TrashPartsOfExam :many
+-- TrashPartsOfExam moves the parts of an exam to the trash along with it and returns their ids.
+UPDATE exam_parts
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE exam_id = @exam_id
+  AND deleted_at IS NULL
+RETURNING part_id;
+
+-- name: TrashExamPart :execrows
+UPDATE exam_parts
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE part_id = @part_id
+  AND deleted_at IS NULL;
+
+-- name: TrashParagraphsOfParts :exec
+UPDATE paragraphs
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at IS NULL;
+
+-- name: TrashQuestionsOfParts :exec
+UPDATE questions
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at IS NULL;
+
+-- name: TrashParagraph :execrows
+UPDATE paragraphs
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE paragraph_id = @paragraph_id
+  AND deleted_at IS NULL;
+
+-- name: TrashQuestionsOfParagraph :exec
+UPDATE questions
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE paragraph_id = @paragraph_id
+  AND deleted_at IS NULL;
+
+-- name: TrashQuestion :execrows
+UPDATE questions
+SET deleted_at = @deleted_at,
+    deleted_by = @deleted_by
+WHERE question_id = @question_id
+  AND deleted_at IS NULL;
+
+-- name: GetTrashItems :many
+-- GetTrashItems lists the items deleted on their own, most recent first.
+SELECT *
+FROM trash_items
+WHERE sqlc.narg('content_type')::varchar IS NULL OR content_type = sqlc.narg('content_type')
+ORDER BY deleted_at DESC, content_id
+LIMIT $1 OFFSET $2;
+
+-- name: GetTrashItemsCount :one
+SELECT COUNT(*)
+FROM trash_items
+WHERE sqlc.narg('content_type')::varchar IS NULL OR content_type = sqlc.narg('content_type');
+
+-- name: GetTrashItem :one
+SELECT *
+FROM trash_items
+WHERE content_type = $1
+  AND content_id = $2;
+
+-- name: RestoreTrashedExam :execrows
+-- RestoreTrashedExam and the queries below take an item out of the trash along with the children trashed
+-- at the same time; children deleted before their parent stay in the trash.
+UPDATE exams
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE exam_id = @exam_id
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedPartsOfExam :many
+UPDATE exam_parts
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE exam_id = @exam_id
+  AND deleted_at = @deleted_at
+RETURNING part_id;
+
+-- name: RestoreTrashedExamPart :execrows
+UPDATE exam_parts
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = @part_id
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedParagraphsOfParts :exec
+UPDATE paragraphs
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedQuestionsOfParts :exec
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE part_id = ANY (@part_ids::uuid[])
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedParagraph :execrows
+UPDATE paragraphs
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE paragraph_id = @paragraph_id
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedQuestionsOfParagraph :exec
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE paragraph_id = @paragraph_id
+  AND deleted_at = @deleted_at;
+
+-- name: RestoreTrashedQuestion :execrows
+UPDATE questions
+SET deleted_at = NULL,
+    deleted_by = NULL
+WHERE question_id = @question_id
+  AND deleted_at = @deleted_at;
+
+-- name: PurgeTrashedQuestions :execrows
+-- PurgeTrashedQuestions and the queries below delete for good the content trashed before deleted_before,
+-- children first. Content attempts refer to stays in the trash, and so do the parents of whatever stayed.
+DELETE
+FROM questions q
+WHERE q.deleted_at < @deleted_before
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.question_id = q.question_id);
+
+-- name: PurgeTrashedParagraphs :execrows
+DELETE
+FROM paragraphs g
+WHERE g.deleted_at < @deleted_before
+  AND NOT EXISTS (SELECT 1 FROM questions q WHERE q.paragraph_id = g.paragraph_id)
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.paragraph_id = g.paragraph_id);
+
+-- name: PurgeTrashedExamParts :execrows
+DELETE
+FROM exam_parts p
+WHERE p.deleted_at < @deleted_before
+  AND NOT EXISTS (SELECT 1 FROM paragraphs g WHERE g.part_id = p.part_id)
+  AND NOT EXISTS (SELECT 1 FROM questions q WHERE q.part_id = p.part_id)
+  AND NOT EXISTS (SELECT 1 FROM attempt_questions aq WHERE aq.part_id = p.part_id);
+
+-- name: PurgeTrashedExams :execrows
+DELETE
+FROM exams e
+WHERE e.deleted_at < @deleted_before
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.exam_id = e.exam_id)
+  AND NOT EXISTS (SELECT 1 FROM exam_attempts a WHERE a.exam_id = e.exam_id);
+
+-- name: GetContentMediaReferences :many
+-- GetContentMediaReferences lists the audio and image references held by questions and paragraphs, trashed
+-- ones and revisions included, which is every uploaded file library content may still use.
+SELECT DISTINCT r.reference::text AS reference
+FROM (SELECT audio_url AS reference FROM questions
+      UNION ALL SELECT image_url FROM questions
+      UNION ALL SELECT audio_url FROM paragraphs
+      UNION ALL SELECT image_url FROM paragraphs
+      UNION ALL SELECT audio_url FROM question_revisions
+      UNION ALL SELECT image_url FROM question_revisions
+      UNION ALL SELECT audio_url FROM paragraph_revisions
+      UNION ALL SELECT image_url FROM paragraph_revisions) r
+WHERE r.reference IS NOT NULL
+  AND r.reference <> '';
+
+-- name: GetContentIds :many
+-- GetContentIds lists the ids of all questions and paragraphs, which name their transcript files.
+SELECT question_id AS content_id
+FROM questions
+UNION ALL
+SELECT paragraph_id
+FROM paragraphs;
+
+---
+-- Attempt Responses Queries
+---
+
+-- name: StartAttemptResponse :execrows
+-- StartAttemptResponse opens the windows of a spoken or written response while the attempt is in progress
+-- and before its deadline; zero rows means they were already open or the attempt is closed.
+INSERT INTO attempt_responses (attempt_id, question_id, response_type, preparation_seconds, response_seconds)
+SELECT ea.attempt_id, @question_id::uuid, @response_type::varchar, @preparation_seconds::int, @response_seconds::int
+FROM exam_attempts ea
+WHERE ea.attempt_id = @attempt_id
+  AND ea.status = 'IN_PROGRESS'
+  AND (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP)
+ON CONFLICT (attempt_id, question_id) DO NOTHING;
+
+-- name: GetAttemptResponse :one
+SELECT *
+FROM attempt_responses
+WHERE attempt_id = $1
+  AND question_id = $2;
+
+-- name: ListAttemptResponses :many
+SELECT *
+FROM attempt_responses
+WHERE attempt_id = $1;
+
+-- name: SaveAttemptResponse :execrows
+-- SaveAttemptResponse stores a response from the end of the preparation window until the response window
+-- closes, grace_seconds included, while the attempt is in progress and before its deadline.
+UPDATE attempt_responses r
+SET audio_object  = sqlc.narg('audio_object'),
+    audio_url     = sqlc.narg('audio_url'),
+    text_response = sqlc.narg('text_response'),
+    word_count    = sqlc.narg('word_count'),
+    submitted_at  = CURRENT_TIMESTAMP
+FROM exam_attempts ea
+WHERE r.attempt_id = ea.attempt_id
+  AND r.attempt_id = @attempt_id
+  AND r.question_id = @question_id
+  AND ea.status = 'IN_PROGRESS'
+  AND (ea.deadline_at IS NULL OR ea.deadline_at > CURRENT_TIMESTAMP)
+  AND CURRENT_TIMESTAMP >= r.started_at + make_interval(secs => r.preparation_seconds)
+  AND CURRENT_TIMESTAMP <= r.started_at + make_interval(secs => r.preparation_seconds + r.response_seconds + @grace_seconds::int);
+
+---
+-- Response Ratings Queries
+---
+
+-- name: ReleaseStaleRatingAssignments :execrows
+-- ReleaseStaleRatingAssignments gives back to the queue the responses assigned but not rated in time.
+DELETE FROM response_ratings
+WHERE score IS NULL
+  AND assigned_at < @assigned_before;
+
+-- name: GetOpenRatingAssignment :one
+-- GetOpenRatingAssignment retrieves the response a rater was assigned and has not rated yet.
+SELECT *
+FROM response_ratings
+WHERE rater_id = $1
+  AND score IS NULL
+ORDER BY assigned_at
+LIMIT 1;
+
+-- name: LockNextResponseToRate :one
+-- LockNextResponseToRate picks the oldest submitted response that still needs a rating the rater may give:
+-- not on their own attempt, not a response they already rated, adjudications first. The row stays locked
+-- until the assignment is committed, so concurrent raters pick different responses.
+SELECT r.attempt_id, r.question_id, r.rating_status
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND ea.user_id <> @rater_id
+  AND r.submitted_at IS NOT NULL
+  AND r.rating_status <> 'RATED'
+  AND NOT EXISTS (
+      SELECT 1
+      FROM response_ratings rr
+      WHERE rr.attempt_id = r.attempt_id
+        AND rr.question_id = r.question_id
+        AND rr.rater_id = @rater_id
+  )
+  AND (SELECT COUNT(*) FROM response_ratings rr WHERE rr.attempt_id = r.attempt_id AND rr.question_id = r.question_id)
+      < CASE WHEN r.rating_status = 'ADJUDICATION' THEN 3 ELSE 2 END
+ORDER BY r.rating_status = 'ADJUDICATION' DESC, ea.submitted_at, r.submitted_at
+LIMIT 1
+FOR UPDATE OF r SKIP LOCKED;
+
+-- name: CreateRatingAssignment :one
+-- CreateRatingAssignment assigns a response to a rater for the given round.
+INSERT INTO response_ratings (attempt_id, question_id, rater_id, round)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetRatingTask :one
+-- GetRatingTask retrieves what a rater sees of an assigned response: the pinned question and the
+-- response, without the learner or the other ratings.
+SELECT rr.rating_id,
+       rr.round,
+       rr.assigned_at,
+       r.attempt_id,
+       ea.exam_id,
+       r.question_id,
+       r.response_type,
+       r.audio_url,
+       r.text_response,
+       r.word_count,
+       r.submitted_at,
+       q.question_content,
+       q.question_type,
+       q.toeic_question_section,
+       q.audio_url AS question_audio_url,
+       q.image_url AS question_image_url,
+       r.auto_score,
+       r.auto_max_score,
+       r.auto_feedback,
+       r.auto_scorer,
+       r.auto_scored_at
+FROM response_ratings rr
+JOIN attempt_responses r ON r.attempt_id = rr.attempt_id AND r.question_id = rr.question_id
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+JOIN attempt_questions aq ON aq.attempt_id = r.attempt_id AND aq.question_id = r.question_id
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE rr.rating_id = $1;
+
+-- name: SubmitRating :one
+-- SubmitRating records the score of an open assignment of the rater.
+UPDATE response_ratings
+SET score     = @score,
+    max_score = @max_score,
+    comment   = sqlc.narg('comment'),
+    rated_at  = CURRENT_TIMESTAMP
+WHERE rating_id = @rating_id
+  AND rater_id = @rater_id
+  AND score IS NULL
+RETURNING *;
+
+-- name: ListResponseRatings :many
+-- ListResponseRatings retrieves the ratings of a response in round order.
+SELECT *
+FROM response_ratings
+WHERE attempt_id = $1
+  AND question_id = $2
+ORDER BY round;
+
+-- name: ResolveResponseRating :exec
+-- ResolveResponseRating moves a response to ADJUDICATION, or to RATED with its final score.
+UPDATE attempt_responses
+SET rating_status = @rating_status,
+    final_score   = sqlc.narg('final_score'),
+    max_score     = sqlc.narg('max_score'),
+    rated_at      = CASE WHEN @rating_status::varchar = 'RATED' THEN CURRENT_TIMESTAMP END
+WHERE attempt_id = @attempt_id
+  AND question_id = @question_id;
+
+-- name: GetRatingQueueStats :one
+-- GetRatingQueueStats counts the submitted responses waiting for raters.
+SELECT COUNT(*) FILTER (WHERE r.rating_status = 'PENDING')::int      AS awaiting_rating,
+       COUNT(*) FILTER (WHERE r.rating_status = 'ADJUDICATION')::int AS awaiting_adjudication,
+       (SELECT COUNT(*) FROM response_ratings rr WHERE rr.score IS NULL)::int AS assigned
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND r.submitted_at IS NOT NULL
+  AND r.rating_status <> 'RATED';
+
+-- name: ListAttemptRatingStates :many
+-- ListAttemptRatingStates retrieves the Speaking and Writing questions of an attempt with the rating state
+-- of their responses; a question without a submitted response has no response_submitted_at.
+SELECT aq.question_id,
+       q.question_type,
+       q.toeic_question_section,
+       r.submitted_at AS response_submitted_at,
+       r.rating_status,
+       r.final_score,
+       r.max_score
+FROM attempt_questions aq
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+LEFT JOIN attempt_responses r ON r.attempt_id = aq.attempt_id AND r.question_id = aq.question_id
+WHERE aq.attempt_id = $1
+  AND q.toeic_question_section IN ('Speaking', 'Writing')
+ORDER BY aq.sequence_number;
+
+-- name: UpdateAttemptResultRatings :one
+-- UpdateAttemptResultRatings stores the rolled up Speaking and Writing scores. total_scaled stays the
+-- Listening and Reading total, as on the TOEIC L&R score report.
+UPDATE attempt_results
+SET speaking_points     = @speaking_points,
+    speaking_max_points = @speaking_max_points,
+    speaking_pending    = @speaking_pending,
+    speaking_scaled     = sqlc.narg('speaking_scaled'),
+    writing_points      = @writing_points,
+    writing_max_points  = @writing_max_points,
+    writing_pending     = @writing_pending,
+    writing_scaled      = sqlc.narg('writing_scaled')
+WHERE attempt_id = @attempt_id
+RETURNING attempt_id, conversion_table_id, listening_correct, listening_total, listening_scaled, reading_correct, reading_total, reading_scaled, total_scaled, scored_at,
+          speaking_points, speaking_max_points, speaking_pending, speaking_scaled, writing_points, writing_max_points, writing_pending, writing_scaled;
+
+---
+-- Response Auto Scores Queries
+---
+
+-- name: ListResponsesToAutoScore :many
+-- ListResponsesToAutoScore retrieves the submitted responses of finalized attempts to the given question
+-- types that the automated scorer has not handled yet, oldest first.
+SELECT r.attempt_id,
+       r.question_id,
+       r.response_type,
+       r.audio_url,
+       r.text_response,
+       r.word_count,
+       q.question_content,
+       q.question_type,
+       q.toeic_question_section
+FROM attempt_responses r
+JOIN exam_attempts ea ON ea.attempt_id = r.attempt_id
+JOIN attempt_questions aq ON aq.attempt_id = r.attempt_id AND aq.question_id = r.question_id
+JOIN question_revisions q ON q.revision_id = aq.question_revision_id
+WHERE ea.status IN ('SUBMITTED', 'EXPIRED')
+  AND r.submitted_at IS NOT NULL
+  AND r.auto_scored_at IS NULL
+  AND r.auto_score_failures < @max_failures
+  AND q.question_type = ANY (@question_types::varchar[])
+ORDER BY r.submitted_at
+LIMIT @batch_size;
+
+-- name: SaveResponseAutoScore :execrows
+-- SaveResponseAutoScore records the pre-score of a response once; auto_score is NULL when the scorer
+-- cannot handle the response.
+UPDATE attempt_responses
+SET auto_score     = sqlc.narg('auto_score'),
+    auto_max_score = sqlc.narg('auto_max_score'),
+    auto_feedback  = @auto_feedback,
+    auto_scorer    = @auto_scorer,
+    auto_scored_at = CURRENT_TIMESTAMP
+WHERE attempt_id = @attempt_id
+  AND question_id = @question_id
+  AND auto_scored_at IS NULL;
+
+-- name: RecordResponseAutoScoreFailure :exec
+-- RecordResponseAutoScoreFailure counts a failed call to the scorer for a response.
+UPDATE attempt_responses
+SET auto_score_failures = auto_score_failures + 1
+WHERE attempt_id = $1
+  AND question_id = $2;
+
+---
+-- Question Explanations Queries
+---
+
+-- name: ListQuestionExplanations :many
+-- ListQuestionExplanations retrieves every translation of the explanations of a question.
+SELECT *
+FROM question_explanations
+WHERE question_id = $1
+ORDER BY lang;
+
+-- name: UpsertQuestionExplanation :one
+-- UpsertQuestionExplanation stores the explanations of a question in one language, replacing the previous ones.
+INSERT INTO question_explanations (question_id, lang, explanation, option_explanations, updated_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (question_id, lang) DO UPDATE
+SET explanation         = EXCLUDED.explanation,
+    option_explanations = EXCLUDED.option_explanations,
+    updated_by          = EXCLUDED.updated_by
+RETURNING *;
+
+-- name: DeleteQuestionExplanation :execrows
+-- DeleteQuestionExplanation removes the explanations of a question in one language.
+DELETE FROM question_explanations
+WHERE question_id = $1
+  AND lang = $2;
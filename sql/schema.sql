@@ -268,4 +268,615 @@ CREATE TABLE questions (
                                                  'Instruction'          -- A question that serves as an instruction for a group of sub-questions (though you removed ParentQuestionID, this type can still be useful for visual grouping)
                                    )
                                )
-);
\ No newline at end of file
+);
+---------------====================003
+-- ========================
+-- ExamAttempts
+-- ========================
+CREATE TABLE exam_attempts (
+                               attempt_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                               exam_id UUID NOT NULL,
+                               user_id UUID NOT NULL,
+                               status VARCHAR(20) NOT NULL DEFAULT 'IN_PROGRESS',
+
+                               started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               deadline_at TIMESTAMPTZ, -- NULL when the exam has no duration_minutes
+                               submitted_at TIMESTAMPTZ,
+
+                               created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                               updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                               CONSTRAINT FK_attempt_exam FOREIGN KEY (exam_id) REFERENCES exams (exam_id),
+                               CONSTRAINT FK_attempt_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                               CONSTRAINT chk_attempt_status CHECK (status IN (
+                                                                               'IN_PROGRESS',
+                                                                               'SUBMITTED',
+                                                                               'EXPIRED'
+                                   ))
+);
+CREATE INDEX idx_exam_attempts_user_exam ON exam_attempts (user_id, exam_id);
+
+-- ========================
+-- AttemptQuestions
+-- ========================
+-- Snapshot of the questions served in an attempt, together with the learner's answer.
+CREATE TABLE attempt_questions (
+                                   attempt_id UUID NOT NULL,
+                                   question_id UUID NOT NULL,
+                                   part_id UUID NOT NULL,
+                                   paragraph_id UUID,
+                                   sequence_number INT NOT NULL, -- position of the question inside the attempt
+
+                                   answer TEXT,
+                                   answered_at TIMESTAMPTZ,
+
+                                   PRIMARY KEY (attempt_id, question_id),
+                                   FOREIGN KEY (attempt_id) REFERENCES exam_attempts (attempt_id) ON DELETE CASCADE,
+                                   FOREIGN KEY (question_id) REFERENCES questions (question_id),
+                                   FOREIGN KEY (part_id) REFERENCES exam_parts (part_id),
+                                   FOREIGN KEY (paragraph_id) REFERENCES paragraphs (paragraph_id) ON DELETE SET NULL
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_exam_attempts_updated_at
+    BEFORE UPDATE ON exam_attempts
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+---------------====================004
+-- ========================
+-- ScoreConversionTables
+-- ========================
+CREATE TABLE score_conversion_tables (
+                                         table_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                         name VARCHAR(100) NOT NULL UNIQUE,
+                                         description TEXT,
+                                         is_default BOOLEAN NOT NULL DEFAULT FALSE,
+
+                                         created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                         updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+-- Only one table can be the default one
+CREATE UNIQUE INDEX uq_score_conversion_tables_default ON score_conversion_tables (is_default) WHERE is_default;
+
+-- ========================
+-- ScoreConversionEntries
+-- ========================
+CREATE TABLE score_conversion_entries (
+                                          table_id UUID NOT NULL,
+                                          section VARCHAR(20) NOT NULL,
+                                          raw_score INT NOT NULL,
+                                          scaled_score INT NOT NULL,
+
+                                          PRIMARY KEY (table_id, section, raw_score),
+                                          FOREIGN KEY (table_id) REFERENCES score_conversion_tables (table_id) ON DELETE CASCADE,
+                                          CONSTRAINT chk_conversion_section CHECK (section IN ('Listening', 'Reading')),
+                                          CONSTRAINT chk_conversion_raw_score CHECK (raw_score >= 0),
+                                          CONSTRAINT chk_conversion_scaled_score CHECK (scaled_score BETWEEN 5 AND 495)
+);
+
+-- An exam may pin its own table, otherwise the default table is used
+ALTER TABLE exams ADD COLUMN score_conversion_table_id UUID REFERENCES score_conversion_tables (table_id) ON DELETE SET NULL;
+
+-- ========================
+-- AttemptResults
+-- ========================
+CREATE TABLE attempt_results (
+                                 attempt_id UUID PRIMARY KEY,
+                                 conversion_table_id UUID,
+
+                                 listening_correct INT NOT NULL DEFAULT 0,
+                                 listening_total INT NOT NULL DEFAULT 0,
+                                 listening_scaled INT,
+                                 reading_correct INT NOT NULL DEFAULT 0,
+                                 reading_total INT NOT NULL DEFAULT 0,
+                                 reading_scaled INT,
+                                 total_scaled INT,
+
+                                 scored_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                 FOREIGN KEY (attempt_id) REFERENCES exam_attempts (attempt_id) ON DELETE CASCADE,
+                                 FOREIGN KEY (conversion_table_id) REFERENCES score_conversion_tables (table_id) ON DELETE SET NULL
+);
+
+-- NULL until graded, stays NULL for question types that are not scored automatically
+ALTER TABLE attempt_questions ADD COLUMN is_correct BOOLEAN;
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_score_conversion_tables_updated_at
+    BEFORE UPDATE ON score_conversion_tables
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+
+---------------====================007
+-- ========================
+-- UserSessions
+-- ========================
+-- One row per login. Every refresh token issued for the login belongs to the same session (token family).
+CREATE TABLE user_sessions (
+                               session_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                               user_id UUID NOT NULL,
+
+                               expires_at TIMESTAMPTZ NOT NULL,
+                               last_used_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               revoked_at TIMESTAMPTZ,
+                               revoke_reason VARCHAR(50) NOT NULL DEFAULT '',
+
+                               created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                               CONSTRAINT FK_session_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_user_sessions_user ON user_sessions (user_id);
+
+-- ========================
+-- RefreshTokens
+-- ========================
+-- token_id is the jti of the refresh token. A token may be exchanged once; presenting a used token revokes its session.
+CREATE TABLE refresh_tokens (
+                                token_id UUID PRIMARY KEY,
+                                session_id UUID NOT NULL,
+
+                                expires_at TIMESTAMPTZ NOT NULL,
+                                used_at TIMESTAMPTZ,
+
+                                created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                FOREIGN KEY (session_id) REFERENCES user_sessions (session_id) ON DELETE CASCADE
+);
+CREATE INDEX idx_refresh_tokens_session ON refresh_tokens (session_id);
+
+---------------====================008
+-- ========================
+-- UserSessions
+-- ========================
+-- Device details captured at login, shown in the active sessions list
+ALTER TABLE user_sessions
+    ADD COLUMN user_agent TEXT NOT NULL DEFAULT '',
+    ADD COLUMN ip_address VARCHAR(64) NOT NULL DEFAULT '';
+
+---------------====================009
+-- ========================
+-- USERS
+-- ========================
+-- NULL until the owner confirms the address through the verification link
+ALTER TABLE users
+    ADD COLUMN email_verified_at TIMESTAMPTZ;
+
+---------------====================010
+-- ========================
+-- MailDeadLetters
+-- ========================
+-- Mails the queue gave up on after exhausting its retries. job_id is the queue job id, so a
+-- retried mail that fails again updates its existing row.
+CREATE TABLE mail_dead_letters (
+                                   job_id UUID PRIMARY KEY,
+                                   recipients TEXT NOT NULL,
+                                   subject TEXT NOT NULL DEFAULT '',
+                                   payload JSONB NOT NULL,
+                                   attempts INT NOT NULL,
+                                   last_error TEXT NOT NULL DEFAULT '',
+
+                                   failed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                   requeued_at TIMESTAMPTZ
+);
+CREATE INDEX idx_mail_dead_letters_failed_at ON mail_dead_letters (failed_at DESC);
+
+---------------====================011
+-- ========================
+-- SubscriptionPlans
+-- ========================
+CREATE TABLE subscription_plans (
+                                    plan_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    code VARCHAR(50) NOT NULL UNIQUE,
+                                    name VARCHAR(100) NOT NULL,
+                                    description TEXT NOT NULL DEFAULT '',
+                                    duration_days INT NOT NULL,
+                                    grace_period_days INT NOT NULL DEFAULT 0,
+                                    price_cents BIGINT NOT NULL DEFAULT 0,
+                                    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+                                    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+
+                                    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                    CONSTRAINT chk_plan_duration CHECK (duration_days > 0),
+                                    CONSTRAINT chk_plan_grace_period CHECK (grace_period_days >= 0),
+                                    CONSTRAINT chk_plan_price CHECK (price_cents >= 0)
+);
+
+-- ========================
+-- UserSubscriptions
+-- ========================
+-- A period during which the user may read SUBSCRIPTION content. Access lasts until grace_ends_at,
+-- which is fixed from the plan's grace period when the subscription is created.
+CREATE TABLE user_subscriptions (
+                                    subscription_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    user_id UUID NOT NULL,
+                                    plan_id UUID NOT NULL,
+                                    source VARCHAR(20) NOT NULL,
+                                    starts_at TIMESTAMPTZ NOT NULL,
+                                    ends_at TIMESTAMPTZ NOT NULL,
+                                    grace_ends_at TIMESTAMPTZ NOT NULL,
+                                    granted_by UUID,
+                                    note TEXT NOT NULL DEFAULT '',
+                                    canceled_at TIMESTAMPTZ,
+
+                                    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                    CONSTRAINT FK_subscription_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                                    CONSTRAINT FK_subscription_plan FOREIGN KEY (plan_id) REFERENCES subscription_plans (plan_id),
+                                    CONSTRAINT FK_subscription_granted_by FOREIGN KEY (granted_by) REFERENCES users (id) ON DELETE SET NULL,
+                                    CONSTRAINT chk_subscription_source CHECK (source IN ('ADMIN_GRANT', 'PURCHASE')),
+                                    CONSTRAINT chk_subscription_period CHECK (ends_at > starts_at AND grace_ends_at >= ends_at)
+);
+CREATE INDEX idx_user_subscriptions_user ON user_subscriptions (user_id, grace_ends_at DESC);
+
+---------------====================012
+-- ========================
+-- PaymentOrders
+-- ========================
+-- A purchase of a subscription plan through a payment provider's checkout page.
+CREATE TABLE payment_orders (
+                                order_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                user_id UUID NOT NULL,
+                                plan_id UUID NOT NULL,
+                                provider VARCHAR(30) NOT NULL,
+                                provider_session_id VARCHAR(255),
+                                amount_cents BIGINT NOT NULL,
+                                currency VARCHAR(3) NOT NULL,
+                                status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+                                failure_reason TEXT NOT NULL DEFAULT '',
+                                subscription_id UUID,
+                                paid_at TIMESTAMPTZ,
+
+                                created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                CONSTRAINT FK_payment_order_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+                                CONSTRAINT FK_payment_order_plan FOREIGN KEY (plan_id) REFERENCES subscription_plans (plan_id),
+                                CONSTRAINT FK_payment_order_subscription FOREIGN KEY (subscription_id) REFERENCES user_subscriptions (subscription_id) ON DELETE SET NULL,
+                                CONSTRAINT chk_payment_order_status CHECK (status IN ('PENDING', 'PAID', 'FAILED', 'EXPIRED'))
+);
+CREATE INDEX idx_payment_orders_user ON payment_orders (user_id, created_at DESC);
+CREATE UNIQUE INDEX uq_payment_orders_session ON payment_orders (provider, provider_session_id) WHERE provider_session_id IS NOT NULL;
+
+-- ========================
+-- PaymentEvents
+-- ========================
+-- Every webhook event received, keyed by the provider's event id so that redelivered events are applied once.
+CREATE TABLE payment_events (
+                                provider VARCHAR(30) NOT NULL,
+                                event_id VARCHAR(255) NOT NULL,
+                                event_type VARCHAR(100) NOT NULL,
+                                order_id UUID,
+                                payload JSONB NOT NULL,
+
+                                received_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                PRIMARY KEY (provider, event_id),
+                                FOREIGN KEY (order_id) REFERENCES payment_orders (order_id) ON DELETE SET NULL
+);
+CREATE INDEX idx_payment_events_order ON payment_events (order_id);
+
+---------------====================013
+-- ========================
+-- EXAMS / EXAM_PARTS
+-- ========================
+-- Publishing workflow: DRAFT -> IN_REVIEW -> PUBLISHED -> ARCHIVED. Learners only see PUBLISHED content;
+-- the status of an exam part only matters for practice parts, exam parts follow their exam.
+ALTER TABLE exams
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_exam_status CHECK (status IN ('DRAFT', 'IN_REVIEW', 'PUBLISHED', 'ARCHIVED'));
+
+ALTER TABLE exam_parts
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'DRAFT',
+    ADD COLUMN published_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_part_status CHECK (status IN ('DRAFT', 'IN_REVIEW', 'PUBLISHED', 'ARCHIVED'));
+
+-- ========================
+-- CONTENT_STATUS_CHANGES
+-- ========================
+-- Audit trail of every transition, with who made it and why.
+CREATE TABLE content_status_changes (
+                                        change_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                        content_type VARCHAR(20) NOT NULL,
+                                        content_id UUID NOT NULL,
+                                        from_status VARCHAR(20) NOT NULL,
+                                        to_status VARCHAR(20) NOT NULL,
+                                        changed_by UUID,
+                                        note TEXT NOT NULL DEFAULT '',
+                                        changed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                        FOREIGN KEY (changed_by) REFERENCES users (id) ON DELETE SET NULL,
+                                        CONSTRAINT chk_status_change_content_type CHECK (content_type IN ('EXAM', 'PART'))
+);
+CREATE INDEX idx_content_status_changes_content ON content_status_changes (content_type, content_id, changed_at DESC);
+
+---------------====================014
+-- ========================
+-- QUESTION_REVISIONS
+-- ========================
+-- Immutable snapshots of a question, one per change. diff maps every changed field to its old and new value.
+CREATE TABLE question_revisions (
+                                    revision_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    question_id UUID NOT NULL,
+                                    revision_number INT NOT NULL,
+
+                                    question_content TEXT NOT NULL,
+                                    question_type VARCHAR(50) NOT NULL,
+                                    part_id UUID NOT NULL,
+                                    paragraph_id UUID,
+                                    question_order INT NOT NULL,
+                                    audio_url VARCHAR(255),
+                                    image_url VARCHAR(255),
+                                    toeic_question_section VARCHAR(20) NOT NULL,
+                                    question_number_in_part INT,
+                                    answer_option JSON,
+                                    correct_answer TEXT,
+
+                                    diff JSONB NOT NULL DEFAULT '{}',
+                                    restored_from INT, -- revision_number this revision restored, if any
+                                    created_by UUID,
+                                    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                    UNIQUE (question_id, revision_number),
+                                    FOREIGN KEY (question_id) REFERENCES questions (question_id) ON DELETE CASCADE,
+                                    FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- ========================
+-- PARAGRAPH_REVISIONS
+-- ========================
+CREATE TABLE paragraph_revisions (
+                                     revision_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                     paragraph_id UUID NOT NULL,
+                                     revision_number INT NOT NULL,
+
+                                     paragraph_content TEXT NOT NULL,
+                                     title VARCHAR(255),
+                                     part_id UUID NOT NULL,
+                                     paragraph_order INT NOT NULL,
+                                     paragraph_type VARCHAR(50),
+                                     audio_url VARCHAR(255),
+                                     image_url VARCHAR(255),
+
+                                     diff JSONB NOT NULL DEFAULT '{}',
+                                     restored_from INT,
+                                     created_by UUID,
+                                     created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                     UNIQUE (paragraph_id, revision_number),
+                                     FOREIGN KEY (paragraph_id) REFERENCES paragraphs (paragraph_id) ON DELETE CASCADE,
+                                     FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- ========================
+-- ATTEMPT_QUESTIONS
+-- ========================
+-- An attempt is served, reviewed and scored against the revisions current when it started.
+ALTER TABLE attempt_questions
+    ADD COLUMN question_revision_id UUID REFERENCES question_revisions (revision_id),
+    ADD COLUMN paragraph_revision_id UUID REFERENCES paragraph_revisions (revision_id) ON DELETE SET NULL;
+
+ALTER TABLE attempt_questions ALTER COLUMN question_revision_id SET NOT NULL;
+
+---------------====================015
+-- ========================
+-- EXAMS / EXAM_PARTS / PARAGRAPHS / QUESTIONS
+-- ========================
+-- Deleted content goes to the trash first: it is hidden everywhere but can be restored until the purge
+-- removes it for good. Deleting an item trashes its children with the same deleted_at, which is how a
+-- restore finds the children to bring back.
+ALTER TABLE exams
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE exam_parts
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE paragraphs
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE questions
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD COLUMN deleted_by UUID REFERENCES users (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_exams_deleted_at ON exams (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_exam_parts_deleted_at ON exam_parts (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_paragraphs_deleted_at ON paragraphs (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_questions_deleted_at ON questions (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- ========================
+-- TRASH_ITEMS
+-- ========================
+-- The items deleted on their own; children trashed along with their parent only show through it.
+CREATE VIEW trash_items AS
+SELECT 'EXAM'::varchar AS content_type, e.exam_id AS content_id, e.exam_title::text AS title,
+       NULL::varchar AS parent_type, NULL::uuid AS parent_id, e.deleted_at, e.deleted_by
+FROM exams e
+WHERE e.deleted_at IS NOT NULL
+UNION ALL
+SELECT 'PART', p.part_id, p.part_title, CASE WHEN p.exam_id IS NULL THEN NULL ELSE 'EXAM' END, p.exam_id,
+       p.deleted_at, p.deleted_by
+FROM exam_parts p
+WHERE p.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exams e WHERE e.exam_id = p.exam_id AND e.deleted_at = p.deleted_at)
+UNION ALL
+SELECT 'PARAGRAPH', g.paragraph_id, COALESCE(g.title, ''), 'PART', g.part_id, g.deleted_at, g.deleted_by
+FROM paragraphs g
+WHERE g.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.part_id = g.part_id AND p.deleted_at = g.deleted_at)
+UNION ALL
+SELECT 'QUESTION', q.question_id, q.question_content, CASE WHEN q.paragraph_id IS NULL THEN 'PART' ELSE 'PARAGRAPH' END,
+       COALESCE(q.paragraph_id, q.part_id), q.deleted_at, q.deleted_by
+FROM questions q
+WHERE q.deleted_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM exam_parts p WHERE p.part_id = q.part_id AND p.deleted_at = q.deleted_at)
+  AND NOT EXISTS (SELECT 1 FROM paragraphs g WHERE g.paragraph_id = q.paragraph_id AND g.deleted_at = q.deleted_at);
+
+---------------====================016
+-- ========================
+-- QUESTIONS / QUESTION_REVISIONS
+-- ========================
+-- Time windows of a spoken or written response: the learner prepares for preparation_seconds, then
+-- answers within response_seconds. NULL falls back to the TOEIC timing of the question type.
+ALTER TABLE questions
+    ADD COLUMN preparation_seconds INT CHECK (preparation_seconds >= 0),
+    ADD COLUMN response_seconds INT CHECK (response_seconds > 0);
+
+ALTER TABLE question_revisions
+    ADD COLUMN preparation_seconds INT,
+    ADD COLUMN response_seconds INT;
+
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- The spoken or written response to a Speaking or Writing question of an attempt. The row is created
+-- when the learner opens the question, which starts the clock; the windows are copied so that later edits
+-- of the question do not move it.
+CREATE TABLE attempt_responses (
+                                   attempt_id UUID NOT NULL,
+                                   question_id UUID NOT NULL,
+                                   response_type VARCHAR(10) NOT NULL,
+
+                                   started_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                   preparation_seconds INT NOT NULL,
+                                   response_seconds INT NOT NULL,
+
+                                   audio_object VARCHAR(255), -- object name in the attempt-responses bucket
+                                   audio_url VARCHAR(255),
+                                   text_response TEXT,
+                                   word_count INT,
+                                   submitted_at TIMESTAMPTZ,
+
+                                   created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                   updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                   PRIMARY KEY (attempt_id, question_id),
+                                   FOREIGN KEY (attempt_id, question_id) REFERENCES attempt_questions (attempt_id, question_id) ON DELETE CASCADE,
+                                   CONSTRAINT chk_response_type CHECK (response_type IN ('AUDIO', 'TEXT'))
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_attempt_responses_updated_at
+    BEFORE UPDATE ON attempt_responses
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+---------------====================017
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- A submitted response is PENDING until two raters scored it. Equal scores make it RATED, different
+-- scores send it to ADJUDICATION, where a third rater's score is final. max_score is the top of the
+-- rubric the response was rated against.
+ALTER TABLE attempt_responses
+    ADD COLUMN rating_status VARCHAR(20) NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN final_score INT,
+    ADD COLUMN max_score INT,
+    ADD COLUMN rated_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_rating_status CHECK (rating_status IN ('PENDING', 'ADJUDICATION', 'RATED'));
+
+-- ========================
+-- RESPONSE_RATINGS
+-- ========================
+-- One rater's score of a response. The row is created when the response is assigned to the rater and
+-- scored later; rounds 1 and 2 are the double rating, round 3 the adjudication.
+CREATE TABLE response_ratings (
+                                  rating_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                  attempt_id UUID NOT NULL,
+                                  question_id UUID NOT NULL,
+                                  rater_id UUID NOT NULL,
+                                  round INT NOT NULL,
+
+                                  score INT,
+                                  max_score INT, -- top of the rubric, stored with the score
+                                  comment TEXT,
+
+                                  assigned_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  rated_at TIMESTAMPTZ,
+
+                                  FOREIGN KEY (attempt_id, question_id) REFERENCES attempt_responses (attempt_id, question_id) ON DELETE CASCADE,
+                                  FOREIGN KEY (rater_id) REFERENCES users (id) ON DELETE CASCADE,
+                                  CONSTRAINT uq_response_ratings_round UNIQUE (attempt_id, question_id, round),
+                                  CONSTRAINT uq_response_ratings_rater UNIQUE (attempt_id, question_id, rater_id),
+                                  CONSTRAINT chk_rating_round CHECK (round BETWEEN 1 AND 3),
+                                  CONSTRAINT chk_rating_score CHECK (score BETWEEN 0 AND max_score)
+);
+CREATE INDEX idx_response_ratings_rater ON response_ratings (rater_id) WHERE score IS NULL;
+
+-- ========================
+-- ATTEMPT_RESULTS
+-- ========================
+-- Rubric points of the Speaking and Writing responses. The scaled score stays NULL while some responses
+-- of the section still wait for a rating.
+ALTER TABLE attempt_results
+    ADD COLUMN speaking_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_max_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_pending INT NOT NULL DEFAULT 0,
+    ADD COLUMN speaking_scaled INT,
+    ADD COLUMN writing_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_max_points INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_pending INT NOT NULL DEFAULT 0,
+    ADD COLUMN writing_scaled INT;
+
+
+---------------====================018
+-- ========================
+-- ATTEMPT_RESPONSES
+-- ========================
+-- Pre-score of an Essay or OpenResponse answer by the automated scorer, shown to raters and learners
+-- next to the human rating. auto_scored_at is set once the scorer is done with the response; the score
+-- stays NULL when the scorer cannot handle it. auto_score_failures counts the calls that failed, so a
+-- response the scorer keeps failing on is eventually left to the raters alone.
+ALTER TABLE attempt_responses
+    ADD COLUMN auto_score INT,
+    ADD COLUMN auto_max_score INT,
+    ADD COLUMN auto_feedback JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN auto_scorer VARCHAR(50),
+    ADD COLUMN auto_scored_at TIMESTAMPTZ,
+    ADD COLUMN auto_score_failures INT NOT NULL DEFAULT 0;
+
+CREATE INDEX idx_attempt_responses_auto_score ON attempt_responses (submitted_at)
+    WHERE auto_scored_at IS NULL AND submitted_at IS NOT NULL;
+
+---------------====================019
+-- ========================
+-- QUESTION_EXPLANATIONS
+-- ========================
+-- Why the correct answer is right, and why each option is right or wrong, in one language per row
+-- ('vn', 'eng', as for the transcripts). option_explanations maps an option key of answer_option to
+-- its rationale: {"A": "...", "C": "..."}. Learners only see them when reviewing a submitted attempt.
+CREATE TABLE question_explanations (
+                                       question_id UUID NOT NULL,
+                                       lang VARCHAR(10) NOT NULL,
+                                       explanation TEXT NOT NULL DEFAULT '',
+                                       option_explanations JSONB NOT NULL DEFAULT '{}',
+
+                                       updated_by UUID,
+                                       created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+                                       updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+
+                                       PRIMARY KEY (question_id, lang),
+                                       FOREIGN KEY (question_id) REFERENCES questions (question_id) ON DELETE CASCADE,
+                                       FOREIGN KEY (updated_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- ======================
+-- Trigger
+-- ======================
+CREATE TRIGGER update_question_explanations_updated_at
+    BEFORE UPDATE ON question_explanations
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();